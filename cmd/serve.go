@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	_ "embed"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -21,11 +22,14 @@ import (
 	"github.com/outlet-sh/outlet/internal/config"
 	"github.com/outlet-sh/outlet/internal/errorx"
 	"github.com/outlet-sh/outlet/internal/handler"
+	adminimports "github.com/outlet-sh/outlet/internal/handler/admin/imports"
 	publiclogic "github.com/outlet-sh/outlet/internal/logic/public"
 	outletmcp "github.com/outlet-sh/outlet/internal/mcp"
 	mcpoauth "github.com/outlet-sh/outlet/internal/mcp/oauth"
 	"github.com/outlet-sh/outlet/internal/middleware"
 	publicpages "github.com/outlet-sh/outlet/internal/public"
+	"github.com/outlet-sh/outlet/internal/services/tracking"
+	"github.com/outlet-sh/outlet/internal/supervisor"
 	"github.com/outlet-sh/outlet/internal/svc"
 	"github.com/outlet-sh/outlet/internal/types"
 	"github.com/outlet-sh/outlet/internal/webhook"
@@ -172,6 +176,9 @@ func runServe(cmd *cobra.Command, args []string) {
 	// Custom webhook handlers (need raw body access)
 	registerWebhookRoutes(server, ctx)
 
+	// CSV import uploads (need multipart/form-data handling)
+	registerImportUploadRoutes(server, ctx)
+
 	// MCP OAuth endpoints (well-known, DCR, authorize, token)
 	registerMCPOAuthRoutes(server, ctx, c.App.BaseURL)
 
@@ -184,17 +191,11 @@ func runServe(cmd *cobra.Command, args []string) {
 	// WebSocket endpoint for real-time updates
 	registerWebSocketRoute(server, ctx)
 
-	// Start email worker in background
-	emailWorker := workers.StartEmailWorker(ctx)
-	fmt.Println("Email worker started")
-
-	// Start campaign scheduler in background
-	campaignScheduler := workers.StartCampaignScheduler(ctx)
-	fmt.Println("Campaign scheduler started")
-
-	// Start domain verification worker in background
-	domainVerificationWorker := workers.StartDomainVerificationWorker(ctx)
-	fmt.Println("Domain verification worker started")
+	// Start background workers under a supervisor that restarts crashed
+	// workers and drains them on shutdown
+	workerSupervisor := startWorkers(ctx)
+	ctx.Workers = workerSupervisor
+	fmt.Println("Background workers started")
 
 	// Start MCP session cleanup job (runs every hour, cleans sessions older than 30 days)
 	cleanupCtx, cleanupCancel := context.WithCancel(context.Background())
@@ -258,18 +259,8 @@ func runServe(cmd *cobra.Command, args []string) {
 			fmt.Println("MCP session cleanup job stopped")
 
 			// Stop workers
-			if emailWorker != nil {
-				emailWorker.Stop()
-				fmt.Println("Email worker stopped")
-			}
-			if campaignScheduler != nil {
-				campaignScheduler.Stop()
-				fmt.Println("Campaign scheduler stopped")
-			}
-			if domainVerificationWorker != nil {
-				domainVerificationWorker.Stop()
-				fmt.Println("Domain verification worker stopped")
-			}
+			workerSupervisor.Stop()
+			fmt.Println("Background workers stopped")
 			if smtpServer != nil {
 				smtpServer.Stop()
 				fmt.Println("SMTP server stopped")
@@ -491,18 +482,8 @@ func runServe(cmd *cobra.Command, args []string) {
 	cleanupCancel()
 	fmt.Println("MCP session cleanup job stopped")
 
-	if emailWorker != nil {
-		emailWorker.Stop()
-		fmt.Println("Email worker stopped")
-	}
-	if campaignScheduler != nil {
-		campaignScheduler.Stop()
-		fmt.Println("Campaign scheduler stopped")
-	}
-	if domainVerificationWorker != nil {
-		domainVerificationWorker.Stop()
-		fmt.Println("Domain verification worker stopped")
-	}
+	workerSupervisor.Stop()
+	fmt.Println("Background workers stopped")
 	if smtpServer != nil {
 		smtpServer.Stop()
 		fmt.Println("SMTP server stopped")
@@ -525,51 +506,119 @@ func runServe(cmd *cobra.Command, args []string) {
 	fmt.Println("All servers shut down successfully")
 }
 
+// startWorkers starts every background worker under a supervisor
+func startWorkers(ctx *svc.ServiceContext) *supervisor.Supervisor {
+	s := supervisor.New(10 * time.Second)
+
+	s.Add("email", func() supervisor.Worker { return workers.StartEmailWorker(ctx) }, func(w supervisor.Worker) map[string]int64 {
+		sent, failed, retried, circuitOpen := w.(*workers.EmailWorker).Stats()
+		var open int64
+		if circuitOpen {
+			open = 1
+		}
+		return map[string]int64{"sent": sent, "failed": failed, "retried": retried, "circuit_open": open}
+	})
+	s.Add("campaign_scheduler", func() supervisor.Worker { return workers.StartCampaignScheduler(ctx) }, func(w supervisor.Worker) map[string]int64 {
+		scheduled, sent, failed := w.(*workers.CampaignScheduler).Stats()
+		return map[string]int64{"scheduled": scheduled, "sent": sent, "failed": failed}
+	})
+	s.Add("retry", func() supervisor.Worker { return workers.StartRetryWorker(ctx) }, func(w supervisor.Worker) map[string]int64 {
+		retried, succeeded, exhausted := w.(*workers.RetryWorker).Stats()
+		return map[string]int64{"retried": retried, "succeeded": succeeded, "exhausted": exhausted}
+	})
+	s.Add("import", func() supervisor.Worker { return workers.StartImportWorker(ctx) }, func(w supervisor.Worker) map[string]int64 {
+		processed, failed := w.(*workers.ImportWorker).Stats()
+		return map[string]int64{"processed": processed, "failed": failed}
+	})
+	s.Add("domain_verification", func() supervisor.Worker { return workers.StartDomainVerificationWorker(ctx) }, nil)
+	s.Add("segment", func() supervisor.Worker { return workers.StartSegmentWorker(ctx) }, nil)
+	s.Add("list_verification", func() supervisor.Worker { return workers.StartListVerificationWorker(ctx) }, nil)
+	s.Add("backup", func() supervisor.Worker { return workers.StartBackupWorker(ctx) }, nil)
+	s.Add("export", func() supervisor.Worker { return workers.StartExportWorker(ctx) }, nil)
+	s.Add("attachment_retention", func() supervisor.Worker { return workers.StartAttachmentRetentionWorker(ctx) }, nil)
+
+	s.Start()
+	return s
+}
+
 // registerEmailTrackingRoutes adds email open/click tracking endpoints
 func registerEmailTrackingRoutes(server *rest.Server, ctx *svc.ServiceContext) {
 	// Email open tracking - returns 1x1 transparent pixel
-	server.AddRoute(rest.Route{
-		Method: http.MethodGet,
-		Path:   "/api/e/o/:token",
-		Handler: func(w http.ResponseWriter, r *http.Request) {
-			token := r.PathValue("token")
-			if token == "" {
-				parts := strings.Split(r.URL.Path, "/")
-				if len(parts) >= 4 {
-					token = parts[len(parts)-1]
-				}
+	openHandler := func(w http.ResponseWriter, r *http.Request) {
+		token := r.PathValue("token")
+		if token == "" {
+			parts := strings.Split(r.URL.Path, "/")
+			if len(parts) >= 4 {
+				token = parts[len(parts)-1]
 			}
+		}
 
-			// Track the open (fire and forget)
-			go func(token string) {
-				_ = ctx.Tracking.RecordOpen(context.Background(), token)
-			}(token)
-
-			// Return 1x1 transparent GIF
-			w.Header().Set("Content-Type", "image/gif")
-			w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate")
-			transparentGIF := []byte{
-				0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00,
-				0x80, 0x00, 0x00, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x21,
-				0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00,
-				0x00, 0x00, 0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44,
-				0x01, 0x00, 0x3b,
-			}
-			w.Write(transparentGIF)
-		},
-	})
+		recordTracking(r, "open", func(rctx context.Context) error {
+			return ctx.Tracking.RecordOpen(rctx, token, trackingClient(r))
+		})
+
+		// Return 1x1 transparent GIF
+		w.Header().Set("Content-Type", "image/gif")
+		w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate")
+		transparentGIF := []byte{
+			0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00,
+			0x80, 0x00, 0x00, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x21,
+			0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44,
+			0x01, 0x00, 0x3b,
+		}
+		w.Write(transparentGIF)
+	}
 
 	// Email click tracking - tracks click and redirects
+	clickHandler := func(w http.ResponseWriter, r *http.Request) {
+		token := r.PathValue("token")
+		if token == "" {
+			parts := strings.Split(r.URL.Path, "/")
+			if len(parts) >= 4 {
+				token = parts[len(parts)-1]
+			}
+		}
+
+		// Only redirect to targets we signed for this email
+		query := r.URL.Query()
+		click := tracking.Click{
+			URL:      query.Get("url"),
+			LinkName: query.Get("n"),
+			Client:   trackingClient(r),
+		}
+		if err := ctx.Tracking.VerifyClick(token, click.URL, click.LinkName, query.Get("s")); err != nil {
+			http.Error(w, "Invalid link", http.StatusBadRequest)
+			return
+		}
+
+		recordTracking(r, "click", func(rctx context.Context) error {
+			return ctx.Tracking.RecordClick(rctx, token, click)
+		})
+
+		http.Redirect(w, r, click.URL, http.StatusTemporaryRedirect)
+	}
+
+	// Link scanners and proxies often probe with HEAD; those hits are
+	// recorded too, flagged as machine traffic
+	for _, method := range []string{http.MethodGet, http.MethodHead} {
+		server.AddRoute(rest.Route{
+			Method:  method,
+			Path:    "/api/e/o/:token",
+			Handler: openHandler,
+		})
+		server.AddRoute(rest.Route{
+			Method:  method,
+			Path:    "/api/e/c/:token",
+			Handler: clickHandler,
+		})
+	}
+
+	// Email unsubscribe
 	server.AddRoute(rest.Route{
 		Method: http.MethodGet,
-		Path:   "/api/e/c/:token",
+		Path:   "/api/e/u/:token",
 		Handler: func(w http.ResponseWriter, r *http.Request) {
-			redirectUrl := r.URL.Query().Get("url")
-			if redirectUrl == "" {
-				http.Error(w, "Missing url parameter", http.StatusBadRequest)
-				return
-			}
-
 			token := r.PathValue("token")
 			if token == "" {
 				parts := strings.Split(r.URL.Path, "/")
@@ -578,18 +627,21 @@ func registerEmailTrackingRoutes(server *rest.Server, ctx *svc.ServiceContext) {
 				}
 			}
 
-			// Track the click (fire and forget)
-			go func(token string) {
-				_ = ctx.Tracking.RecordClick(context.Background(), token)
-			}(token)
+			if token == "" {
+				http.Error(w, "Invalid unsubscribe link", http.StatusBadRequest)
+				return
+			}
 
-			http.Redirect(w, r, redirectUrl, http.StatusTemporaryRedirect)
+			_ = ctx.Tracking.Unsubscribe(context.Background(), token)
+			http.Redirect(w, r, "/unsubscribed", http.StatusTemporaryRedirect)
 		},
 	})
 
-	// Email unsubscribe
+	// One-click unsubscribe (RFC 8058). Mailbox providers POST
+	// "List-Unsubscribe=One-Click" to the List-Unsubscribe URL and expect
+	// a plain success response rather than a confirmation page.
 	server.AddRoute(rest.Route{
-		Method: http.MethodGet,
+		Method: http.MethodPost,
 		Path:   "/api/e/u/:token",
 		Handler: func(w http.ResponseWriter, r *http.Request) {
 			token := r.PathValue("token")
@@ -605,8 +657,17 @@ func registerEmailTrackingRoutes(server *rest.Server, ctx *svc.ServiceContext) {
 				return
 			}
 
-			_ = ctx.Tracking.Unsubscribe(context.Background(), token)
-			http.Redirect(w, r, "/unsubscribed", http.StatusTemporaryRedirect)
+			if err := ctx.Tracking.OneClickUnsubscribe(r.Context(), token); err != nil {
+				if errors.Is(err, tracking.ErrNotFound) {
+					http.Error(w, "Unknown unsubscribe link", http.StatusNotFound)
+					return
+				}
+				log.Printf("One-click unsubscribe failed: %v", err)
+				http.Error(w, "Unsubscribe failed", http.StatusInternalServerError)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
 		},
 	})
 
@@ -662,6 +723,56 @@ func registerEmailTrackingRoutes(server *rest.Server, ctx *svc.ServiceContext) {
 	})
 }
 
+// trackingTimeout bounds how long recording an open or click may hold up
+// the pixel or redirect
+const trackingTimeout = 5 * time.Second
+
+// trackingClient describes the client behind an open or click request
+func trackingClient(r *http.Request) tracking.Client {
+	return tracking.Client{
+		UserAgent: r.UserAgent(),
+		IP:        middleware.ClientIP(r),
+		Method:    r.Method,
+	}
+}
+
+// recordTracking records an open or click before the response is written.
+// The client going away does not cancel the write.
+func recordTracking(r *http.Request, kind string, record func(context.Context) error) {
+	rctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), trackingTimeout)
+	defer cancel()
+
+	err := record(rctx)
+	if err != nil && !errors.Is(err, tracking.ErrNotFound) && !errors.Is(err, tracking.ErrInvalidToken) {
+		log.Printf("Failed to record email %s: %v", kind, err)
+	}
+}
+
+// registerImportUploadRoutes adds the admin CSV upload endpoints that create import jobs
+func registerImportUploadRoutes(server *rest.Server, ctx *svc.ServiceContext) {
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{ctx.Auth},
+			[]rest.Route{
+				{
+					Method:  http.MethodPost,
+					Path:    "/import-jobs",
+					Handler: adminimports.CreateImportJobHandler(ctx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/import-jobs/preview",
+					Handler: adminimports.PreviewImportHandler(ctx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/admin"),
+		// The server-wide 1MB body limit would reject uploads before the
+		// handlers apply their own limit
+		rest.WithMaxBytes(adminimports.MaxImportUploadSize),
+	)
+}
+
 // registerWebhookRoutes adds webhook handlers that need raw body access
 func registerWebhookRoutes(server *rest.Server, ctx *svc.ServiceContext) {
 	// SES webhook (for bounces/complaints) - includes org ID in path
@@ -670,6 +781,13 @@ func registerWebhookRoutes(server *rest.Server, ctx *svc.ServiceContext) {
 		Path:    "/webhooks/ses/:orgId",
 		Handler: webhook.SESHandler(ctx),
 	})
+
+	// Webhook for the org's HTTP API provider (Postmark, Mailgun, SendGrid, Resend)
+	server.AddRoute(rest.Route{
+		Method:  http.MethodPost,
+		Path:    "/webhooks/email/:orgId",
+		Handler: webhook.ProviderHandler(ctx),
+	})
 }
 
 // registerMCPOAuthRoutes adds MCP OAuth 2.1 endpoints for ChatGPT and other AI integrations
@@ -29,11 +29,24 @@ type Config struct {
 	Email struct {
 		// High-volume dispatcher settings (per-org SMTP config is in database)
 		WorkerCount int     `json:",default=10"`  // Concurrent email workers
-		RateLimit   float64 `json:",default=14"`  // Emails per second (SES limit)
-		RateBurst   int     `json:",default=50"`  // Max burst size
+		RateLimit   float64 `json:",default=14"`  // Emails per second for mail without an org (orgs use their SES rate)
+		RateBurst   int     `json:",default=50"`  // Max burst size for mail without an org
 		BatchSize   int     `json:",default=100"` // Emails per batch fetch
+
+		// Days to keep attachment content of transactional sends (0 = metadata only)
+		AttachmentRetentionDays int `json:",optional"`
+
+		// Directory the file provider writes .eml files to
+		OutboxDir string `json:",optional"`
+		// Write all mail to OutboxDir instead of sending it (staging)
+		CaptureOutbox bool `json:",optional"`
 	}
 	SMTP SMTPConfig
+	Geo  struct {
+		// MaxMind GeoLite2 or GeoIP2 City database (.mmdb) used to infer
+		// contact time zones from click IPs; empty disables inference
+		Database string `json:",optional"`
+	}
 	Encryption struct {
 		Key string // 32-byte hex-encoded key for AES-256 encryption
 	}
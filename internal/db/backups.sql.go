@@ -222,6 +222,51 @@ func (q *Queries) GetBackupsInProgress(ctx context.Context) ([]BackupHistory, er
 	return items, nil
 }
 
+const getExpiredBackups = `-- name: GetExpiredBackups :many
+SELECT id, filename, file_path, file_size, backup_type, storage_type, s3_bucket, s3_key, status, error_message, created_by, started_at, completed_at, created_at FROM backup_history
+WHERE created_at < datetime('now', ?1 || ' days')
+  AND status = 'completed'
+ORDER BY created_at ASC
+`
+
+func (q *Queries) GetExpiredBackups(ctx context.Context, daysAgo sql.NullString) ([]BackupHistory, error) {
+	rows, err := q.db.QueryContext(ctx, getExpiredBackups, daysAgo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BackupHistory
+	for rows.Next() {
+		var i BackupHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.Filename,
+			&i.FilePath,
+			&i.FileSize,
+			&i.BackupType,
+			&i.StorageType,
+			&i.S3Bucket,
+			&i.S3Key,
+			&i.Status,
+			&i.ErrorMessage,
+			&i.CreatedBy,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLatestBackup = `-- name: GetLatestBackup :one
 SELECT id, filename, file_path, file_size, backup_type, storage_type, s3_bucket, s3_key, status, error_message, created_by, started_at, completed_at, created_at FROM backup_history
 WHERE status = 'completed'
@@ -356,7 +401,7 @@ const updateBackupStatus = `-- name: UpdateBackupStatus :one
 UPDATE backup_history
 SET status = ?1,
     error_message = ?2,
-    completed_at = CASE WHEN sqlc.arg(status) IN ('completed', 'failed') THEN datetime('now') ELSE completed_at END
+    completed_at = CASE WHEN ?1 IN ('completed', 'failed') THEN datetime('now') ELSE completed_at END
 WHERE id = ?3
 RETURNING id, filename, file_path, file_size, backup_type, storage_type, s3_bucket, s3_key, status, error_message, created_by, started_at, completed_at, created_at
 `
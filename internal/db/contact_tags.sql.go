@@ -83,7 +83,7 @@ func (q *Queries) GetContactTags(ctx context.Context, contactID sql.NullString)
 }
 
 const getContactsByTag = `-- name: GetContactsByTag :many
-SELECT c.id, c.org_id, c.name, c.email, c.source, c.created_at, c.updated_at, c.email_verified, c.verification_token, c.verification_sent_at, c.verified_at, c.unsubscribed_at, c.blocked_at, c.status, c.gdpr_consent, c.gdpr_consent_at
+SELECT c.id, c.org_id, c.name, c.email, c.source, c.created_at, c.updated_at, c.email_verified, c.verification_token, c.verification_sent_at, c.verified_at, c.unsubscribed_at, c.blocked_at, c.status, c.gdpr_consent, c.gdpr_consent_at, c.validation_status, c.validation_reason, c.validated_at, c.timezone
 FROM contacts c
 JOIN contact_tags ct ON ct.contact_id = c.id
 WHERE ct.tag = ?1
@@ -123,6 +123,10 @@ func (q *Queries) GetContactsByTag(ctx context.Context, arg GetContactsByTagPara
 			&i.Status,
 			&i.GdprConsent,
 			&i.GdprConsentAt,
+			&i.ValidationStatus,
+			&i.ValidationReason,
+			&i.ValidatedAt,
+			&i.Timezone,
 		); err != nil {
 			return nil, err
 		}
@@ -99,7 +99,7 @@ INSERT INTO contacts (
     ?1, ?2, ?3, ?4,
     ?5, COALESCE(?6, 'new'),
     datetime('now'), datetime('now')
-) RETURNING id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at
+) RETURNING id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at, validation_status, validation_reason, validated_at, timezone
 `
 
 type CreateContactParams struct {
@@ -138,6 +138,10 @@ func (q *Queries) CreateContact(ctx context.Context, arg CreateContactParams) (C
 		&i.Status,
 		&i.GdprConsent,
 		&i.GdprConsentAt,
+		&i.ValidationStatus,
+		&i.ValidationReason,
+		&i.ValidatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
@@ -232,7 +236,7 @@ func (q *Queries) GetAllOrgsContactStats(ctx context.Context) ([]GetAllOrgsConta
 }
 
 const getContact = `-- name: GetContact :one
-SELECT id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at FROM contacts WHERE id = ?1
+SELECT id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at, validation_status, validation_reason, validated_at, timezone FROM contacts WHERE id = ?1
 `
 
 func (q *Queries) GetContact(ctx context.Context, id string) (Contact, error) {
@@ -255,12 +259,16 @@ func (q *Queries) GetContact(ctx context.Context, id string) (Contact, error) {
 		&i.Status,
 		&i.GdprConsent,
 		&i.GdprConsentAt,
+		&i.ValidationStatus,
+		&i.ValidationReason,
+		&i.ValidatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
 
 const getContactByEmail = `-- name: GetContactByEmail :one
-SELECT id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at FROM contacts WHERE email = ?1 ORDER BY created_at DESC LIMIT 1
+SELECT id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at, validation_status, validation_reason, validated_at, timezone FROM contacts WHERE email = ?1 ORDER BY created_at DESC LIMIT 1
 `
 
 func (q *Queries) GetContactByEmail(ctx context.Context, email string) (Contact, error) {
@@ -283,12 +291,16 @@ func (q *Queries) GetContactByEmail(ctx context.Context, email string) (Contact,
 		&i.Status,
 		&i.GdprConsent,
 		&i.GdprConsentAt,
+		&i.ValidationStatus,
+		&i.ValidationReason,
+		&i.ValidatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
 
 const getContactByID = `-- name: GetContactByID :one
-SELECT id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at FROM contacts WHERE id = ?1
+SELECT id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at, validation_status, validation_reason, validated_at, timezone FROM contacts WHERE id = ?1
 `
 
 func (q *Queries) GetContactByID(ctx context.Context, id string) (Contact, error) {
@@ -311,12 +323,16 @@ func (q *Queries) GetContactByID(ctx context.Context, id string) (Contact, error
 		&i.Status,
 		&i.GdprConsent,
 		&i.GdprConsentAt,
+		&i.ValidationStatus,
+		&i.ValidationReason,
+		&i.ValidatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
 
 const getContactByOrgAndEmail = `-- name: GetContactByOrgAndEmail :one
-SELECT id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at FROM contacts
+SELECT id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at, validation_status, validation_reason, validated_at, timezone FROM contacts
 WHERE org_id = ?1 AND email = ?2
 ORDER BY created_at DESC LIMIT 1
 `
@@ -346,12 +362,16 @@ func (q *Queries) GetContactByOrgAndEmail(ctx context.Context, arg GetContactByO
 		&i.Status,
 		&i.GdprConsent,
 		&i.GdprConsentAt,
+		&i.ValidationStatus,
+		&i.ValidationReason,
+		&i.ValidatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
 
 const getContactByOrgID = `-- name: GetContactByOrgID :one
-SELECT id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at FROM contacts
+SELECT id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at, validation_status, validation_reason, validated_at, timezone FROM contacts
 WHERE id = ?1 AND org_id = ?2
 `
 
@@ -380,12 +400,16 @@ func (q *Queries) GetContactByOrgID(ctx context.Context, arg GetContactByOrgIDPa
 		&i.Status,
 		&i.GdprConsent,
 		&i.GdprConsentAt,
+		&i.ValidationStatus,
+		&i.ValidationReason,
+		&i.ValidatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
 
 const getContactByVerificationToken = `-- name: GetContactByVerificationToken :one
-SELECT id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at FROM contacts WHERE verification_token = ?1
+SELECT id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at, validation_status, validation_reason, validated_at, timezone FROM contacts WHERE verification_token = ?1
 `
 
 func (q *Queries) GetContactByVerificationToken(ctx context.Context, token sql.NullString) (Contact, error) {
@@ -408,6 +432,10 @@ func (q *Queries) GetContactByVerificationToken(ctx context.Context, token sql.N
 		&i.Status,
 		&i.GdprConsent,
 		&i.GdprConsentAt,
+		&i.ValidationStatus,
+		&i.ValidationReason,
+		&i.ValidatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
@@ -708,8 +736,58 @@ func (q *Queries) GlobalUnsubscribeByOrgAndEmail(ctx context.Context, arg Global
 	return err
 }
 
+const inferContactTimezone = `-- name: InferContactTimezone :exec
+UPDATE contacts
+SET timezone = ?1, updated_at = datetime('now')
+WHERE id = ?2 AND timezone IS NULL
+`
+
+type InferContactTimezoneParams struct {
+	Timezone sql.NullString `json:"timezone"`
+	ID       string         `json:"id"`
+}
+
+// Sets an inferred time zone, never replacing one already known
+func (q *Queries) InferContactTimezone(ctx context.Context, arg InferContactTimezoneParams) error {
+	_, err := q.db.ExecContext(ctx, inferContactTimezone, arg.Timezone, arg.ID)
+	return err
+}
+
+const listContactTimezones = `-- name: ListContactTimezones :many
+SELECT id, timezone FROM contacts
+WHERE org_id = ?1 AND timezone IS NOT NULL
+`
+
+type ListContactTimezonesRow struct {
+	ID       string         `json:"id"`
+	Timezone sql.NullString `json:"timezone"`
+}
+
+func (q *Queries) ListContactTimezones(ctx context.Context, orgID sql.NullString) ([]ListContactTimezonesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listContactTimezones, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListContactTimezonesRow{}
+	for rows.Next() {
+		var i ListContactTimezonesRow
+		if err := rows.Scan(&i.ID, &i.Timezone); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listContacts = `-- name: ListContacts :many
-SELECT id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at FROM contacts
+SELECT id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at, validation_status, validation_reason, validated_at, timezone FROM contacts
 ORDER BY created_at DESC
 LIMIT ?2 OFFSET ?1
 `
@@ -745,6 +823,10 @@ func (q *Queries) ListContacts(ctx context.Context, arg ListContactsParams) ([]C
 			&i.Status,
 			&i.GdprConsent,
 			&i.GdprConsentAt,
+			&i.ValidationStatus,
+			&i.ValidationReason,
+			&i.ValidatedAt,
+			&i.Timezone,
 		); err != nil {
 			return nil, err
 		}
@@ -760,7 +842,7 @@ func (q *Queries) ListContacts(ctx context.Context, arg ListContactsParams) ([]C
 }
 
 const listContactsByOrg = `-- name: ListContactsByOrg :many
-SELECT id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at FROM contacts
+SELECT id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at, validation_status, validation_reason, validated_at, timezone FROM contacts
 WHERE org_id = ?1
 ORDER BY created_at DESC
 LIMIT ?3 OFFSET ?2
@@ -798,6 +880,10 @@ func (q *Queries) ListContactsByOrg(ctx context.Context, arg ListContactsByOrgPa
 			&i.Status,
 			&i.GdprConsent,
 			&i.GdprConsentAt,
+			&i.ValidationStatus,
+			&i.ValidationReason,
+			&i.ValidatedAt,
+			&i.Timezone,
 		); err != nil {
 			return nil, err
 		}
@@ -834,6 +920,22 @@ func (q *Queries) ResubscribeContact(ctx context.Context, id string) error {
 	return err
 }
 
+const setContactTimezone = `-- name: SetContactTimezone :exec
+UPDATE contacts
+SET timezone = ?1, updated_at = datetime('now')
+WHERE id = ?2
+`
+
+type SetContactTimezoneParams struct {
+	Timezone sql.NullString `json:"timezone"`
+	ID       string         `json:"id"`
+}
+
+func (q *Queries) SetContactTimezone(ctx context.Context, arg SetContactTimezoneParams) error {
+	_, err := q.db.ExecContext(ctx, setContactTimezone, arg.Timezone, arg.ID)
+	return err
+}
+
 const setContactVerificationToken = `-- name: SetContactVerificationToken :exec
 UPDATE contacts
 SET verification_token = ?1, verification_sent_at = datetime('now')
@@ -911,12 +1013,32 @@ func (q *Queries) UpdateContactStatus(ctx context.Context, arg UpdateContactStat
 	return err
 }
 
+const updateContactValidation = `-- name: UpdateContactValidation :exec
+UPDATE contacts
+SET validation_status = ?1,
+    validation_reason = ?2,
+    validated_at = datetime('now'),
+    updated_at = datetime('now')
+WHERE id = ?3
+`
+
+type UpdateContactValidationParams struct {
+	ValidationStatus sql.NullString `json:"validation_status"`
+	ValidationReason sql.NullString `json:"validation_reason"`
+	ID               string         `json:"id"`
+}
+
+func (q *Queries) UpdateContactValidation(ctx context.Context, arg UpdateContactValidationParams) error {
+	_, err := q.db.ExecContext(ctx, updateContactValidation, arg.ValidationStatus, arg.ValidationReason, arg.ID)
+	return err
+}
+
 const updateSDKContact = `-- name: UpdateSDKContact :one
 UPDATE contacts
 SET name = COALESCE(NULLIF(?1, ''), name),
     updated_at = datetime('now')
 WHERE id = ?2 AND org_id = ?3
-RETURNING id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at
+RETURNING id, org_id, name, email, source, created_at, updated_at, email_verified, verification_token, verification_sent_at, verified_at, unsubscribed_at, blocked_at, status, gdpr_consent, gdpr_consent_at, validation_status, validation_reason, validated_at, timezone
 `
 
 type UpdateSDKContactParams struct {
@@ -945,6 +1067,10 @@ func (q *Queries) UpdateSDKContact(ctx context.Context, arg UpdateSDKContactPara
 		&i.Status,
 		&i.GdprConsent,
 		&i.GdprConsentAt,
+		&i.ValidationStatus,
+		&i.ValidationReason,
+		&i.ValidatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
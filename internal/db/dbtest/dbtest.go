@@ -0,0 +1,41 @@
+// Package dbtest opens migrated SQLite databases for tests.
+package dbtest
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/db/migrations"
+
+	"github.com/pressly/goose/v3"
+	_ "modernc.org/sqlite"
+)
+
+// New returns a store backed by a fresh, fully migrated database in the
+// test's temp dir. The pool holds a single connection, so PRAGMAs a test
+// runs apply to every query.
+func New(t testing.TB) *db.Store {
+	t.Helper()
+
+	conn, err := sql.Open("sqlite", t.TempDir()+"/outlet.db?_pragma=foreign_keys(1)")
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	conn.SetMaxOpenConns(1)
+	t.Cleanup(func() { conn.Close() })
+
+	goose.SetLogger(goose.NopLogger())
+	if err := migrations.Run(conn); err != nil {
+		t.Fatalf("migrate test database: %v", err)
+	}
+	return db.NewStore(conn)
+}
+
+// Exec runs a fixture statement and fails the test on error
+func Exec(t testing.TB, store *db.Store, query string, args ...any) {
+	t.Helper()
+	if _, err := store.GetDB().Exec(query, args...); err != nil {
+		t.Fatalf("%s: %v", query, err)
+	}
+}
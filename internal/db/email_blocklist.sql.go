@@ -89,6 +89,21 @@ func (q *Queries) BlockContactByEmail(ctx context.Context, email string) error {
 	return err
 }
 
+const blockOrgContactByEmail = `-- name: BlockOrgContactByEmail :exec
+UPDATE contacts SET blocked_at = datetime('now'), updated_at = datetime('now')
+WHERE org_id = ?1 AND LOWER(email) = LOWER(?2) AND blocked_at IS NULL
+`
+
+type BlockOrgContactByEmailParams struct {
+	OrgID string `json:"org_id"`
+	Email string `json:"email"`
+}
+
+func (q *Queries) BlockOrgContactByEmail(ctx context.Context, arg BlockOrgContactByEmailParams) error {
+	_, err := q.db.ExecContext(ctx, blockOrgContactByEmail, arg.OrgID, arg.Email)
+	return err
+}
+
 const bulkInsertBlockedDomains = `-- name: BulkInsertBlockedDomains :exec
 INSERT INTO blocked_domains (org_id, domain, reason)
 VALUES (?1, LOWER(?2), ?3)
@@ -1074,7 +1089,7 @@ const updateImportJobStatus = `-- name: UpdateImportJobStatus :exec
 UPDATE import_jobs
 SET status = ?1,
     started_at = CASE WHEN ?1 = 'processing' THEN datetime('now') ELSE started_at END,
-    completed_at = CASE WHEN sqlc.arg(status) IN ('completed', 'failed', 'cancelled') THEN datetime('now') ELSE completed_at END
+    completed_at = CASE WHEN ?1 IN ('completed', 'failed', 'cancelled') THEN datetime('now') ELSE completed_at END
 WHERE id = ?2
 `
 
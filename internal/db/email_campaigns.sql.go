@@ -104,11 +104,11 @@ INSERT INTO email_campaigns (
     from_name, from_email, reply_to,
     html_body, plain_text,
     list_ids, exclude_list_ids, segment_filter,
-    status, scheduled_at, track_opens, track_clicks,
-    created_at, updated_at
+    status, scheduled_at, track_opens, track_clicks, segment_id,
+    delivery_mode, created_at, updated_at
 )
-VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13, ?14, ?15, ?16, ?17, ?18, datetime('now'), datetime('now'))
-RETURNING id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13, ?14, ?15, ?16, ?17, ?18, ?19, COALESCE(NULLIF(?20, ''), 'immediate'), datetime('now'), datetime('now'))
+RETURNING id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at, segment_id, delivery_mode
 `
 
 type CreateCampaignParams struct {
@@ -130,6 +130,8 @@ type CreateCampaignParams struct {
 	ScheduledAt    sql.NullString `json:"scheduled_at"`
 	TrackOpens     sql.NullInt64  `json:"track_opens"`
 	TrackClicks    sql.NullInt64  `json:"track_clicks"`
+	SegmentID      sql.NullString `json:"segment_id"`
+	DeliveryMode   interface{}    `json:"delivery_mode"`
 }
 
 // Email Campaigns (One-time Broadcasts)
@@ -154,6 +156,8 @@ func (q *Queries) CreateCampaign(ctx context.Context, arg CreateCampaignParams)
 		arg.ScheduledAt,
 		arg.TrackOpens,
 		arg.TrackClicks,
+		arg.SegmentID,
+		arg.DeliveryMode,
 	)
 	var i EmailCampaign
 	err := row.Scan(
@@ -187,15 +191,17 @@ func (q *Queries) CreateCampaign(ctx context.Context, arg CreateCampaignParams)
 		&i.UnsubscribedCount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SegmentID,
+		&i.DeliveryMode,
 	)
 	return i, err
 }
 
 const createCampaignClick = `-- name: CreateCampaignClick :one
 
-INSERT INTO campaign_clicks (id, campaign_send_id, campaign_id, contact_id, link_url, link_name, clicked_at)
-VALUES (?1, ?2, ?3, ?4, ?5, ?6, datetime('now'))
-RETURNING id, campaign_send_id, campaign_id, contact_id, link_url, link_name, clicked_at
+INSERT INTO campaign_clicks (id, campaign_send_id, campaign_id, contact_id, link_url, link_name, user_agent, ip_address, clicked_at)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, datetime('now'))
+RETURNING id, campaign_send_id, campaign_id, contact_id, link_url, link_name, clicked_at, user_agent, ip_address
 `
 
 type CreateCampaignClickParams struct {
@@ -205,6 +211,8 @@ type CreateCampaignClickParams struct {
 	ContactID      string         `json:"contact_id"`
 	LinkUrl        string         `json:"link_url"`
 	LinkName       sql.NullString `json:"link_name"`
+	UserAgent      sql.NullString `json:"user_agent"`
+	IpAddress      sql.NullString `json:"ip_address"`
 }
 
 // Campaign Clicks
@@ -216,6 +224,8 @@ func (q *Queries) CreateCampaignClick(ctx context.Context, arg CreateCampaignCli
 		arg.ContactID,
 		arg.LinkUrl,
 		arg.LinkName,
+		arg.UserAgent,
+		arg.IpAddress,
 	)
 	var i CampaignClick
 	err := row.Scan(
@@ -226,15 +236,17 @@ func (q *Queries) CreateCampaignClick(ctx context.Context, arg CreateCampaignCli
 		&i.LinkUrl,
 		&i.LinkName,
 		&i.ClickedAt,
+		&i.UserAgent,
+		&i.IpAddress,
 	)
 	return i, err
 }
 
 const createCampaignSend = `-- name: CreateCampaignSend :one
 
-INSERT INTO campaign_sends (id, campaign_id, contact_id, list_id, tracking_token, status, created_at)
-VALUES (?1, ?2, ?3, ?4, ?5, 'pending', datetime('now'))
-RETURNING id, campaign_id, contact_id, list_id, status, sent_at, delivered_at, tracking_token, opened_at, open_count, clicked_at, click_count, error_message, bounce_type, created_at, retry_count, failed_at
+INSERT INTO campaign_sends (id, campaign_id, contact_id, list_id, tracking_token, variant_id, send_at, status, created_at)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, 'pending', datetime('now'))
+RETURNING id, campaign_id, contact_id, list_id, status, sent_at, delivered_at, tracking_token, opened_at, open_count, clicked_at, click_count, error_message, bounce_type, created_at, retry_count, failed_at, variant_id, send_at
 `
 
 type CreateCampaignSendParams struct {
@@ -243,6 +255,8 @@ type CreateCampaignSendParams struct {
 	ContactID     string         `json:"contact_id"`
 	ListID        sql.NullInt64  `json:"list_id"`
 	TrackingToken sql.NullString `json:"tracking_token"`
+	VariantID     sql.NullString `json:"variant_id"`
+	SendAt        sql.NullString `json:"send_at"`
 }
 
 // Campaign Sends
@@ -253,6 +267,8 @@ func (q *Queries) CreateCampaignSend(ctx context.Context, arg CreateCampaignSend
 		arg.ContactID,
 		arg.ListID,
 		arg.TrackingToken,
+		arg.VariantID,
+		arg.SendAt,
 	)
 	var i CampaignSend
 	err := row.Scan(
@@ -273,10 +289,28 @@ func (q *Queries) CreateCampaignSend(ctx context.Context, arg CreateCampaignSend
 		&i.CreatedAt,
 		&i.RetryCount,
 		&i.FailedAt,
+		&i.VariantID,
+		&i.SendAt,
 	)
 	return i, err
 }
 
+const deferCampaignSend = `-- name: DeferCampaignSend :exec
+UPDATE campaign_sends
+SET send_at = ?1
+WHERE id = ?2 AND status = 'pending'
+`
+
+type DeferCampaignSendParams struct {
+	SendAt sql.NullString `json:"send_at"`
+	ID     string         `json:"id"`
+}
+
+func (q *Queries) DeferCampaignSend(ctx context.Context, arg DeferCampaignSendParams) error {
+	_, err := q.db.ExecContext(ctx, deferCampaignSend, arg.SendAt, arg.ID)
+	return err
+}
+
 const deleteCampaign = `-- name: DeleteCampaign :exec
 DELETE FROM email_campaigns
 WHERE id = ?1 AND org_id = ?2 AND status = 'draft'
@@ -376,7 +410,7 @@ func (q *Queries) GetAllOrgsEmailStats30Days(ctx context.Context) ([]GetAllOrgsE
 }
 
 const getCampaign = `-- name: GetCampaign :one
-SELECT id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at FROM email_campaigns
+SELECT id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at, segment_id, delivery_mode FROM email_campaigns
 WHERE id = ?1 AND org_id = ?2
 `
 
@@ -419,13 +453,15 @@ func (q *Queries) GetCampaign(ctx context.Context, arg GetCampaignParams) (Email
 		&i.UnsubscribedCount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SegmentID,
+		&i.DeliveryMode,
 	)
 	return i, err
 }
 
 const getCampaignByID = `-- name: GetCampaignByID :one
 
-SELECT id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at FROM email_campaigns
+SELECT id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at, segment_id, delivery_mode FROM email_campaigns
 WHERE id = ?1
 `
 
@@ -464,6 +500,8 @@ func (q *Queries) GetCampaignByID(ctx context.Context, id string) (EmailCampaign
 		&i.UnsubscribedCount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SegmentID,
+		&i.DeliveryMode,
 	)
 	return i, err
 }
@@ -506,7 +544,7 @@ func (q *Queries) GetCampaignLinkStats(ctx context.Context, campaignID string) (
 }
 
 const getCampaignSend = `-- name: GetCampaignSend :one
-SELECT id, campaign_id, contact_id, list_id, status, sent_at, delivered_at, tracking_token, opened_at, open_count, clicked_at, click_count, error_message, bounce_type, created_at, retry_count, failed_at FROM campaign_sends
+SELECT id, campaign_id, contact_id, list_id, status, sent_at, delivered_at, tracking_token, opened_at, open_count, clicked_at, click_count, error_message, bounce_type, created_at, retry_count, failed_at, variant_id, send_at FROM campaign_sends
 WHERE id = ?1
 `
 
@@ -531,12 +569,14 @@ func (q *Queries) GetCampaignSend(ctx context.Context, id string) (CampaignSend,
 		&i.CreatedAt,
 		&i.RetryCount,
 		&i.FailedAt,
+		&i.VariantID,
+		&i.SendAt,
 	)
 	return i, err
 }
 
 const getCampaignSendByTracking = `-- name: GetCampaignSendByTracking :one
-SELECT id, campaign_id, contact_id, list_id, status, sent_at, delivered_at, tracking_token, opened_at, open_count, clicked_at, click_count, error_message, bounce_type, created_at, retry_count, failed_at FROM campaign_sends
+SELECT id, campaign_id, contact_id, list_id, status, sent_at, delivered_at, tracking_token, opened_at, open_count, clicked_at, click_count, error_message, bounce_type, created_at, retry_count, failed_at, variant_id, send_at FROM campaign_sends
 WHERE tracking_token = ?1
 `
 
@@ -561,6 +601,8 @@ func (q *Queries) GetCampaignSendByTracking(ctx context.Context, trackingToken s
 		&i.CreatedAt,
 		&i.RetryCount,
 		&i.FailedAt,
+		&i.VariantID,
+		&i.SendAt,
 	)
 	return i, err
 }
@@ -595,10 +637,14 @@ const getFailedCampaignSendsForRetry = `-- name: GetFailedCampaignSendsForRetry
 
 SELECT cs.id, cs.campaign_id, cs.contact_id, cs.tracking_token, cs.retry_count, cs.failed_at,
        c.email, c.name,
-       ec.subject, ec.html_body, ec.from_name, ec.from_email, ec.reply_to
+       COALESCE(cv.subject, ec.subject) AS subject, COALESCE(cv.html_body, ec.html_body) AS html_body,
+       COALESCE(cv.plain_text, ec.plain_text) AS plain_text, COALESCE(cv.from_name, ec.from_name) AS from_name,
+       ec.from_email, ec.reply_to,
+       ec.org_id
 FROM campaign_sends cs
 JOIN contacts c ON c.id = cs.contact_id
 JOIN email_campaigns ec ON ec.id = cs.campaign_id
+LEFT JOIN campaign_variants cv ON cv.id = cs.variant_id
 WHERE cs.status = 'failed' AND cs.retry_count < 3
 ORDER BY cs.failed_at ASC
 LIMIT ?1
@@ -615,9 +661,11 @@ type GetFailedCampaignSendsForRetryRow struct {
 	Name          string         `json:"name"`
 	Subject       string         `json:"subject"`
 	HtmlBody      string         `json:"html_body"`
+	PlainText     sql.NullString `json:"plain_text"`
 	FromName      sql.NullString `json:"from_name"`
 	FromEmail     sql.NullString `json:"from_email"`
 	ReplyTo       sql.NullString `json:"reply_to"`
+	OrgID         string         `json:"org_id"`
 }
 
 // Retry Worker Queries
@@ -641,9 +689,11 @@ func (q *Queries) GetFailedCampaignSendsForRetry(ctx context.Context, limitCount
 			&i.Name,
 			&i.Subject,
 			&i.HtmlBody,
+			&i.PlainText,
 			&i.FromName,
 			&i.FromEmail,
 			&i.ReplyTo,
+			&i.OrgID,
 		); err != nil {
 			return nil, err
 		}
@@ -661,13 +711,17 @@ func (q *Queries) GetFailedCampaignSendsForRetry(ctx context.Context, limitCount
 const getPendingCampaignSends = `-- name: GetPendingCampaignSends :many
 SELECT cs.id, cs.campaign_id, cs.contact_id, cs.list_id, cs.tracking_token, cs.status,
        c.email, c.name,
-       ec.subject, ec.html_body, ec.plain_text, ec.from_name, ec.from_email, ec.reply_to,
+       COALESCE(cv.subject, ec.subject) AS subject, COALESCE(cv.html_body, ec.html_body) AS html_body,
+       COALESCE(cv.plain_text, ec.plain_text) AS plain_text, COALESCE(cv.from_name, ec.from_name) AS from_name,
+       ec.from_email, ec.reply_to,
        ec.track_opens, ec.track_clicks, ec.org_id
 FROM campaign_sends cs
 JOIN contacts c ON c.id = cs.contact_id
 JOIN email_campaigns ec ON ec.id = cs.campaign_id
-WHERE cs.status = 'pending'
-ORDER BY cs.created_at ASC
+LEFT JOIN campaign_variants cv ON cv.id = cs.variant_id
+WHERE cs.status = 'pending' AND ec.status = 'sending'
+  AND (cs.send_at IS NULL OR cs.send_at <= datetime('now'))
+ORDER BY ROW_NUMBER() OVER (PARTITION BY ec.org_id ORDER BY cs.created_at), cs.created_at
 LIMIT ?1
 `
 
@@ -691,6 +745,8 @@ type GetPendingCampaignSendsRow struct {
 	OrgID         string         `json:"org_id"`
 }
 
+// Round-robin across organizations so one large campaign cannot fill the batch.
+// Sends timed for a recipient wait until they are due.
 func (q *Queries) GetPendingCampaignSends(ctx context.Context, limitCount int64) ([]GetPendingCampaignSendsRow, error) {
 	rows, err := q.db.QueryContext(ctx, getPendingCampaignSends, limitCount)
 	if err != nil {
@@ -733,7 +789,7 @@ func (q *Queries) GetPendingCampaignSends(ctx context.Context, limitCount int64)
 }
 
 const getScheduledCampaigns = `-- name: GetScheduledCampaigns :many
-SELECT id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at FROM email_campaigns
+SELECT id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at, segment_id, delivery_mode FROM email_campaigns
 WHERE status = 'scheduled' AND scheduled_at <= datetime('now')
 ORDER BY scheduled_at ASC
 `
@@ -778,6 +834,8 @@ func (q *Queries) GetScheduledCampaigns(ctx context.Context) ([]EmailCampaign, e
 			&i.UnsubscribedCount,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SegmentID,
+			&i.DeliveryMode,
 		); err != nil {
 			return nil, err
 		}
@@ -837,7 +895,7 @@ func (q *Queries) IncrementCampaignSent(ctx context.Context, id string) error {
 }
 
 const listCampaignClicks = `-- name: ListCampaignClicks :many
-SELECT cc.id, cc.campaign_send_id, cc.campaign_id, cc.contact_id, cc.link_url, cc.link_name, cc.clicked_at, c.email
+SELECT cc.id, cc.campaign_send_id, cc.campaign_id, cc.contact_id, cc.link_url, cc.link_name, cc.clicked_at, cc.user_agent, cc.ip_address, c.email
 FROM campaign_clicks cc
 JOIN contacts c ON cc.contact_id = c.id
 WHERE cc.campaign_id = ?1
@@ -859,6 +917,8 @@ type ListCampaignClicksRow struct {
 	LinkUrl        string         `json:"link_url"`
 	LinkName       sql.NullString `json:"link_name"`
 	ClickedAt      sql.NullString `json:"clicked_at"`
+	UserAgent      sql.NullString `json:"user_agent"`
+	IpAddress      sql.NullString `json:"ip_address"`
 	Email          string         `json:"email"`
 }
 
@@ -879,6 +939,8 @@ func (q *Queries) ListCampaignClicks(ctx context.Context, arg ListCampaignClicks
 			&i.LinkUrl,
 			&i.LinkName,
 			&i.ClickedAt,
+			&i.UserAgent,
+			&i.IpAddress,
 			&i.Email,
 		); err != nil {
 			return nil, err
@@ -895,7 +957,7 @@ func (q *Queries) ListCampaignClicks(ctx context.Context, arg ListCampaignClicks
 }
 
 const listCampaignSends = `-- name: ListCampaignSends :many
-SELECT cs.id, cs.campaign_id, cs.contact_id, cs.list_id, cs.status, cs.sent_at, cs.delivered_at, cs.tracking_token, cs.opened_at, cs.open_count, cs.clicked_at, cs.click_count, cs.error_message, cs.bounce_type, cs.created_at, cs.retry_count, cs.failed_at, c.email, c.name
+SELECT cs.id, cs.campaign_id, cs.contact_id, cs.list_id, cs.status, cs.sent_at, cs.delivered_at, cs.tracking_token, cs.opened_at, cs.open_count, cs.clicked_at, cs.click_count, cs.error_message, cs.bounce_type, cs.created_at, cs.retry_count, cs.failed_at, cs.variant_id, c.email, c.name
 FROM campaign_sends cs
 JOIN contacts c ON cs.contact_id = c.id
 WHERE cs.campaign_id = ?1
@@ -927,6 +989,7 @@ type ListCampaignSendsRow struct {
 	CreatedAt     sql.NullString `json:"created_at"`
 	RetryCount    sql.NullInt64  `json:"retry_count"`
 	FailedAt      sql.NullString `json:"failed_at"`
+	VariantID     sql.NullString `json:"variant_id"`
 	Email         string         `json:"email"`
 	Name          string         `json:"name"`
 }
@@ -958,6 +1021,7 @@ func (q *Queries) ListCampaignSends(ctx context.Context, arg ListCampaignSendsPa
 			&i.CreatedAt,
 			&i.RetryCount,
 			&i.FailedAt,
+			&i.VariantID,
 			&i.Email,
 			&i.Name,
 		); err != nil {
@@ -975,7 +1039,7 @@ func (q *Queries) ListCampaignSends(ctx context.Context, arg ListCampaignSendsPa
 }
 
 const listCampaigns = `-- name: ListCampaigns :many
-SELECT id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at FROM email_campaigns
+SELECT id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at, segment_id, delivery_mode FROM email_campaigns
 WHERE org_id = ?1
 ORDER BY created_at DESC
 LIMIT ?3 OFFSET ?2
@@ -1027,6 +1091,8 @@ func (q *Queries) ListCampaigns(ctx context.Context, arg ListCampaignsParams) ([
 			&i.UnsubscribedCount,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SegmentID,
+			&i.DeliveryMode,
 		); err != nil {
 			return nil, err
 		}
@@ -1042,7 +1108,7 @@ func (q *Queries) ListCampaigns(ctx context.Context, arg ListCampaignsParams) ([
 }
 
 const listCampaignsByStatus = `-- name: ListCampaignsByStatus :many
-SELECT id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at FROM email_campaigns
+SELECT id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at, segment_id, delivery_mode FROM email_campaigns
 WHERE org_id = ?1 AND status = ?2
 ORDER BY created_at DESC
 LIMIT ?4 OFFSET ?3
@@ -1100,6 +1166,8 @@ func (q *Queries) ListCampaignsByStatus(ctx context.Context, arg ListCampaignsBy
 			&i.UnsubscribedCount,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SegmentID,
+			&i.DeliveryMode,
 		); err != nil {
 			return nil, err
 		}
@@ -1114,6 +1182,21 @@ func (q *Queries) ListCampaignsByStatus(ctx context.Context, arg ListCampaignsBy
 	return items, nil
 }
 
+const markCampaignSendClicked = `-- name: MarkCampaignSendClicked :execrows
+UPDATE campaign_sends
+SET clicked_at = datetime('now')
+WHERE id = ?1 AND clicked_at IS NULL
+`
+
+// Sets clicked_at on a send's first click. Affects no rows once it is set.
+func (q *Queries) MarkCampaignSendClicked(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markCampaignSendClicked, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const markCampaignSendFailed = `-- name: MarkCampaignSendFailed :exec
 UPDATE campaign_sends
 SET status = 'failed', error_message = ?1
@@ -1130,6 +1213,22 @@ func (q *Queries) MarkCampaignSendFailed(ctx context.Context, arg MarkCampaignSe
 	return err
 }
 
+const markCampaignSendOpened = `-- name: MarkCampaignSendOpened :execrows
+UPDATE campaign_sends
+SET opened_at = datetime('now')
+WHERE id = ?1 AND opened_at IS NULL
+`
+
+// Sets opened_at on a send's first open. Affects no rows once it is set, so
+// concurrent opens count the recipient once.
+func (q *Queries) MarkCampaignSendOpened(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markCampaignSendOpened, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const markCampaignSendPermanentlyFailed = `-- name: MarkCampaignSendPermanentlyFailed :exec
 UPDATE campaign_sends
 SET status = 'permanent_failure'
@@ -1152,6 +1251,22 @@ func (q *Queries) MarkCampaignSendSent(ctx context.Context, id string) error {
 	return err
 }
 
+const pauseSendingCampaign = `-- name: PauseSendingCampaign :execrows
+UPDATE email_campaigns
+SET status = 'paused', updated_at = datetime('now')
+WHERE id = ?1 AND status = 'sending'
+`
+
+// Pauses a campaign that is still sending. Affects no rows once it was
+// paused or cancelled for another reason.
+func (q *Queries) PauseSendingCampaign(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, pauseSendingCampaign, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const recordCampaignClick = `-- name: RecordCampaignClick :exec
 UPDATE campaign_sends
 SET clicked_at = COALESCE(clicked_at, datetime('now')),
@@ -1176,13 +1291,27 @@ func (q *Queries) RecordCampaignOpen(ctx context.Context, id string) error {
 	return err
 }
 
+const resumeQuotaPausedCampaign = `-- name: ResumeQuotaPausedCampaign :exec
+UPDATE email_campaigns
+SET status = 'sending', updated_at = datetime('now')
+WHERE id = ?1 AND status = 'paused'
+  AND EXISTS (SELECT 1 FROM campaign_quota_pauses WHERE campaign_id = ?1)
+`
+
+// Resumes a campaign paused for quota unless it was cancelled or paused for
+// another reason meanwhile
+func (q *Queries) ResumeQuotaPausedCampaign(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, resumeQuotaPausedCampaign, id)
+	return err
+}
+
 const scheduleCampaign = `-- name: ScheduleCampaign :one
 UPDATE email_campaigns
 SET status = 'scheduled',
     scheduled_at = ?1,
     updated_at = datetime('now')
 WHERE id = ?2 AND org_id = ?3 AND status = 'draft'
-RETURNING id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at
+RETURNING id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at, segment_id, delivery_mode
 `
 
 type ScheduleCampaignParams struct {
@@ -1225,6 +1354,8 @@ func (q *Queries) ScheduleCampaign(ctx context.Context, arg ScheduleCampaignPara
 		&i.UnsubscribedCount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SegmentID,
+		&i.DeliveryMode,
 	)
 	return i, err
 }
@@ -1261,9 +1392,11 @@ SET name = COALESCE(NULLIF(?1, ''), name),
     segment_filter = COALESCE(NULLIF(?11, ''), segment_filter),
     track_opens = COALESCE(?12, track_opens),
     track_clicks = COALESCE(?13, track_clicks),
+    segment_id = COALESCE(NULLIF(?14, ''), segment_id),
+    delivery_mode = COALESCE(NULLIF(?15, ''), delivery_mode),
     updated_at = datetime('now')
-WHERE id = ?14 AND org_id = ?15 AND status = 'draft'
-RETURNING id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at
+WHERE id = ?16 AND org_id = ?17 AND status = 'draft'
+RETURNING id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at, segment_id, delivery_mode
 `
 
 type UpdateCampaignParams struct {
@@ -1280,6 +1413,8 @@ type UpdateCampaignParams struct {
 	SegmentFilter  interface{}   `json:"segment_filter"`
 	TrackOpens     sql.NullInt64 `json:"track_opens"`
 	TrackClicks    sql.NullInt64 `json:"track_clicks"`
+	SegmentID      interface{}   `json:"segment_id"`
+	DeliveryMode   interface{}   `json:"delivery_mode"`
 	ID             string        `json:"id"`
 	OrgID          string        `json:"org_id"`
 }
@@ -1299,6 +1434,8 @@ func (q *Queries) UpdateCampaign(ctx context.Context, arg UpdateCampaignParams)
 		arg.SegmentFilter,
 		arg.TrackOpens,
 		arg.TrackClicks,
+		arg.SegmentID,
+		arg.DeliveryMode,
 		arg.ID,
 		arg.OrgID,
 	)
@@ -1334,6 +1471,8 @@ func (q *Queries) UpdateCampaign(ctx context.Context, arg UpdateCampaignParams)
 		&i.UnsubscribedCount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SegmentID,
+		&i.DeliveryMode,
 	)
 	return i, err
 }
@@ -1414,7 +1553,7 @@ SET status = ?1,
     completed_at = CASE WHEN ?1 = 'sent' THEN datetime('now') ELSE completed_at END,
     updated_at = datetime('now')
 WHERE id = ?2 AND org_id = ?3
-RETURNING id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at
+RETURNING id, org_id, design_id, name, subject, preview_text, from_name, from_email, reply_to, html_body, plain_text, list_ids, exclude_list_ids, segment_filter, status, scheduled_at, started_at, completed_at, track_opens, track_clicks, recipients_count, sent_count, delivered_count, opened_count, clicked_count, bounced_count, complained_count, unsubscribed_count, created_at, updated_at, segment_id, delivery_mode
 `
 
 type UpdateCampaignStatusParams struct {
@@ -1457,6 +1596,8 @@ func (q *Queries) UpdateCampaignStatus(ctx context.Context, arg UpdateCampaignSt
 		&i.UnsubscribedCount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SegmentID,
+		&i.DeliveryMode,
 	)
 	return i, err
 }
@@ -87,7 +87,7 @@ INSERT INTO email_lists (
 ) VALUES (
     ?1, ?2, ?3, ?4, ?5,
     ?6, datetime('now'), datetime('now')
-) RETURNING id, public_id, org_id, name, slug, description, double_optin, confirmation_email_subject, confirmation_email_body, created_at, updated_at, public_page_enabled, thank_you_url, confirm_redirect_url, unsubscribe_redirect_url, thank_you_email_enabled, thank_you_email_subject, thank_you_email_body, already_subscribed_url, goodbye_email_enabled, goodbye_email_subject, goodbye_email_body, unsubscribe_behavior, unsubscribe_scope
+) RETURNING id, public_id, org_id, name, slug, description, double_optin, confirmation_email_subject, confirmation_email_body, created_at, updated_at, public_page_enabled, thank_you_url, confirm_redirect_url, unsubscribe_redirect_url, thank_you_email_enabled, thank_you_email_subject, thank_you_email_body, already_subscribed_url, goodbye_email_enabled, goodbye_email_subject, goodbye_email_body, unsubscribe_behavior, unsubscribe_scope, validation_policy
 `
 
 type CreateEmailListParams struct {
@@ -134,6 +134,7 @@ func (q *Queries) CreateEmailList(ctx context.Context, arg CreateEmailListParams
 		&i.GoodbyeEmailBody,
 		&i.UnsubscribeBehavior,
 		&i.UnsubscribeScope,
+		&i.ValidationPolicy,
 	)
 	return i, err
 }
@@ -215,7 +216,7 @@ func (q *Queries) GetAllOrgsListCounts(ctx context.Context) ([]GetAllOrgsListCou
 }
 
 const getEmailList = `-- name: GetEmailList :one
-SELECT id, public_id, org_id, name, slug, description, double_optin, confirmation_email_subject, confirmation_email_body, created_at, updated_at, public_page_enabled, thank_you_url, confirm_redirect_url, unsubscribe_redirect_url, thank_you_email_enabled, thank_you_email_subject, thank_you_email_body, already_subscribed_url, goodbye_email_enabled, goodbye_email_subject, goodbye_email_body, unsubscribe_behavior, unsubscribe_scope FROM email_lists
+SELECT id, public_id, org_id, name, slug, description, double_optin, confirmation_email_subject, confirmation_email_body, created_at, updated_at, public_page_enabled, thank_you_url, confirm_redirect_url, unsubscribe_redirect_url, thank_you_email_enabled, thank_you_email_subject, thank_you_email_body, already_subscribed_url, goodbye_email_enabled, goodbye_email_subject, goodbye_email_body, unsubscribe_behavior, unsubscribe_scope, validation_policy FROM email_lists
 WHERE id = ?1
 `
 
@@ -247,12 +248,13 @@ func (q *Queries) GetEmailList(ctx context.Context, id int64) (EmailList, error)
 		&i.GoodbyeEmailBody,
 		&i.UnsubscribeBehavior,
 		&i.UnsubscribeScope,
+		&i.ValidationPolicy,
 	)
 	return i, err
 }
 
 const getEmailListByOrgAndSlug = `-- name: GetEmailListByOrgAndSlug :one
-SELECT id, public_id, org_id, name, slug, description, double_optin, confirmation_email_subject, confirmation_email_body, created_at, updated_at, public_page_enabled, thank_you_url, confirm_redirect_url, unsubscribe_redirect_url, thank_you_email_enabled, thank_you_email_subject, thank_you_email_body, already_subscribed_url, goodbye_email_enabled, goodbye_email_subject, goodbye_email_body, unsubscribe_behavior, unsubscribe_scope FROM email_lists
+SELECT id, public_id, org_id, name, slug, description, double_optin, confirmation_email_subject, confirmation_email_body, created_at, updated_at, public_page_enabled, thank_you_url, confirm_redirect_url, unsubscribe_redirect_url, thank_you_email_enabled, thank_you_email_subject, thank_you_email_body, already_subscribed_url, goodbye_email_enabled, goodbye_email_subject, goodbye_email_body, unsubscribe_behavior, unsubscribe_scope, validation_policy FROM email_lists
 WHERE org_id = ?1 AND slug = ?2
 LIMIT 1
 `
@@ -290,12 +292,13 @@ func (q *Queries) GetEmailListByOrgAndSlug(ctx context.Context, arg GetEmailList
 		&i.GoodbyeEmailBody,
 		&i.UnsubscribeBehavior,
 		&i.UnsubscribeScope,
+		&i.ValidationPolicy,
 	)
 	return i, err
 }
 
 const getEmailListByPublicID = `-- name: GetEmailListByPublicID :one
-SELECT id, public_id, org_id, name, slug, description, double_optin, confirmation_email_subject, confirmation_email_body, created_at, updated_at, public_page_enabled, thank_you_url, confirm_redirect_url, unsubscribe_redirect_url, thank_you_email_enabled, thank_you_email_subject, thank_you_email_body, already_subscribed_url, goodbye_email_enabled, goodbye_email_subject, goodbye_email_body, unsubscribe_behavior, unsubscribe_scope FROM email_lists
+SELECT id, public_id, org_id, name, slug, description, double_optin, confirmation_email_subject, confirmation_email_body, created_at, updated_at, public_page_enabled, thank_you_url, confirm_redirect_url, unsubscribe_redirect_url, thank_you_email_enabled, thank_you_email_subject, thank_you_email_body, already_subscribed_url, goodbye_email_enabled, goodbye_email_subject, goodbye_email_body, unsubscribe_behavior, unsubscribe_scope, validation_policy FROM email_lists
 WHERE public_id = ?1
 `
 
@@ -327,6 +330,7 @@ func (q *Queries) GetEmailListByPublicID(ctx context.Context, publicID string) (
 		&i.GoodbyeEmailBody,
 		&i.UnsubscribeBehavior,
 		&i.UnsubscribeScope,
+		&i.ValidationPolicy,
 	)
 	return i, err
 }
@@ -650,7 +654,7 @@ func (q *Queries) GetSubscriberSequenceEnrollments(ctx context.Context, contactI
 }
 
 const listEmailLists = `-- name: ListEmailLists :many
-SELECT id, public_id, org_id, name, slug, description, double_optin, confirmation_email_subject, confirmation_email_body, created_at, updated_at, public_page_enabled, thank_you_url, confirm_redirect_url, unsubscribe_redirect_url, thank_you_email_enabled, thank_you_email_subject, thank_you_email_body, already_subscribed_url, goodbye_email_enabled, goodbye_email_subject, goodbye_email_body, unsubscribe_behavior, unsubscribe_scope FROM email_lists
+SELECT id, public_id, org_id, name, slug, description, double_optin, confirmation_email_subject, confirmation_email_body, created_at, updated_at, public_page_enabled, thank_you_url, confirm_redirect_url, unsubscribe_redirect_url, thank_you_email_enabled, thank_you_email_subject, thank_you_email_body, already_subscribed_url, goodbye_email_enabled, goodbye_email_subject, goodbye_email_body, unsubscribe_behavior, unsubscribe_scope, validation_policy FROM email_lists
 WHERE org_id = ?1
 ORDER BY created_at DESC
 `
@@ -689,6 +693,7 @@ func (q *Queries) ListEmailLists(ctx context.Context, orgID string) ([]EmailList
 			&i.GoodbyeEmailBody,
 			&i.UnsubscribeBehavior,
 			&i.UnsubscribeScope,
+			&i.ValidationPolicy,
 		); err != nil {
 			return nil, err
 		}
@@ -913,7 +918,7 @@ SET name = COALESCE(?1, name),
     unsubscribe_scope = COALESCE(NULLIF(?17, ''), unsubscribe_scope),
     updated_at = datetime('now')
 WHERE id = ?18
-RETURNING id, public_id, org_id, name, slug, description, double_optin, confirmation_email_subject, confirmation_email_body, created_at, updated_at, public_page_enabled, thank_you_url, confirm_redirect_url, unsubscribe_redirect_url, thank_you_email_enabled, thank_you_email_subject, thank_you_email_body, already_subscribed_url, goodbye_email_enabled, goodbye_email_subject, goodbye_email_body, unsubscribe_behavior, unsubscribe_scope
+RETURNING id, public_id, org_id, name, slug, description, double_optin, confirmation_email_subject, confirmation_email_body, created_at, updated_at, public_page_enabled, thank_you_url, confirm_redirect_url, unsubscribe_redirect_url, thank_you_email_enabled, thank_you_email_subject, thank_you_email_body, already_subscribed_url, goodbye_email_enabled, goodbye_email_subject, goodbye_email_body, unsubscribe_behavior, unsubscribe_scope, validation_policy
 `
 
 type UpdateEmailListParams struct {
@@ -984,6 +989,24 @@ func (q *Queries) UpdateEmailList(ctx context.Context, arg UpdateEmailListParams
 		&i.GoodbyeEmailBody,
 		&i.UnsubscribeBehavior,
 		&i.UnsubscribeScope,
+		&i.ValidationPolicy,
 	)
 	return i, err
 }
+
+const updateListValidationPolicy = `-- name: UpdateListValidationPolicy :exec
+UPDATE email_lists
+SET validation_policy = ?1, updated_at = datetime('now')
+WHERE id = ?2 AND org_id = ?3
+`
+
+type UpdateListValidationPolicyParams struct {
+	ValidationPolicy sql.NullString `json:"validation_policy"`
+	ID               int64          `json:"id"`
+	OrgID            string         `json:"org_id"`
+}
+
+func (q *Queries) UpdateListValidationPolicy(ctx context.Context, arg UpdateListValidationPolicyParams) error {
+	_, err := q.db.ExecContext(ctx, updateListValidationPolicy, arg.ValidationPolicy, arg.ID, arg.OrgID)
+	return err
+}
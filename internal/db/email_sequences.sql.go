@@ -284,6 +284,22 @@ func (q *Queries) CreateTemplate(ctx context.Context, arg CreateTemplateParams)
 	return i, err
 }
 
+const deferEmail = `-- name: DeferEmail :exec
+UPDATE email_queue
+SET scheduled_for = ?1
+WHERE id = ?2 AND status = 'pending'
+`
+
+type DeferEmailParams struct {
+	ScheduledFor string `json:"scheduled_for"`
+	ID           string `json:"id"`
+}
+
+func (q *Queries) DeferEmail(ctx context.Context, arg DeferEmailParams) error {
+	_, err := q.db.ExecContext(ctx, deferEmail, arg.ScheduledFor, arg.ID)
+	return err
+}
+
 const deleteSequence = `-- name: DeleteSequence :exec
 DELETE FROM email_sequences WHERE id = ?1
 `
@@ -344,7 +360,7 @@ func (q *Queries) GetConfirmationTemplate(ctx context.Context, sequenceID sql.Nu
 }
 
 const getContactByTrackingToken = `-- name: GetContactByTrackingToken :one
-SELECT c.id, c.org_id, c.name, c.email, c.source, c.created_at, c.updated_at, c.email_verified, c.verification_token, c.verification_sent_at, c.verified_at, c.unsubscribed_at, c.blocked_at, c.status, c.gdpr_consent, c.gdpr_consent_at FROM contacts c
+SELECT c.id, c.org_id, c.name, c.email, c.source, c.created_at, c.updated_at, c.email_verified, c.verification_token, c.verification_sent_at, c.verified_at, c.unsubscribed_at, c.blocked_at, c.status, c.gdpr_consent, c.gdpr_consent_at, c.validation_status, c.validation_reason, c.validated_at, c.timezone FROM contacts c
 JOIN email_queue eq ON eq.contact_id = c.id
 WHERE eq.tracking_token = ?1
 `
@@ -369,6 +385,10 @@ func (q *Queries) GetContactByTrackingToken(ctx context.Context, trackingToken s
 		&i.Status,
 		&i.GdprConsent,
 		&i.GdprConsentAt,
+		&i.ValidationStatus,
+		&i.ValidationReason,
+		&i.ValidatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
@@ -747,13 +767,13 @@ func (q *Queries) GetNextTemplate(ctx context.Context, arg GetNextTemplateParams
 
 const getPendingEmails = `-- name: GetPendingEmails :many
 SELECT eq.id, eq.contact_id, eq.template_id, eq.scheduled_for, eq.status, eq.tracking_token,
-       et.subject, et.html_body, et.template_type, et.is_transactional,
-       c.email, c.name
+       et.subject, et.html_body, et.plain_text, et.template_type, et.is_transactional,
+       c.email, c.name, et.org_id
 FROM email_queue eq
 JOIN email_templates et ON et.id = eq.template_id
 JOIN contacts c ON c.id = eq.contact_id
 WHERE eq.status = 'pending' AND eq.scheduled_for <= ?1 AND c.unsubscribed_at IS NULL
-ORDER BY eq.scheduled_for
+ORDER BY ROW_NUMBER() OVER (PARTITION BY et.org_id ORDER BY eq.scheduled_for), eq.scheduled_for
 LIMIT ?2
 `
 
@@ -771,12 +791,15 @@ type GetPendingEmailsRow struct {
 	TrackingToken   sql.NullString `json:"tracking_token"`
 	Subject         string         `json:"subject"`
 	HtmlBody        string         `json:"html_body"`
+	PlainText       sql.NullString `json:"plain_text"`
 	TemplateType    sql.NullString `json:"template_type"`
 	IsTransactional sql.NullInt64  `json:"is_transactional"`
 	Email           string         `json:"email"`
 	Name            string         `json:"name"`
+	OrgID           sql.NullString `json:"org_id"`
 }
 
+// Round-robin across organizations so one organization cannot fill the batch
 func (q *Queries) GetPendingEmails(ctx context.Context, arg GetPendingEmailsParams) ([]GetPendingEmailsRow, error) {
 	rows, err := q.db.QueryContext(ctx, getPendingEmails, arg.ScheduledBefore, arg.LimitCount)
 	if err != nil {
@@ -795,10 +818,12 @@ func (q *Queries) GetPendingEmails(ctx context.Context, arg GetPendingEmailsPara
 			&i.TrackingToken,
 			&i.Subject,
 			&i.HtmlBody,
+			&i.PlainText,
 			&i.TemplateType,
 			&i.IsTransactional,
 			&i.Email,
 			&i.Name,
+			&i.OrgID,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,28 @@
+-- +goose Up
+-- Saved segments: named, reusable audience filters
+-- Membership is computed on demand from the segment filter DSL; contact_count is a cache
+-- refreshed by the segment worker
+
+CREATE TABLE IF NOT EXISTS segments (
+    id TEXT PRIMARY KEY,
+    org_id TEXT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT,
+    list_ids TEXT DEFAULT '[]',   -- JSON array of list IDs; empty = all lists in the org
+    filter TEXT NOT NULL DEFAULT '{}',
+    contact_count INTEGER DEFAULT 0,
+    count_refreshed_at TEXT,
+    created_at TEXT DEFAULT (datetime('now')),
+    updated_at TEXT DEFAULT (datetime('now')),
+    UNIQUE(org_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_segments_org_id ON segments(org_id);
+
+-- Campaigns can target a saved segment in addition to (or instead of) their own filter
+ALTER TABLE email_campaigns ADD COLUMN segment_id TEXT REFERENCES segments(id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE email_campaigns DROP COLUMN segment_id;
+DROP INDEX IF EXISTS idx_segments_org_id;
+DROP TABLE IF EXISTS segments;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Entry rules created through MCP used "list_subscribe"; the runtime matches "list_join"
+UPDATE sequence_entry_rules SET trigger_type = 'list_join' WHERE trigger_type = 'list_subscribe';
+
+CREATE INDEX IF NOT EXISTS idx_sequence_entry_rules_trigger_source ON sequence_entry_rules(trigger_type, source_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_sequence_entry_rules_trigger_source;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Signup email validation: per-list policy and per-contact results
+
+-- JSON validation policy for the list; NULL inherits the organization policy
+-- stored under "validation" in organizations.settings
+ALTER TABLE email_lists ADD COLUMN validation_policy TEXT;
+
+-- Outcome of the last validation run for the contact
+-- 'valid' = accepted, 'invalid' = rejected by policy
+ALTER TABLE contacts ADD COLUMN validation_status TEXT;
+ALTER TABLE contacts ADD COLUMN validation_reason TEXT;
+ALTER TABLE contacts ADD COLUMN validated_at TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_contacts_validation_status ON contacts(org_id, validation_status);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_contacts_validation_status;
+ALTER TABLE contacts DROP COLUMN validated_at;
+ALTER TABLE contacts DROP COLUMN validation_reason;
+ALTER TABLE contacts DROP COLUMN validation_status;
+ALTER TABLE email_lists DROP COLUMN validation_policy;
@@ -0,0 +1,52 @@
+-- +goose Up
+-- Bulk list verification: background jobs that run every subscriber of a
+-- list through emailval and record a verdict per list_subscribers row
+
+CREATE TABLE IF NOT EXISTS list_verification_jobs (
+    id TEXT PRIMARY KEY,
+    org_id TEXT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    list_id INTEGER NOT NULL REFERENCES email_lists(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled')),
+    check_smtp INTEGER NOT NULL DEFAULT 0,
+    concurrency INTEGER NOT NULL DEFAULT 5,
+    total_count INTEGER NOT NULL DEFAULT 0,
+    processed_count INTEGER NOT NULL DEFAULT 0,
+    valid_count INTEGER NOT NULL DEFAULT 0,
+    invalid_count INTEGER NOT NULL DEFAULT 0,
+    disposable_count INTEGER NOT NULL DEFAULT 0,
+    role_count INTEGER NOT NULL DEFAULT 0,
+    catch_all_count INTEGER NOT NULL DEFAULT 0,
+    unknown_count INTEGER NOT NULL DEFAULT 0,
+    error_message TEXT,
+    created_by TEXT,
+    started_at TEXT,
+    completed_at TEXT,
+    created_at TEXT DEFAULT (datetime('now')),
+    updated_at TEXT DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_list_verification_jobs_list ON list_verification_jobs(list_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_list_verification_jobs_status ON list_verification_jobs(status);
+
+-- One row per verified subscriber. Jobs resume after a restart by skipping
+-- subscribers that already have a result.
+-- verdict: 'valid', 'invalid', 'disposable', 'role', 'catch-all', 'unknown'
+CREATE TABLE IF NOT EXISTS list_verification_results (
+    job_id TEXT NOT NULL REFERENCES list_verification_jobs(id) ON DELETE CASCADE,
+    subscriber_id TEXT NOT NULL REFERENCES list_subscribers(id) ON DELETE CASCADE,
+    contact_id TEXT NOT NULL,
+    email TEXT NOT NULL,
+    verdict TEXT NOT NULL,
+    reason TEXT,
+    checked_at TEXT DEFAULT (datetime('now')),
+    PRIMARY KEY (job_id, subscriber_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_list_verification_results_verdict ON list_verification_results(job_id, verdict);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_list_verification_results_verdict;
+DROP TABLE IF EXISTS list_verification_results;
+DROP INDEX IF EXISTS idx_list_verification_jobs_status;
+DROP INDEX IF EXISTS idx_list_verification_jobs_list;
+DROP TABLE IF EXISTS list_verification_jobs;
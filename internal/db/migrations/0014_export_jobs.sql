@@ -0,0 +1,35 @@
+-- +goose Up
+-- Export jobs: background exports of lists, segments, the suppression list,
+-- blocked domains and campaign sends to CSV or NDJSON. Files are written to
+-- local disk and optionally moved to the backup S3 bucket.
+
+CREATE TABLE IF NOT EXISTS export_jobs (
+    id TEXT PRIMARY KEY,
+    org_id TEXT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    type TEXT NOT NULL CHECK (type IN ('list', 'segment', 'suppression_list', 'blocked_domains', 'campaign_sends')),
+    source_id TEXT,               -- list, segment or campaign ID; NULL for org-wide exports
+    format TEXT NOT NULL DEFAULT 'csv' CHECK (format IN ('csv', 'ndjson')),
+    storage_type TEXT NOT NULL DEFAULT 'local' CHECK (storage_type IN ('local', 's3')),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled')),
+    filename TEXT NOT NULL,
+    file_path TEXT,
+    s3_bucket TEXT,
+    s3_key TEXT,
+    file_size INTEGER NOT NULL DEFAULT 0,
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    error_message TEXT,
+    created_by TEXT,
+    started_at TEXT,
+    completed_at TEXT,
+    created_at TEXT DEFAULT (datetime('now')),
+    updated_at TEXT DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_export_jobs_org ON export_jobs(org_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_export_jobs_status ON export_jobs(status);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_export_jobs_status;
+DROP INDEX IF EXISTS idx_export_jobs_org;
+DROP TABLE IF EXISTS export_jobs;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- Attachments on transactional sends. Metadata is always kept so the send
+-- API can report what was attached; the content itself is only kept until
+-- expires_at when attachment retention is enabled.
+
+CREATE TABLE IF NOT EXISTS transactional_attachments (
+    id TEXT PRIMARY KEY,
+    send_id TEXT NOT NULL REFERENCES transactional_sends(id) ON DELETE CASCADE,
+    org_id TEXT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size INTEGER NOT NULL DEFAULT 0,
+    content_id TEXT,              -- set for inline images referenced as cid:
+    content BLOB,                 -- NULL when not retained or after expiry
+    expires_at TEXT,
+    created_at TEXT DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_transactional_attachments_send ON transactional_attachments(send_id, position);
+CREATE INDEX IF NOT EXISTS idx_transactional_attachments_expires ON transactional_attachments(expires_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_transactional_attachments_expires;
+DROP INDEX IF EXISTS idx_transactional_attachments_send;
+DROP TABLE IF EXISTS transactional_attachments;
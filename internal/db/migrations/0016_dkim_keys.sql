@@ -0,0 +1,31 @@
+-- +goose Up
+-- DKIM keys for domains that send through the SMTP relay. SES signs with its
+-- own keys; these are used when Outlet signs the message itself. Each
+-- rotation adds a new version with its own selectors. A version starts as
+-- pending and becomes active once its DNS records are published, which
+-- retires the version before it.
+
+CREATE TABLE IF NOT EXISTS dkim_keys (
+    id TEXT PRIMARY KEY,
+    org_id TEXT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    domain_identity_id TEXT NOT NULL REFERENCES domain_identities(id) ON DELETE CASCADE,
+    domain TEXT NOT NULL,
+    selector TEXT NOT NULL,
+    algorithm TEXT NOT NULL CHECK (algorithm IN ('rsa-sha256', 'ed25519-sha256')),
+    version INTEGER NOT NULL DEFAULT 1,
+    private_key BLOB NOT NULL,    -- PKCS#8, encrypted with the platform key
+    public_key TEXT NOT NULL,     -- base64 value for the p= tag
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'active', 'retired')),
+    activated_at TEXT,
+    retired_at TEXT,
+    created_at TEXT DEFAULT (datetime('now')),
+    UNIQUE(domain_identity_id, selector)
+);
+
+CREATE INDEX IF NOT EXISTS idx_dkim_keys_identity ON dkim_keys(domain_identity_id, version);
+CREATE INDEX IF NOT EXISTS idx_dkim_keys_domain_status ON dkim_keys(domain, status);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_dkim_keys_domain_status;
+DROP INDEX IF EXISTS idx_dkim_keys_identity;
+DROP TABLE IF EXISTS dkim_keys;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- Per-organization daily send counters, so the SES daily quota holds across
+-- restarts. Days are UTC dates; the quota resets at UTC midnight.
+
+CREATE TABLE IF NOT EXISTS org_daily_sends (
+    org_id TEXT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    day TEXT NOT NULL,
+    sent_count INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (org_id, day)
+);
+
+-- Campaigns paused because their organization reached its daily quota. The
+-- scheduler resumes them at resume_at; manual pauses have no row here.
+CREATE TABLE IF NOT EXISTS campaign_quota_pauses (
+    campaign_id TEXT PRIMARY KEY REFERENCES email_campaigns(id) ON DELETE CASCADE,
+    org_id TEXT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    resume_at TEXT NOT NULL,
+    created_at TEXT DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaign_quota_pauses_resume ON campaign_quota_pauses(resume_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_campaign_quota_pauses_resume;
+DROP TABLE IF EXISTS campaign_quota_pauses;
+DROP TABLE IF EXISTS org_daily_sends;
@@ -0,0 +1,38 @@
+-- +goose Up
+-- Durable send queue for transactional mail. The API and SMTP ingress store
+-- the rendered message here and return immediately; the dispatcher sends
+-- queued mail ahead of marketing traffic and retries failures with backoff.
+-- A row is removed once its send is sent or has permanently failed.
+
+CREATE TABLE IF NOT EXISTS transactional_queue (
+    send_id TEXT PRIMARY KEY REFERENCES transactional_sends(id) ON DELETE CASCADE,
+    org_id TEXT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    to_email TEXT NOT NULL,
+    from_name TEXT,
+    from_email TEXT,
+    reply_to TEXT,
+    subject TEXT NOT NULL,
+    html_body TEXT NOT NULL,
+    text_body TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TEXT NOT NULL DEFAULT (datetime('now')),
+    last_error TEXT,
+    created_at TEXT DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_transactional_queue_next ON transactional_queue(next_attempt_at);
+
+-- Idempotency-Key values seen by the send API, so a retried request returns
+-- the original send instead of sending again. Keys are unique per organization.
+CREATE TABLE IF NOT EXISTS transactional_idempotency_keys (
+    org_id TEXT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    idempotency_key TEXT NOT NULL,
+    send_id TEXT NOT NULL REFERENCES transactional_sends(id) ON DELETE CASCADE,
+    created_at TEXT DEFAULT (datetime('now')),
+    PRIMARY KEY (org_id, idempotency_key)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS transactional_idempotency_keys;
+DROP INDEX IF EXISTS idx_transactional_queue_next;
+DROP TABLE IF EXISTS transactional_queue;
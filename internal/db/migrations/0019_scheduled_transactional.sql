@@ -0,0 +1,8 @@
+-- +goose Up
+-- Scheduled transactional sends. send_at is the requested delivery time;
+-- the send is not claimed before it. NULL sends as soon as possible.
+
+ALTER TABLE transactional_queue ADD COLUMN send_at TEXT;
+
+-- +goose Down
+ALTER TABLE transactional_queue DROP COLUMN send_at;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Click tracking records the user agent and IP of campaign clicks, as it
+-- already does for sequence emails.
+
+ALTER TABLE campaign_clicks ADD COLUMN user_agent TEXT;
+ALTER TABLE campaign_clicks ADD COLUMN ip_address TEXT;
+
+-- Keys for signing URLs handed out in emails. Generated once per install.
+CREATE TABLE IF NOT EXISTS signing_keys (
+    name TEXT PRIMARY KEY,
+    secret BLOB NOT NULL,
+    created_at TEXT DEFAULT (datetime('now'))
+);
+
+INSERT INTO signing_keys (name, secret) VALUES ('tracking_links', randomblob(32))
+ON CONFLICT (name) DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS signing_keys;
+ALTER TABLE campaign_clicks DROP COLUMN ip_address;
+ALTER TABLE campaign_clicks DROP COLUMN user_agent;
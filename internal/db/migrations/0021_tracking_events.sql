@@ -0,0 +1,23 @@
+-- +goose Up
+-- Every open and click, whichever kind of send it belongs to. Backs the
+-- per-message event timelines; the send tables keep their summary counters.
+
+CREATE TABLE IF NOT EXISTS tracking_events (
+    id TEXT PRIMARY KEY,
+    org_id TEXT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    send_type TEXT NOT NULL CHECK (send_type IN ('sequence', 'campaign', 'transactional')),
+    send_id TEXT NOT NULL,
+    contact_id TEXT REFERENCES contacts(id) ON DELETE SET NULL,
+    event TEXT NOT NULL CHECK (event IN ('opened', 'clicked')),
+    link_url TEXT,
+    link_name TEXT,
+    user_agent TEXT,
+    ip_address TEXT,
+    created_at TEXT DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_tracking_events_send ON tracking_events(send_type, send_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_tracking_events_contact ON tracking_events(contact_id, event);
+
+-- +goose Down
+DROP TABLE IF EXISTS tracking_events;
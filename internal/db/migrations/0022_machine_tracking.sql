@@ -0,0 +1,11 @@
+-- +goose Up
+-- Opens and clicks made by privacy proxies, link scanners and bots are still
+-- recorded, but flagged so stats can report human engagement separately.
+
+ALTER TABLE tracking_events ADD COLUMN machine INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_tracking_events_org ON tracking_events(org_id, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tracking_events_org;
+ALTER TABLE tracking_events DROP COLUMN machine;
@@ -0,0 +1,43 @@
+-- +goose Up
+-- A/B tests for campaigns. Each variant overrides some of the campaign's
+-- subject, from name and content; unset fields fall back to the campaign.
+-- A share of the audience gets the variants, and the rest gets the winner.
+
+CREATE TABLE IF NOT EXISTS campaign_variants (
+    id TEXT PRIMARY KEY,
+    campaign_id TEXT NOT NULL REFERENCES email_campaigns(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    subject TEXT,
+    from_name TEXT,
+    html_body TEXT,
+    plain_text TEXT,
+    created_at TEXT DEFAULT (datetime('now')),
+    UNIQUE (campaign_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS campaign_ab_tests (
+    campaign_id TEXT PRIMARY KEY REFERENCES email_campaigns(id) ON DELETE CASCADE,
+    test_percent INTEGER NOT NULL CHECK (test_percent BETWEEN 1 AND 100),
+    winner_metric TEXT NOT NULL DEFAULT 'open_rate' CHECK (winner_metric IN ('open_rate', 'click_rate', 'manual')),
+    wait_hours INTEGER NOT NULL DEFAULT 4,
+    -- pending: not started; testing: variants sending; waiting: variants
+    -- sent, waiting for a winner; finished: the rest went to the winner
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'testing', 'waiting', 'finished')),
+    winner_variant_id TEXT REFERENCES campaign_variants(id) ON DELETE SET NULL,
+    test_completed_at TEXT,
+    created_at TEXT DEFAULT (datetime('now')),
+    updated_at TEXT DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaign_ab_tests_status ON campaign_ab_tests(status);
+
+ALTER TABLE campaign_sends ADD COLUMN variant_id TEXT REFERENCES campaign_variants(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_campaign_sends_variant ON campaign_sends(variant_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_campaign_sends_variant;
+ALTER TABLE campaign_sends DROP COLUMN variant_id;
+DROP TABLE IF EXISTS campaign_ab_tests;
+DROP TABLE IF EXISTS campaign_variants;
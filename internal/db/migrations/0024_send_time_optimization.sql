@@ -0,0 +1,22 @@
+-- +goose Up
+-- Campaign delivery timed per recipient instead of all at once.
+
+-- IANA time zone name, set through the SDK or inferred from click IPs
+ALTER TABLE contacts ADD COLUMN timezone TEXT;
+
+-- 'immediate' = send when the campaign starts
+-- 'local_time' = 9am in each contact's time zone
+-- 'optimal_time' = the hour each contact has historically opened most
+ALTER TABLE email_campaigns ADD COLUMN delivery_mode TEXT NOT NULL DEFAULT 'immediate'
+    CHECK (delivery_mode IN ('immediate', 'local_time', 'optimal_time'));
+
+-- When the send is due; NULL sends as soon as possible
+ALTER TABLE campaign_sends ADD COLUMN send_at TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_campaign_sends_due ON campaign_sends(status, send_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_campaign_sends_due;
+ALTER TABLE campaign_sends DROP COLUMN send_at;
+ALTER TABLE email_campaigns DROP COLUMN delivery_mode;
+ALTER TABLE contacts DROP COLUMN timezone;
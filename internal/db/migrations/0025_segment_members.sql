@@ -0,0 +1,21 @@
+-- +goose Up
+-- Membership of segments that have segment_enter entry rules, as of the
+-- segment worker's last pass. Only contacts missing from the previous pass
+-- are enrolled. A segment has a snapshot row once its baseline membership
+-- has been recorded; the first pass records the baseline without enrolling.
+
+CREATE TABLE IF NOT EXISTS segment_member_snapshots (
+    segment_id TEXT PRIMARY KEY REFERENCES segments(id) ON DELETE CASCADE,
+    taken_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE TABLE IF NOT EXISTS segment_members (
+    segment_id TEXT NOT NULL REFERENCES segments(id) ON DELETE CASCADE,
+    contact_id TEXT NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+    entered_at TEXT NOT NULL DEFAULT (datetime('now')),
+    PRIMARY KEY (segment_id, contact_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS segment_members;
+DROP TABLE IF EXISTS segment_member_snapshots;
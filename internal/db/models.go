@@ -61,6 +61,18 @@ type BlockedDomain struct {
 	UpdatedAt     sql.NullString `json:"updated_at"`
 }
 
+type CampaignAbTest struct {
+	CampaignID      string         `json:"campaign_id"`
+	TestPercent     int64          `json:"test_percent"`
+	WinnerMetric    string         `json:"winner_metric"`
+	WaitHours       int64          `json:"wait_hours"`
+	Status          string         `json:"status"`
+	WinnerVariantID sql.NullString `json:"winner_variant_id"`
+	TestCompletedAt sql.NullString `json:"test_completed_at"`
+	CreatedAt       sql.NullString `json:"created_at"`
+	UpdatedAt       sql.NullString `json:"updated_at"`
+}
+
 type CampaignClick struct {
 	ID             string         `json:"id"`
 	CampaignSendID string         `json:"campaign_send_id"`
@@ -69,6 +81,15 @@ type CampaignClick struct {
 	LinkUrl        string         `json:"link_url"`
 	LinkName       sql.NullString `json:"link_name"`
 	ClickedAt      sql.NullString `json:"clicked_at"`
+	UserAgent      sql.NullString `json:"user_agent"`
+	IpAddress      sql.NullString `json:"ip_address"`
+}
+
+type CampaignQuotaPause struct {
+	CampaignID string         `json:"campaign_id"`
+	OrgID      string         `json:"org_id"`
+	ResumeAt   string         `json:"resume_at"`
+	CreatedAt  sql.NullString `json:"created_at"`
 }
 
 type CampaignSend struct {
@@ -89,6 +110,20 @@ type CampaignSend struct {
 	CreatedAt     sql.NullString `json:"created_at"`
 	RetryCount    sql.NullInt64  `json:"retry_count"`
 	FailedAt      sql.NullString `json:"failed_at"`
+	VariantID     sql.NullString `json:"variant_id"`
+	SendAt        sql.NullString `json:"send_at"`
+}
+
+type CampaignVariant struct {
+	ID         string         `json:"id"`
+	CampaignID string         `json:"campaign_id"`
+	Name       string         `json:"name"`
+	Position   int64          `json:"position"`
+	Subject    sql.NullString `json:"subject"`
+	FromName   sql.NullString `json:"from_name"`
+	HtmlBody   sql.NullString `json:"html_body"`
+	PlainText  sql.NullString `json:"plain_text"`
+	CreatedAt  sql.NullString `json:"created_at"`
 }
 
 type Contact struct {
@@ -108,6 +143,10 @@ type Contact struct {
 	Status             sql.NullString `json:"status"`
 	GdprConsent        sql.NullInt64  `json:"gdpr_consent"`
 	GdprConsentAt      sql.NullString `json:"gdpr_consent_at"`
+	ValidationStatus   sql.NullString `json:"validation_status"`
+	ValidationReason   sql.NullString `json:"validation_reason"`
+	ValidatedAt        sql.NullString `json:"validated_at"`
+	Timezone           sql.NullString `json:"timezone"`
 }
 
 type ContactSequenceState struct {
@@ -153,6 +192,22 @@ type CustomFieldValue struct {
 	UpdatedAt    sql.NullString `json:"updated_at"`
 }
 
+type DkimKey struct {
+	ID               string         `json:"id"`
+	OrgID            string         `json:"org_id"`
+	DomainIdentityID string         `json:"domain_identity_id"`
+	Domain           string         `json:"domain"`
+	Selector         string         `json:"selector"`
+	Algorithm        string         `json:"algorithm"`
+	Version          int64          `json:"version"`
+	PrivateKey       []byte         `json:"private_key"`
+	PublicKey        string         `json:"public_key"`
+	Status           string         `json:"status"`
+	ActivatedAt      sql.NullString `json:"activated_at"`
+	RetiredAt        sql.NullString `json:"retired_at"`
+	CreatedAt        sql.NullString `json:"created_at"`
+}
+
 type DomainIdentity struct {
 	ID                 string         `json:"id"`
 	OrgID              string         `json:"org_id"`
@@ -213,6 +268,8 @@ type EmailCampaign struct {
 	UnsubscribedCount sql.NullInt64  `json:"unsubscribed_count"`
 	CreatedAt         sql.NullString `json:"created_at"`
 	UpdatedAt         sql.NullString `json:"updated_at"`
+	SegmentID         sql.NullString `json:"segment_id"`
+	DeliveryMode      string         `json:"delivery_mode"`
 }
 
 type EmailClick struct {
@@ -278,6 +335,7 @@ type EmailList struct {
 	GoodbyeEmailBody         sql.NullString `json:"goodbye_email_body"`
 	UnsubscribeBehavior      sql.NullString `json:"unsubscribe_behavior"`
 	UnsubscribeScope         sql.NullString `json:"unsubscribe_scope"`
+	ValidationPolicy         sql.NullString `json:"validation_policy"`
 }
 
 type EmailQueue struct {
@@ -327,6 +385,29 @@ type EmailTemplate struct {
 	CreatedAt       sql.NullString `json:"created_at"`
 }
 
+type ExportJob struct {
+	ID            string         `json:"id"`
+	OrgID         string         `json:"org_id"`
+	Type          string         `json:"type"`
+	SourceID      sql.NullString `json:"source_id"`
+	Format        string         `json:"format"`
+	StorageType   string         `json:"storage_type"`
+	Status        string         `json:"status"`
+	Filename      string         `json:"filename"`
+	FilePath      sql.NullString `json:"file_path"`
+	S3Bucket      sql.NullString `json:"s3_bucket"`
+	S3Key         sql.NullString `json:"s3_key"`
+	FileSize      int64          `json:"file_size"`
+	TotalRows     int64          `json:"total_rows"`
+	ProcessedRows int64          `json:"processed_rows"`
+	ErrorMessage  sql.NullString `json:"error_message"`
+	CreatedBy     sql.NullString `json:"created_by"`
+	StartedAt     sql.NullString `json:"started_at"`
+	CompletedAt   sql.NullString `json:"completed_at"`
+	CreatedAt     sql.NullString `json:"created_at"`
+	UpdatedAt     sql.NullString `json:"updated_at"`
+}
+
 type ImportJob struct {
 	ID            string         `json:"id"`
 	OrgID         string         `json:"org_id"`
@@ -358,6 +439,39 @@ type ListSubscriber struct {
 	UnsubscribedAt     sql.NullString `json:"unsubscribed_at"`
 }
 
+type ListVerificationJob struct {
+	ID              string         `json:"id"`
+	OrgID           string         `json:"org_id"`
+	ListID          int64          `json:"list_id"`
+	Status          string         `json:"status"`
+	CheckSmtp       int64          `json:"check_smtp"`
+	Concurrency     int64          `json:"concurrency"`
+	TotalCount      int64          `json:"total_count"`
+	ProcessedCount  int64          `json:"processed_count"`
+	ValidCount      int64          `json:"valid_count"`
+	InvalidCount    int64          `json:"invalid_count"`
+	DisposableCount int64          `json:"disposable_count"`
+	RoleCount       int64          `json:"role_count"`
+	CatchAllCount   int64          `json:"catch_all_count"`
+	UnknownCount    int64          `json:"unknown_count"`
+	ErrorMessage    sql.NullString `json:"error_message"`
+	CreatedBy       sql.NullString `json:"created_by"`
+	StartedAt       sql.NullString `json:"started_at"`
+	CompletedAt     sql.NullString `json:"completed_at"`
+	CreatedAt       sql.NullString `json:"created_at"`
+	UpdatedAt       sql.NullString `json:"updated_at"`
+}
+
+type ListVerificationResult struct {
+	JobID        string         `json:"job_id"`
+	SubscriberID string         `json:"subscriber_id"`
+	ContactID    string         `json:"contact_id"`
+	Email        string         `json:"email"`
+	Verdict      string         `json:"verdict"`
+	Reason       sql.NullString `json:"reason"`
+	CheckedAt    sql.NullString `json:"checked_at"`
+}
+
 type McpApiKey struct {
 	ID         string         `json:"id"`
 	UserID     string         `json:"user_id"`
@@ -422,6 +536,12 @@ type McpSession struct {
 	UpdatedAt string         `json:"updated_at"`
 }
 
+type OrgDailySend struct {
+	OrgID     string `json:"org_id"`
+	Day       string `json:"day"`
+	SentCount int64  `json:"sent_count"`
+}
+
 type OrgInvitation struct {
 	ID         string         `json:"id"`
 	OrgID      string         `json:"org_id"`
@@ -499,6 +619,30 @@ type RuleTemplate struct {
 	CreatedAt          sql.NullString `json:"created_at"`
 }
 
+type Segment struct {
+	ID               string         `json:"id"`
+	OrgID            string         `json:"org_id"`
+	Name             string         `json:"name"`
+	Description      sql.NullString `json:"description"`
+	ListIds          sql.NullString `json:"list_ids"`
+	Filter           string         `json:"filter"`
+	ContactCount     sql.NullInt64  `json:"contact_count"`
+	CountRefreshedAt sql.NullString `json:"count_refreshed_at"`
+	CreatedAt        sql.NullString `json:"created_at"`
+	UpdatedAt        sql.NullString `json:"updated_at"`
+}
+
+type SegmentMember struct {
+	SegmentID string `json:"segment_id"`
+	ContactID string `json:"contact_id"`
+	EnteredAt string `json:"entered_at"`
+}
+
+type SegmentMemberSnapshot struct {
+	SegmentID string `json:"segment_id"`
+	TakenAt   string `json:"taken_at"`
+}
+
 type SequenceEntryRule struct {
 	ID          string         `json:"id"`
 	SequenceID  string         `json:"sequence_id"`
@@ -509,6 +653,12 @@ type SequenceEntryRule struct {
 	CreatedAt   sql.NullString `json:"created_at"`
 }
 
+type SigningKey struct {
+	Name      string         `json:"name"`
+	Secret    []byte         `json:"secret"`
+	CreatedAt sql.NullString `json:"created_at"`
+}
+
 type SubscriberCustomField struct {
 	ID         int64          `json:"id"`
 	ListID     int64          `json:"list_id"`
@@ -530,6 +680,35 @@ type SuppressionList struct {
 	CreatedAt     sql.NullString `json:"created_at"`
 }
 
+type TrackingEvent struct {
+	ID        string         `json:"id"`
+	OrgID     string         `json:"org_id"`
+	SendType  string         `json:"send_type"`
+	SendID    string         `json:"send_id"`
+	ContactID sql.NullString `json:"contact_id"`
+	Event     string         `json:"event"`
+	LinkUrl   sql.NullString `json:"link_url"`
+	LinkName  sql.NullString `json:"link_name"`
+	UserAgent sql.NullString `json:"user_agent"`
+	IpAddress sql.NullString `json:"ip_address"`
+	CreatedAt sql.NullString `json:"created_at"`
+	Machine   int64          `json:"machine"`
+}
+
+type TransactionalAttachment struct {
+	ID          string         `json:"id"`
+	SendID      string         `json:"send_id"`
+	OrgID       string         `json:"org_id"`
+	Position    int64          `json:"position"`
+	Filename    string         `json:"filename"`
+	ContentType string         `json:"content_type"`
+	Size        int64          `json:"size"`
+	ContentID   sql.NullString `json:"content_id"`
+	Content     []byte         `json:"content"`
+	ExpiresAt   sql.NullString `json:"expires_at"`
+	CreatedAt   sql.NullString `json:"created_at"`
+}
+
 type TransactionalEmail struct {
 	ID          string         `json:"id"`
 	OrgID       string         `json:"org_id"`
@@ -548,6 +727,30 @@ type TransactionalEmail struct {
 	UpdatedAt   sql.NullString `json:"updated_at"`
 }
 
+type TransactionalIdempotencyKey struct {
+	OrgID          string         `json:"org_id"`
+	IdempotencyKey string         `json:"idempotency_key"`
+	SendID         string         `json:"send_id"`
+	CreatedAt      sql.NullString `json:"created_at"`
+}
+
+type TransactionalQueue struct {
+	SendID        string         `json:"send_id"`
+	OrgID         string         `json:"org_id"`
+	ToEmail       string         `json:"to_email"`
+	FromName      sql.NullString `json:"from_name"`
+	FromEmail     sql.NullString `json:"from_email"`
+	ReplyTo       sql.NullString `json:"reply_to"`
+	Subject       string         `json:"subject"`
+	HtmlBody      string         `json:"html_body"`
+	TextBody      sql.NullString `json:"text_body"`
+	Attempts      int64          `json:"attempts"`
+	NextAttemptAt string         `json:"next_attempt_at"`
+	LastError     sql.NullString `json:"last_error"`
+	CreatedAt     sql.NullString `json:"created_at"`
+	SendAt        sql.NullString `json:"send_at"`
+}
+
 type TransactionalSend struct {
 	ID            string         `json:"id"`
 	TemplateID    string         `json:"template_id"`
@@ -69,7 +69,7 @@ func (q *Queries) GetCampaignSendByTrackingToken(ctx context.Context, token sql.
 }
 
 const getContactByEmailForPublicPage = `-- name: GetContactByEmailForPublicPage :one
-SELECT c.id, c.org_id, c.name, c.email, c.source, c.created_at, c.updated_at, c.email_verified, c.verification_token, c.verification_sent_at, c.verified_at, c.unsubscribed_at, c.blocked_at, c.status, c.gdpr_consent, c.gdpr_consent_at, ls.status as subscription_status, ls.list_id
+SELECT c.id, c.org_id, c.name, c.email, c.source, c.created_at, c.updated_at, c.email_verified, c.verification_token, c.verification_sent_at, c.verified_at, c.unsubscribed_at, c.blocked_at, c.status, c.gdpr_consent, c.gdpr_consent_at, c.validation_status, c.validation_reason, c.validated_at, c.timezone, ls.status as subscription_status, ls.list_id
 FROM contacts c
 LEFT JOIN list_subscribers ls ON ls.contact_id = c.id AND ls.list_id = ?1
 WHERE c.email = ?2 AND c.org_id = ?3
@@ -99,6 +99,10 @@ type GetContactByEmailForPublicPageRow struct {
 	Status             sql.NullString `json:"status"`
 	GdprConsent        sql.NullInt64  `json:"gdpr_consent"`
 	GdprConsentAt      sql.NullString `json:"gdpr_consent_at"`
+	ValidationStatus   sql.NullString `json:"validation_status"`
+	ValidationReason   sql.NullString `json:"validation_reason"`
+	ValidatedAt        sql.NullString `json:"validated_at"`
+	Timezone           sql.NullString `json:"timezone"`
 	SubscriptionStatus sql.NullString `json:"subscription_status"`
 	ListID             sql.NullInt64  `json:"list_id"`
 }
@@ -123,6 +127,10 @@ func (q *Queries) GetContactByEmailForPublicPage(ctx context.Context, arg GetCon
 		&i.Status,
 		&i.GdprConsent,
 		&i.GdprConsentAt,
+		&i.ValidationStatus,
+		&i.ValidationReason,
+		&i.ValidatedAt,
+		&i.Timezone,
 		&i.SubscriptionStatus,
 		&i.ListID,
 	)
@@ -280,6 +288,7 @@ SELECT
     el.unsubscribe_redirect_url,
     el.confirmation_email_subject,
     el.confirmation_email_body,
+    el.validation_policy,
     o.name as org_name,
     o.slug as org_slug,
     o.from_name,
@@ -306,6 +315,7 @@ type GetListByPublicIDForPublicPageRow struct {
 	UnsubscribeRedirectUrl   sql.NullString `json:"unsubscribe_redirect_url"`
 	ConfirmationEmailSubject sql.NullString `json:"confirmation_email_subject"`
 	ConfirmationEmailBody    sql.NullString `json:"confirmation_email_body"`
+	ValidationPolicy         sql.NullString `json:"validation_policy"`
 	OrgName                  string         `json:"org_name"`
 	OrgSlug                  string         `json:"org_slug"`
 	FromName                 sql.NullString `json:"from_name"`
@@ -330,6 +340,7 @@ func (q *Queries) GetListByPublicIDForPublicPage(ctx context.Context, publicID s
 		&i.UnsubscribeRedirectUrl,
 		&i.ConfirmationEmailSubject,
 		&i.ConfirmationEmailBody,
+		&i.ValidationPolicy,
 		&i.OrgName,
 		&i.OrgSlug,
 		&i.FromName,
@@ -10,14 +10,17 @@ import (
 )
 
 type Querier interface {
+	ActivateDKIMKeyVersion(ctx context.Context, arg ActivateDKIMKeyVersionParams) error
 	AddBlockedDomain(ctx context.Context, arg AddBlockedDomainParams) (BlockedDomain, error)
 	AddContactTag(ctx context.Context, arg AddContactTagParams) (ContactTag, error)
+	AddSegmentMember(ctx context.Context, arg AddSegmentMemberParams) error
 	// ========== SUPPRESSION LIST ==========
 	AddToSuppressionList(ctx context.Context, arg AddToSuppressionListParams) (SuppressionList, error)
 	AddUserToOrganization(ctx context.Context, arg AddUserToOrganizationParams) error
 	BlockContact(ctx context.Context, id string) error
 	// ========== BLOCK CONTACT BY EMAIL ==========
 	BlockContactByEmail(ctx context.Context, email string) error
+	BlockOrgContactByEmail(ctx context.Context, arg BlockOrgContactByEmailParams) error
 	// Used when subscribing with multiple field values at once
 	BulkCreateCustomFieldValues(ctx context.Context, arg BulkCreateCustomFieldValuesParams) error
 	BulkInsertBlockedDomains(ctx context.Context, arg BulkInsertBlockedDomainsParams) error
@@ -25,6 +28,7 @@ type Querier interface {
 	CancelContactSequence(ctx context.Context, arg CancelContactSequenceParams) error
 	CancelEmail(ctx context.Context, id string) error
 	CancelEmailsForContact(ctx context.Context, contactID sql.NullString) error
+	CancelExportJob(ctx context.Context, arg CancelExportJobParams) (ExportJob, error)
 	CancelImportJob(ctx context.Context, arg CancelImportJobParams) error
 	CancelPendingEmailsForContactSequence(ctx context.Context, arg CancelPendingEmailsForContactSequenceParams) error
 	CheckCampaignSendExists(ctx context.Context, arg CheckCampaignSendExistsParams) (int64, error)
@@ -32,13 +36,21 @@ type Querier interface {
 	// Check if an event has already been processed by a rule
 	CheckEventProcessed(ctx context.Context, arg CheckEventProcessedParams) (int64, error)
 	CheckListSubscription(ctx context.Context, arg CheckListSubscriptionParams) (int64, error)
+	// Claims due messages by pushing next_attempt_at to lease_until, so a message
+	// whose sender crashed is picked up again once the lease runs out.
+	// Round-robin across organizations so one organization cannot fill the batch.
+	ClaimTransactionalQueue(ctx context.Context, arg ClaimTransactionalQueueParams) ([]TransactionalQueue, error)
 	CleanupExpiredMCPOAuthCodes(ctx context.Context) error
 	CleanupExpiredMCPOAuthTokens(ctx context.Context) error
 	// Delete sessions older than 30 days
 	CleanupOldMCPSessions(ctx context.Context) error
+	ClearCampaignSegment(ctx context.Context, arg ClearCampaignSegmentParams) error
 	ClearSuppressionList(ctx context.Context, orgID string) error
 	CompleteContactSequence(ctx context.Context, arg CompleteContactSequenceParams) error
+	CompleteExportJob(ctx context.Context, arg CompleteExportJobParams) (ExportJob, error)
 	ConfirmListSubscription(ctx context.Context, token sql.NullString) (ListSubscriber, error)
+	// Campaigns that would widen to their whole lists if the segment went away
+	CountActiveCampaignsUsingSegment(ctx context.Context, arg CountActiveCampaignsUsingSegmentParams) (int64, error)
 	CountActiveSequencesForContact(ctx context.Context, contactID sql.NullString) (int64, error)
 	CountActiveSubscribers(ctx context.Context, listID int64) (int64, error)
 	// Count automation log entries with optional filters
@@ -46,6 +58,7 @@ type Querier interface {
 	CountBackups(ctx context.Context) (int64, error)
 	CountBlockedDomains(ctx context.Context, orgID string) (int64, error)
 	CountBouncesInDateRange(ctx context.Context, arg CountBouncesInDateRangeParams) (int64, error)
+	CountCampaignSends(ctx context.Context, campaignID string) (int64, error)
 	CountCampaignSendsByStatus(ctx context.Context, arg CountCampaignSendsByStatusParams) (int64, error)
 	CountCampaigns(ctx context.Context, orgID string) (int64, error)
 	CountCampaignsByStatus(ctx context.Context, arg CountCampaignsByStatusParams) (int64, error)
@@ -57,8 +70,11 @@ type Querier interface {
 	CountCustomFieldsByList(ctx context.Context, listID int64) (int64, error)
 	CountEmailDesigns(ctx context.Context, orgID string) (int64, error)
 	CountEmailDesignsByCategory(ctx context.Context, arg CountEmailDesignsByCategoryParams) (int64, error)
+	CountExportJobs(ctx context.Context, orgID string) (int64, error)
 	CountInactiveContacts90Days(ctx context.Context, orgID sql.NullString) (int64, error)
 	CountListSubscribers(ctx context.Context, arg CountListSubscribersParams) (int64, error)
+	CountListSubscribersForVerification(ctx context.Context, listID int64) (int64, error)
+	CountListVerificationResults(ctx context.Context, arg CountListVerificationResultsParams) (int64, error)
 	// Count rules with optional filters
 	CountOrgRules(ctx context.Context, arg CountOrgRulesParams) (int64, error)
 	CountPendingCampaignSends(ctx context.Context, campaignID string) (int64, error)
@@ -81,11 +97,13 @@ type Querier interface {
 	CreateCampaignClick(ctx context.Context, arg CreateCampaignClickParams) (CampaignClick, error)
 	// Campaign Sends
 	CreateCampaignSend(ctx context.Context, arg CreateCampaignSendParams) (CampaignSend, error)
+	CreateCampaignVariant(ctx context.Context, arg CreateCampaignVariantParams) (CampaignVariant, error)
 	CreateContact(ctx context.Context, arg CreateContactParams) (Contact, error)
 	CreateContactSequenceState(ctx context.Context, arg CreateContactSequenceStateParams) (ContactSequenceState, error)
 	CreateCustomField(ctx context.Context, arg CreateCustomFieldParams) (CustomField, error)
 	// Custom Field Values
 	CreateCustomFieldValue(ctx context.Context, arg CreateCustomFieldValueParams) (CustomFieldValue, error)
+	CreateDKIMKey(ctx context.Context, arg CreateDKIMKeyParams) (DkimKey, error)
 	CreateDomainIdentity(ctx context.Context, arg CreateDomainIdentityParams) (DomainIdentity, error)
 	// Email blocklist queries for bounce and complaint management
 	// ========== BOUNCES ==========
@@ -98,9 +116,12 @@ type Querier interface {
 	CreateEmailDesign(ctx context.Context, arg CreateEmailDesignParams) (EmailDesign, error)
 	CreateEmailList(ctx context.Context, arg CreateEmailListParams) (EmailList, error)
 	CreateEntryRule(ctx context.Context, arg CreateEntryRuleParams) (SequenceEntryRule, error)
+	CreateExportJob(ctx context.Context, arg CreateExportJobParams) (ExportJob, error)
 	// ========== IMPORT JOBS ==========
 	CreateImportJob(ctx context.Context, arg CreateImportJobParams) (ImportJob, error)
 	CreateListSubscriber(ctx context.Context, arg CreateListSubscriberParams) (ListSubscriber, error)
+	CreateListVerificationJob(ctx context.Context, arg CreateListVerificationJobParams) (ListVerificationJob, error)
+	CreateListVerificationResult(ctx context.Context, arg CreateListVerificationResultParams) error
 	// MCP API Keys
 	CreateMCPAPIKey(ctx context.Context, arg CreateMCPAPIKeyParams) (McpApiKey, error)
 	// MCP OAuth Clients
@@ -114,23 +135,38 @@ type Querier interface {
 	CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (Organization, error)
 	// Create a new rule template (platform admin only)
 	CreateRuleTemplate(ctx context.Context, arg CreateRuleTemplateParams) (RuleTemplate, error)
+	// Saved Segments
+	// Named, reusable audience filters
+	CreateSegment(ctx context.Context, arg CreateSegmentParams) (Segment, error)
 	CreateSequence(ctx context.Context, arg CreateSequenceParams) (EmailSequence, error)
 	CreateTemplate(ctx context.Context, arg CreateTemplateParams) (EmailTemplate, error)
+	CreateTrackingEvent(ctx context.Context, arg CreateTrackingEventParams) error
+	// Attachments
+	CreateTransactionalAttachment(ctx context.Context, arg CreateTransactionalAttachmentParams) error
 	// Transactional Emails
 	// API-triggered transactional email templates and sends
 	// Templates
 	CreateTransactionalEmail(ctx context.Context, arg CreateTransactionalEmailParams) (TransactionalEmail, error)
+	// Records the send for a key. Affects no rows when the key was already used.
+	CreateTransactionalIdempotencyKey(ctx context.Context, arg CreateTransactionalIdempotencyKeyParams) (int64, error)
 	// Sends (Logging)
 	CreateTransactionalSend(ctx context.Context, arg CreateTransactionalSendParams) (TransactionalSend, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error)
 	CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error)
 	CreateWebhookLog(ctx context.Context, arg CreateWebhookLogParams) (WebhookLog, error)
 	DeactivateMCPOAuthClient(ctx context.Context, id string) error
+	DeferCampaignSend(ctx context.Context, arg DeferCampaignSendParams) error
+	DeferEmail(ctx context.Context, arg DeferEmailParams) error
+	// Reschedules a message without counting the claim as an attempt
+	DeferTransactionalQueueItem(ctx context.Context, arg DeferTransactionalQueueItemParams) error
 	DeleteAuthTokensByUser(ctx context.Context, arg DeleteAuthTokensByUserParams) error
 	DeleteBackup(ctx context.Context, id string) error
 	DeleteBlockedDomain(ctx context.Context, arg DeleteBlockedDomainParams) error
 	DeleteBlockedDomainByID(ctx context.Context, arg DeleteBlockedDomainByIDParams) error
 	DeleteCampaign(ctx context.Context, arg DeleteCampaignParams) error
+	DeleteCampaignABTest(ctx context.Context, campaignID string) error
+	DeleteCampaignQuotaPause(ctx context.Context, campaignID string) error
+	DeleteCampaignVariants(ctx context.Context, campaignID string) error
 	DeleteContact(ctx context.Context, id string) error
 	DeleteCustomField(ctx context.Context, id string) error
 	DeleteCustomFieldValue(ctx context.Context, arg DeleteCustomFieldValueParams) error
@@ -145,26 +181,45 @@ type Querier interface {
 	DeleteEntryRule(ctx context.Context, id string) error
 	DeleteEntryRulesBySequence(ctx context.Context, sequenceID string) error
 	DeleteExpiredAuthTokens(ctx context.Context) error
+	DeleteExportJob(ctx context.Context, arg DeleteExportJobParams) error
 	DeleteFromSuppressionList(ctx context.Context, arg DeleteFromSuppressionListParams) error
 	DeleteImportJob(ctx context.Context, arg DeleteImportJobParams) error
 	DeleteInactiveContacts90Days(ctx context.Context, orgID sql.NullString) (int64, error)
 	DeleteMCPAPIKey(ctx context.Context, id string) error
 	DeleteMCPSession(ctx context.Context, sessionID string) error
 	DeleteOldBackups(ctx context.Context, daysAgo sql.NullString) error
+	DeleteOrgDailySendsBefore(ctx context.Context, day string) error
 	// Delete a rule
 	DeleteOrgRule(ctx context.Context, arg DeleteOrgRuleParams) error
 	DeleteOrganization(ctx context.Context, id string) error
 	DeletePlatformSetting(ctx context.Context, key string) error
 	// Delete a rule template
 	DeleteRuleTemplate(ctx context.Context, id string) error
+	DeleteSegment(ctx context.Context, arg DeleteSegmentParams) error
+	DeleteSegmentMemberSnapshot(ctx context.Context, segmentID string) error
+	DeleteSegmentMembers(ctx context.Context, segmentID string) error
 	DeleteSequence(ctx context.Context, id string) error
 	DeleteSuppressionByID(ctx context.Context, arg DeleteSuppressionByIDParams) error
 	DeleteTemplate(ctx context.Context, id string) error
 	DeleteTransactionalEmail(ctx context.Context, arg DeleteTransactionalEmailParams) error
+	DeleteTransactionalIdempotencyKeys(ctx context.Context, sendID string) error
+	DeleteTransactionalQueueItem(ctx context.Context, sendID string) error
+	DeleteTransactionalSend(ctx context.Context, arg DeleteTransactionalSendParams) error
+	DeleteTransactionalSendAttachments(ctx context.Context, sendID string) error
 	DeleteUnconfirmedContactsOlderThan(ctx context.Context, arg DeleteUnconfirmedContactsOlderThanParams) (int64, error)
+	DeleteUnknownListVerificationResults(ctx context.Context, jobID string) error
 	DeleteUser(ctx context.Context, id string) error
 	DeleteWebhook(ctx context.Context, arg DeleteWebhookParams) error
+	// Removes a send from the queue if the dispatcher has not claimed it yet.
+	// Affects no rows once the send has been picked up.
+	DequeueUnclaimedTransactionalSend(ctx context.Context, arg DequeueUnclaimedTransactionalSendParams) (int64, error)
+	// A message with send_at is not claimed before that time.
+	EnqueueTransactionalSend(ctx context.Context, arg EnqueueTransactionalSendParams) error
+	FailExportJob(ctx context.Context, arg FailExportJobParams) (ExportJob, error)
 	GetActiveEntryRuleForTrigger(ctx context.Context, arg GetActiveEntryRuleForTriggerParams) ([]GetActiveEntryRuleForTriggerRow, error)
+	// Active entry rules of an org for triggers whose source is not a globally unique ID (tag_added, link_clicked)
+	GetActiveEntryRulesForOrgTrigger(ctx context.Context, arg GetActiveEntryRulesForOrgTriggerParams) ([]GetActiveEntryRulesForOrgTriggerRow, error)
+	GetActiveListVerificationJob(ctx context.Context, listID int64) (ListVerificationJob, error)
 	GetActiveSequenceForContact(ctx context.Context, contactID sql.NullString) (GetActiveSequenceForContactRow, error)
 	GetActiveSubscribersForList(ctx context.Context, listID int64) ([]GetActiveSubscribersForListRow, error)
 	// Get all rules for an organization (including disabled), for admin listing
@@ -185,13 +240,21 @@ type Querier interface {
 	GetBackupsByType(ctx context.Context, arg GetBackupsByTypeParams) ([]BackupHistory, error)
 	GetBackupsInProgress(ctx context.Context) ([]BackupHistory, error)
 	GetBlockedDomain(ctx context.Context, arg GetBlockedDomainParams) (BlockedDomain, error)
+	GetBlockedDomainsForExport(ctx context.Context, arg GetBlockedDomainsForExportParams) ([]BlockedDomain, error)
 	GetCampaign(ctx context.Context, arg GetCampaignParams) (EmailCampaign, error)
+	GetCampaignABTest(ctx context.Context, campaignID string) (CampaignAbTest, error)
 	// Campaign Scheduler Queries
 	GetCampaignByID(ctx context.Context, id string) (EmailCampaign, error)
+	// Counts the campaign's recipients with an open or click that was not made
+	// by a machine
+	GetCampaignHumanEngagement(ctx context.Context, campaignID string) (GetCampaignHumanEngagementRow, error)
 	GetCampaignLinkStats(ctx context.Context, campaignID string) ([]GetCampaignLinkStatsRow, error)
 	GetCampaignSend(ctx context.Context, id string) (CampaignSend, error)
 	GetCampaignSendByTracking(ctx context.Context, trackingToken sql.NullString) (CampaignSend, error)
 	GetCampaignSendByTrackingToken(ctx context.Context, token sql.NullString) (GetCampaignSendByTrackingTokenRow, error)
+	GetCampaignSendsForExport(ctx context.Context, arg GetCampaignSendsForExportParams) ([]GetCampaignSendsForExportRow, error)
+	// Opens and clicks per variant; human counts leave out machine traffic
+	GetCampaignVariantStats(ctx context.Context, campaignID string) ([]GetCampaignVariantStatsRow, error)
 	GetConfirmationTemplate(ctx context.Context, sequenceID sql.NullString) (GetConfirmationTemplateRow, error)
 	GetContact(ctx context.Context, id string) (Contact, error)
 	GetContactByEmail(ctx context.Context, email string) (Contact, error)
@@ -242,6 +305,8 @@ type Querier interface {
 	// Get enabled rules for a specific entity (e.g., email_list, product)
 	GetEntityRules(ctx context.Context, arg GetEntityRulesParams) ([]OrgRule, error)
 	GetEntryRule(ctx context.Context, id string) (SequenceEntryRule, error)
+	GetExpiredBackups(ctx context.Context, daysAgo sql.NullString) ([]BackupHistory, error)
+	GetExportJob(ctx context.Context, arg GetExportJobParams) (ExportJob, error)
 	// Retry Worker Queries
 	GetFailedCampaignSendsForRetry(ctx context.Context, limitCount int64) ([]GetFailedCampaignSendsForRetryRow, error)
 	GetImportJob(ctx context.Context, arg GetImportJobParams) (ImportJob, error)
@@ -255,6 +320,8 @@ type Querier interface {
 	GetListSubscriberByID(ctx context.Context, id string) (GetListSubscriberByIDRow, error)
 	GetListSubscriberByToken(ctx context.Context, token sql.NullString) (GetListSubscriberByTokenRow, error)
 	GetListSubscriberDetail(ctx context.Context, id string) (GetListSubscriberDetailRow, error)
+	GetListSubscribersForExport(ctx context.Context, arg GetListSubscribersForExportParams) ([]GetListSubscribersForExportRow, error)
+	GetListVerificationJob(ctx context.Context, id string) (ListVerificationJob, error)
 	GetMCPAPIKeyByHash(ctx context.Context, keyHash string) (GetMCPAPIKeyByHashRow, error)
 	GetMCPAPIKeyByPrefix(ctx context.Context, keyPrefix string) ([]McpApiKey, error)
 	GetMCPOAuthClientByClientID(ctx context.Context, clientID string) (McpOauthClient, error)
@@ -264,9 +331,14 @@ type Querier interface {
 	GetMCPSession(ctx context.Context, sessionID string) (McpSession, error)
 	// Fallback: get most recent org selection for a user (when session ID changes)
 	GetMCPSessionByUser(ctx context.Context, userID string) (McpSession, error)
+	GetMaxDKIMKeyVersion(ctx context.Context, domainIdentityID string) (int64, error)
 	GetNextTemplate(ctx context.Context, arg GetNextTemplateParams) (GetNextTemplateRow, error)
+	GetOrgDailySends(ctx context.Context, arg GetOrgDailySendsParams) (int64, error)
 	GetOrgEmailConfig(ctx context.Context, id string) (GetOrgEmailConfigRow, error)
 	GetOrgEmailSettings(ctx context.Context, id string) (GetOrgEmailSettingsRow, error)
+	// Counts the org's opened and clicked sends in a period, in total and by
+	// humans only
+	GetOrgEngagement(ctx context.Context, arg GetOrgEngagementParams) (GetOrgEngagementRow, error)
 	// Get a single rule by ID
 	GetOrgRuleById(ctx context.Context, arg GetOrgRuleByIdParams) (OrgRule, error)
 	// =====================================================
@@ -283,12 +355,16 @@ type Querier interface {
 	GetOrganizationBySlug(ctx context.Context, slug string) (Organization, error)
 	GetOrganizationUsers(ctx context.Context, orgID string) ([]GetOrganizationUsersRow, error)
 	GetPendingAuthToken(ctx context.Context, arg GetPendingAuthTokenParams) (AuthToken, error)
+	// Round-robin across organizations so one large campaign cannot fill the batch
 	GetPendingCampaignSends(ctx context.Context, limitCount int64) ([]GetPendingCampaignSendsRow, error)
+	// Round-robin across organizations so one organization cannot fill the batch
 	GetPendingEmails(ctx context.Context, arg GetPendingEmailsParams) ([]GetPendingEmailsRow, error)
+	GetPendingExportJobs(ctx context.Context) ([]ExportJob, error)
 	GetPlatformSetting(ctx context.Context, key string) (PlatformSetting, error)
 	GetPlatformSettingValue(ctx context.Context, key string) (GetPlatformSettingValueRow, error)
 	GetPlatformSettingsByCategory(ctx context.Context, category string) ([]PlatformSetting, error)
 	GetPlatformSettingsValues(ctx context.Context, category string) ([]GetPlatformSettingsValuesRow, error)
+	GetResumableListVerificationJobs(ctx context.Context) ([]ListVerificationJob, error)
 	// Get a single rule template by ID
 	GetRuleTemplateById(ctx context.Context, id string) (RuleTemplate, error)
 	// =====================================================
@@ -301,25 +377,33 @@ type Querier interface {
 	// Get rules that need revalidation (hash doesn't match content)
 	GetRulesWithStaleValidation(ctx context.Context, orgID string) ([]OrgRule, error)
 	GetScheduledCampaigns(ctx context.Context) ([]EmailCampaign, error)
+	GetSegment(ctx context.Context, arg GetSegmentParams) (Segment, error)
+	GetSegmentByID(ctx context.Context, id string) (Segment, error)
+	GetSegmentMemberSnapshot(ctx context.Context, segmentID string) (SegmentMemberSnapshot, error)
 	GetSequenceByID(ctx context.Context, id string) (GetSequenceByIDRow, error)
 	GetSequenceByListAndSlug(ctx context.Context, arg GetSequenceByListAndSlugParams) (GetSequenceByListAndSlugRow, error)
 	GetSequenceByListAndTrigger(ctx context.Context, arg GetSequenceByListAndTriggerParams) (GetSequenceByListAndTriggerRow, error)
 	// SDK Sequence queries
 	GetSequenceByOrgAndSlug(ctx context.Context, arg GetSequenceByOrgAndSlugParams) (GetSequenceByOrgAndSlugRow, error)
 	GetSequenceStats(ctx context.Context, sequenceID sql.NullString) (GetSequenceStatsRow, error)
+	GetSigningKey(ctx context.Context, name string) ([]byte, error)
 	GetSubscriberCampaignActivity(ctx context.Context, contactID string) ([]GetSubscriberCampaignActivityRow, error)
 	// Returns field_key -> value pairs for use in email merge tags
 	GetSubscriberCustomFieldsForMerge(ctx context.Context, subscriberID string) ([]GetSubscriberCustomFieldsForMergeRow, error)
 	GetSubscriberSequenceEnrollments(ctx context.Context, contactID sql.NullString) ([]GetSubscriberSequenceEnrollmentsRow, error)
 	GetSuppressedEmail(ctx context.Context, arg GetSuppressedEmailParams) (SuppressionList, error)
+	GetSuppressionListForExport(ctx context.Context, arg GetSuppressionListForExportParams) ([]SuppressionList, error)
 	GetTemplateByID(ctx context.Context, id string) (GetTemplateByIDRow, error)
 	GetTransactionalEmail(ctx context.Context, arg GetTransactionalEmailParams) (TransactionalEmail, error)
 	GetTransactionalEmailBySlug(ctx context.Context, arg GetTransactionalEmailBySlugParams) (TransactionalEmail, error)
+	GetTransactionalQueueItem(ctx context.Context, sendID string) (TransactionalQueue, error)
 	GetTransactionalSend(ctx context.Context, id string) (TransactionalSend, error)
+	GetTransactionalSendByIdempotencyKey(ctx context.Context, arg GetTransactionalSendByIdempotencyKeyParams) (TransactionalSend, error)
 	GetTransactionalSendByTracking(ctx context.Context, trackingToken sql.NullString) (TransactionalSend, error)
 	GetTransactionalSendByTrackingAndOrg(ctx context.Context, arg GetTransactionalSendByTrackingAndOrgParams) (GetTransactionalSendByTrackingAndOrgRow, error)
 	GetTransactionalSendByTrackingToken(ctx context.Context, token sql.NullString) (GetTransactionalSendByTrackingTokenRow, error)
 	GetTransactionalStats(ctx context.Context, templateID string) (GetTransactionalStatsRow, error)
+	GetUnverifiedListSubscribers(ctx context.Context, arg GetUnverifiedListSubscribersParams) ([]GetUnverifiedListSubscribersRow, error)
 	GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error)
 	GetUserByID(ctx context.Context, id string) (GetUserByIDRow, error)
 	GetUserOrganizations(ctx context.Context, userID string) ([]GetUserOrganizationsRow, error)
@@ -334,6 +418,8 @@ type Querier interface {
 	IncrementCampaignSent(ctx context.Context, id string) error
 	IncrementFailedLogins(ctx context.Context, id string) error
 	IncrementSuppressionAttempts(ctx context.Context, arg IncrementSuppressionAttemptsParams) error
+	// Sets an inferred time zone, never replacing one already known
+	InferContactTimezone(ctx context.Context, arg InferContactTimezoneParams) error
 	IsDomainBlocked(ctx context.Context, arg IsDomainBlockedParams) (int64, error)
 	// ========== COMBINED CHECK ==========
 	IsEmailBlocked(ctx context.Context, arg IsEmailBlockedParams) (int64, error)
@@ -343,40 +429,62 @@ type Querier interface {
 	IsEmailFullyBlocked(ctx context.Context, arg IsEmailFullyBlockedParams) (int64, error)
 	IsEmailSuppressed(ctx context.Context, arg IsEmailSuppressedParams) (int64, error)
 	ListActiveAgents(ctx context.Context) ([]ListActiveAgentsRow, error)
+	ListActiveDKIMKeysByDomain(ctx context.Context, domain string) ([]DkimKey, error)
 	ListAllContactTags(ctx context.Context) ([]ListAllContactTagsRow, error)
+	ListAllSegments(ctx context.Context) ([]Segment, error)
 	ListAllSequences(ctx context.Context) ([]ListAllSequencesRow, error)
 	ListBackups(ctx context.Context, arg ListBackupsParams) ([]BackupHistory, error)
 	ListBlockedDomains(ctx context.Context, arg ListBlockedDomainsParams) ([]BlockedDomain, error)
 	ListCampaignClicks(ctx context.Context, arg ListCampaignClicksParams) ([]ListCampaignClicksRow, error)
 	ListCampaignSends(ctx context.Context, arg ListCampaignSendsParams) ([]ListCampaignSendsRow, error)
+	ListCampaignVariants(ctx context.Context, campaignID string) ([]CampaignVariant, error)
 	ListCampaigns(ctx context.Context, arg ListCampaignsParams) ([]EmailCampaign, error)
 	ListCampaignsByStatus(ctx context.Context, arg ListCampaignsByStatusParams) ([]EmailCampaign, error)
+	// Human opens per contact and UTC hour of the day
+	ListContactOpenHours(ctx context.Context, arg ListContactOpenHoursParams) ([]ListContactOpenHoursRow, error)
 	ListContactSequenceStatesWithDetails(ctx context.Context, arg ListContactSequenceStatesWithDetailsParams) ([]ListContactSequenceStatesWithDetailsRow, error)
+	ListContactTimezones(ctx context.Context, orgID sql.NullString) ([]ListContactTimezonesRow, error)
 	ListContacts(ctx context.Context, arg ListContactsParams) ([]Contact, error)
 	ListContactsByOrg(ctx context.Context, arg ListContactsByOrgParams) ([]Contact, error)
 	ListCustomFieldValuesBySubscriber(ctx context.Context, subscriberID string) ([]ListCustomFieldValuesBySubscriberRow, error)
 	ListCustomFieldsByList(ctx context.Context, listID int64) ([]CustomField, error)
+	// Pending and active keys, newest version first
+	ListDKIMKeysByIdentity(ctx context.Context, domainIdentityID string) ([]DkimKey, error)
 	ListDomainIdentitiesByOrg(ctx context.Context, orgID string) ([]DomainIdentity, error)
+	// Tests with a winner picked, or whose wait for an automatic pick is over
+	ListDueCampaignABTests(ctx context.Context, now string) ([]CampaignAbTest, error)
+	ListDueCampaignQuotaPauses(ctx context.Context, now string) ([]string, error)
 	ListEmailDesigns(ctx context.Context, orgID string) ([]EmailDesign, error)
 	ListEmailDesignsByCategory(ctx context.Context, arg ListEmailDesignsByCategoryParams) ([]EmailDesign, error)
 	ListEmailLists(ctx context.Context, orgID string) ([]EmailList, error)
 	ListEmailQueueByOrg(ctx context.Context, arg ListEmailQueueByOrgParams) ([]ListEmailQueueByOrgRow, error)
 	ListEntryRulesByList(ctx context.Context, sourceID string) ([]ListEntryRulesByListRow, error)
 	ListEntryRulesBySequence(ctx context.Context, sequenceID string) ([]ListEntryRulesBySequenceRow, error)
+	ListExportJobs(ctx context.Context, arg ListExportJobsParams) ([]ExportJob, error)
 	ListImportJobs(ctx context.Context, arg ListImportJobsParams) ([]ImportJob, error)
 	ListListSubscribers(ctx context.Context, arg ListListSubscribersParams) ([]ListListSubscribersRow, error)
+	ListListVerificationJobs(ctx context.Context, arg ListListVerificationJobsParams) ([]ListVerificationJob, error)
+	ListListVerificationResults(ctx context.Context, arg ListListVerificationResultsParams) ([]ListVerificationResult, error)
 	ListMCPAPIKeysByUser(ctx context.Context, userID string) ([]McpApiKey, error)
 	ListMCPOAuthClients(ctx context.Context) ([]McpOauthClient, error)
+	// Same as GetOrgEngagement, per day
+	ListOrgEngagementByDay(ctx context.Context, arg ListOrgEngagementByDayParams) ([]ListOrgEngagementByDayRow, error)
 	ListOrganizations(ctx context.Context) ([]Organization, error)
+	ListPendingDKIMKeys(ctx context.Context) ([]DkimKey, error)
 	ListPendingDomainIdentities(ctx context.Context) ([]DomainIdentity, error)
 	ListPendingImportJobs(ctx context.Context) ([]ImportJob, error)
 	ListPlatformSettings(ctx context.Context) ([]PlatformSetting, error)
 	ListRecentBounces(ctx context.Context, arg ListRecentBouncesParams) ([]EmailBounce, error)
 	ListRecentComplaints(ctx context.Context, arg ListRecentComplaintsParams) ([]EmailComplaint, error)
+	ListSegmentMemberIDs(ctx context.Context, segmentID string) ([]string, error)
+	ListSegments(ctx context.Context, orgID string) ([]Segment, error)
 	ListSequencesByList(ctx context.Context, listID sql.NullInt64) ([]ListSequencesByListRow, error)
 	ListSequencesByOrg(ctx context.Context, orgID sql.NullString) ([]ListSequencesByOrgRow, error)
 	ListSuppressedEmails(ctx context.Context, arg ListSuppressedEmailsParams) ([]SuppressionList, error)
 	ListTemplatesBySequence(ctx context.Context, sequenceID sql.NullString) ([]ListTemplatesBySequenceRow, error)
+	ListTrackingEventsBySend(ctx context.Context, arg ListTrackingEventsBySendParams) ([]TrackingEvent, error)
+	ListTransactionalAttachmentContent(ctx context.Context, sendID string) ([]ListTransactionalAttachmentContentRow, error)
+	ListTransactionalAttachmentsBySend(ctx context.Context, sendID string) ([]ListTransactionalAttachmentsBySendRow, error)
 	ListTransactionalEmails(ctx context.Context, orgID string) ([]TransactionalEmail, error)
 	ListTransactionalSends(ctx context.Context, arg ListTransactionalSendsParams) ([]TransactionalSend, error)
 	ListTransactionalSendsByOrg(ctx context.Context, arg ListTransactionalSendsByOrgParams) ([]ListTransactionalSendsByOrgRow, error)
@@ -390,13 +498,22 @@ type Querier interface {
 	LogAutomation(ctx context.Context, arg LogAutomationParams) (AutomationLog, error)
 	ManuallyVerifyContact(ctx context.Context, id string) error
 	MarkAuthTokenUsed(ctx context.Context, id string) error
+	// Sets clicked_at on a send's first click. Affects no rows once it is set.
+	MarkCampaignSendClicked(ctx context.Context, id string) (int64, error)
 	MarkCampaignSendFailed(ctx context.Context, arg MarkCampaignSendFailedParams) error
+	// Sets opened_at on a send's first open. Affects no rows once it is set, so
+	// concurrent opens count the recipient once.
+	MarkCampaignSendOpened(ctx context.Context, id string) (int64, error)
 	MarkCampaignSendPermanentlyFailed(ctx context.Context, id string) error
 	MarkCampaignSendSent(ctx context.Context, id string) error
 	MarkEmailFailed(ctx context.Context, arg MarkEmailFailedParams) error
 	MarkEmailSent(ctx context.Context, id string) error
 	MarkMCPOAuthCodeUsed(ctx context.Context, id string) error
 	PauseContactSequence(ctx context.Context, arg PauseContactSequenceParams) error
+	// Pauses a campaign that is still sending. Affects no rows once it was
+	// paused or cancelled for another reason.
+	PauseSendingCampaign(ctx context.Context, id string) (int64, error)
+	PurgeExpiredTransactionalAttachments(ctx context.Context) (int64, error)
 	QueueEmail(ctx context.Context, arg QueueEmailParams) (EmailQueue, error)
 	// Email tracking queries
 	QueueEmailWithTracking(ctx context.Context, arg QueueEmailWithTrackingParams) (EmailQueue, error)
@@ -406,22 +523,49 @@ type Querier interface {
 	RecordEmailOpen(ctx context.Context, id string) error
 	RecordTransactionalClick(ctx context.Context, id string) error
 	RecordTransactionalOpen(ctx context.Context, id string) error
+	RefreshListVerificationJobCounts(ctx context.Context, id string) (ListVerificationJob, error)
 	RegenerateAPIKey(ctx context.Context, arg RegenerateAPIKeyParams) (Organization, error)
+	// Gives back a reserved send that was not delivered
+	ReleaseOrgDailySend(ctx context.Context, arg ReleaseOrgDailySendParams) error
+	// Drops content kept only until the send was processed
+	ReleaseTransactionalAttachmentContent(ctx context.Context, sendID string) error
 	RemoveContactTag(ctx context.Context, arg RemoveContactTagParams) error
+	RemoveSegmentMember(ctx context.Context, arg RemoveSegmentMemberParams) error
 	RemoveSubscriberFromList(ctx context.Context, arg RemoveSubscriberFromListParams) error
 	RemoveUserFromOrganization(ctx context.Context, arg RemoveUserFromOrganizationParams) error
+	// Counts one send against the org's daily quota. Returns no row when the
+	// count has already reached daily_quota.
+	ReserveOrgDailySend(ctx context.Context, arg ReserveOrgDailySendParams) (int64, error)
 	ResetFailedLogins(ctx context.Context, id string) error
+	// Exports restart from scratch, so jobs interrupted by a restart go back to pending
+	ResetRunningExportJobs(ctx context.Context) error
+	// Resolves a tracking token to its send, whichever kind of email it was
+	ResolveTrackingToken(ctx context.Context, trackingToken sql.NullString) (ResolveTrackingTokenRow, error)
 	ResubscribeContact(ctx context.Context, id string) error
 	ResumeContactSequence(ctx context.Context, arg ResumeContactSequenceParams) error
+	// Resumes a campaign paused for quota unless it was cancelled or paused for
+	// another reason meanwhile
+	ResumeQuotaPausedCampaign(ctx context.Context, id string) error
+	RetireDKIMKeysBeforeVersion(ctx context.Context, arg RetireDKIMKeysBeforeVersionParams) error
+	// Drops an unpublished version when it is superseded by another rotation
+	RetirePendingDKIMKeys(ctx context.Context, domainIdentityID string) error
+	RetryTransactionalQueueItem(ctx context.Context, arg RetryTransactionalQueueItemParams) error
 	RevokeMCPAPIKey(ctx context.Context, id string) error
 	RevokeMCPOAuthToken(ctx context.Context, id string) error
 	RevokeMCPOAuthTokensByUser(ctx context.Context, userID string) error
+	SaveSegmentMemberSnapshot(ctx context.Context, segmentID string) error
 	ScheduleCampaign(ctx context.Context, arg ScheduleCampaignParams) (EmailCampaign, error)
+	SetCampaignABTestStatus(ctx context.Context, arg SetCampaignABTestStatusParams) error
+	// Picks the winner of a test whose remaining recipients have not been sent to
+	SetCampaignABTestWinner(ctx context.Context, arg SetCampaignABTestWinnerParams) (int64, error)
 	SetCampaignRecipientsCount(ctx context.Context, arg SetCampaignRecipientsCountParams) error
+	SetContactTimezone(ctx context.Context, arg SetContactTimezoneParams) error
 	SetContactVerificationToken(ctx context.Context, arg SetContactVerificationTokenParams) error
 	SetImportJobErrors(ctx context.Context, arg SetImportJobErrorsParams) error
 	SetImportJobTotalRows(ctx context.Context, arg SetImportJobTotalRowsParams) error
 	SetUserEmailVerified(ctx context.Context, id string) error
+	StartExportJob(ctx context.Context, arg StartExportJobParams) (ExportJob, error)
+	StartListVerificationJob(ctx context.Context, arg StartListVerificationJobParams) (ListVerificationJob, error)
 	SubscribeToList(ctx context.Context, arg SubscribeToListParams) (ListSubscriber, error)
 	SubscribeToListPending(ctx context.Context, arg SubscribeToListPendingParams) (ListSubscriber, error)
 	// Toggle enabled state of a rule
@@ -430,6 +574,8 @@ type Querier interface {
 	UnsubscribeContact(ctx context.Context, id string) error
 	UnsubscribeContactFromSequence(ctx context.Context, contactID sql.NullString) error
 	UnsubscribeFromList(ctx context.Context, arg UnsubscribeFromListParams) error
+	// Record the outcome of a rule execution claimed with LogAutomation
+	UpdateAutomationLogResult(ctx context.Context, arg UpdateAutomationLogResultParams) error
 	UpdateBackupComplete(ctx context.Context, arg UpdateBackupCompleteParams) (BackupHistory, error)
 	UpdateBackupS3Key(ctx context.Context, arg UpdateBackupS3KeyParams) error
 	UpdateBackupStatus(ctx context.Context, arg UpdateBackupStatusParams) (BackupHistory, error)
@@ -442,6 +588,7 @@ type Querier interface {
 	UpdateContactName(ctx context.Context, arg UpdateContactNameParams) error
 	UpdateContactSequencePosition(ctx context.Context, arg UpdateContactSequencePositionParams) error
 	UpdateContactStatus(ctx context.Context, arg UpdateContactStatusParams) error
+	UpdateContactValidation(ctx context.Context, arg UpdateContactValidationParams) error
 	UpdateCustomField(ctx context.Context, arg UpdateCustomFieldParams) (CustomField, error)
 	UpdateDomainIdentityDNSRecords(ctx context.Context, arg UpdateDomainIdentityDNSRecordsParams) (DomainIdentity, error)
 	UpdateDomainIdentityFull(ctx context.Context, arg UpdateDomainIdentityFullParams) (DomainIdentity, error)
@@ -450,10 +597,13 @@ type Querier interface {
 	UpdateEmailDesign(ctx context.Context, arg UpdateEmailDesignParams) (EmailDesign, error)
 	UpdateEmailList(ctx context.Context, arg UpdateEmailListParams) (EmailList, error)
 	UpdateEntryRule(ctx context.Context, arg UpdateEntryRuleParams) error
+	UpdateExportJobProgress(ctx context.Context, arg UpdateExportJobProgressParams) (ExportJob, error)
 	UpdateImportJobProgress(ctx context.Context, arg UpdateImportJobProgressParams) error
 	UpdateImportJobStatus(ctx context.Context, arg UpdateImportJobStatusParams) error
 	UpdateLastLogin(ctx context.Context, id string) error
 	UpdateListPublicPageSettings(ctx context.Context, arg UpdateListPublicPageSettingsParams) error
+	UpdateListValidationPolicy(ctx context.Context, arg UpdateListValidationPolicyParams) error
+	UpdateListVerificationJobStatus(ctx context.Context, arg UpdateListVerificationJobStatusParams) (ListVerificationJob, error)
 	UpdateMCPAPIKeyLastUsed(ctx context.Context, id string) error
 	UpdateMCPOAuthClient(ctx context.Context, arg UpdateMCPOAuthClientParams) (McpOauthClient, error)
 	UpdateOrgAppUrl(ctx context.Context, arg UpdateOrgAppUrlParams) (Organization, error)
@@ -468,6 +618,8 @@ type Querier interface {
 	// Update just the validation fields (after recompiling)
 	UpdateRuleValidation(ctx context.Context, arg UpdateRuleValidationParams) error
 	UpdateSDKContact(ctx context.Context, arg UpdateSDKContactParams) (Contact, error)
+	UpdateSegment(ctx context.Context, arg UpdateSegmentParams) (Segment, error)
+	UpdateSegmentCount(ctx context.Context, arg UpdateSegmentCountParams) error
 	UpdateSequence(ctx context.Context, arg UpdateSequenceParams) error
 	UpdateTemplate(ctx context.Context, arg UpdateTemplateParams) error
 	UpdateTransactionalEmail(ctx context.Context, arg UpdateTransactionalEmailParams) (TransactionalEmail, error)
@@ -476,6 +628,8 @@ type Querier interface {
 	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
 	UpdateWebhook(ctx context.Context, arg UpdateWebhookParams) (Webhook, error)
 	UpdateWebhookDeliveryStats(ctx context.Context, arg UpdateWebhookDeliveryStatsParams) error
+	UpsertCampaignABTest(ctx context.Context, arg UpsertCampaignABTestParams) (CampaignAbTest, error)
+	UpsertCampaignQuotaPause(ctx context.Context, arg UpsertCampaignQuotaPauseParams) error
 	UpsertCustomFieldValue(ctx context.Context, arg UpsertCustomFieldValueParams) (CustomFieldValue, error)
 	// MCP Sessions (for persisting org selection across server restarts)
 	UpsertMCPSession(ctx context.Context, arg UpsertMCPSessionParams) error
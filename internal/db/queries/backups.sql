@@ -68,3 +68,9 @@ LIMIT sqlc.arg(limit_val);
 UPDATE backup_history
 SET s3_key = sqlc.arg(s3_key)
 WHERE id = sqlc.arg(id);
+
+-- name: GetExpiredBackups :many
+SELECT * FROM backup_history
+WHERE created_at < datetime('now', sqlc.arg(days_ago) || ' days')
+  AND status = 'completed'
+ORDER BY created_at ASC;
@@ -0,0 +1,78 @@
+-- name: CreateCampaignVariant :one
+INSERT INTO campaign_variants (id, campaign_id, name, position, subject, from_name, html_body, plain_text, created_at)
+VALUES (sqlc.arg(id), sqlc.arg(campaign_id), sqlc.arg(name), sqlc.arg(position), sqlc.arg(subject), sqlc.arg(from_name), sqlc.arg(html_body), sqlc.arg(plain_text), datetime('now'))
+RETURNING *;
+
+-- name: ListCampaignVariants :many
+SELECT * FROM campaign_variants
+WHERE campaign_id = sqlc.arg(campaign_id)
+ORDER BY position;
+
+-- name: DeleteCampaignVariants :exec
+DELETE FROM campaign_variants
+WHERE campaign_id = sqlc.arg(campaign_id);
+
+-- name: UpsertCampaignABTest :one
+INSERT INTO campaign_ab_tests (campaign_id, test_percent, winner_metric, wait_hours, status, created_at, updated_at)
+VALUES (sqlc.arg(campaign_id), sqlc.arg(test_percent), sqlc.arg(winner_metric), sqlc.arg(wait_hours), 'pending', datetime('now'), datetime('now'))
+ON CONFLICT (campaign_id) DO UPDATE SET
+    test_percent = excluded.test_percent,
+    winner_metric = excluded.winner_metric,
+    wait_hours = excluded.wait_hours,
+    updated_at = datetime('now')
+RETURNING *;
+
+-- name: GetCampaignABTest :one
+SELECT * FROM campaign_ab_tests
+WHERE campaign_id = sqlc.arg(campaign_id);
+
+-- name: DeleteCampaignABTest :exec
+DELETE FROM campaign_ab_tests
+WHERE campaign_id = sqlc.arg(campaign_id);
+
+-- name: SetCampaignABTestStatus :exec
+UPDATE campaign_ab_tests
+SET status = sqlc.arg(status),
+    test_completed_at = CASE WHEN sqlc.arg(status) = 'waiting' THEN datetime('now') ELSE test_completed_at END,
+    updated_at = datetime('now')
+WHERE campaign_id = sqlc.arg(campaign_id);
+
+-- Picks the winner of a test whose remaining recipients have not been sent to
+-- name: SetCampaignABTestWinner :execrows
+UPDATE campaign_ab_tests
+SET winner_variant_id = sqlc.arg(winner_variant_id),
+    updated_at = datetime('now')
+WHERE campaign_id = sqlc.arg(campaign_id)
+  AND status IN ('testing', 'waiting')
+  AND EXISTS (
+      SELECT 1 FROM campaign_variants cv
+      WHERE cv.id = sqlc.arg(winner_variant_id) AND cv.campaign_id = sqlc.arg(campaign_id)
+  );
+
+-- Tests with a winner picked, or whose wait for an automatic pick is over
+-- name: ListDueCampaignABTests :many
+SELECT * FROM campaign_ab_tests
+WHERE (status IN ('testing', 'waiting') AND winner_variant_id IS NOT NULL)
+   OR (status = 'waiting' AND winner_metric != 'manual'
+       AND datetime(test_completed_at, '+' || wait_hours || ' hours') <= sqlc.arg(now));
+
+-- Opens and clicks per variant; human counts leave out machine traffic
+-- name: GetCampaignVariantStats :many
+SELECT cv.id, cv.name, cv.position,
+       COUNT(cs.id) AS recipients,
+       COUNT(cs.sent_at) AS sent,
+       COUNT(cs.opened_at) AS opened,
+       COUNT(cs.clicked_at) AS clicked,
+       (SELECT COUNT(DISTINCT te.send_id) FROM tracking_events te
+        JOIN campaign_sends s ON s.id = te.send_id
+        WHERE te.send_type = 'campaign' AND s.variant_id = cv.id
+          AND te.event = 'opened' AND te.machine = 0) AS human_opened,
+       (SELECT COUNT(DISTINCT te.send_id) FROM tracking_events te
+        JOIN campaign_sends s ON s.id = te.send_id
+        WHERE te.send_type = 'campaign' AND s.variant_id = cv.id
+          AND te.event = 'clicked' AND te.machine = 0) AS human_clicked
+FROM campaign_variants cv
+LEFT JOIN campaign_sends cs ON cs.variant_id = cv.id
+WHERE cv.campaign_id = sqlc.arg(campaign_id)
+GROUP BY cv.id
+ORDER BY cv.position;
@@ -242,3 +242,26 @@ LEFT JOIN email_templates et ON et.id = eq.template_id
 LEFT JOIN email_sequences es ON es.id = et.sequence_id
 WHERE eq.contact_id = sqlc.arg(contact_id)
 ORDER BY eq.sent_at DESC;
+
+-- name: UpdateContactValidation :exec
+UPDATE contacts
+SET validation_status = sqlc.arg(validation_status),
+    validation_reason = sqlc.arg(validation_reason),
+    validated_at = datetime('now'),
+    updated_at = datetime('now')
+WHERE id = sqlc.arg(id);
+
+-- name: SetContactTimezone :exec
+UPDATE contacts
+SET timezone = sqlc.arg(timezone), updated_at = datetime('now')
+WHERE id = sqlc.arg(id);
+
+-- Sets an inferred time zone, never replacing one already known
+-- name: InferContactTimezone :exec
+UPDATE contacts
+SET timezone = sqlc.arg(timezone), updated_at = datetime('now')
+WHERE id = sqlc.arg(id) AND timezone IS NULL;
+
+-- name: ListContactTimezones :many
+SELECT id, timezone FROM contacts
+WHERE org_id = sqlc.arg(org_id) AND timezone IS NOT NULL;
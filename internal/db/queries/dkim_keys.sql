@@ -0,0 +1,41 @@
+-- name: CreateDKIMKey :one
+INSERT INTO dkim_keys (
+    id, org_id, domain_identity_id, domain, selector, algorithm, version, private_key, public_key
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetMaxDKIMKeyVersion :one
+SELECT CAST(COALESCE(MAX(version), 0) AS INTEGER) FROM dkim_keys
+WHERE domain_identity_id = ?;
+
+-- Pending and active keys, newest version first
+-- name: ListDKIMKeysByIdentity :many
+SELECT * FROM dkim_keys
+WHERE domain_identity_id = ? AND status != 'retired'
+ORDER BY version DESC, algorithm ASC;
+
+-- name: ListActiveDKIMKeysByDomain :many
+SELECT * FROM dkim_keys
+WHERE domain = ? AND status = 'active'
+ORDER BY version DESC, algorithm ASC;
+
+-- name: ListPendingDKIMKeys :many
+SELECT * FROM dkim_keys
+WHERE status = 'pending'
+ORDER BY domain_identity_id, version;
+
+-- name: ActivateDKIMKeyVersion :exec
+UPDATE dkim_keys
+SET status = 'active', activated_at = datetime('now')
+WHERE domain_identity_id = sqlc.arg(domain_identity_id) AND version = sqlc.arg(version) AND status = 'pending';
+
+-- name: RetireDKIMKeysBeforeVersion :exec
+UPDATE dkim_keys
+SET status = 'retired', retired_at = datetime('now')
+WHERE domain_identity_id = sqlc.arg(domain_identity_id) AND version < sqlc.arg(version) AND status != 'retired';
+
+-- Drops an unpublished version when it is superseded by another rotation
+-- name: RetirePendingDKIMKeys :exec
+UPDATE dkim_keys
+SET status = 'retired', retired_at = datetime('now')
+WHERE domain_identity_id = ? AND status = 'pending';
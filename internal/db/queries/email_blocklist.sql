@@ -87,6 +87,10 @@ SELECT CASE WHEN (
 UPDATE contacts SET blocked_at = datetime('now'), updated_at = datetime('now')
 WHERE LOWER(email) = LOWER(sqlc.arg(email)) AND blocked_at IS NULL;
 
+-- name: BlockOrgContactByEmail :exec
+UPDATE contacts SET blocked_at = datetime('now'), updated_at = datetime('now')
+WHERE org_id = sqlc.arg(org_id) AND LOWER(email) = LOWER(sqlc.arg(email)) AND blocked_at IS NULL;
+
 -- ========== BLOCKED DOMAINS ==========
 
 -- name: CreateBlockedDomain :one
@@ -7,10 +7,10 @@ INSERT INTO email_campaigns (
     from_name, from_email, reply_to,
     html_body, plain_text,
     list_ids, exclude_list_ids, segment_filter,
-    status, scheduled_at, track_opens, track_clicks,
-    created_at, updated_at
+    status, scheduled_at, track_opens, track_clicks, segment_id,
+    delivery_mode, created_at, updated_at
 )
-VALUES (sqlc.arg(id), sqlc.arg(org_id), sqlc.arg(design_id), sqlc.arg(name), sqlc.arg(subject), sqlc.arg(preview_text), sqlc.arg(from_name), sqlc.arg(from_email), sqlc.arg(reply_to), sqlc.arg(html_body), sqlc.arg(plain_text), sqlc.arg(list_ids), sqlc.arg(exclude_list_ids), sqlc.arg(segment_filter), sqlc.arg(status), sqlc.arg(scheduled_at), sqlc.arg(track_opens), sqlc.arg(track_clicks), datetime('now'), datetime('now'))
+VALUES (sqlc.arg(id), sqlc.arg(org_id), sqlc.arg(design_id), sqlc.arg(name), sqlc.arg(subject), sqlc.arg(preview_text), sqlc.arg(from_name), sqlc.arg(from_email), sqlc.arg(reply_to), sqlc.arg(html_body), sqlc.arg(plain_text), sqlc.arg(list_ids), sqlc.arg(exclude_list_ids), sqlc.arg(segment_filter), sqlc.arg(status), sqlc.arg(scheduled_at), sqlc.arg(track_opens), sqlc.arg(track_clicks), sqlc.arg(segment_id), COALESCE(NULLIF(sqlc.arg(delivery_mode), ''), 'immediate'), datetime('now'), datetime('now'))
 RETURNING *;
 
 -- name: GetCampaign :one
@@ -44,6 +44,8 @@ SET name = COALESCE(NULLIF(sqlc.arg(name), ''), name),
     segment_filter = COALESCE(NULLIF(sqlc.arg(segment_filter), ''), segment_filter),
     track_opens = COALESCE(sqlc.arg(track_opens), track_opens),
     track_clicks = COALESCE(sqlc.arg(track_clicks), track_clicks),
+    segment_id = COALESCE(NULLIF(sqlc.arg(segment_id), ''), segment_id),
+    delivery_mode = COALESCE(NULLIF(sqlc.arg(delivery_mode), ''), delivery_mode),
     updated_at = datetime('now')
 WHERE id = sqlc.arg(id) AND org_id = sqlc.arg(org_id) AND status = 'draft'
 RETURNING *;
@@ -112,8 +114,8 @@ ORDER BY scheduled_at ASC;
 -- Campaign Sends
 
 -- name: CreateCampaignSend :one
-INSERT INTO campaign_sends (id, campaign_id, contact_id, list_id, tracking_token, status, created_at)
-VALUES (sqlc.arg(id), sqlc.arg(campaign_id), sqlc.arg(contact_id), sqlc.arg(list_id), sqlc.arg(tracking_token), 'pending', datetime('now'))
+INSERT INTO campaign_sends (id, campaign_id, contact_id, list_id, tracking_token, variant_id, send_at, status, created_at)
+VALUES (sqlc.arg(id), sqlc.arg(campaign_id), sqlc.arg(contact_id), sqlc.arg(list_id), sqlc.arg(tracking_token), sqlc.arg(variant_id), sqlc.arg(send_at), 'pending', datetime('now'))
 RETURNING *;
 
 -- name: GetCampaignSend :one
@@ -133,6 +135,19 @@ SET status = sqlc.arg(status),
     bounce_type = sqlc.arg(bounce_type)
 WHERE id = sqlc.arg(id);
 
+-- Sets opened_at on a send's first open. Affects no rows once it is set, so
+-- concurrent opens count the recipient once.
+-- name: MarkCampaignSendOpened :execrows
+UPDATE campaign_sends
+SET opened_at = datetime('now')
+WHERE id = sqlc.arg(id) AND opened_at IS NULL;
+
+-- Sets clicked_at on a send's first click. Affects no rows once it is set.
+-- name: MarkCampaignSendClicked :execrows
+UPDATE campaign_sends
+SET clicked_at = datetime('now')
+WHERE id = sqlc.arg(id) AND clicked_at IS NULL;
+
 -- name: RecordCampaignOpen :exec
 UPDATE campaign_sends
 SET opened_at = COALESCE(opened_at, datetime('now')),
@@ -160,8 +175,8 @@ WHERE campaign_id = sqlc.arg(campaign_id) AND status = sqlc.arg(status);
 -- Campaign Clicks
 
 -- name: CreateCampaignClick :one
-INSERT INTO campaign_clicks (id, campaign_send_id, campaign_id, contact_id, link_url, link_name, clicked_at)
-VALUES (sqlc.arg(id), sqlc.arg(campaign_send_id), sqlc.arg(campaign_id), sqlc.arg(contact_id), sqlc.arg(link_url), sqlc.arg(link_name), datetime('now'))
+INSERT INTO campaign_clicks (id, campaign_send_id, campaign_id, contact_id, link_url, link_name, user_agent, ip_address, clicked_at)
+VALUES (sqlc.arg(id), sqlc.arg(campaign_send_id), sqlc.arg(campaign_id), sqlc.arg(contact_id), sqlc.arg(link_url), sqlc.arg(link_name), sqlc.arg(user_agent), sqlc.arg(ip_address), datetime('now'))
 RETURNING *;
 
 -- name: ListCampaignClicks :many
@@ -199,16 +214,22 @@ SET recipients_count = sqlc.arg(recipients_count),
     updated_at = datetime('now')
 WHERE id = sqlc.arg(id);
 
+-- Round-robin across organizations so one large campaign cannot fill the batch.
+-- Sends timed for a recipient wait until they are due.
 -- name: GetPendingCampaignSends :many
 SELECT cs.id, cs.campaign_id, cs.contact_id, cs.list_id, cs.tracking_token, cs.status,
        c.email, c.name,
-       ec.subject, ec.html_body, ec.plain_text, ec.from_name, ec.from_email, ec.reply_to,
+       COALESCE(cv.subject, ec.subject) AS subject, COALESCE(cv.html_body, ec.html_body) AS html_body,
+       COALESCE(cv.plain_text, ec.plain_text) AS plain_text, COALESCE(cv.from_name, ec.from_name) AS from_name,
+       ec.from_email, ec.reply_to,
        ec.track_opens, ec.track_clicks, ec.org_id
 FROM campaign_sends cs
 JOIN contacts c ON c.id = cs.contact_id
 JOIN email_campaigns ec ON ec.id = cs.campaign_id
-WHERE cs.status = 'pending'
-ORDER BY cs.created_at ASC
+LEFT JOIN campaign_variants cv ON cv.id = cs.variant_id
+WHERE cs.status = 'pending' AND ec.status = 'sending'
+  AND (cs.send_at IS NULL OR cs.send_at <= datetime('now'))
+ORDER BY ROW_NUMBER() OVER (PARTITION BY ec.org_id ORDER BY cs.created_at), cs.created_at
 LIMIT sqlc.arg(limit_count);
 
 -- name: MarkCampaignSendSent :exec
@@ -221,6 +242,11 @@ UPDATE campaign_sends
 SET status = 'failed', error_message = sqlc.arg(error_message)
 WHERE id = sqlc.arg(id);
 
+-- name: DeferCampaignSend :exec
+UPDATE campaign_sends
+SET send_at = sqlc.arg(send_at)
+WHERE id = sqlc.arg(id) AND status = 'pending';
+
 -- name: GetActiveSubscribersForList :many
 SELECT c.id as contact_id, c.email, c.name, ls.list_id
 FROM list_subscribers ls
@@ -247,10 +273,14 @@ WHERE campaign_id = sqlc.arg(campaign_id) AND status = 'sent';
 -- name: GetFailedCampaignSendsForRetry :many
 SELECT cs.id, cs.campaign_id, cs.contact_id, cs.tracking_token, cs.retry_count, cs.failed_at,
        c.email, c.name,
-       ec.subject, ec.html_body, ec.from_name, ec.from_email, ec.reply_to
+       COALESCE(cv.subject, ec.subject) AS subject, COALESCE(cv.html_body, ec.html_body) AS html_body,
+       COALESCE(cv.plain_text, ec.plain_text) AS plain_text, COALESCE(cv.from_name, ec.from_name) AS from_name,
+       ec.from_email, ec.reply_to,
+       ec.org_id
 FROM campaign_sends cs
 JOIN contacts c ON c.id = cs.contact_id
 JOIN email_campaigns ec ON ec.id = cs.campaign_id
+LEFT JOIN campaign_variants cv ON cv.id = cs.variant_id
 WHERE cs.status = 'failed' AND cs.retry_count < 3
 ORDER BY cs.failed_at ASC
 LIMIT sqlc.arg(limit_count);
@@ -265,6 +295,21 @@ UPDATE campaign_sends
 SET status = 'permanent_failure'
 WHERE id = sqlc.arg(id);
 
+-- Pauses a campaign that is still sending. Affects no rows once it was
+-- paused or cancelled for another reason.
+-- name: PauseSendingCampaign :execrows
+UPDATE email_campaigns
+SET status = 'paused', updated_at = datetime('now')
+WHERE id = sqlc.arg(id) AND status = 'sending';
+
+-- Resumes a campaign paused for quota unless it was cancelled or paused for
+-- another reason meanwhile
+-- name: ResumeQuotaPausedCampaign :exec
+UPDATE email_campaigns
+SET status = 'sending', updated_at = datetime('now')
+WHERE id = sqlc.arg(id) AND status = 'paused'
+  AND EXISTS (SELECT 1 FROM campaign_quota_pauses WHERE campaign_id = sqlc.arg(id));
+
 -- Dashboard Stats
 
 -- name: GetDashboardEmailStats30Days :one
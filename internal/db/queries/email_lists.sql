@@ -212,3 +212,8 @@ FROM contact_sequence_state css
 LEFT JOIN email_sequences es ON es.id = css.sequence_id
 WHERE css.contact_id = sqlc.arg(contact_id)
 ORDER BY css.started_at DESC;
+
+-- name: UpdateListValidationPolicy :exec
+UPDATE email_lists
+SET validation_policy = sqlc.arg(validation_policy), updated_at = datetime('now')
+WHERE id = sqlc.arg(id) AND org_id = sqlc.arg(org_id);
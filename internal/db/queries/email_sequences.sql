@@ -92,15 +92,16 @@ INSERT INTO email_queue (id, contact_id, template_id, scheduled_for, status, tra
 VALUES (sqlc.arg(id), sqlc.arg(contact_id), sqlc.arg(template_id), sqlc.arg(scheduled_for), 'pending', sqlc.arg(tracking_token), datetime('now'))
 RETURNING *;
 
+-- Round-robin across organizations so one organization cannot fill the batch
 -- name: GetPendingEmails :many
 SELECT eq.id, eq.contact_id, eq.template_id, eq.scheduled_for, eq.status, eq.tracking_token,
-       et.subject, et.html_body, et.template_type, et.is_transactional,
-       c.email, c.name
+       et.subject, et.html_body, et.plain_text, et.template_type, et.is_transactional,
+       c.email, c.name, et.org_id
 FROM email_queue eq
 JOIN email_templates et ON et.id = eq.template_id
 JOIN contacts c ON c.id = eq.contact_id
 WHERE eq.status = 'pending' AND eq.scheduled_for <= sqlc.arg(scheduled_before) AND c.unsubscribed_at IS NULL
-ORDER BY eq.scheduled_for
+ORDER BY ROW_NUMBER() OVER (PARTITION BY et.org_id ORDER BY eq.scheduled_for), eq.scheduled_for
 LIMIT sqlc.arg(limit_count);
 
 -- name: MarkEmailSent :exec
@@ -113,6 +114,11 @@ UPDATE email_queue
 SET status = 'failed', error_message = sqlc.arg(error_message)
 WHERE id = sqlc.arg(id);
 
+-- name: DeferEmail :exec
+UPDATE email_queue
+SET scheduled_for = sqlc.arg(scheduled_for)
+WHERE id = sqlc.arg(id) AND status = 'pending';
+
 -- name: CancelEmail :exec
 UPDATE email_queue
 SET status = 'cancelled'
@@ -0,0 +1,157 @@
+-- name: CreateExportJob :one
+INSERT INTO export_jobs (
+    id, org_id, type, source_id, format, storage_type, filename, created_by
+) VALUES (
+    sqlc.arg(id), sqlc.arg(org_id), sqlc.arg(type), sqlc.arg(source_id),
+    sqlc.arg(format), sqlc.arg(storage_type), sqlc.arg(filename), sqlc.arg(created_by)
+)
+RETURNING *;
+
+-- name: GetExportJob :one
+SELECT * FROM export_jobs
+WHERE id = sqlc.arg(id) AND org_id = sqlc.arg(org_id)
+LIMIT 1;
+
+-- name: ListExportJobs :many
+SELECT * FROM export_jobs
+WHERE org_id = sqlc.arg(org_id)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit_val) OFFSET sqlc.arg(offset_val);
+
+-- name: CountExportJobs :one
+SELECT COUNT(*) FROM export_jobs
+WHERE org_id = sqlc.arg(org_id);
+
+-- name: GetPendingExportJobs :many
+SELECT * FROM export_jobs
+WHERE status = 'pending'
+ORDER BY created_at ASC
+LIMIT 10;
+
+-- name: ResetRunningExportJobs :exec
+-- Exports restart from scratch, so jobs interrupted by a restart go back to pending
+UPDATE export_jobs
+SET status = 'pending', processed_rows = 0, updated_at = datetime('now')
+WHERE status = 'running';
+
+-- name: StartExportJob :one
+UPDATE export_jobs
+SET status = 'running',
+    total_rows = sqlc.arg(total_rows),
+    processed_rows = 0,
+    started_at = datetime('now'),
+    updated_at = datetime('now')
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
+
+-- name: UpdateExportJobProgress :one
+UPDATE export_jobs
+SET processed_rows = sqlc.arg(processed_rows),
+    updated_at = datetime('now')
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CompleteExportJob :one
+UPDATE export_jobs
+SET status = 'completed',
+    storage_type = sqlc.arg(storage_type),
+    file_path = sqlc.arg(file_path),
+    s3_bucket = sqlc.arg(s3_bucket),
+    s3_key = sqlc.arg(s3_key),
+    file_size = sqlc.arg(file_size),
+    processed_rows = sqlc.arg(processed_rows),
+    completed_at = datetime('now'),
+    updated_at = datetime('now')
+WHERE id = sqlc.arg(id) AND status = 'running'
+RETURNING *;
+
+-- name: FailExportJob :one
+UPDATE export_jobs
+SET status = 'failed',
+    error_message = sqlc.arg(error_message),
+    completed_at = datetime('now'),
+    updated_at = datetime('now')
+WHERE id = sqlc.arg(id) AND status IN ('pending', 'running')
+RETURNING *;
+
+-- name: CancelExportJob :one
+UPDATE export_jobs
+SET status = 'cancelled',
+    completed_at = datetime('now'),
+    updated_at = datetime('now')
+WHERE id = sqlc.arg(id) AND org_id = sqlc.arg(org_id)
+  AND status IN ('pending', 'running')
+RETURNING *;
+
+-- name: DeleteExportJob :exec
+DELETE FROM export_jobs
+WHERE id = sqlc.arg(id) AND org_id = sqlc.arg(org_id);
+
+-- ========== EXPORT DATA ==========
+-- Keyset-paginated reads used by the export worker
+
+-- name: GetListSubscribersForExport :many
+SELECT ls.rowid AS row_id,
+    COALESCE(ls.id, '') AS subscriber_id,
+    ls.contact_id,
+    c.email,
+    c.name,
+    ls.status,
+    ls.subscribed_at,
+    ls.unsubscribed_at,
+    CAST(COALESCE((
+        SELECT json_group_array(ct.tag) FROM contact_tags ct WHERE ct.contact_id = ls.contact_id
+    ), '[]') AS TEXT) AS tags,
+    CAST(COALESCE((
+        SELECT json_group_object(cf.field_key, cfv.value)
+        FROM custom_field_values cfv
+        JOIN custom_fields cf ON cf.id = cfv.field_id
+        WHERE cfv.subscriber_id = ls.id
+    ), '{}') AS TEXT) AS custom_fields
+FROM list_subscribers ls
+JOIN contacts c ON c.id = ls.contact_id
+WHERE ls.list_id = sqlc.arg(list_id)
+  AND ls.rowid > sqlc.arg(after_row_id)
+ORDER BY ls.rowid
+LIMIT sqlc.arg(limit_val);
+
+-- name: GetSuppressionListForExport :many
+SELECT * FROM suppression_list
+WHERE org_id = sqlc.arg(org_id)
+  AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(limit_val);
+
+-- name: GetBlockedDomainsForExport :many
+SELECT * FROM blocked_domains
+WHERE org_id = sqlc.arg(org_id)
+  AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(limit_val);
+
+-- name: CountCampaignSends :one
+SELECT COUNT(*) FROM campaign_sends
+WHERE campaign_id = sqlc.arg(campaign_id);
+
+-- name: GetCampaignSendsForExport :many
+SELECT cs.rowid AS row_id,
+    cs.id,
+    cs.contact_id,
+    c.email,
+    c.name,
+    cs.list_id,
+    cs.status,
+    cs.sent_at,
+    cs.delivered_at,
+    cs.opened_at,
+    cs.open_count,
+    cs.clicked_at,
+    cs.click_count,
+    cs.bounce_type,
+    cs.error_message
+FROM campaign_sends cs
+JOIN contacts c ON c.id = cs.contact_id
+WHERE cs.campaign_id = sqlc.arg(campaign_id)
+  AND cs.rowid > sqlc.arg(after_row_id)
+ORDER BY cs.rowid
+LIMIT sqlc.arg(limit_val);
@@ -0,0 +1,110 @@
+-- name: CreateListVerificationJob :one
+INSERT INTO list_verification_jobs (
+    id, org_id, list_id, check_smtp, concurrency, created_by
+) VALUES (
+    sqlc.arg(id), sqlc.arg(org_id), sqlc.arg(list_id), sqlc.arg(check_smtp),
+    sqlc.arg(concurrency), sqlc.arg(created_by)
+)
+RETURNING *;
+
+-- name: GetListVerificationJob :one
+SELECT * FROM list_verification_jobs
+WHERE id = sqlc.arg(id)
+LIMIT 1;
+
+-- name: ListListVerificationJobs :many
+SELECT * FROM list_verification_jobs
+WHERE list_id = sqlc.arg(list_id)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit_val) OFFSET sqlc.arg(offset_val);
+
+-- name: GetActiveListVerificationJob :one
+SELECT * FROM list_verification_jobs
+WHERE list_id = sqlc.arg(list_id)
+  AND status IN ('pending', 'running')
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: GetResumableListVerificationJobs :many
+SELECT * FROM list_verification_jobs
+WHERE status IN ('pending', 'running')
+ORDER BY created_at ASC;
+
+-- name: StartListVerificationJob :one
+UPDATE list_verification_jobs
+SET status = 'running',
+    total_count = sqlc.arg(total_count),
+    started_at = COALESCE(started_at, datetime('now')),
+    updated_at = datetime('now')
+WHERE id = sqlc.arg(id)
+  AND status IN ('pending', 'running')
+RETURNING *;
+
+-- name: UpdateListVerificationJobStatus :one
+UPDATE list_verification_jobs
+SET status = sqlc.arg(status),
+    error_message = sqlc.arg(error_message),
+    completed_at = CASE WHEN sqlc.arg(status) IN ('completed', 'failed', 'cancelled') THEN datetime('now') ELSE completed_at END,
+    updated_at = datetime('now')
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: RefreshListVerificationJobCounts :one
+UPDATE list_verification_jobs
+SET processed_count = (SELECT COUNT(*) FROM list_verification_results r WHERE r.job_id = list_verification_jobs.id),
+    valid_count = (SELECT COUNT(*) FROM list_verification_results r WHERE r.job_id = list_verification_jobs.id AND r.verdict = 'valid'),
+    invalid_count = (SELECT COUNT(*) FROM list_verification_results r WHERE r.job_id = list_verification_jobs.id AND r.verdict = 'invalid'),
+    disposable_count = (SELECT COUNT(*) FROM list_verification_results r WHERE r.job_id = list_verification_jobs.id AND r.verdict = 'disposable'),
+    role_count = (SELECT COUNT(*) FROM list_verification_results r WHERE r.job_id = list_verification_jobs.id AND r.verdict = 'role'),
+    catch_all_count = (SELECT COUNT(*) FROM list_verification_results r WHERE r.job_id = list_verification_jobs.id AND r.verdict = 'catch-all'),
+    unknown_count = (SELECT COUNT(*) FROM list_verification_results r WHERE r.job_id = list_verification_jobs.id AND r.verdict = 'unknown'),
+    updated_at = datetime('now')
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CountListSubscribersForVerification :one
+SELECT COUNT(*) FROM list_subscribers
+WHERE list_id = sqlc.arg(list_id)
+  AND COALESCE(status, 'active') != 'unsubscribed';
+
+-- name: GetUnverifiedListSubscribers :many
+SELECT ls.id, ls.contact_id, c.email
+FROM list_subscribers ls
+JOIN contacts c ON c.id = ls.contact_id
+WHERE ls.list_id = sqlc.arg(list_id)
+  AND COALESCE(ls.status, 'active') != 'unsubscribed'
+  AND NOT EXISTS (
+    SELECT 1 FROM list_verification_results r
+    WHERE r.job_id = sqlc.arg(job_id) AND r.subscriber_id = ls.id
+  )
+ORDER BY ls.id
+LIMIT sqlc.arg(limit_val);
+
+-- name: CreateListVerificationResult :exec
+INSERT INTO list_verification_results (
+    job_id, subscriber_id, contact_id, email, verdict, reason
+) VALUES (
+    sqlc.arg(job_id), sqlc.arg(subscriber_id), sqlc.arg(contact_id),
+    sqlc.arg(email), sqlc.arg(verdict), sqlc.arg(reason)
+)
+ON CONFLICT (job_id, subscriber_id) DO UPDATE SET
+    verdict = excluded.verdict,
+    reason = excluded.reason,
+    checked_at = datetime('now');
+
+-- name: ListListVerificationResults :many
+SELECT * FROM list_verification_results
+WHERE job_id = sqlc.arg(job_id)
+  AND (sqlc.arg(verdict) IS NULL OR sqlc.arg(verdict) = '' OR verdict = sqlc.arg(verdict))
+ORDER BY email
+LIMIT sqlc.arg(limit_val) OFFSET sqlc.arg(offset_val);
+
+-- name: CountListVerificationResults :one
+SELECT COUNT(*) FROM list_verification_results
+WHERE job_id = sqlc.arg(job_id)
+  AND (sqlc.arg(verdict) IS NULL OR sqlc.arg(verdict) = '' OR verdict = sqlc.arg(verdict));
+
+-- name: DeleteUnknownListVerificationResults :exec
+DELETE FROM list_verification_results
+WHERE job_id = sqlc.arg(job_id)
+  AND verdict = 'unknown';
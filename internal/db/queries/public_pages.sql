@@ -41,6 +41,7 @@ SELECT
     el.unsubscribe_redirect_url,
     el.confirmation_email_subject,
     el.confirmation_email_body,
+    el.validation_policy,
     o.name as org_name,
     o.slug as org_slug,
     o.from_name,
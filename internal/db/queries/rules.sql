@@ -118,6 +118,15 @@ VALUES (sqlc.arg(id), sqlc.arg(org_id), sqlc.arg(event_id), sqlc.arg(event_type)
 ON CONFLICT (org_id, event_id, rule_id) DO NOTHING
 RETURNING *;
 
+-- name: UpdateAutomationLogResult :exec
+-- Record the outcome of a rule execution claimed with LogAutomation
+UPDATE automation_log
+SET actions_executed = sqlc.arg(actions_executed),
+    success = sqlc.arg(success),
+    error_message = sqlc.arg(error_message),
+    execution_time_ms = sqlc.arg(execution_time_ms)
+WHERE id = sqlc.arg(id);
+
 -- name: CheckEventProcessed :one
 -- Check if an event has already been processed by a rule
 SELECT CASE WHEN COUNT(*) > 0 THEN 1 ELSE 0 END as found
@@ -0,0 +1,83 @@
+-- Saved Segments
+-- Named, reusable audience filters
+
+-- name: CreateSegment :one
+INSERT INTO segments (id, org_id, name, description, list_ids, filter, created_at, updated_at)
+VALUES (sqlc.arg(id), sqlc.arg(org_id), sqlc.arg(name), sqlc.arg(description), sqlc.arg(list_ids), sqlc.arg(filter), datetime('now'), datetime('now'))
+RETURNING *;
+
+-- name: GetSegment :one
+SELECT * FROM segments
+WHERE id = sqlc.arg(id) AND org_id = sqlc.arg(org_id);
+
+-- name: GetSegmentByID :one
+SELECT * FROM segments
+WHERE id = sqlc.arg(id);
+
+-- name: ListSegments :many
+SELECT * FROM segments
+WHERE org_id = sqlc.arg(org_id)
+ORDER BY name ASC;
+
+-- name: ListAllSegments :many
+SELECT * FROM segments
+ORDER BY count_refreshed_at ASC;
+
+-- name: UpdateSegment :one
+UPDATE segments
+SET name = COALESCE(NULLIF(sqlc.arg(name), ''), name),
+    description = COALESCE(sqlc.arg(description), description),
+    list_ids = COALESCE(sqlc.arg(list_ids), list_ids),
+    filter = COALESCE(NULLIF(sqlc.arg(filter), ''), filter),
+    updated_at = datetime('now')
+WHERE id = sqlc.arg(id) AND org_id = sqlc.arg(org_id)
+RETURNING *;
+
+-- name: UpdateSegmentCount :exec
+UPDATE segments
+SET contact_count = sqlc.arg(contact_count),
+    count_refreshed_at = datetime('now')
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteSegment :exec
+DELETE FROM segments
+WHERE id = sqlc.arg(id) AND org_id = sqlc.arg(org_id);
+
+-- Campaigns that would widen to their whole lists if the segment went away
+-- name: CountActiveCampaignsUsingSegment :one
+SELECT COUNT(*) FROM email_campaigns
+WHERE segment_id = sqlc.arg(segment_id) AND org_id = sqlc.arg(org_id)
+  AND status IN ('scheduled', 'sending', 'paused');
+
+-- name: ClearCampaignSegment :exec
+UPDATE email_campaigns
+SET segment_id = NULL, updated_at = datetime('now')
+WHERE segment_id = sqlc.arg(segment_id) AND org_id = sqlc.arg(org_id);
+
+-- ========== ENTRY SNAPSHOTS ==========
+
+-- name: GetSegmentMemberSnapshot :one
+SELECT * FROM segment_member_snapshots WHERE segment_id = sqlc.arg(segment_id);
+
+-- name: SaveSegmentMemberSnapshot :exec
+INSERT INTO segment_member_snapshots (segment_id, taken_at)
+VALUES (sqlc.arg(segment_id), datetime('now'))
+ON CONFLICT (segment_id) DO UPDATE SET taken_at = EXCLUDED.taken_at;
+
+-- name: DeleteSegmentMemberSnapshot :exec
+DELETE FROM segment_member_snapshots WHERE segment_id = sqlc.arg(segment_id);
+
+-- name: ListSegmentMemberIDs :many
+SELECT contact_id FROM segment_members WHERE segment_id = sqlc.arg(segment_id);
+
+-- name: AddSegmentMember :exec
+INSERT INTO segment_members (segment_id, contact_id, entered_at)
+VALUES (sqlc.arg(segment_id), sqlc.arg(contact_id), datetime('now'))
+ON CONFLICT (segment_id, contact_id) DO NOTHING;
+
+-- name: RemoveSegmentMember :exec
+DELETE FROM segment_members
+WHERE segment_id = sqlc.arg(segment_id) AND contact_id = sqlc.arg(contact_id);
+
+-- name: DeleteSegmentMembers :exec
+DELETE FROM segment_members WHERE segment_id = sqlc.arg(segment_id);
@@ -0,0 +1,35 @@
+-- Counts one send against the org's daily quota. Returns no row when the
+-- count has already reached daily_quota.
+-- name: ReserveOrgDailySend :one
+INSERT INTO org_daily_sends (org_id, day, sent_count)
+VALUES (sqlc.arg(org_id), sqlc.arg(day), 1)
+ON CONFLICT (org_id, day) DO UPDATE
+SET sent_count = sent_count + 1
+WHERE sent_count < sqlc.arg(daily_quota)
+RETURNING sent_count;
+
+-- Gives back a reserved send that was not delivered
+-- name: ReleaseOrgDailySend :exec
+UPDATE org_daily_sends
+SET sent_count = sent_count - 1
+WHERE org_id = sqlc.arg(org_id) AND day = sqlc.arg(day) AND sent_count > 0;
+
+-- name: GetOrgDailySends :one
+SELECT sent_count FROM org_daily_sends
+WHERE org_id = sqlc.arg(org_id) AND day = sqlc.arg(day);
+
+-- name: DeleteOrgDailySendsBefore :exec
+DELETE FROM org_daily_sends WHERE day < sqlc.arg(day);
+
+-- name: UpsertCampaignQuotaPause :exec
+INSERT INTO campaign_quota_pauses (campaign_id, org_id, resume_at)
+VALUES (sqlc.arg(campaign_id), sqlc.arg(org_id), sqlc.arg(resume_at))
+ON CONFLICT (campaign_id) DO UPDATE SET resume_at = excluded.resume_at;
+
+-- name: ListDueCampaignQuotaPauses :many
+SELECT campaign_id FROM campaign_quota_pauses
+WHERE resume_at <= sqlc.arg(now)
+ORDER BY resume_at;
+
+-- name: DeleteCampaignQuotaPause :exec
+DELETE FROM campaign_quota_pauses WHERE campaign_id = sqlc.arg(campaign_id);
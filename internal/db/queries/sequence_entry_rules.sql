@@ -1,9 +1,10 @@
 -- name: ListEntryRulesBySequence :many
 SELECT ser.*, el.name as list_name, el.slug as list_slug,
-       es2.name as source_sequence_name
+       es2.name as source_sequence_name, seg.name as segment_name
 FROM sequence_entry_rules ser
 LEFT JOIN email_lists el ON ser.trigger_type = 'list_join' AND el.id = ser.source_id
 LEFT JOIN email_sequences es2 ON ser.trigger_type = 'sequence_complete' AND es2.id = ser.source_id
+LEFT JOIN segments seg ON ser.trigger_type = 'segment_enter' AND seg.id = ser.source_id
 WHERE ser.sequence_id = sqlc.arg(sequence_id)
 ORDER BY ser.priority, ser.created_at;
 
@@ -27,6 +28,17 @@ WHERE ser.trigger_type = sqlc.arg(trigger_type)
   AND es.is_active = 1
 ORDER BY ser.priority;
 
+-- name: GetActiveEntryRulesForOrgTrigger :many
+-- Active entry rules of an org for triggers whose source is not a globally unique ID (tag_added, link_clicked)
+SELECT ser.*, es.name as sequence_name, es.sequence_type
+FROM sequence_entry_rules ser
+JOIN email_sequences es ON es.id = ser.sequence_id
+WHERE es.org_id = sqlc.arg(org_id)
+  AND ser.trigger_type = sqlc.arg(trigger_type)
+  AND ser.is_active = 1
+  AND es.is_active = 1
+ORDER BY ser.priority;
+
 -- name: CreateEntryRule :one
 INSERT INTO sequence_entry_rules (id, sequence_id, trigger_type, source_id, priority, is_active, created_at)
 VALUES (sqlc.arg(id), sqlc.arg(sequence_id), sqlc.arg(trigger_type), sqlc.arg(source_id), sqlc.arg(priority), sqlc.arg(is_active), datetime('now'))
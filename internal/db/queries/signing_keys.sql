@@ -0,0 +1,2 @@
+-- name: GetSigningKey :one
+SELECT secret FROM signing_keys WHERE name = sqlc.arg(name);
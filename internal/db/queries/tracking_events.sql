@@ -0,0 +1,75 @@
+-- Resolves a tracking token to its send, whichever kind of email it was
+-- name: ResolveTrackingToken :one
+SELECT 'sequence' AS send_type, eq.id, COALESCE(et.org_id, c.org_id, '') AS org_id, eq.contact_id,
+       '' AS campaign_id, COALESCE(et.sequence_id, '') AS sequence_id, COALESCE(et.subject, '') AS subject,
+       eq.opened_at, eq.clicked_at, eq.sent_at
+FROM email_queue eq
+LEFT JOIN contacts c ON c.id = eq.contact_id
+LEFT JOIN email_templates et ON et.id = eq.template_id
+WHERE eq.tracking_token = sqlc.arg(tracking_token)
+UNION ALL
+SELECT 'campaign', cs.id, ec.org_id, cs.contact_id,
+       cs.campaign_id, '', ec.subject,
+       cs.opened_at, cs.clicked_at, cs.sent_at
+FROM campaign_sends cs
+JOIN email_campaigns ec ON ec.id = cs.campaign_id
+WHERE cs.tracking_token = sqlc.arg(tracking_token)
+UNION ALL
+SELECT 'transactional', ts.id, ts.org_id, ts.contact_id,
+       '', '', te.subject,
+       ts.opened_at, ts.clicked_at, ts.sent_at
+FROM transactional_sends ts
+JOIN transactional_emails te ON te.id = ts.template_id
+WHERE ts.tracking_token = sqlc.arg(tracking_token)
+LIMIT 1;
+
+-- name: CreateTrackingEvent :exec
+INSERT INTO tracking_events (
+    id, org_id, send_type, send_id, contact_id, event,
+    link_url, link_name, user_agent, ip_address, machine, created_at
+)
+VALUES (sqlc.arg(id), sqlc.arg(org_id), sqlc.arg(send_type), sqlc.arg(send_id), sqlc.arg(contact_id), sqlc.arg(event), sqlc.arg(link_url), sqlc.arg(link_name), sqlc.arg(user_agent), sqlc.arg(ip_address), sqlc.arg(machine), datetime('now'));
+
+-- name: ListTrackingEventsBySend :many
+SELECT * FROM tracking_events
+WHERE send_type = sqlc.arg(send_type) AND send_id = sqlc.arg(send_id)
+ORDER BY created_at, rowid;
+
+-- Counts the campaign's recipients with an open or click that was not made
+-- by a machine
+-- name: GetCampaignHumanEngagement :one
+SELECT COUNT(DISTINCT CASE WHEN te.event = 'opened' THEN te.send_id END) AS human_opened,
+       COUNT(DISTINCT CASE WHEN te.event = 'clicked' THEN te.send_id END) AS human_clicked
+FROM campaign_sends cs
+JOIN tracking_events te ON te.send_type = 'campaign' AND te.send_id = cs.id
+WHERE cs.campaign_id = sqlc.arg(campaign_id) AND te.machine = 0;
+
+-- Counts the org's opened and clicked sends in a period, in total and by
+-- humans only
+-- name: GetOrgEngagement :one
+SELECT COUNT(DISTINCT CASE WHEN event = 'opened' THEN send_type || ':' || send_id END) AS opened,
+       COUNT(DISTINCT CASE WHEN event = 'opened' AND machine = 0 THEN send_type || ':' || send_id END) AS human_opened,
+       COUNT(DISTINCT CASE WHEN event = 'clicked' THEN send_type || ':' || send_id END) AS clicked,
+       COUNT(DISTINCT CASE WHEN event = 'clicked' AND machine = 0 THEN send_type || ':' || send_id END) AS human_clicked
+FROM tracking_events
+WHERE org_id = sqlc.arg(org_id) AND created_at >= sqlc.arg(start_date) AND created_at < sqlc.arg(end_date);
+
+-- Same as GetOrgEngagement, per day
+-- name: ListOrgEngagementByDay :many
+SELECT CAST(date(created_at) AS TEXT) AS day,
+       COUNT(DISTINCT CASE WHEN event = 'opened' THEN send_type || ':' || send_id END) AS opened,
+       COUNT(DISTINCT CASE WHEN event = 'opened' AND machine = 0 THEN send_type || ':' || send_id END) AS human_opened,
+       COUNT(DISTINCT CASE WHEN event = 'clicked' THEN send_type || ':' || send_id END) AS clicked,
+       COUNT(DISTINCT CASE WHEN event = 'clicked' AND machine = 0 THEN send_type || ':' || send_id END) AS human_clicked
+FROM tracking_events
+WHERE org_id = sqlc.arg(org_id) AND created_at >= sqlc.arg(start_date) AND created_at < sqlc.arg(end_date)
+GROUP BY date(created_at)
+ORDER BY day;
+
+-- Human opens per contact and UTC hour of the day
+-- name: ListContactOpenHours :many
+SELECT contact_id, CAST(strftime('%H', created_at) AS INTEGER) AS hour, COUNT(*) AS opens
+FROM tracking_events
+WHERE org_id = sqlc.arg(org_id) AND event = 'opened' AND machine = 0
+  AND contact_id IS NOT NULL AND created_at >= sqlc.arg(since)
+GROUP BY contact_id, hour;
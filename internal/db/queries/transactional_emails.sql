@@ -121,3 +121,35 @@ SELECT
     SUM(CASE WHEN status = 'failed' THEN 1 ELSE 0 END) as failed
 FROM transactional_sends
 WHERE template_id = sqlc.arg(template_id);
+
+-- Attachments
+
+-- name: CreateTransactionalAttachment :exec
+INSERT INTO transactional_attachments (
+    id, send_id, org_id, position, filename, content_type, size,
+    content_id, content, expires_at, created_at
+)
+VALUES (sqlc.arg(id), sqlc.arg(send_id), sqlc.arg(org_id), sqlc.arg(position), sqlc.arg(filename), sqlc.arg(content_type), sqlc.arg(size), sqlc.arg(content_id), sqlc.arg(content), sqlc.arg(expires_at), datetime('now'));
+
+-- name: ListTransactionalAttachmentsBySend :many
+SELECT id, send_id, org_id, position, filename, content_type, size, content_id, expires_at, created_at
+FROM transactional_attachments
+WHERE send_id = sqlc.arg(send_id)
+ORDER BY position;
+
+-- name: PurgeExpiredTransactionalAttachments :execrows
+UPDATE transactional_attachments
+SET content = NULL, expires_at = NULL
+WHERE expires_at IS NOT NULL AND expires_at <= datetime('now');
+
+-- name: ListTransactionalAttachmentContent :many
+SELECT filename, content_type, content_id, content
+FROM transactional_attachments
+WHERE send_id = sqlc.arg(send_id)
+ORDER BY position;
+
+-- Drops content kept only until the send was processed
+-- name: ReleaseTransactionalAttachmentContent :exec
+UPDATE transactional_attachments
+SET content = NULL
+WHERE send_id = sqlc.arg(send_id) AND expires_at IS NULL;
@@ -0,0 +1,67 @@
+-- A message with send_at is not claimed before that time.
+-- name: EnqueueTransactionalSend :exec
+INSERT INTO transactional_queue (
+    send_id, org_id, to_email, from_name, from_email, reply_to,
+    subject, html_body, text_body, send_at, next_attempt_at, created_at
+)
+VALUES (sqlc.arg(send_id), sqlc.arg(org_id), sqlc.arg(to_email), sqlc.arg(from_name), sqlc.arg(from_email), sqlc.arg(reply_to), sqlc.arg(subject), sqlc.arg(html_body), sqlc.arg(text_body), sqlc.narg(send_at), COALESCE(sqlc.narg(send_at), datetime('now')), datetime('now'));
+
+-- name: GetTransactionalQueueItem :one
+SELECT * FROM transactional_queue WHERE send_id = sqlc.arg(send_id);
+
+-- Claims due messages by pushing next_attempt_at to lease_until, so a message
+-- whose sender crashed is picked up again once the lease runs out.
+-- Round-robin across organizations so one organization cannot fill the batch.
+-- name: ClaimTransactionalQueue :many
+UPDATE transactional_queue
+SET attempts = attempts + 1,
+    next_attempt_at = sqlc.arg(lease_until)
+WHERE send_id IN (
+    SELECT tq.send_id FROM transactional_queue tq
+    WHERE tq.next_attempt_at <= sqlc.arg(now)
+    ORDER BY ROW_NUMBER() OVER (PARTITION BY tq.org_id ORDER BY tq.next_attempt_at), tq.next_attempt_at
+    LIMIT sqlc.arg(limit_count)
+)
+RETURNING *;
+
+-- name: RetryTransactionalQueueItem :exec
+UPDATE transactional_queue
+SET next_attempt_at = sqlc.arg(next_attempt_at),
+    last_error = sqlc.arg(last_error)
+WHERE send_id = sqlc.arg(send_id);
+
+-- Reschedules a message without counting the claim as an attempt
+-- name: DeferTransactionalQueueItem :exec
+UPDATE transactional_queue
+SET attempts = MAX(attempts - 1, 0),
+    next_attempt_at = sqlc.arg(next_attempt_at)
+WHERE send_id = sqlc.arg(send_id);
+
+-- name: DeleteTransactionalQueueItem :exec
+DELETE FROM transactional_queue WHERE send_id = sqlc.arg(send_id);
+
+-- Removes a send from the queue if the dispatcher has not claimed it yet.
+-- Affects no rows once the send has been picked up.
+-- name: DequeueUnclaimedTransactionalSend :execrows
+DELETE FROM transactional_queue
+WHERE send_id = sqlc.arg(send_id) AND org_id = sqlc.arg(org_id) AND attempts = 0;
+
+-- name: DeleteTransactionalSendAttachments :exec
+DELETE FROM transactional_attachments WHERE send_id = sqlc.arg(send_id);
+
+-- name: DeleteTransactionalIdempotencyKeys :exec
+DELETE FROM transactional_idempotency_keys WHERE send_id = sqlc.arg(send_id);
+
+-- name: DeleteTransactionalSend :exec
+DELETE FROM transactional_sends WHERE id = sqlc.arg(id) AND org_id = sqlc.arg(org_id);
+
+-- Records the send for a key. Affects no rows when the key was already used.
+-- name: CreateTransactionalIdempotencyKey :execrows
+INSERT INTO transactional_idempotency_keys (org_id, idempotency_key, send_id, created_at)
+VALUES (sqlc.arg(org_id), sqlc.arg(idempotency_key), sqlc.arg(send_id), datetime('now'))
+ON CONFLICT (org_id, idempotency_key) DO NOTHING;
+
+-- name: GetTransactionalSendByIdempotencyKey :one
+SELECT ts.* FROM transactional_sends ts
+JOIN transactional_idempotency_keys tik ON tik.send_id = ts.id
+WHERE tik.org_id = sqlc.arg(org_id) AND tik.idempotency_key = sqlc.arg(idempotency_key);
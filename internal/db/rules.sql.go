@@ -1018,6 +1018,35 @@ func (q *Queries) ToggleOrgRule(ctx context.Context, arg ToggleOrgRuleParams) (O
 	return i, err
 }
 
+const updateAutomationLogResult = `-- name: UpdateAutomationLogResult :exec
+UPDATE automation_log
+SET actions_executed = ?1,
+    success = ?2,
+    error_message = ?3,
+    execution_time_ms = ?4
+WHERE id = ?5
+`
+
+type UpdateAutomationLogResultParams struct {
+	ActionsExecuted sql.NullString `json:"actions_executed"`
+	Success         int64          `json:"success"`
+	ErrorMessage    sql.NullString `json:"error_message"`
+	ExecutionTimeMs sql.NullInt64  `json:"execution_time_ms"`
+	ID              string         `json:"id"`
+}
+
+// Record the outcome of a rule execution claimed with LogAutomation
+func (q *Queries) UpdateAutomationLogResult(ctx context.Context, arg UpdateAutomationLogResultParams) error {
+	_, err := q.db.ExecContext(ctx, updateAutomationLogResult,
+		arg.ActionsExecuted,
+		arg.Success,
+		arg.ErrorMessage,
+		arg.ExecutionTimeMs,
+		arg.ID,
+	)
+	return err
+}
+
 const updateOrgRule = `-- name: UpdateOrgRule :one
 UPDATE org_rules
 SET name = ?1,
@@ -144,6 +144,68 @@ func (q *Queries) GetActiveEntryRuleForTrigger(ctx context.Context, arg GetActiv
 	return items, nil
 }
 
+const getActiveEntryRulesForOrgTrigger = `-- name: GetActiveEntryRulesForOrgTrigger :many
+SELECT ser.id, ser.sequence_id, ser.trigger_type, ser.source_id, ser.priority, ser.is_active, ser.created_at, es.name as sequence_name, es.sequence_type
+FROM sequence_entry_rules ser
+JOIN email_sequences es ON es.id = ser.sequence_id
+WHERE es.org_id = ?1
+  AND ser.trigger_type = ?2
+  AND ser.is_active = 1
+  AND es.is_active = 1
+ORDER BY ser.priority
+`
+
+type GetActiveEntryRulesForOrgTriggerParams struct {
+	OrgID       sql.NullString `json:"org_id"`
+	TriggerType string         `json:"trigger_type"`
+}
+
+type GetActiveEntryRulesForOrgTriggerRow struct {
+	ID           string         `json:"id"`
+	SequenceID   string         `json:"sequence_id"`
+	TriggerType  string         `json:"trigger_type"`
+	SourceID     string         `json:"source_id"`
+	Priority     sql.NullInt64  `json:"priority"`
+	IsActive     sql.NullInt64  `json:"is_active"`
+	CreatedAt    sql.NullString `json:"created_at"`
+	SequenceName string         `json:"sequence_name"`
+	SequenceType sql.NullString `json:"sequence_type"`
+}
+
+// Active entry rules of an org for triggers whose source is not a globally unique ID (tag_added, link_clicked)
+func (q *Queries) GetActiveEntryRulesForOrgTrigger(ctx context.Context, arg GetActiveEntryRulesForOrgTriggerParams) ([]GetActiveEntryRulesForOrgTriggerRow, error) {
+	rows, err := q.db.QueryContext(ctx, getActiveEntryRulesForOrgTrigger, arg.OrgID, arg.TriggerType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetActiveEntryRulesForOrgTriggerRow
+	for rows.Next() {
+		var i GetActiveEntryRulesForOrgTriggerRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SequenceID,
+			&i.TriggerType,
+			&i.SourceID,
+			&i.Priority,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.SequenceName,
+			&i.SequenceType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getActiveSequenceForContact = `-- name: GetActiveSequenceForContact :one
 SELECT css.id, css.contact_id, css.sequence_id, css.current_position, css.is_active, css.started_at, css.completed_at, css.unsubscribed_at, css.paused_at, es.name as sequence_name, es.sequence_type
 FROM contact_sequence_state css
@@ -263,10 +325,11 @@ func (q *Queries) ListEntryRulesByList(ctx context.Context, sourceID string) ([]
 
 const listEntryRulesBySequence = `-- name: ListEntryRulesBySequence :many
 SELECT ser.id, ser.sequence_id, ser.trigger_type, ser.source_id, ser.priority, ser.is_active, ser.created_at, el.name as list_name, el.slug as list_slug,
-       es2.name as source_sequence_name
+       es2.name as source_sequence_name, seg.name as segment_name
 FROM sequence_entry_rules ser
 LEFT JOIN email_lists el ON ser.trigger_type = 'list_join' AND el.id = ser.source_id
 LEFT JOIN email_sequences es2 ON ser.trigger_type = 'sequence_complete' AND es2.id = ser.source_id
+LEFT JOIN segments seg ON ser.trigger_type = 'segment_enter' AND seg.id = ser.source_id
 WHERE ser.sequence_id = ?1
 ORDER BY ser.priority, ser.created_at
 `
@@ -282,6 +345,7 @@ type ListEntryRulesBySequenceRow struct {
 	ListName           sql.NullString `json:"list_name"`
 	ListSlug           sql.NullString `json:"list_slug"`
 	SourceSequenceName sql.NullString `json:"source_sequence_name"`
+	SegmentName        sql.NullString `json:"segment_name"`
 }
 
 func (q *Queries) ListEntryRulesBySequence(ctx context.Context, sequenceID string) ([]ListEntryRulesBySequenceRow, error) {
@@ -304,6 +368,7 @@ func (q *Queries) ListEntryRulesBySequence(ctx context.Context, sequenceID strin
 			&i.ListName,
 			&i.ListSlug,
 			&i.SourceSequenceName,
+			&i.SegmentName,
 		); err != nil {
 			return nil, err
 		}
@@ -34,6 +34,44 @@ func (q *Queries) CountTransactionalSends(ctx context.Context, templateID string
 	return count, err
 }
 
+const createTransactionalAttachment = `-- name: CreateTransactionalAttachment :exec
+INSERT INTO transactional_attachments (
+    id, send_id, org_id, position, filename, content_type, size,
+    content_id, content, expires_at, created_at
+)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, datetime('now'))
+`
+
+type CreateTransactionalAttachmentParams struct {
+	ID          string         `json:"id"`
+	SendID      string         `json:"send_id"`
+	OrgID       string         `json:"org_id"`
+	Position    int64          `json:"position"`
+	Filename    string         `json:"filename"`
+	ContentType string         `json:"content_type"`
+	Size        int64          `json:"size"`
+	ContentID   sql.NullString `json:"content_id"`
+	Content     []byte         `json:"content"`
+	ExpiresAt   sql.NullString `json:"expires_at"`
+}
+
+// Attachments
+func (q *Queries) CreateTransactionalAttachment(ctx context.Context, arg CreateTransactionalAttachmentParams) error {
+	_, err := q.db.ExecContext(ctx, createTransactionalAttachment,
+		arg.ID,
+		arg.SendID,
+		arg.OrgID,
+		arg.Position,
+		arg.Filename,
+		arg.ContentType,
+		arg.Size,
+		arg.ContentID,
+		arg.Content,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
 const createTransactionalEmail = `-- name: CreateTransactionalEmail :one
 
 
@@ -389,6 +427,102 @@ func (q *Queries) GetTransactionalStats(ctx context.Context, templateID string)
 	return i, err
 }
 
+const listTransactionalAttachmentContent = `-- name: ListTransactionalAttachmentContent :many
+SELECT filename, content_type, content_id, content
+FROM transactional_attachments
+WHERE send_id = ?1
+ORDER BY position
+`
+
+type ListTransactionalAttachmentContentRow struct {
+	Filename    string         `json:"filename"`
+	ContentType string         `json:"content_type"`
+	ContentID   sql.NullString `json:"content_id"`
+	Content     []byte         `json:"content"`
+}
+
+func (q *Queries) ListTransactionalAttachmentContent(ctx context.Context, sendID string) ([]ListTransactionalAttachmentContentRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTransactionalAttachmentContent, sendID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTransactionalAttachmentContentRow
+	for rows.Next() {
+		var i ListTransactionalAttachmentContentRow
+		if err := rows.Scan(
+			&i.Filename,
+			&i.ContentType,
+			&i.ContentID,
+			&i.Content,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionalAttachmentsBySend = `-- name: ListTransactionalAttachmentsBySend :many
+SELECT id, send_id, org_id, position, filename, content_type, size, content_id, expires_at, created_at
+FROM transactional_attachments
+WHERE send_id = ?1
+ORDER BY position
+`
+
+type ListTransactionalAttachmentsBySendRow struct {
+	ID          string         `json:"id"`
+	SendID      string         `json:"send_id"`
+	OrgID       string         `json:"org_id"`
+	Position    int64          `json:"position"`
+	Filename    string         `json:"filename"`
+	ContentType string         `json:"content_type"`
+	Size        int64          `json:"size"`
+	ContentID   sql.NullString `json:"content_id"`
+	ExpiresAt   sql.NullString `json:"expires_at"`
+	CreatedAt   sql.NullString `json:"created_at"`
+}
+
+func (q *Queries) ListTransactionalAttachmentsBySend(ctx context.Context, sendID string) ([]ListTransactionalAttachmentsBySendRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTransactionalAttachmentsBySend, sendID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTransactionalAttachmentsBySendRow
+	for rows.Next() {
+		var i ListTransactionalAttachmentsBySendRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SendID,
+			&i.OrgID,
+			&i.Position,
+			&i.Filename,
+			&i.ContentType,
+			&i.Size,
+			&i.ContentID,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTransactionalEmails = `-- name: ListTransactionalEmails :many
 SELECT id, org_id, design_id, name, slug, description, subject, html_body, plain_text, from_name, from_email, reply_to, is_active, created_at, updated_at FROM transactional_emails
 WHERE org_id = ?1
@@ -562,6 +696,20 @@ func (q *Queries) ListTransactionalSendsByOrg(ctx context.Context, arg ListTrans
 	return items, nil
 }
 
+const purgeExpiredTransactionalAttachments = `-- name: PurgeExpiredTransactionalAttachments :execrows
+UPDATE transactional_attachments
+SET content = NULL, expires_at = NULL
+WHERE expires_at IS NOT NULL AND expires_at <= datetime('now')
+`
+
+func (q *Queries) PurgeExpiredTransactionalAttachments(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeExpiredTransactionalAttachments)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const recordTransactionalClick = `-- name: RecordTransactionalClick :exec
 UPDATE transactional_sends
 SET clicked_at = COALESCE(clicked_at, datetime('now'))
@@ -584,6 +732,18 @@ func (q *Queries) RecordTransactionalOpen(ctx context.Context, id string) error
 	return err
 }
 
+const releaseTransactionalAttachmentContent = `-- name: ReleaseTransactionalAttachmentContent :exec
+UPDATE transactional_attachments
+SET content = NULL
+WHERE send_id = ?1 AND expires_at IS NULL
+`
+
+// Drops content kept only until the send was processed
+func (q *Queries) ReleaseTransactionalAttachmentContent(ctx context.Context, sendID string) error {
+	_, err := q.db.ExecContext(ctx, releaseTransactionalAttachmentContent, sendID)
+	return err
+}
+
 const updateTransactionalEmail = `-- name: UpdateTransactionalEmail :one
 UPDATE transactional_emails
 SET name = COALESCE(NULLIF(?1, ''), name),
@@ -154,7 +154,9 @@ const (
 
 	// Contact/Lead events
 	TopicContactCreated      = "contact.created"      // New contact/subscriber added
+	TopicContactSubscribed   = "contact.subscribed"   // List subscription became active (subscribe or confirm)
 	TopicContactUnsubscribed = "contact.unsubscribed" // Contact unsubscribed from list
+	TopicContactTagAdded     = "contact.tag_added"    // Tag added to contact
 
 	// Order/Checkout events
 	TopicCheckoutCompleted = "checkout.completed" // Checkout session completed
@@ -986,6 +988,7 @@ type EmailEvent struct {
 	Status     string    `json:"status"`                // sent, bounced, complained, opened, clicked
 	BounceType string    `json:"bounce_type,omitempty"` // hard, soft
 	ClickedURL string    `json:"clicked_url,omitempty"`
+	Machine    bool      `json:"machine,omitempty"` // Open or click by a proxy, scanner or bot
 	Timestamp  time.Time `json:"timestamp"`
 }
 
@@ -1007,6 +1010,7 @@ type ContactEvent struct {
 	ContactID string    `json:"contact_id"`
 	Email     string    `json:"email"`
 	ListID    string    `json:"list_id,omitempty"`
+	Tag       string    `json:"tag,omitempty"`    // For contact.tag_added
 	Source    string    `json:"source,omitempty"` // How they were added
 	Timestamp time.Time `json:"timestamp"`
 }
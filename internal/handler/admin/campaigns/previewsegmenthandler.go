@@ -0,0 +1,28 @@
+package campaigns
+
+import (
+	"net/http"
+
+	"github.com/outlet-sh/outlet/internal/logic/admin/campaigns"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func PreviewSegmentHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.SegmentPreviewRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := campaigns.NewPreviewSegmentLogic(r.Context(), svcCtx)
+		resp, err := l.PreviewSegment(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
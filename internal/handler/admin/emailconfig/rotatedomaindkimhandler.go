@@ -0,0 +1,28 @@
+package emailconfig
+
+import (
+	"net/http"
+
+	"github.com/outlet-sh/outlet/internal/logic/admin/emailconfig"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func RotateDomainDKIMHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.RotateDomainDKIMRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := emailconfig.NewRotateDomainDKIMLogic(r.Context(), svcCtx)
+		resp, err := l.RotateDomainDKIM(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
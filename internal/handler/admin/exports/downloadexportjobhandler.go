@@ -0,0 +1,27 @@
+package exports
+
+import (
+	"net/http"
+
+	"github.com/outlet-sh/outlet/internal/logic/admin/exports"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// DownloadExportJobHandler writes the export file itself, so it only
+// responds on error
+func DownloadExportJobHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.DownloadExportJobRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := exports.NewDownloadExportJobLogic(r.Context(), svcCtx)
+		if err := l.DownloadExportJob(w, r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package housekeeping
+
+import (
+	"net/http"
+
+	"github.com/outlet-sh/outlet/internal/logic/admin/housekeeping"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func HousekeepingSegmentHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.HousekeepingSegmentRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := housekeeping.NewHousekeepingSegmentLogic(r.Context(), svcCtx)
+		resp, err := l.HousekeepingSegment(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package imports
+
+import (
+	"net/http"
+
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/logic/admin/imports"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// MaxImportUploadSize caps the size of an uploaded CSV request
+const MaxImportUploadSize = 50 << 20
+
+// CreateImportJobHandler accepts a multipart CSV upload and queues an import job.
+// Registered manually in cmd/serve.go since goctl does not generate multipart handlers.
+func CreateImportJobHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, MaxImportUploadSize)
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			httpx.ErrorCtx(r.Context(), w, errorx.NewBadRequestError("invalid upload: "+err.Error()))
+			return
+		}
+		defer r.MultipartForm.RemoveAll()
+
+		var req types.CreateImportJobRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, errorx.NewBadRequestError("file is required"))
+			return
+		}
+		defer file.Close()
+
+		l := imports.NewCreateImportJobLogic(r.Context(), svcCtx)
+		resp, err := l.CreateImportJob(&req, file, header.Filename)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package imports
+
+import (
+	"net/http"
+
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/logic/admin/imports"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// PreviewImportHandler returns the header and sample rows of an uploaded CSV.
+// Registered manually in cmd/serve.go since goctl does not generate multipart handlers.
+func PreviewImportHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, MaxImportUploadSize)
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			httpx.ErrorCtx(r.Context(), w, errorx.NewBadRequestError("invalid upload: "+err.Error()))
+			return
+		}
+		defer r.MultipartForm.RemoveAll()
+
+		var req types.PreviewImportRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, errorx.NewBadRequestError("file is required"))
+			return
+		}
+		defer file.Close()
+
+		l := imports.NewPreviewImportLogic(r.Context(), svcCtx)
+		resp, err := l.PreviewImport(&req, file)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
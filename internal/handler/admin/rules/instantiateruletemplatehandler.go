@@ -0,0 +1,28 @@
+package rules
+
+import (
+	"net/http"
+
+	"github.com/outlet-sh/outlet/internal/logic/admin/rules"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func InstantiateRuleTemplateHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.InstantiateRuleTemplateRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := rules.NewInstantiateRuleTemplateLogic(r.Context(), svcCtx)
+		resp, err := l.InstantiateRuleTemplate(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
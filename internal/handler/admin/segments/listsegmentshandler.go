@@ -0,0 +1,21 @@
+package segments
+
+import (
+	"net/http"
+
+	"github.com/outlet-sh/outlet/internal/logic/admin/segments"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func ListSegmentsHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := segments.NewListSegmentsLogic(r.Context(), svcCtx)
+		resp, err := l.ListSegments()
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -13,11 +13,14 @@ import (
 	admincampaigns "github.com/outlet-sh/outlet/internal/handler/admin/campaigns"
 	admindesigns "github.com/outlet-sh/outlet/internal/handler/admin/designs"
 	adminemailconfig "github.com/outlet-sh/outlet/internal/handler/admin/emailconfig"
+	adminexports "github.com/outlet-sh/outlet/internal/handler/admin/exports"
 	admingdpr "github.com/outlet-sh/outlet/internal/handler/admin/gdpr"
 	adminhousekeeping "github.com/outlet-sh/outlet/internal/handler/admin/housekeeping"
 	adminimports "github.com/outlet-sh/outlet/internal/handler/admin/imports"
 	adminlists "github.com/outlet-sh/outlet/internal/handler/admin/lists"
 	adminorganizations "github.com/outlet-sh/outlet/internal/handler/admin/organizations"
+	adminrules "github.com/outlet-sh/outlet/internal/handler/admin/rules"
+	adminsegments "github.com/outlet-sh/outlet/internal/handler/admin/segments"
 	adminsequences "github.com/outlet-sh/outlet/internal/handler/admin/sequences"
 	adminsettings "github.com/outlet-sh/outlet/internal/handler/admin/settings"
 	adminsubscribers "github.com/outlet-sh/outlet/internal/handler/admin/subscribers"
@@ -225,6 +228,16 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 					Path:    "/campaigns/:id/stats",
 					Handler: admincampaigns.GetCampaignStatsHandler(serverCtx),
 				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/campaigns/:id/winner",
+					Handler: admincampaigns.PickCampaignWinnerHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/campaigns/segment-preview",
+					Handler: admincampaigns.PreviewSegmentHandler(serverCtx),
+				},
 			}...,
 		),
 		rest.WithPrefix("/api/admin"),
@@ -293,6 +306,11 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 					Path:    "/:org_id/domain-identities/:id/refresh",
 					Handler: adminemailconfig.RefreshDomainIdentityHandler(serverCtx),
 				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/:org_id/domain-identities/:id/dkim/rotate",
+					Handler: adminemailconfig.RotateDomainDKIMHandler(serverCtx),
+				},
 				{
 					Method:  http.MethodGet,
 					Path:    "/:org_id/email-config",
@@ -308,11 +326,60 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 					Path:    "/:org_id/email-config/detect-quota",
 					Handler: adminemailconfig.DetectSESQuotaHandler(serverCtx),
 				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/:org_id/validation-policy",
+					Handler: adminemailconfig.GetOrgValidationPolicyHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/:org_id/validation-policy",
+					Handler: adminemailconfig.UpdateOrgValidationPolicyHandler(serverCtx),
+				},
 			}...,
 		),
 		rest.WithPrefix("/api/admin/organizations"),
 	)
 
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.Auth},
+			[]rest.Route{
+				{
+					Method:  http.MethodPost,
+					Path:    "/export-jobs",
+					Handler: adminexports.CreateExportJobHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/export-jobs",
+					Handler: adminexports.ListExportJobsHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/export-jobs/:id",
+					Handler: adminexports.GetExportJobHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodDelete,
+					Path:    "/export-jobs/:id",
+					Handler: adminexports.DeleteExportJobHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/export-jobs/:id/cancel",
+					Handler: adminexports.CancelExportJobHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/export-jobs/:id/download",
+					Handler: adminexports.DownloadExportJobHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/admin"),
+	)
+
 	server.AddRoutes(
 		rest.WithMiddlewares(
 			[]rest.Middleware{serverCtx.Auth},
@@ -361,6 +428,11 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 					Path:    "/unconfirmed",
 					Handler: adminhousekeeping.HousekeepingUnconfirmedHandler(serverCtx),
 				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/segment",
+					Handler: adminhousekeeping.HousekeepingSegmentHandler(serverCtx),
+				},
 			}...,
 		),
 		rest.WithPrefix("/api/admin/housekeeping"),
@@ -449,6 +521,46 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 					Path:    "/lists/:id/subscribers/:subscriberId",
 					Handler: adminlists.GetSubscriberDetailHandler(serverCtx),
 				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/lists/:id/validation-policy",
+					Handler: adminlists.GetListValidationPolicyHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/lists/:id/validation-policy",
+					Handler: adminlists.UpdateListValidationPolicyHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/lists/:id/verifications",
+					Handler: adminlists.ListListVerificationsHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/lists/:id/verifications/:jobId",
+					Handler: adminlists.GetListVerificationHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/lists/:id/verifications/:jobId/apply",
+					Handler: adminlists.ApplyListVerificationHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/lists/:id/verifications/:jobId/cancel",
+					Handler: adminlists.CancelListVerificationHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/lists/:id/verifications/:jobId/results",
+					Handler: adminlists.ListListVerificationResultsHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/lists/:id/verify",
+					Handler: adminlists.StartListVerificationHandler(serverCtx),
+				},
 				{
 					Method:  http.MethodGet,
 					Path:    "/lists/:listId/custom-fields",
@@ -538,6 +650,104 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 		rest.WithPrefix("/api/admin"),
 	)
 
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.Auth},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/rules",
+					Handler: adminrules.ListRulesHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/rules/:id",
+					Handler: adminrules.GetRuleHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/rules",
+					Handler: adminrules.CreateRuleHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/rules/:id",
+					Handler: adminrules.UpdateRuleHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodDelete,
+					Path:    "/rules/:id",
+					Handler: adminrules.DeleteRuleHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/rules/:id/toggle",
+					Handler: adminrules.ToggleRuleHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/rules/:id/validate",
+					Handler: adminrules.ValidateRuleHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/rule-templates",
+					Handler: adminrules.ListRuleTemplatesHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/rule-templates/:id/instantiate",
+					Handler: adminrules.InstantiateRuleTemplateHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/automation-log",
+					Handler: adminrules.ListAutomationLogHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/admin"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.Auth},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/segments",
+					Handler: adminsegments.ListSegmentsHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/segments/:id",
+					Handler: adminsegments.GetSegmentHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/segments",
+					Handler: adminsegments.CreateSegmentHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/segments/:id",
+					Handler: adminsegments.UpdateSegmentHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodDelete,
+					Path:    "/segments/:id",
+					Handler: adminsegments.DeleteSegmentHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/segments/:id/refresh",
+					Handler: adminsegments.RefreshSegmentHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/admin"),
+	)
+
 	server.AddRoutes(
 		rest.WithMiddlewares(
 			[]rest.Middleware{serverCtx.Auth},
@@ -699,6 +909,11 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 					Path:    "/version",
 					Handler: adminsystem.GetVersionHandler(serverCtx),
 				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/workers",
+					Handler: adminsystem.GetWorkerStatusHandler(serverCtx),
+				},
 			}...,
 		),
 		rest.WithPrefix("/api/admin/system"),
@@ -914,6 +1129,11 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 					Path:    "/lists/:slug/unsubscribe",
 					Handler: sdk.UnsubscribeFromListHandler(serverCtx),
 				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/segments/preview",
+					Handler: sdk.PreviewSegmentHandler(serverCtx),
+				},
 			}...,
 		),
 		rest.WithPrefix("/sdk/v1"),
@@ -967,11 +1187,21 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 					Path:    "/",
 					Handler: sdkemails.SendEmailHandler(serverCtx),
 				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/batch",
+					Handler: sdkemails.SendBatchEmailHandler(serverCtx),
+				},
 				{
 					Method:  http.MethodGet,
 					Path:    "/:messageId",
 					Handler: sdkemails.GetEmailStatusHandler(serverCtx),
 				},
+				{
+					Method:  http.MethodDelete,
+					Path:    "/:messageId",
+					Handler: sdkemails.CancelEmailHandler(serverCtx),
+				},
 				{
 					Method:  http.MethodGet,
 					Path:    "/:messageId/events",
@@ -980,6 +1210,7 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 			}...,
 		),
 		rest.WithPrefix("/sdk/v1/emails"),
+		rest.WithMaxBytes(12582912),
 	)
 
 	server.AddRoutes(
@@ -4,7 +4,6 @@ import (
 	"archive/zip"
 	"context"
 	"database/sql"
-	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
@@ -37,6 +36,24 @@ func NewCreateBackupLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Crea
 }
 
 func (l *CreateBackupLogic) CreateBackup(req *types.CreateBackupRequest) (resp *types.CreateBackupResponse, err error) {
+	return l.createBackup(req, "manual")
+}
+
+// CreateScheduledBackup starts a backup recorded as backup_type 'scheduled'
+func (l *CreateBackupLogic) CreateScheduledBackup(req *types.CreateBackupRequest) (resp *types.CreateBackupResponse, err error) {
+	return l.createBackup(req, "scheduled")
+}
+
+func (l *CreateBackupLogic) createBackup(req *types.CreateBackupRequest, backupType string) (resp *types.CreateBackupResponse, err error) {
+	// Get S3 config if uploading to S3
+	var s3Config *backupService.S3Config
+	if req.UploadToS3 {
+		s3Config = l.getS3Config()
+		if s3Config == nil {
+			return nil, fmt.Errorf("S3 not configured or credentials missing")
+		}
+	}
+
 	// Generate backup ID and filename
 	backupID := uuid.New().String()
 	timestamp := time.Now().Format("20060102-150405")
@@ -64,7 +81,7 @@ func (l *CreateBackupLogic) CreateBackup(req *types.CreateBackupRequest) (resp *
 		Filename:    filename,
 		FilePath:    sql.NullString{String: backupPath, Valid: true},
 		FileSize:    0,
-		BackupType:  "manual",
+		BackupType:  backupType,
 		StorageType: storageType,
 		S3Bucket:    sql.NullString{String: req.S3Bucket, Valid: req.S3Bucket != ""},
 		S3Key:       sql.NullString{},
@@ -75,13 +92,8 @@ func (l *CreateBackupLogic) CreateBackup(req *types.CreateBackupRequest) (resp *
 		return nil, fmt.Errorf("failed to create backup record: %w", err)
 	}
 
-	// Get S3 config if uploading to S3
-	var s3Config *backupService.S3Config
-	if req.UploadToS3 {
-		s3Config = l.getS3Config()
-		if s3Config == nil {
-			return nil, fmt.Errorf("S3 not configured or credentials missing")
-		}
+	if l.svcCtx.WebSocketHub != nil {
+		l.svcCtx.WebSocketHub.Broadcast(websocket.NewBackupUpdate(backupID, "in_progress", filename, 0, ""))
 	}
 
 	// Get database path for use in goroutine - must be absolute
@@ -432,71 +444,5 @@ func (l *CreateBackupLogic) getUserID() string {
 
 // getS3Config retrieves S3 configuration from platform settings
 func (l *CreateBackupLogic) getS3Config() *backupService.S3Config {
-	settings, err := l.svcCtx.DB.GetPlatformSettingsByCategory(l.ctx, "backup")
-	if err != nil {
-		l.Errorf("Failed to get backup settings: %v", err)
-		return nil
-	}
-
-	cfg := &backupService.S3Config{}
-	var accessKeyEncrypted, secretKeyEncrypted []byte
-
-	for _, s := range settings {
-		switch s.Key {
-		case "backup.s3_enabled":
-			if s.ValueText.String != "true" {
-				return nil // S3 not enabled
-			}
-		case "backup.s3_bucket":
-			cfg.Bucket = s.ValueText.String
-		case "backup.s3_region":
-			cfg.Region = s.ValueText.String
-		case "backup.s3_prefix":
-			cfg.Prefix = s.ValueText.String
-		case "backup.s3_access_key":
-			if s.ValueEncrypted.Valid {
-				var err error
-				accessKeyEncrypted, err = hex.DecodeString(s.ValueEncrypted.String)
-				if err != nil {
-					l.Errorf("Failed to decode S3 access key: %v", err)
-				}
-			}
-		case "backup.s3_secret_key":
-			if s.ValueEncrypted.Valid {
-				var err error
-				secretKeyEncrypted, err = hex.DecodeString(s.ValueEncrypted.String)
-				if err != nil {
-					l.Errorf("Failed to decode S3 secret key: %v", err)
-				}
-			}
-		}
-	}
-
-	// Decrypt credentials if crypto service is available
-	if l.svcCtx.CryptoService != nil {
-		if len(accessKeyEncrypted) > 0 {
-			decrypted, err := l.svcCtx.CryptoService.DecryptString(accessKeyEncrypted)
-			if err == nil {
-				cfg.AccessKey = decrypted
-			} else {
-				l.Errorf("Failed to decrypt S3 access key: %v", err)
-			}
-		}
-		if len(secretKeyEncrypted) > 0 {
-			decrypted, err := l.svcCtx.CryptoService.DecryptString(secretKeyEncrypted)
-			if err == nil {
-				cfg.SecretKey = decrypted
-			} else {
-				l.Errorf("Failed to decrypt S3 secret key: %v", err)
-			}
-		}
-	}
-
-	// Validate required fields
-	if cfg.Bucket == "" {
-		l.Errorf("S3 bucket not configured")
-		return nil
-	}
-
-	return cfg
+	return GetS3Config(l.ctx, l.svcCtx)
 }
@@ -3,7 +3,6 @@ package backup
 import (
 	"context"
 	"database/sql"
-	"encoding/hex"
 	"fmt"
 	"os"
 
@@ -73,69 +72,5 @@ func (l *DeleteBackupLogic) DeleteBackup(req *types.DeleteBackupRequest) (resp *
 
 // getS3Config retrieves S3 configuration from platform settings
 func (l *DeleteBackupLogic) getS3Config() *backupService.S3Config {
-	settings, err := l.svcCtx.DB.GetPlatformSettingsByCategory(l.ctx, "backup")
-	if err != nil {
-		l.Errorf("Failed to get backup settings: %v", err)
-		return nil
-	}
-
-	cfg := &backupService.S3Config{}
-	var accessKeyEncrypted, secretKeyEncrypted []byte
-
-	for _, s := range settings {
-		switch s.Key {
-		case "backup.s3_enabled":
-			if s.ValueText.String != "true" {
-				return nil
-			}
-		case "backup.s3_bucket":
-			cfg.Bucket = s.ValueText.String
-		case "backup.s3_region":
-			cfg.Region = s.ValueText.String
-		case "backup.s3_prefix":
-			cfg.Prefix = s.ValueText.String
-		case "backup.s3_access_key":
-			if s.ValueEncrypted.Valid {
-				var err error
-				accessKeyEncrypted, err = hex.DecodeString(s.ValueEncrypted.String)
-				if err != nil {
-					l.Errorf("Failed to decode S3 access key: %v", err)
-				}
-			}
-		case "backup.s3_secret_key":
-			if s.ValueEncrypted.Valid {
-				var err error
-				secretKeyEncrypted, err = hex.DecodeString(s.ValueEncrypted.String)
-				if err != nil {
-					l.Errorf("Failed to decode S3 secret key: %v", err)
-				}
-			}
-		}
-	}
-
-	// Decrypt credentials if crypto service is available
-	if l.svcCtx.CryptoService != nil {
-		if len(accessKeyEncrypted) > 0 {
-			decrypted, err := l.svcCtx.CryptoService.DecryptString(accessKeyEncrypted)
-			if err == nil {
-				cfg.AccessKey = decrypted
-			} else {
-				l.Errorf("Failed to decrypt S3 access key: %v", err)
-			}
-		}
-		if len(secretKeyEncrypted) > 0 {
-			decrypted, err := l.svcCtx.CryptoService.DecryptString(secretKeyEncrypted)
-			if err == nil {
-				cfg.SecretKey = decrypted
-			} else {
-				l.Errorf("Failed to decrypt S3 secret key: %v", err)
-			}
-		}
-	}
-
-	if cfg.Bucket == "" {
-		return nil
-	}
-
-	return cfg
+	return GetS3Config(l.ctx, l.svcCtx)
 }
@@ -0,0 +1,85 @@
+package backup
+
+import (
+	"context"
+	"encoding/hex"
+
+	backupService "github.com/outlet-sh/outlet/internal/services/backup"
+	"github.com/outlet-sh/outlet/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// GetS3Config retrieves the backup S3 configuration from platform settings.
+// Returns nil when S3 is disabled or no bucket is configured.
+func GetS3Config(ctx context.Context, svcCtx *svc.ServiceContext) *backupService.S3Config {
+	logger := logx.WithContext(ctx)
+
+	settings, err := svcCtx.DB.GetPlatformSettingsByCategory(ctx, "backup")
+	if err != nil {
+		logger.Errorf("Failed to get backup settings: %v", err)
+		return nil
+	}
+
+	cfg := &backupService.S3Config{}
+	var accessKeyEncrypted, secretKeyEncrypted []byte
+
+	for _, s := range settings {
+		switch s.Key {
+		case "backup.s3_enabled":
+			if s.ValueText.String != "true" {
+				return nil // S3 not enabled
+			}
+		case "backup.s3_bucket":
+			cfg.Bucket = s.ValueText.String
+		case "backup.s3_region":
+			cfg.Region = s.ValueText.String
+		case "backup.s3_prefix":
+			cfg.Prefix = s.ValueText.String
+		case "backup.s3_access_key":
+			if s.ValueEncrypted.Valid {
+				var err error
+				accessKeyEncrypted, err = hex.DecodeString(s.ValueEncrypted.String)
+				if err != nil {
+					logger.Errorf("Failed to decode S3 access key: %v", err)
+				}
+			}
+		case "backup.s3_secret_key":
+			if s.ValueEncrypted.Valid {
+				var err error
+				secretKeyEncrypted, err = hex.DecodeString(s.ValueEncrypted.String)
+				if err != nil {
+					logger.Errorf("Failed to decode S3 secret key: %v", err)
+				}
+			}
+		}
+	}
+
+	// Decrypt credentials if crypto service is available
+	if svcCtx.CryptoService != nil {
+		if len(accessKeyEncrypted) > 0 {
+			decrypted, err := svcCtx.CryptoService.DecryptString(accessKeyEncrypted)
+			if err == nil {
+				cfg.AccessKey = decrypted
+			} else {
+				logger.Errorf("Failed to decrypt S3 access key: %v", err)
+			}
+		}
+		if len(secretKeyEncrypted) > 0 {
+			decrypted, err := svcCtx.CryptoService.DecryptString(secretKeyEncrypted)
+			if err == nil {
+				cfg.SecretKey = decrypted
+			} else {
+				logger.Errorf("Failed to decrypt S3 secret key: %v", err)
+			}
+		}
+	}
+
+	// Validate required fields
+	if cfg.Bucket == "" {
+		logger.Errorf("S3 bucket not configured")
+		return nil
+	}
+
+	return cfg
+}
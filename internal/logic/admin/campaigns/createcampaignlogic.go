@@ -8,7 +8,10 @@ import (
 	"strconv"
 
 	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
 	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/segment"
+	"github.com/outlet-sh/outlet/internal/services/sendtime"
 	"github.com/outlet-sh/outlet/internal/svc"
 	"github.com/outlet-sh/outlet/internal/types"
 
@@ -36,6 +39,19 @@ func (l *CreateCampaignLogic) CreateCampaign(req *types.CreateCampaignRequest) (
 		return nil, errors.New("org_id not found in context")
 	}
 
+	if _, err := segment.Parse(req.SegmentFilter); err != nil {
+		return nil, errorx.NewBadRequestError(err.Error())
+	}
+	if err := validateSegmentID(l.ctx, l.svcCtx, orgID, req.SegmentId); err != nil {
+		return nil, err
+	}
+	if err := validateABTest(req.AbTest); err != nil {
+		return nil, err
+	}
+	if err := validateDeliveryMode(req.DeliveryMode); err != nil {
+		return nil, err
+	}
+
 	listIdsJSON, _ := json.Marshal(req.ListIds)
 	excludeListIdsJSON, _ := json.Marshal(req.ExcludeListIds)
 
@@ -72,15 +88,99 @@ func (l *CreateCampaignLogic) CreateCampaign(req *types.CreateCampaignRequest) (
 		PlainText:      sql.NullString{String: req.PlainText, Valid: req.PlainText != ""},
 		ListIds:        sql.NullString{String: string(listIdsJSON), Valid: true},
 		ExcludeListIds: sql.NullString{String: string(excludeListIdsJSON), Valid: len(req.ExcludeListIds) > 0},
+		SegmentFilter:  sql.NullString{String: req.SegmentFilter, Valid: req.SegmentFilter != ""},
+		SegmentID:      sql.NullString{String: req.SegmentId, Valid: req.SegmentId != ""},
 		Status:         sql.NullString{String: "draft", Valid: true},
 		TrackOpens:     trackOpens,
 		TrackClicks:    trackClicks,
+		DeliveryMode:   req.DeliveryMode,
 	})
 	if err != nil {
 		l.Errorf("Failed to create campaign: %v", err)
 		return nil, err
 	}
 
+	if err := saveABTest(l.ctx, l.svcCtx, campaign.ID, req.AbTest); err != nil {
+		l.Errorf("Failed to save campaign A/B test: %v", err)
+		return nil, err
+	}
+
 	info := campaignToInfo(campaign)
 	return &info, nil
 }
+
+// validateDeliveryMode checks a campaign delivery mode; empty keeps the
+// current one
+func validateDeliveryMode(mode string) error {
+	if mode != "" && !sendtime.ValidMode(mode) {
+		return errorx.NewBadRequestError("delivery_mode must be immediate, local_time or optimal_time")
+	}
+	return nil
+}
+
+// validateABTest checks an A/B test's settings before any of it is saved
+func validateABTest(test *types.CampaignAbTestInput) error {
+	if test == nil || len(test.Variants) == 0 {
+		return nil
+	}
+	if len(test.Variants) < 2 {
+		return errorx.NewBadRequestError("an A/B test needs at least two variants")
+	}
+	if test.TestPercent < 1 || test.TestPercent > 100 {
+		return errorx.NewBadRequestError("test_percent must be between 1 and 100")
+	}
+	switch test.WinnerMetric {
+	case "open_rate", "click_rate", "manual":
+	default:
+		return errorx.NewBadRequestError("winner_metric must be open_rate, click_rate or manual")
+	}
+	if test.WaitHours < 0 {
+		return errorx.NewBadRequestError("wait_hours cannot be negative")
+	}
+	for _, v := range test.Variants {
+		if v.Name == "" {
+			return errorx.NewBadRequestError("every variant needs a name")
+		}
+	}
+	return nil
+}
+
+// saveABTest replaces a campaign's A/B test and variants. A test with no
+// variants removes it.
+func saveABTest(ctx context.Context, svcCtx *svc.ServiceContext, campaignID string, test *types.CampaignAbTestInput) error {
+	if test == nil {
+		return nil
+	}
+
+	return svcCtx.DB.ExecTx(ctx, func(q *db.Queries) error {
+		if err := q.DeleteCampaignVariants(ctx, campaignID); err != nil {
+			return err
+		}
+		if len(test.Variants) == 0 {
+			return q.DeleteCampaignABTest(ctx, campaignID)
+		}
+
+		for i, v := range test.Variants {
+			if _, err := q.CreateCampaignVariant(ctx, db.CreateCampaignVariantParams{
+				ID:         uuid.New().String(),
+				CampaignID: campaignID,
+				Name:       v.Name,
+				Position:   int64(i),
+				Subject:    sql.NullString{String: v.Subject, Valid: v.Subject != ""},
+				FromName:   sql.NullString{String: v.FromName, Valid: v.FromName != ""},
+				HtmlBody:   sql.NullString{String: v.HtmlBody, Valid: v.HtmlBody != ""},
+				PlainText:  sql.NullString{String: v.PlainText, Valid: v.PlainText != ""},
+			}); err != nil {
+				return err
+			}
+		}
+
+		_, err := q.UpsertCampaignABTest(ctx, db.UpsertCampaignABTestParams{
+			CampaignID:   campaignID,
+			TestPercent:  int64(test.TestPercent),
+			WinnerMetric: test.WinnerMetric,
+			WaitHours:    int64(test.WaitHours),
+		})
+		return err
+	})
+}
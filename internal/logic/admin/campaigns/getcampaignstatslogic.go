@@ -2,6 +2,7 @@ package campaigns
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 
 	"github.com/outlet-sh/outlet/internal/db"
@@ -55,9 +56,72 @@ func (l *GetCampaignStatsLogic) GetCampaignStats(req *types.GetCampaignRequest)
 		})
 	}
 
+	// Sends tracked before machine hits were flagged have no events, so
+	// human counts only cover opens and clicks recorded since
+	human, err := l.svcCtx.DB.GetCampaignHumanEngagement(l.ctx, req.Id)
+	if err != nil {
+		l.Errorf("Failed to get campaign human engagement: %v", err)
+	}
+
+	abTest, err := campaignABTestInfo(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		l.Errorf("Failed to get campaign A/B test: %v", err)
+	}
+
 	info := campaignToInfo(campaign)
 	return &types.CampaignStatsResponse{
-		Campaign: info,
-		Links:    linkStats,
+		Campaign:     info,
+		Links:        linkStats,
+		TotalOpened:  info.OpenedCount,
+		HumanOpened:  int(human.HumanOpened),
+		TotalClicked: info.ClickedCount,
+		HumanClicked: int(human.HumanClicked),
+		AbTest:       abTest,
+	}, nil
+}
+
+// campaignABTestInfo returns a campaign's A/B test with per-variant stats, or
+// nil when the campaign is not a test
+func campaignABTestInfo(ctx context.Context, svcCtx *svc.ServiceContext, campaignID string) (*types.CampaignAbTestInfo, error) {
+	test, err := svcCtx.DB.GetCampaignABTest(ctx, campaignID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := svcCtx.DB.GetCampaignVariantStats(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	variants := make([]types.CampaignVariantStat, 0, len(stats))
+	for _, v := range stats {
+		stat := types.CampaignVariantStat{
+			Id:           v.ID,
+			Name:         v.Name,
+			Recipients:   int(v.Recipients),
+			Sent:         int(v.Sent),
+			Opened:       int(v.Opened),
+			Clicked:      int(v.Clicked),
+			HumanOpened:  int(v.HumanOpened),
+			HumanClicked: int(v.HumanClicked),
+		}
+		if v.Sent > 0 {
+			stat.OpenRate = float64(v.HumanOpened) / float64(v.Sent)
+			stat.ClickRate = float64(v.HumanClicked) / float64(v.Sent)
+		}
+		variants = append(variants, stat)
+	}
+
+	return &types.CampaignAbTestInfo{
+		TestPercent:     int(test.TestPercent),
+		WinnerMetric:    test.WinnerMetric,
+		WaitHours:       int(test.WaitHours),
+		Status:          test.Status,
+		WinnerVariantId: test.WinnerVariantID.String,
+		TestCompletedAt: test.TestCompletedAt.String,
+		Variants:        variants,
 	}, nil
 }
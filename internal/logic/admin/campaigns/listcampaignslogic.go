@@ -120,12 +120,15 @@ func campaignToInfo(c db.EmailCampaign) types.CampaignInfo {
 		PlainText:         c.PlainText.String,
 		ListIds:           listIds,
 		ExcludeListIds:    excludeListIds,
+		SegmentFilter:     c.SegmentFilter.String,
+		SegmentId:         c.SegmentID.String,
 		Status:            c.Status.String,
 		ScheduledAt:       utils.FormatNullString(c.ScheduledAt),
 		StartedAt:         utils.FormatNullString(c.StartedAt),
 		CompletedAt:       utils.FormatNullString(c.CompletedAt),
 		TrackOpens:        c.TrackOpens.Int64 == 1,
 		TrackClicks:       c.TrackClicks.Int64 == 1,
+		DeliveryMode:      c.DeliveryMode,
 		RecipientsCount:   int(c.RecipientsCount.Int64),
 		SentCount:         int(c.SentCount.Int64),
 		DeliveredCount:    int(c.DeliveredCount.Int64),
@@ -0,0 +1,64 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type PickCampaignWinnerLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewPickCampaignWinnerLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PickCampaignWinnerLogic {
+	return &PickCampaignWinnerLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// PickCampaignWinner sets the winning variant of a running A/B test. The
+// campaign scheduler sends it to the rest of the audience.
+func (l *PickCampaignWinnerLogic) PickCampaignWinner(req *types.PickCampaignWinnerRequest) (resp *types.CampaignStatsResponse, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	_, err = l.svcCtx.DB.GetCampaign(l.ctx, db.GetCampaignParams{
+		ID:    req.Id,
+		OrgID: orgID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errorx.NewNotFoundError("campaign not found")
+	}
+	if err != nil {
+		l.Errorf("Failed to get campaign: %v", err)
+		return nil, err
+	}
+
+	picked, err := l.svcCtx.DB.SetCampaignABTestWinner(l.ctx, db.SetCampaignABTestWinnerParams{
+		WinnerVariantID: sql.NullString{String: req.VariantId, Valid: true},
+		CampaignID:      req.Id,
+	})
+	if err != nil {
+		l.Errorf("Failed to set A/B test winner: %v", err)
+		return nil, err
+	}
+	if picked == 0 {
+		return nil, errorx.NewBadRequestError("campaign has no running A/B test with this variant")
+	}
+
+	return NewGetCampaignStatsLogic(l.ctx, l.svcCtx).GetCampaignStats(&types.GetCampaignRequest{Id: req.Id})
+}
@@ -7,7 +7,9 @@ import (
 	"errors"
 
 	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
 	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/segment"
 	"github.com/outlet-sh/outlet/internal/svc"
 	"github.com/outlet-sh/outlet/internal/types"
 
@@ -34,6 +36,29 @@ func (l *UpdateCampaignLogic) UpdateCampaign(req *types.UpdateCampaignRequest) (
 		return nil, errors.New("org_id not found in context")
 	}
 
+	if _, err := segment.Parse(req.SegmentFilter); err != nil {
+		return nil, errorx.NewBadRequestError(err.Error())
+	}
+	if err := validateSegmentID(l.ctx, l.svcCtx, orgID, req.SegmentId); err != nil {
+		return nil, err
+	}
+	if err := validateABTest(req.AbTest); err != nil {
+		return nil, err
+	}
+	if err := validateDeliveryMode(req.DeliveryMode); err != nil {
+		return nil, err
+	}
+	if req.AbTest != nil {
+		// Variants are dealt out when the campaign starts sending
+		current, err := l.svcCtx.DB.GetCampaign(l.ctx, db.GetCampaignParams{ID: req.Id, OrgID: orgID})
+		if err != nil {
+			return nil, err
+		}
+		if status := current.Status.String; status != "draft" && status != "scheduled" {
+			return nil, errorx.NewBadRequestError("A/B tests can only be changed before a campaign starts sending")
+		}
+	}
+
 	var listIds, excludeListIds interface{}
 	if len(req.ListIds) > 0 {
 		data, _ := json.Marshal(req.ListIds)
@@ -69,14 +94,22 @@ func (l *UpdateCampaignLogic) UpdateCampaign(req *types.UpdateCampaignRequest) (
 		PlainText:      req.PlainText,
 		ListIds:        listIds,
 		ExcludeListIds: excludeListIds,
+		SegmentFilter:  req.SegmentFilter,
+		SegmentID:      req.SegmentId,
 		TrackOpens:     trackOpens,
 		TrackClicks:    trackClicks,
+		DeliveryMode:   req.DeliveryMode,
 	})
 	if err != nil {
 		l.Errorf("Failed to update campaign: %v", err)
 		return nil, err
 	}
 
+	if err := saveABTest(l.ctx, l.svcCtx, campaign.ID, req.AbTest); err != nil {
+		l.Errorf("Failed to save campaign A/B test: %v", err)
+		return nil, err
+	}
+
 	info := campaignToInfo(campaign)
 	return &info, nil
 }
@@ -58,19 +58,13 @@ func (l *CreateDomainIdentityLogic) CreateDomainIdentity(req *types.CreateDomain
 		return nil, errorx.NewBadRequestError("Domain identity already exists for this domain")
 	}
 
-	// Get AWS credentials from platform settings
-	region, accessKey, secretKey, err := getAWSCredentials(l.ctx, l.svcCtx)
+	// Get AWS credentials, preferring the org's own
+	region, accessKey, secretKey, sesConfigured, err := sesCredentials(l.ctx, l.svcCtx, req.OrgId)
 	if err != nil {
 		return nil, err
 	}
-
-	// Get email config for org-specific AWS credentials
-	emailConfig, err := email.GetOrgEmailConfig(l.ctx, l.svcCtx.DB, req.OrgId)
-	if err == nil && emailConfig.HasOwnAWSCredentials() {
-		// Use org's own credentials
-		region = emailConfig.AWSRegion
-		accessKey = emailConfig.AWSAccessKey
-		secretKey = emailConfig.AWSSecretKey
+	if !sesConfigured {
+		return l.createRelayIdentity(req.OrgId, domain)
 	}
 
 	// Determine MAIL FROM subdomain (default to "mail" if not specified)
@@ -141,10 +135,87 @@ func (l *CreateDomainIdentityLogic) CreateDomainIdentity(req *types.CreateDomain
 		MailFromDomain:     identity.MailFromDomain.String,
 		MailFromStatus:     "not_started",
 		DNSRecords:         dnsRecords,
+		DKIMKeys:           []types.DKIMKeyInfo{},
 		CreatedAt:          identity.CreatedAt.String,
 	}, nil
 }
 
+// createRelayIdentity sets up a domain for SMTP relay sending when SES is not
+// configured. Outlet signs mail for the domain with its own DKIM keys, which
+// become active once their DNS records are published.
+func (l *CreateDomainIdentityLogic) createRelayIdentity(orgID, domain string) (*types.DomainIdentityInfo, error) {
+	records := []*email.DNSRecord{{
+		Type:    "TXT",
+		Name:    "_dmarc." + domain,
+		Value:   "v=DMARC1; p=none;",
+		Purpose: "dmarc",
+	}}
+	dnsRecordsJSON, err := email.DNSRecordsToJSON(records)
+	if err != nil {
+		l.Errorf("Failed to serialize DNS records: %v", err)
+		return nil, errorx.NewInternalError("Failed to serialize DNS records")
+	}
+
+	identity, err := l.svcCtx.DB.CreateDomainIdentity(l.ctx, db.CreateDomainIdentityParams{
+		ID:                 uuid.New().String(),
+		OrgID:              orgID,
+		Domain:             domain,
+		VerificationStatus: sql.NullString{String: "pending", Valid: true},
+		DkimStatus:         sql.NullString{String: "pending", Valid: true},
+		DnsRecords:         sql.NullString{String: dnsRecordsJSON, Valid: true},
+	})
+	if err != nil {
+		l.Errorf("Failed to create domain identity record: %v", err)
+		return nil, errorx.NewInternalError("Failed to save domain identity")
+	}
+
+	if _, err := l.svcCtx.EmailService.GenerateDKIMKeys(l.ctx, identity); err != nil {
+		l.Errorf("Failed to generate DKIM keys for %s: %v", domain, err)
+		if delErr := l.svcCtx.DB.DeleteDomainIdentity(l.ctx, identity.ID); delErr != nil {
+			l.Errorf("Failed to remove domain identity %s: %v", identity.ID, delErr)
+		}
+		return nil, errorx.NewInternalError("Failed to generate DKIM keys: " + err.Error())
+	}
+
+	resp := &types.DomainIdentityInfo{
+		Id:                 identity.ID,
+		OrgId:              identity.OrgID,
+		Domain:             identity.Domain,
+		VerificationStatus: identity.VerificationStatus.String,
+		DKIMStatus:         identity.DkimStatus.String,
+		MailFromStatus:     "not_started",
+		DNSRecords: []types.DNSRecord{{
+			Type:    records[0].Type,
+			Name:    records[0].Name,
+			Value:   records[0].Value,
+			Purpose: records[0].Purpose,
+		}},
+		CreatedAt: identity.CreatedAt.String,
+	}
+	addDKIMKeys(l.ctx, l.svcCtx, resp)
+
+	return resp, nil
+}
+
+// sesCredentials resolves the AWS credentials for an org's domain identities,
+// preferring the org's own over the platform's. configured is false when
+// neither is set up; domains are then used for SMTP relay sending only.
+func sesCredentials(ctx context.Context, svcCtx *svc.ServiceContext, orgID string) (region, accessKey, secretKey string, configured bool, err error) {
+	emailConfig, cfgErr := email.GetOrgEmailConfig(ctx, svcCtx.DB, orgID)
+	if cfgErr == nil && emailConfig.HasOwnAWSCredentials() {
+		return emailConfig.AWSRegion, emailConfig.AWSAccessKey, emailConfig.AWSSecretKey, true, nil
+	}
+
+	region, accessKey, secretKey, err = getAWSCredentials(ctx, svcCtx)
+	if codeErr, ok := err.(*errorx.CodeError); ok && codeErr.Code == errorx.CodeBadRequest {
+		return "", "", "", false, nil
+	}
+	if err != nil {
+		return "", "", "", false, err
+	}
+	return region, accessKey, secretKey, true, nil
+}
+
 // getAWSCredentials retrieves AWS credentials from platform settings
 func getAWSCredentials(ctx context.Context, svcCtx *svc.ServiceContext) (region, accessKey, secretKey string, err error) {
 	awsSettings, err := svcCtx.DB.GetPlatformSettingsByCategory(ctx, "aws")
@@ -53,7 +53,7 @@ func (l *GetDomainIdentityLogic) GetDomainIdentity(req *types.GetDomainIdentityR
 		}
 	}
 
-	return &types.DomainIdentityInfo{
+	resp = &types.DomainIdentityInfo{
 		Id:                 identity.ID,
 		OrgId:              identity.OrgID,
 		Domain:             identity.Domain,
@@ -64,5 +64,8 @@ func (l *GetDomainIdentityLogic) GetDomainIdentity(req *types.GetDomainIdentityR
 		DNSRecords:         dnsRecords,
 		LastCheckedAt:      identity.LastCheckedAt.String,
 		CreatedAt:          identity.CreatedAt.String,
-	}, nil
+	}
+	addDKIMKeys(l.ctx, l.svcCtx, resp)
+
+	return resp, nil
 }
@@ -0,0 +1,50 @@
+package emailconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/outlet-sh/outlet/internal/services/email"
+	"github.com/outlet-sh/outlet/internal/services/emailval"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetOrgValidationPolicyLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetOrgValidationPolicyLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetOrgValidationPolicyLogic {
+	return &GetOrgValidationPolicyLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetOrgValidationPolicyLogic) GetOrgValidationPolicy(req *types.GetOrgValidationPolicyRequest) (resp *types.ValidationPolicyInfo, err error) {
+	policy, err := email.GetOrgValidationPolicy(l.ctx, l.svcCtx.DB, req.OrgId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validation policy: %w", err)
+	}
+
+	return policyToInfo(policy), nil
+}
+
+// policyToInfo converts a validation policy to its API representation; nil means disabled
+func policyToInfo(policy *emailval.Policy) *types.ValidationPolicyInfo {
+	if policy == nil {
+		return &types.ValidationPolicyInfo{}
+	}
+	return &types.ValidationPolicyInfo{
+		Enabled:         policy.Enabled,
+		BlockDisposable: policy.BlockDisposable,
+		RejectRole:      policy.RejectRole,
+		CheckSMTP:       policy.CheckSMTP,
+		TimeoutSeconds:  policy.TimeoutSeconds,
+	}
+}
@@ -50,7 +50,7 @@ func (l *ListDomainIdentitiesLogic) ListDomainIdentities(req *types.ListDomainId
 			}
 		}
 
-		result = append(result, types.DomainIdentityInfo{
+		info := types.DomainIdentityInfo{
 			Id:                 identity.ID,
 			OrgId:              identity.OrgID,
 			Domain:             identity.Domain,
@@ -61,7 +61,9 @@ func (l *ListDomainIdentitiesLogic) ListDomainIdentities(req *types.ListDomainId
 			DNSRecords:         dnsRecords,
 			LastCheckedAt:      identity.LastCheckedAt.String,
 			CreatedAt:          identity.CreatedAt.String,
-		})
+		}
+		addDKIMKeys(l.ctx, l.svcCtx, &info)
+		result = append(result, info)
 	}
 
 	return &types.ListDomainIdentitiesResponse{Identities: result}, nil
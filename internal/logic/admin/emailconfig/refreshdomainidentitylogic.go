@@ -39,29 +39,36 @@ func (l *RefreshDomainIdentityLogic) RefreshDomainIdentity(req *types.RefreshDom
 		return nil, errorx.NewNotFoundError("Domain identity not found")
 	}
 
-	// Get AWS credentials from platform settings
-	region, accessKey, secretKey, err := getAWSCredentials(l.ctx, l.svcCtx)
+	// Activate DKIM keys whose DNS records have been published
+	dkimActivated, err := l.svcCtx.EmailService.ActivatePublishedDKIMKeys(l.ctx, identity.ID)
 	if err != nil {
-		return nil, err
+		l.Errorf("Failed to check DKIM keys for %s: %v", identity.Domain, err)
 	}
 
-	// Get email config for org-specific AWS credentials
-	emailConfig, err := email.GetOrgEmailConfig(l.ctx, l.svcCtx.DB, req.OrgId)
-	if err == nil && emailConfig.HasOwnAWSCredentials() {
-		region = emailConfig.AWSRegion
-		accessKey = emailConfig.AWSAccessKey
-		secretKey = emailConfig.AWSSecretKey
+	// Get AWS credentials, preferring the org's own
+	region, accessKey, secretKey, sesConfigured, err := sesCredentials(l.ctx, l.svcCtx, req.OrgId)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get the current status from AWS SES
-	status, err := email.GetDomainIdentityStatus(l.ctx, region, accessKey, secretKey, identity.Domain)
-	if err != nil {
-		l.Errorf("Failed to get domain identity status: %v", err)
-		return nil, errorx.NewInternalError("Failed to check domain status with AWS SES: " + err.Error())
+	// Without SES the domain only sends through the SMTP relay; publishing
+	// its DKIM keys verifies it
+	status := &email.DomainIdentityStatus{
+		VerificationStatus: identity.VerificationStatus.String,
+		DKIMStatus:         identity.DkimStatus.String,
+	}
+	if sesConfigured {
+		status, err = l.sesStatus(identity.Domain, region, accessKey, secretKey)
+		if err != nil {
+			return nil, err
+		}
+	} else if dkimActivated {
+		status.VerificationStatus = "success"
+		status.DKIMStatus = "success"
 	}
 
 	// If status is "not_started", the domain was never registered with SES - register it now
-	if status.VerificationStatus == "not_started" {
+	if sesConfigured && status.VerificationStatus == "not_started" {
 		l.Infof("Domain %s not registered with SES, initiating verification...", identity.Domain)
 		result, verifyErr := email.VerifyDomainIdentity(l.ctx, region, accessKey, secretKey, identity.Domain)
 		if verifyErr != nil {
@@ -104,7 +111,7 @@ func (l *RefreshDomainIdentityLogic) RefreshDomainIdentity(req *types.RefreshDom
 			}
 		}
 
-		return &types.DomainIdentityInfo{
+		resp = &types.DomainIdentityInfo{
 			Id:                 updated.ID,
 			OrgId:              updated.OrgID,
 			Domain:             updated.Domain,
@@ -115,7 +122,10 @@ func (l *RefreshDomainIdentityLogic) RefreshDomainIdentity(req *types.RefreshDom
 			DNSRecords:         dnsRecords,
 			LastCheckedAt:      updated.LastCheckedAt.String,
 			CreatedAt:          updated.CreatedAt.String,
-		}, nil
+		}
+		addDKIMKeys(l.ctx, l.svcCtx, resp)
+
+		return resp, nil
 	}
 
 	// Update the status in the database
@@ -146,7 +156,7 @@ func (l *RefreshDomainIdentityLogic) RefreshDomainIdentity(req *types.RefreshDom
 		}
 	}
 
-	return &types.DomainIdentityInfo{
+	resp = &types.DomainIdentityInfo{
 		Id:                 updated.ID,
 		OrgId:              updated.OrgID,
 		Domain:             updated.Domain,
@@ -157,5 +167,18 @@ func (l *RefreshDomainIdentityLogic) RefreshDomainIdentity(req *types.RefreshDom
 		DNSRecords:         dnsRecords,
 		LastCheckedAt:      updated.LastCheckedAt.String,
 		CreatedAt:          updated.CreatedAt.String,
-	}, nil
+	}
+	addDKIMKeys(l.ctx, l.svcCtx, resp)
+
+	return resp, nil
+}
+
+// sesStatus gets the current status of a domain from AWS SES
+func (l *RefreshDomainIdentityLogic) sesStatus(domain, region, accessKey, secretKey string) (*email.DomainIdentityStatus, error) {
+	status, err := email.GetDomainIdentityStatus(l.ctx, region, accessKey, secretKey, domain)
+	if err != nil {
+		l.Errorf("Failed to get domain identity status: %v", err)
+		return nil, errorx.NewInternalError("Failed to check domain status with AWS SES: " + err.Error())
+	}
+	return status, nil
 }
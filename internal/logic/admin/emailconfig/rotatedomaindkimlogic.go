@@ -0,0 +1,110 @@
+package emailconfig
+
+import (
+	"context"
+
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/services/email"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RotateDomainDKIMLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRotateDomainDKIMLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RotateDomainDKIMLogic {
+	return &RotateDomainDKIMLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// RotateDomainDKIM generates a new version of the domain's DKIM keys. The
+// current keys keep signing until the new DNS records are published and the
+// domain is refreshed.
+func (l *RotateDomainDKIMLogic) RotateDomainDKIM(req *types.RotateDomainDKIMRequest) (resp *types.DomainIdentityInfo, err error) {
+	identity, err := l.svcCtx.DB.GetDomainIdentity(l.ctx, req.Id)
+	if err != nil {
+		return nil, errorx.NewNotFoundError("Domain identity not found")
+	}
+
+	// Verify the identity belongs to the requested org
+	if identity.OrgID != req.OrgId {
+		return nil, errorx.NewNotFoundError("Domain identity not found")
+	}
+
+	if _, err := l.svcCtx.EmailService.GenerateDKIMKeys(l.ctx, identity); err != nil {
+		l.Errorf("Failed to generate DKIM keys for %s: %v", identity.Domain, err)
+		return nil, errorx.NewInternalError("Failed to generate DKIM keys: " + err.Error())
+	}
+
+	// Parse DNS records from JSON
+	var dnsRecords []types.DNSRecord
+	if identity.DnsRecords.Valid && identity.DnsRecords.String != "" {
+		records, err := email.DNSRecordsFromJSON(identity.DnsRecords.String)
+		if err == nil {
+			for _, r := range records {
+				dnsRecords = append(dnsRecords, types.DNSRecord{
+					Type:     r.Type,
+					Name:     r.Name,
+					Value:    r.Value,
+					Priority: r.Priority,
+					Purpose:  r.Purpose,
+				})
+			}
+		}
+	}
+
+	resp = &types.DomainIdentityInfo{
+		Id:                 identity.ID,
+		OrgId:              identity.OrgID,
+		Domain:             identity.Domain,
+		VerificationStatus: identity.VerificationStatus.String,
+		DKIMStatus:         identity.DkimStatus.String,
+		MailFromDomain:     identity.MailFromDomain.String,
+		MailFromStatus:     identity.MailFromStatus.String,
+		DNSRecords:         dnsRecords,
+		LastCheckedAt:      identity.LastCheckedAt.String,
+		CreatedAt:          identity.CreatedAt.String,
+	}
+	addDKIMKeys(l.ctx, l.svcCtx, resp)
+
+	return resp, nil
+}
+
+// addDKIMKeys adds the locally managed DKIM keys of a domain identity and
+// the TXT records that publish them
+func addDKIMKeys(ctx context.Context, svcCtx *svc.ServiceContext, info *types.DomainIdentityInfo) {
+	info.DKIMKeys = []types.DKIMKeyInfo{}
+
+	keys, err := svcCtx.DB.ListDKIMKeysByIdentity(ctx, info.Id)
+	if err != nil {
+		logx.WithContext(ctx).Errorf("Failed to list DKIM keys for %s: %v", info.Domain, err)
+		return
+	}
+
+	for _, r := range email.DKIMDNSRecords(keys) {
+		info.DNSRecords = append(info.DNSRecords, types.DNSRecord{
+			Type:    r.Type,
+			Name:    r.Name,
+			Value:   r.Value,
+			Purpose: r.Purpose,
+		})
+	}
+	for _, key := range keys {
+		info.DKIMKeys = append(info.DKIMKeys, types.DKIMKeyInfo{
+			Selector:    key.Selector,
+			Algorithm:   key.Algorithm,
+			Version:     key.Version,
+			Status:      key.Status,
+			ActivatedAt: key.ActivatedAt.String,
+			CreatedAt:   key.CreatedAt.String,
+		})
+	}
+}
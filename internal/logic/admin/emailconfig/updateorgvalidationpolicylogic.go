@@ -0,0 +1,50 @@
+package emailconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/services/email"
+	"github.com/outlet-sh/outlet/internal/services/emailval"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpdateOrgValidationPolicyLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUpdateOrgValidationPolicyLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateOrgValidationPolicyLogic {
+	return &UpdateOrgValidationPolicyLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UpdateOrgValidationPolicyLogic) UpdateOrgValidationPolicy(req *types.UpdateOrgValidationPolicyRequest) (resp *types.ValidationPolicyInfo, err error) {
+	if req.TimeoutSeconds < 0 || req.TimeoutSeconds > 30 {
+		return nil, errorx.NewBadRequestError("timeout_seconds must be between 0 and 30")
+	}
+
+	policy := &emailval.Policy{
+		Enabled:         req.Enabled,
+		BlockDisposable: req.BlockDisposable,
+		RejectRole:      req.RejectRole,
+		CheckSMTP:       req.CheckSMTP,
+		TimeoutSeconds:  req.TimeoutSeconds,
+	}
+
+	if err := email.SaveOrgValidationPolicy(l.ctx, l.svcCtx.DB, req.OrgId, policy); err != nil {
+		return nil, fmt.Errorf("failed to save validation policy: %w", err)
+	}
+
+	l.Infof("Updated validation policy for org %s: %+v", req.OrgId, *policy)
+
+	return policyToInfo(policy), nil
+}
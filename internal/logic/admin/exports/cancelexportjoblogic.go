@@ -0,0 +1,67 @@
+package exports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/outlet-sh/outlet/internal/websocket"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CancelExportJobLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCancelExportJobLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CancelExportJobLogic {
+	return &CancelExportJobLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// CancelExportJob cancels a pending or running export. A running export stops
+// after its current batch and removes its partial file.
+func (l *CancelExportJobLogic) CancelExportJob(req *types.CancelExportJobRequest) (resp *types.Response, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	job, err := l.svcCtx.DB.CancelExportJob(l.ctx, db.CancelExportJobParams{
+		ID:    req.Id,
+		OrgID: orgID,
+	})
+	if err == sql.ErrNoRows {
+		return nil, errorx.NewBadRequestError("export job not found or already finished")
+	}
+	if err != nil {
+		l.Errorf("Failed to cancel export job: %v", err)
+		return nil, errorx.NewInternalError("failed to cancel export job")
+	}
+
+	if l.svcCtx.WebSocketHub != nil {
+		l.svcCtx.WebSocketHub.BroadcastToOrg(job.OrgID, websocket.NewExportUpdate(websocket.ExportUpdate{
+			ID:        job.ID,
+			OrgID:     job.OrgID,
+			Type:      job.Type,
+			Status:    job.Status,
+			Total:     job.TotalRows,
+			Processed: job.ProcessedRows,
+			Filename:  job.Filename,
+		}))
+	}
+
+	l.Infof("Cancelled export job: org=%s id=%s", orgID, req.Id)
+
+	return &types.Response{Success: true, Message: "export job cancelled"}, nil
+}
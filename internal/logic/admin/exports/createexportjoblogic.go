@@ -0,0 +1,155 @@
+package exports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/logic/admin/backup"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/outlet-sh/outlet/internal/workers"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// nonSlugChars matches characters replaced when naming export files
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+type CreateExportJobLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateExportJobLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateExportJobLogic {
+	return &CreateExportJobLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// CreateExportJob validates the export source and queues a pending export job.
+// The export worker writes the file and reports progress over the websocket hub.
+func (l *CreateExportJobLogic) CreateExportJob(req *types.CreateExportJobRequest) (resp *types.ExportJobInfo, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	format := req.Format
+	if format == "" {
+		format = workers.ExportFormatCSV
+	}
+	if format != workers.ExportFormatCSV && format != workers.ExportFormatNDJSON {
+		return nil, errorx.NewBadRequestError("format must be csv or ndjson")
+	}
+
+	storageType := req.StorageType
+	if storageType == "" {
+		storageType = "local"
+	}
+	switch storageType {
+	case "local":
+	case "s3":
+		if backup.GetS3Config(l.ctx, l.svcCtx) == nil {
+			return nil, errorx.NewBadRequestError("S3 storage is not configured")
+		}
+	default:
+		return nil, errorx.NewBadRequestError("storage_type must be local or s3")
+	}
+
+	name, err := l.sourceName(orgID, req.Type, req.SourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceID := sql.NullString{}
+	if req.Type == workers.ExportTypeList || req.Type == workers.ExportTypeSegment || req.Type == workers.ExportTypeCampaignSends {
+		sourceID = sql.NullString{String: req.SourceId, Valid: true}
+	}
+
+	createdBy := sql.NullString{}
+	if userID, ok := l.ctx.Value("userId").(string); ok && userID != "" {
+		createdBy = sql.NullString{String: userID, Valid: true}
+	}
+
+	filename := name + "-" + time.Now().UTC().Format("20060102-150405") + workers.ExportFileExtension(format)
+
+	job, err := l.svcCtx.DB.CreateExportJob(l.ctx, db.CreateExportJobParams{
+		ID:          uuid.New().String(),
+		OrgID:       orgID,
+		Type:        req.Type,
+		SourceID:    sourceID,
+		Format:      format,
+		StorageType: storageType,
+		Filename:    filename,
+		CreatedBy:   createdBy,
+	})
+	if err != nil {
+		l.Errorf("Failed to create export job: %v", err)
+		return nil, errorx.NewInternalError("failed to create export job")
+	}
+
+	l.Infof("Created export job: org=%s id=%s type=%s format=%s storage=%s", orgID, job.ID, job.Type, job.Format, job.StorageType)
+
+	return exportJobToInfo(job), nil
+}
+
+// sourceName checks the export source belongs to the org and returns a name
+// for the export file
+func (l *CreateExportJobLogic) sourceName(orgID, exportType, sourceID string) (string, error) {
+	switch exportType {
+	case workers.ExportTypeSuppressionList, workers.ExportTypeBlockedDomains:
+		return strings.ReplaceAll(exportType, "_", "-"), nil
+	case workers.ExportTypeList, workers.ExportTypeSegment, workers.ExportTypeCampaignSends:
+		if sourceID == "" {
+			return "", errorx.NewBadRequestError("source_id is required for " + exportType + " exports")
+		}
+	default:
+		return "", errorx.NewBadRequestError("type must be list, segment, suppression_list, blocked_domains or campaign_sends")
+	}
+
+	switch exportType {
+	case workers.ExportTypeList:
+		id, err := strconv.ParseInt(sourceID, 10, 64)
+		if err != nil {
+			return "", errorx.NewBadRequestError("invalid list ID")
+		}
+		list, err := l.svcCtx.DB.GetEmailList(l.ctx, id)
+		if err != nil || list.OrgID != orgID {
+			return "", errorx.NewNotFoundError("list not found")
+		}
+		return exportSlug(list.Name, "list"), nil
+	case workers.ExportTypeSegment:
+		seg, err := l.svcCtx.DB.GetSegment(l.ctx, db.GetSegmentParams{ID: sourceID, OrgID: orgID})
+		if err != nil {
+			return "", errorx.NewNotFoundError("segment not found")
+		}
+		return exportSlug(seg.Name, "segment"), nil
+	default:
+		campaign, err := l.svcCtx.DB.GetCampaign(l.ctx, db.GetCampaignParams{ID: sourceID, OrgID: orgID})
+		if err != nil {
+			return "", errorx.NewNotFoundError("campaign not found")
+		}
+		return exportSlug(campaign.Name, "campaign") + "-sends", nil
+	}
+}
+
+// exportSlug turns a name into a file name prefix
+func exportSlug(name, fallback string) string {
+	slug := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if slug == "" {
+		return fallback
+	}
+	return slug
+}
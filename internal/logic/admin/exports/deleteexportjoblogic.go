@@ -0,0 +1,72 @@
+package exports
+
+import (
+	"context"
+	"os"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/logic/admin/backup"
+	backupService "github.com/outlet-sh/outlet/internal/services/backup"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteExportJobLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteExportJobLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteExportJobLogic {
+	return &DeleteExportJobLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// DeleteExportJob removes an export and its file from local disk or S3.
+// Running exports must be cancelled first.
+func (l *DeleteExportJobLogic) DeleteExportJob(req *types.DeleteExportJobRequest) (resp *types.Response, err error) {
+	job, err := getExportJob(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status == "pending" || job.Status == "running" {
+		return nil, errorx.NewBadRequestError("cancel the export before deleting it")
+	}
+
+	if job.FilePath.Valid && job.FilePath.String != "" {
+		if err := os.Remove(job.FilePath.String); err != nil && !os.IsNotExist(err) {
+			l.Errorf("Failed to delete export file %s: %v", job.FilePath.String, err)
+		}
+	}
+
+	if job.S3Key.Valid && job.S3Key.String != "" {
+		s3Config := backup.GetS3Config(l.ctx, l.svcCtx)
+		if s3Config == nil {
+			l.Errorf("S3 not configured, leaving export object %s in place", job.S3Key.String)
+		} else {
+			s3Config.Bucket = job.S3Bucket.String
+			if err := backupService.DeleteFromS3(l.ctx, *s3Config, job.S3Key.String); err != nil {
+				l.Errorf("Failed to delete export from S3: %v", err)
+			}
+		}
+	}
+
+	if err := l.svcCtx.DB.DeleteExportJob(l.ctx, db.DeleteExportJobParams{
+		ID:    job.ID,
+		OrgID: job.OrgID,
+	}); err != nil {
+		l.Errorf("Failed to delete export job: %v", err)
+		return nil, errorx.NewInternalError("failed to delete export job")
+	}
+
+	l.Infof("Deleted export job: org=%s id=%s", job.OrgID, job.ID)
+
+	return &types.Response{Success: true, Message: "export job deleted"}, nil
+}
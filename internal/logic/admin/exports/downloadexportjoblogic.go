@@ -0,0 +1,99 @@
+package exports
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/logic/admin/backup"
+	backupService "github.com/outlet-sh/outlet/internal/services/backup"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/outlet-sh/outlet/internal/workers"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// exportDownloadURLExpiry is how long presigned S3 download links stay valid
+const exportDownloadURLExpiry = 15 * time.Minute
+
+type DownloadExportJobLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDownloadExportJobLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DownloadExportJobLogic {
+	return &DownloadExportJobLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// DownloadExportJob streams a local export, or redirects to a presigned URL
+// for exports stored in S3
+func (l *DownloadExportJobLogic) DownloadExportJob(w http.ResponseWriter, r *http.Request, req *types.DownloadExportJobRequest) error {
+	job, err := getExportJob(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return err
+	}
+
+	if job.Status != "completed" {
+		return errorx.NewBadRequestError(fmt.Sprintf("export is not ready for download (status: %s)", job.Status))
+	}
+
+	if job.S3Key.Valid && job.S3Key.String != "" {
+		s3Config := backup.GetS3Config(l.ctx, l.svcCtx)
+		if s3Config == nil {
+			return errorx.NewBadRequestError("S3 storage is not configured")
+		}
+		s3Config.Bucket = job.S3Bucket.String
+
+		url, err := backupService.PresignS3URL(l.ctx, *s3Config, job.S3Key.String, exportDownloadURLExpiry)
+		if err != nil {
+			l.Errorf("Failed to presign export download: %v", err)
+			return errorx.NewInternalError("failed to create download link")
+		}
+		http.Redirect(w, r, url, http.StatusTemporaryRedirect)
+		return nil
+	}
+
+	if !job.FilePath.Valid || job.FilePath.String == "" {
+		return errorx.NewNotFoundError("export file not available")
+	}
+
+	file, err := os.Open(job.FilePath.String)
+	if err != nil {
+		l.Errorf("Failed to open export file: %v", err)
+		return errorx.NewNotFoundError("export file not found on disk")
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		l.Errorf("Failed to stat export file: %v", err)
+		return errorx.NewInternalError("failed to read export file")
+	}
+
+	contentType := "text/csv"
+	if job.Format == workers.ExportFormatNDJSON {
+		contentType = "application/x-ndjson"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", job.Filename))
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", fileInfo.Size()))
+	w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate")
+
+	if _, err := io.Copy(w, file); err != nil {
+		l.Errorf("Failed to stream export file: %v", err)
+		return nil // Don't return error after headers sent
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package exports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetExportJobLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetExportJobLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetExportJobLogic {
+	return &GetExportJobLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetExportJobLogic) GetExportJob(req *types.GetExportJobRequest) (resp *types.ExportJobInfo, err error) {
+	job, err := getExportJob(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return exportJobToInfo(job), nil
+}
+
+// getExportJob loads an export job belonging to the current org
+func getExportJob(ctx context.Context, svcCtx *svc.ServiceContext, id string) (db.ExportJob, error) {
+	orgID, ok := ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return db.ExportJob{}, errors.New("org_id not found in context")
+	}
+
+	job, err := svcCtx.DB.GetExportJob(ctx, db.GetExportJobParams{
+		ID:    id,
+		OrgID: orgID,
+	})
+	if err == sql.ErrNoRows {
+		return db.ExportJob{}, errorx.NewNotFoundError("export job not found")
+	}
+	if err != nil {
+		return db.ExportJob{}, err
+	}
+	return job, nil
+}
+
+// exportJobToInfo converts an export job row to its API representation.
+// Completed jobs link to the download endpoint, which redirects to S3 when needed.
+func exportJobToInfo(job db.ExportJob) *types.ExportJobInfo {
+	info := &types.ExportJobInfo{
+		Id:            job.ID,
+		OrgId:         job.OrgID,
+		Type:          job.Type,
+		SourceId:      job.SourceID.String,
+		Format:        job.Format,
+		StorageType:   job.StorageType,
+		Status:        job.Status,
+		Filename:      job.Filename,
+		FileSize:      job.FileSize,
+		TotalRows:     int(job.TotalRows),
+		ProcessedRows: int(job.ProcessedRows),
+		ErrorMessage:  job.ErrorMessage.String,
+		StartedAt:     job.StartedAt.String,
+		CompletedAt:   job.CompletedAt.String,
+		CreatedAt:     job.CreatedAt.String,
+	}
+	if job.Status == "completed" {
+		info.DownloadUrl = "/api/admin/export-jobs/" + job.ID + "/download"
+	}
+	return info
+}
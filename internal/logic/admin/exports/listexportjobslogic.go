@@ -0,0 +1,72 @@
+package exports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListExportJobsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListExportJobsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListExportJobsLogic {
+	return &ListExportJobsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListExportJobsLogic) ListExportJobs(req *types.ListExportJobsRequest) (resp *types.ListExportJobsResponse, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit < 1 {
+		limit = 20
+	}
+	offset := (page - 1) * limit
+
+	jobs, err := l.svcCtx.DB.ListExportJobs(l.ctx, db.ListExportJobsParams{
+		OrgID:     orgID,
+		LimitVal:  int64(limit),
+		OffsetVal: int64(offset),
+	})
+	if err != nil {
+		l.Errorf("Failed to list export jobs: %v", err)
+		return nil, err
+	}
+
+	total, err := l.svcCtx.DB.CountExportJobs(l.ctx, orgID)
+	if err != nil {
+		l.Errorf("Failed to count export jobs: %v", err)
+		return nil, err
+	}
+
+	jobInfos := make([]types.ExportJobInfo, 0, len(jobs))
+	for _, job := range jobs {
+		jobInfos = append(jobInfos, *exportJobToInfo(job))
+	}
+
+	return &types.ListExportJobsResponse{
+		Jobs:  jobInfos,
+		Total: int(total),
+		Page:  page,
+		Limit: limit,
+	}, nil
+}
@@ -0,0 +1,110 @@
+package housekeeping
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/segment"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type HousekeepingSegmentLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewHousekeepingSegmentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HousekeepingSegmentLogic {
+	return &HousekeepingSegmentLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *HousekeepingSegmentLogic) HousekeepingSegment(req *types.HousekeepingSegmentRequest) (resp *types.HousekeepingResponse, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, fmt.Errorf("organization ID not found")
+	}
+
+	action := req.Action
+	if action == "" {
+		action = "unsubscribe"
+	}
+	if action != "unsubscribe" && action != "delete" {
+		return nil, errorx.NewBadRequestError("action must be unsubscribe or delete")
+	}
+
+	saved, err := l.svcCtx.DB.GetSegment(l.ctx, db.GetSegmentParams{ID: req.SegmentId, OrgID: orgID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errorx.NewNotFoundError("segment not found")
+	}
+	if err != nil {
+		l.Errorf("Failed to get segment: %v", err)
+		return nil, err
+	}
+
+	audience, err := segment.FromSaved(saved)
+	if err != nil {
+		return nil, errorx.NewBadRequestError(err.Error())
+	}
+
+	l.Infof("HousekeepingSegment called for org %s: Segment=%s, Action=%s, DryRun=%v",
+		orgID, saved.Name, action, req.DryRun)
+
+	conn := l.svcCtx.DB.GetDB()
+
+	if req.DryRun {
+		// Dry run - just count matching contacts
+		count, err := segment.Count(l.ctx, conn, audience)
+		if err != nil {
+			l.Errorf("Failed to count segment contacts: %v", err)
+			return nil, err
+		}
+
+		return &types.HousekeepingResponse{
+			AffectedCount: int(count),
+			DryRun:        true,
+			Message:       fmt.Sprintf("Would %s %d contacts in segment %q", action, count, saved.Name),
+		}, nil
+	}
+
+	if action == "delete" {
+		deleted, err := segment.Delete(l.ctx, conn, audience)
+		if err != nil {
+			l.Errorf("Failed to delete segment contacts: %v", err)
+			return nil, err
+		}
+
+		l.Infof("Deleted %d contacts in segment %s for org %s", deleted, saved.ID, orgID)
+
+		return &types.HousekeepingResponse{
+			AffectedCount: int(deleted),
+			DryRun:        false,
+			Message:       fmt.Sprintf("Deleted %d contacts in segment %q", deleted, saved.Name),
+		}, nil
+	}
+
+	unsubscribed, err := segment.Unsubscribe(l.ctx, conn, audience)
+	if err != nil {
+		l.Errorf("Failed to unsubscribe segment contacts: %v", err)
+		return nil, err
+	}
+
+	l.Infof("Unsubscribed %d list subscriptions in segment %s for org %s", unsubscribed, saved.ID, orgID)
+
+	return &types.HousekeepingResponse{
+		AffectedCount: int(unsubscribed),
+		DryRun:        false,
+		Message:       fmt.Sprintf("Unsubscribed %d list subscriptions in segment %q", unsubscribed, saved.Name),
+	}, nil
+}
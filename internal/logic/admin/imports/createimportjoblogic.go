@@ -0,0 +1,186 @@
+package imports
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/outlet-sh/outlet/internal/workers"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// unsafeFilenameChars matches characters stripped from uploaded file names
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+type CreateImportJobLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateImportJobLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateImportJobLogic {
+	return &CreateImportJobLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// CreateImportJob stores an uploaded CSV in the import worker's upload
+// directory and queues a pending import job for it. Jobs without a mapping
+// get the one suggested by the preview, so every job records its mapping.
+func (l *CreateImportJobLogic) CreateImportJob(req *types.CreateImportJobRequest, file io.Reader, filename string) (resp *types.ImportJobInfo, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	if workers.ImportFields(req.Type) == nil {
+		return nil, errorx.NewBadRequestError("type must be subscribers, suppression or blocked_domains")
+	}
+
+	var opts types.ImportJobOptions
+	if req.Options != "" {
+		if err := json.Unmarshal([]byte(req.Options), &opts); err != nil {
+			return nil, errorx.NewBadRequestError("invalid options: " + err.Error())
+		}
+	}
+
+	list, err := getImportList(l.ctx, l.svcCtx, orgID, req.Type, req.ListId)
+	if err != nil {
+		return nil, err
+	}
+	if opts.SendDoubleOptIn && list == nil {
+		return nil, errorx.NewBadRequestError("send_double_opt_in requires a list")
+	}
+
+	jobID := uuid.New().String()
+	storedName := jobID + "_" + sanitizeFilename(filename)
+	path := filepath.Join(workers.DefaultImportWorkerConfig().UploadDir, storedName)
+
+	if err := saveUpload(path, file); err != nil {
+		l.Errorf("Failed to save import upload: %v", err)
+		return nil, errorx.NewInternalError("failed to save uploaded file")
+	}
+
+	header, err := readUploadHeader(path)
+	if err != nil {
+		os.Remove(path)
+		return nil, errorx.NewBadRequestError("failed to read CSV header: " + err.Error())
+	}
+
+	customKeys, err := listCustomFieldKeys(l.ctx, l.svcCtx, list)
+	if err != nil {
+		os.Remove(path)
+		l.Errorf("Failed to load custom fields: %v", err)
+		return nil, errorx.NewInternalError("failed to load custom fields")
+	}
+
+	if len(opts.Mapping) == 0 {
+		opts.Mapping = workers.DetectImportMapping(req.Type, header, customKeys)
+	}
+	if err := validateMapping(req.Type, header, opts.Mapping, customKeys, list != nil); err != nil {
+		os.Remove(path)
+		return nil, errorx.NewBadRequestError(err.Error())
+	}
+
+	optionsJSON, err := json.Marshal(opts)
+	if err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+
+	var listID sql.NullInt64
+	if list != nil {
+		listID = sql.NullInt64{Int64: list.ID, Valid: true}
+	}
+
+	// The import worker picks the job up on its next poll
+	job, err := l.svcCtx.DB.CreateImportJob(l.ctx, db.CreateImportJobParams{
+		ID:       jobID,
+		OrgID:    orgID,
+		ListID:   listID,
+		Type:     req.Type,
+		Filename: storedName,
+		Options:  sql.NullString{String: string(optionsJSON), Valid: true},
+	})
+	if err != nil {
+		os.Remove(path)
+		l.Errorf("Failed to create import job: %v", err)
+		return nil, errorx.NewInternalError("failed to create import job")
+	}
+
+	l.Infof("Import job created: id=%s org=%s type=%s file=%s", job.ID, orgID, job.Type, storedName)
+	return importJobToInfo(job), nil
+}
+
+// validateMapping checks the mapping and that mapped custom fields exist on the list
+func validateMapping(importType string, header []string, mapping map[string]string, customKeys []string, hasList bool) error {
+	if err := workers.ValidateImportMapping(importType, header, mapping, hasList); err != nil {
+		return err
+	}
+
+	known := make(map[string]bool, len(customKeys))
+	for _, key := range customKeys {
+		known[key] = true
+	}
+	for col, field := range mapping {
+		if key, ok := strings.CutPrefix(field, workers.ImportCustomFieldPrefix); ok && !known[key] {
+			return fmt.Errorf("column %q maps to unknown custom field %q", col, key)
+		}
+	}
+	return nil
+}
+
+// sanitizeFilename keeps the base name of an upload safe for the upload directory
+func sanitizeFilename(name string) string {
+	name = unsafeFilenameChars.ReplaceAllString(filepath.Base(name), "_")
+	name = strings.Trim(name, "._")
+	if name == "" {
+		return "import.csv"
+	}
+	return name
+}
+
+// saveUpload writes the uploaded file to path
+func saveUpload(path string, file io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, file); err != nil {
+		out.Close()
+		os.Remove(path)
+		return err
+	}
+	return out.Close()
+}
+
+// readUploadHeader returns the header row of a stored CSV
+func readUploadHeader(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return newImportReader(f).Read()
+}
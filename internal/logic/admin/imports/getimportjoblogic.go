@@ -9,6 +9,7 @@ import (
 	"github.com/outlet-sh/outlet/internal/middleware"
 	"github.com/outlet-sh/outlet/internal/svc"
 	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/outlet-sh/outlet/internal/workers"
 
 	"github.com/zeromicro/go-zero/core/logx"
 )
@@ -42,6 +43,11 @@ func (l *GetImportJobLogic) GetImportJob(req *types.GetImportJobRequest) (resp *
 		return nil, err
 	}
 
+	return importJobToInfo(job), nil
+}
+
+// importJobToInfo converts an import job row to its API representation
+func importJobToInfo(job db.ImportJob) *types.ImportJobInfo {
 	listID := ""
 	if job.ListID.Valid {
 		listID = strconv.FormatInt(job.ListID.Int64, 10)
@@ -52,6 +58,9 @@ func (l *GetImportJobLogic) GetImportJob(req *types.GetImportJobRequest) (resp *
 		status = job.Status.String
 	}
 
+	// Options are informational here, so unreadable JSON is left empty
+	opts, _ := workers.ParseImportOptions(job.Options)
+
 	return &types.ImportJobInfo{
 		Id:            job.ID,
 		OrgId:         job.OrgID,
@@ -63,5 +72,11 @@ func (l *GetImportJobLogic) GetImportJob(req *types.GetImportJobRequest) (resp *
 		ProcessedRows: int(job.ProcessedRows.Int64),
 		SuccessCount:  int(job.SuccessCount.Int64),
 		ErrorCount:    int(job.ErrorCount.Int64),
-	}, nil
+		SkipCount:     int(job.SkipCount.Int64),
+		Errors:        job.Errors.String,
+		Options:       opts,
+		StartedAt:     job.StartedAt.String,
+		CompletedAt:   job.CompletedAt.String,
+		CreatedAt:     job.CreatedAt.String,
+	}
 }
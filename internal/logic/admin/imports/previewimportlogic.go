@@ -0,0 +1,138 @@
+package imports
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/outlet-sh/outlet/internal/workers"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// previewRows is the number of sample rows returned by a preview
+const previewRows = 10
+
+type PreviewImportLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewPreviewImportLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PreviewImportLogic {
+	return &PreviewImportLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// PreviewImport reads the header and first rows of an uploaded CSV and
+// suggests a column mapping. Nothing is stored.
+func (l *PreviewImportLogic) PreviewImport(req *types.PreviewImportRequest, file io.Reader) (resp *types.PreviewImportResponse, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	fields := workers.ImportFields(req.Type)
+	if fields == nil {
+		return nil, errorx.NewBadRequestError("type must be subscribers, suppression or blocked_domains")
+	}
+
+	list, err := getImportList(l.ctx, l.svcCtx, orgID, req.Type, req.ListId)
+	if err != nil {
+		return nil, err
+	}
+	customKeys, err := listCustomFieldKeys(l.ctx, l.svcCtx, list)
+	if err != nil {
+		l.Errorf("Failed to load custom fields: %v", err)
+		return nil, errorx.NewInternalError("failed to load custom fields")
+	}
+
+	reader := newImportReader(file)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errorx.NewBadRequestError("failed to read CSV header: " + err.Error())
+	}
+
+	rows := [][]string{}
+	for len(rows) < previewRows {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errorx.NewBadRequestError("failed to parse CSV: " + err.Error())
+		}
+		rows = append(rows, record)
+	}
+
+	for _, key := range customKeys {
+		fields = append(fields, workers.ImportCustomFieldPrefix+key)
+	}
+
+	return &types.PreviewImportResponse{
+		Type:    req.Type,
+		Header:  header,
+		Rows:    rows,
+		Mapping: workers.DetectImportMapping(req.Type, header, customKeys),
+		Fields:  fields,
+	}, nil
+}
+
+// newImportReader returns a CSV reader configured like the import worker's
+func newImportReader(r io.Reader) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	return reader
+}
+
+// getImportList resolves the optional target list of an import and checks it
+// belongs to the org. Returns nil when no list is given.
+func getImportList(ctx context.Context, svcCtx *svc.ServiceContext, orgID, importType, listID string) (*db.EmailList, error) {
+	if listID == "" {
+		return nil, nil
+	}
+	if importType != workers.ImportTypeSubscribers {
+		return nil, errorx.NewBadRequestError("list_id is only supported for subscriber imports")
+	}
+
+	id, err := strconv.ParseInt(listID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid list ID: %w", err)
+	}
+
+	list, err := svcCtx.DB.GetEmailList(ctx, id)
+	if err != nil || list.OrgID != orgID {
+		return nil, errorx.NewNotFoundError("list not found")
+	}
+	return &list, nil
+}
+
+// listCustomFieldKeys returns the custom field keys of a list, if any
+func listCustomFieldKeys(ctx context.Context, svcCtx *svc.ServiceContext, list *db.EmailList) ([]string, error) {
+	if list == nil {
+		return nil, nil
+	}
+
+	fields, err := svcCtx.DB.ListCustomFieldsByList(ctx, list.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(fields))
+	for _, f := range fields {
+		keys = append(keys, f.FieldKey)
+	}
+	return keys, nil
+}
@@ -0,0 +1,138 @@
+package lists
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/events"
+	"github.com/outlet-sh/outlet/internal/services/emailval"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ApplyListVerificationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewApplyListVerificationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ApplyListVerificationLogic {
+	return &ApplyListVerificationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ApplyListVerification suppresses or tags the contacts behind the selected verdicts
+func (l *ApplyListVerificationLogic) ApplyListVerification(req *types.ApplyListVerificationRequest) (resp *types.ApplyListVerificationResponse, err error) {
+	if req.Action != "suppress" && req.Action != "tag" {
+		return nil, errorx.NewBadRequestError("action must be suppress or tag")
+	}
+
+	verdicts := req.Verdicts
+	if len(verdicts) == 0 {
+		verdicts = []string{emailval.VerdictInvalid}
+	}
+	for _, v := range verdicts {
+		if !slices.Contains(emailval.Verdicts, v) {
+			return nil, errorx.NewBadRequestError(fmt.Sprintf("unknown verdict %q (expected one of: %s)", v, strings.Join(emailval.Verdicts, ", ")))
+		}
+	}
+
+	job, err := getListVerificationJob(l.ctx, l.svcCtx, req.Id, req.JobId)
+	if err != nil {
+		return nil, err
+	}
+
+	affected := 0
+	for _, verdict := range verdicts {
+		for offset := int64(0); ; offset += 500 {
+			results, err := l.svcCtx.DB.ListListVerificationResults(l.ctx, db.ListListVerificationResultsParams{
+				JobID:     job.ID,
+				Verdict:   verdict,
+				LimitVal:  500,
+				OffsetVal: offset,
+			})
+			if err != nil {
+				l.Errorf("Failed to load verification results: %v", err)
+				return nil, errorx.NewInternalError("failed to load verification results")
+			}
+
+			for _, r := range results {
+				var applyErr error
+				if req.Action == "suppress" {
+					applyErr = l.suppress(job, r)
+				} else {
+					applyErr = l.tag(job, r, req.Tag)
+				}
+				if applyErr != nil {
+					l.Errorf("Failed to %s %s: %v", req.Action, r.Email, applyErr)
+					continue
+				}
+				affected++
+			}
+
+			if len(results) < 500 {
+				break
+			}
+		}
+	}
+
+	l.Infof("Applied %s to %d contacts from verification job %s", req.Action, affected, job.ID)
+
+	return &types.ApplyListVerificationResponse{
+		Action:   req.Action,
+		Affected: affected,
+	}, nil
+}
+
+func (l *ApplyListVerificationLogic) suppress(job db.ListVerificationJob, r db.ListVerificationResult) error {
+	_, err := l.svcCtx.DB.AddToSuppressionList(l.ctx, db.AddToSuppressionListParams{
+		OrgID:  job.OrgID,
+		Email:  r.Email,
+		Reason: sql.NullString{String: "list verification: " + r.Verdict, Valid: true},
+		Source: sql.NullString{String: "list_verification", Valid: true},
+	})
+	return err
+}
+
+func (l *ApplyListVerificationLogic) tag(job db.ListVerificationJob, r db.ListVerificationResult, tag string) error {
+	if tag == "" {
+		tag = "email-" + r.Verdict
+	}
+
+	_, err := l.svcCtx.DB.AddContactTag(l.ctx, db.AddContactTagParams{
+		ContactID: sql.NullString{String: r.ContactID, Valid: true},
+		Tag:       tag,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		// Tag already present
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Emit contact.tag_added event for rules engine and tag_added entry rules
+	if l.svcCtx.Events != nil {
+		_ = events.Emit(l.svcCtx.Events, events.TopicContactTagAdded, events.ContactEvent{
+			OrgID:     job.OrgID,
+			ContactID: r.ContactID,
+			Email:     r.Email,
+			Tag:       tag,
+			Source:    "list_verification",
+			Timestamp: time.Now(),
+		})
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package lists
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CancelListVerificationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCancelListVerificationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CancelListVerificationLogic {
+	return &CancelListVerificationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CancelListVerificationLogic) CancelListVerification(req *types.GetListVerificationRequest) (resp *types.ListVerificationJobInfo, err error) {
+	job, err := getListVerificationJob(l.ctx, l.svcCtx, req.Id, req.JobId)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status != "pending" && job.Status != "running" {
+		return nil, errorx.NewBadRequestError("verification job is not in progress")
+	}
+
+	// A running job stops after its current batch; results so far are kept
+	job, err = l.svcCtx.DB.UpdateListVerificationJobStatus(l.ctx, db.UpdateListVerificationJobStatusParams{
+		Status:       "cancelled",
+		ErrorMessage: sql.NullString{},
+		ID:           job.ID,
+	})
+	if err != nil {
+		l.Errorf("Failed to cancel verification job %s: %v", req.JobId, err)
+		return nil, errorx.NewInternalError("failed to cancel verification job")
+	}
+
+	return verificationJobToInfo(job), nil
+}
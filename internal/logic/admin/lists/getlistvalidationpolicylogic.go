@@ -0,0 +1,65 @@
+package lists
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/services/email"
+	"github.com/outlet-sh/outlet/internal/services/emailval"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetListValidationPolicyLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetListValidationPolicyLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetListValidationPolicyLogic {
+	return &GetListValidationPolicyLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetListValidationPolicyLogic) GetListValidationPolicy(req *types.GetListValidationPolicyRequest) (resp *types.ValidationPolicyInfo, err error) {
+	id, err := strconv.ParseInt(req.Id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid list ID: %w", err)
+	}
+
+	list, err := l.svcCtx.DB.GetEmailList(l.ctx, id)
+	if err != nil {
+		return nil, errorx.NewNotFoundError("list not found")
+	}
+
+	policy, err := email.ResolveValidationPolicy(l.ctx, l.svcCtx.DB, list.OrgID, list.ValidationPolicy)
+	if err != nil {
+		l.Errorf("Failed to resolve validation policy for list %d: %v", id, err)
+		return nil, err
+	}
+
+	info := policyToInfo(policy)
+	info.Inherited = !list.ValidationPolicy.Valid
+	return info, nil
+}
+
+// policyToInfo converts a validation policy to its API representation; nil means disabled
+func policyToInfo(policy *emailval.Policy) *types.ValidationPolicyInfo {
+	if policy == nil {
+		return &types.ValidationPolicyInfo{}
+	}
+	return &types.ValidationPolicyInfo{
+		Enabled:         policy.Enabled,
+		BlockDisposable: policy.BlockDisposable,
+		RejectRole:      policy.RejectRole,
+		CheckSMTP:       policy.CheckSMTP,
+		TimeoutSeconds:  policy.TimeoutSeconds,
+	}
+}
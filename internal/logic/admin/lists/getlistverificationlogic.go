@@ -0,0 +1,77 @@
+package lists
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/services/emailval"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/outlet-sh/outlet/internal/utils"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetListVerificationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetListVerificationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetListVerificationLogic {
+	return &GetListVerificationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetListVerificationLogic) GetListVerification(req *types.GetListVerificationRequest) (resp *types.ListVerificationJobInfo, err error) {
+	job, err := getListVerificationJob(l.ctx, l.svcCtx, req.Id, req.JobId)
+	if err != nil {
+		return nil, err
+	}
+	return verificationJobToInfo(job), nil
+}
+
+// getListVerificationJob loads a verification job and checks it belongs to the list
+func getListVerificationJob(ctx context.Context, svcCtx *svc.ServiceContext, listID, jobID string) (db.ListVerificationJob, error) {
+	id, err := strconv.ParseInt(listID, 10, 64)
+	if err != nil {
+		return db.ListVerificationJob{}, errorx.NewBadRequestError("invalid list ID")
+	}
+
+	job, err := svcCtx.DB.GetListVerificationJob(ctx, jobID)
+	if err != nil || job.ListID != id {
+		return db.ListVerificationJob{}, errorx.NewNotFoundError("verification job not found")
+	}
+	return job, nil
+}
+
+// verificationJobToInfo converts a verification job to its API representation
+func verificationJobToInfo(job db.ListVerificationJob) *types.ListVerificationJobInfo {
+	return &types.ListVerificationJobInfo{
+		Id:          job.ID,
+		ListId:      strconv.FormatInt(job.ListID, 10),
+		Status:      job.Status,
+		CheckSMTP:   job.CheckSmtp == 1,
+		Concurrency: int(job.Concurrency),
+		Total:       int(job.TotalCount),
+		Processed:   int(job.ProcessedCount),
+		Verdicts: map[string]int{
+			emailval.VerdictValid:      int(job.ValidCount),
+			emailval.VerdictInvalid:    int(job.InvalidCount),
+			emailval.VerdictDisposable: int(job.DisposableCount),
+			emailval.VerdictRole:       int(job.RoleCount),
+			emailval.VerdictCatchAll:   int(job.CatchAllCount),
+			emailval.VerdictUnknown:    int(job.UnknownCount),
+		},
+		ErrorMessage: job.ErrorMessage.String,
+		CreatedBy:    job.CreatedBy.String,
+		StartedAt:    utils.FormatNullString(job.StartedAt),
+		CompletedAt:  utils.FormatNullString(job.CompletedAt),
+		CreatedAt:    utils.FormatNullString(job.CreatedAt),
+	}
+}
@@ -0,0 +1,83 @@
+package lists
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/outlet-sh/outlet/internal/utils"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListListVerificationResultsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListListVerificationResultsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListListVerificationResultsLogic {
+	return &ListListVerificationResultsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListListVerificationResultsLogic) ListListVerificationResults(req *types.ListVerificationResultsRequest) (resp *types.ListVerificationResultsResponse, err error) {
+	job, err := getListVerificationJob(l.ctx, l.svcCtx, req.Id, req.JobId)
+	if err != nil {
+		return nil, err
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit < 1 {
+		limit = 50
+	}
+
+	var verdictFilter interface{}
+	if req.Verdict != "" {
+		verdictFilter = req.Verdict
+	}
+
+	results, err := l.svcCtx.DB.ListListVerificationResults(l.ctx, db.ListListVerificationResultsParams{
+		JobID:     job.ID,
+		Verdict:   verdictFilter,
+		LimitVal:  int64(limit),
+		OffsetVal: int64((page - 1) * limit),
+	})
+	if err != nil {
+		l.Errorf("Failed to list verification results: %v", err)
+		return nil, fmt.Errorf("failed to list verification results: %w", err)
+	}
+
+	total, _ := l.svcCtx.DB.CountListVerificationResults(l.ctx, db.CountListVerificationResultsParams{
+		JobID:   job.ID,
+		Verdict: verdictFilter,
+	})
+
+	items := make([]types.ListVerificationResultInfo, 0, len(results))
+	for _, r := range results {
+		items = append(items, types.ListVerificationResultInfo{
+			SubscriberId: r.SubscriberID,
+			ContactId:    r.ContactID,
+			Email:        r.Email,
+			Verdict:      r.Verdict,
+			Reason:       utils.FormatNullString(r.Reason),
+			CheckedAt:    utils.FormatNullString(r.CheckedAt),
+		})
+	}
+
+	return &types.ListVerificationResultsResponse{
+		Results: items,
+		Total:   int(total),
+		Page:    page,
+		Limit:   limit,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package lists
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListListVerificationsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListListVerificationsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListListVerificationsLogic {
+	return &ListListVerificationsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListListVerificationsLogic) ListListVerifications(req *types.ListListVerificationsRequest) (resp *types.ListListVerificationsResponse, err error) {
+	listID, err := strconv.ParseInt(req.Id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid list ID: %w", err)
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit < 1 {
+		limit = 20
+	}
+
+	jobs, err := l.svcCtx.DB.ListListVerificationJobs(l.ctx, db.ListListVerificationJobsParams{
+		ListID:    listID,
+		LimitVal:  int64(limit),
+		OffsetVal: int64((page - 1) * limit),
+	})
+	if err != nil {
+		l.Errorf("Failed to list verification jobs: %v", err)
+		return nil, fmt.Errorf("failed to list verification jobs: %w", err)
+	}
+
+	items := make([]types.ListVerificationJobInfo, 0, len(jobs))
+	for _, job := range jobs {
+		items = append(items, *verificationJobToInfo(job))
+	}
+
+	return &types.ListListVerificationsResponse{
+		Jobs:  items,
+		Page:  page,
+		Limit: limit,
+	}, nil
+}
@@ -0,0 +1,79 @@
+package lists
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/outlet-sh/outlet/internal/workers"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type StartListVerificationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewStartListVerificationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *StartListVerificationLogic {
+	return &StartListVerificationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *StartListVerificationLogic) StartListVerification(req *types.StartListVerificationRequest) (resp *types.ListVerificationJobInfo, err error) {
+	id, err := strconv.ParseInt(req.Id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid list ID: %w", err)
+	}
+
+	concurrency := req.Concurrency
+	if concurrency == 0 {
+		concurrency = workers.DefaultListVerificationConcurrency
+	}
+	if concurrency < 1 || concurrency > workers.MaxListVerificationConcurrency {
+		return nil, errorx.NewBadRequestError(fmt.Sprintf("concurrency must be between 1 and %d", workers.MaxListVerificationConcurrency))
+	}
+
+	list, err := l.svcCtx.DB.GetEmailList(l.ctx, id)
+	if err != nil {
+		return nil, errorx.NewNotFoundError("list not found")
+	}
+
+	// One job per list at a time
+	if active, err := l.svcCtx.DB.GetActiveListVerificationJob(l.ctx, list.ID); err == nil {
+		return nil, errorx.NewBadRequestError(fmt.Sprintf("verification job %s is already in progress for this list", active.ID))
+	}
+
+	var checkSMTP int64
+	if req.CheckSMTP {
+		checkSMTP = 1
+	}
+
+	userID, _ := l.ctx.Value("userId").(string)
+
+	// The list verification worker picks the job up on its next poll
+	job, err := l.svcCtx.DB.CreateListVerificationJob(l.ctx, db.CreateListVerificationJobParams{
+		ID:          uuid.New().String(),
+		OrgID:       list.OrgID,
+		ListID:      list.ID,
+		CheckSmtp:   checkSMTP,
+		Concurrency: int64(concurrency),
+		CreatedBy:   sql.NullString{String: userID, Valid: userID != ""},
+	})
+	if err != nil {
+		l.Errorf("Failed to create verification job for list %d: %v", id, err)
+		return nil, errorx.NewInternalError("failed to create verification job")
+	}
+
+	return verificationJobToInfo(job), nil
+}
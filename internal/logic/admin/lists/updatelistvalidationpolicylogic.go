@@ -0,0 +1,74 @@
+package lists
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/services/emailval"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpdateListValidationPolicyLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUpdateListValidationPolicyLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateListValidationPolicyLogic {
+	return &UpdateListValidationPolicyLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UpdateListValidationPolicyLogic) UpdateListValidationPolicy(req *types.UpdateListValidationPolicyRequest) (resp *types.ValidationPolicyInfo, err error) {
+	id, err := strconv.ParseInt(req.Id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid list ID: %w", err)
+	}
+	if req.TimeoutSeconds < 0 || req.TimeoutSeconds > 30 {
+		return nil, errorx.NewBadRequestError("timeout_seconds must be between 0 and 30")
+	}
+
+	list, err := l.svcCtx.DB.GetEmailList(l.ctx, id)
+	if err != nil {
+		return nil, errorx.NewNotFoundError("list not found")
+	}
+
+	// Inherit clears the list policy so the org policy applies
+	var raw sql.NullString
+	if !req.Inherit {
+		policyJSON, err := json.Marshal(emailval.Policy{
+			Enabled:         req.Enabled,
+			BlockDisposable: req.BlockDisposable,
+			RejectRole:      req.RejectRole,
+			CheckSMTP:       req.CheckSMTP,
+			TimeoutSeconds:  req.TimeoutSeconds,
+		})
+		if err != nil {
+			return nil, err
+		}
+		raw = sql.NullString{String: string(policyJSON), Valid: true}
+	}
+
+	err = l.svcCtx.DB.UpdateListValidationPolicy(l.ctx, db.UpdateListValidationPolicyParams{
+		ValidationPolicy: raw,
+		ID:               list.ID,
+		OrgID:            list.OrgID,
+	})
+	if err != nil {
+		l.Errorf("Failed to update validation policy for list %d: %v", id, err)
+		return nil, err
+	}
+
+	return NewGetListValidationPolicyLogic(l.ctx, l.svcCtx).GetListValidationPolicy(&types.GetListValidationPolicyRequest{Id: req.Id})
+}
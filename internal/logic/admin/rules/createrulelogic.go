@@ -0,0 +1,82 @@
+package rules
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/automation"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateRuleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateRuleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateRuleLogic {
+	return &CreateRuleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateRuleLogic) CreateRule(req *types.CreateRuleRequest) (resp *types.RuleInfo, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errorx.NewBadRequestError("name is required")
+	}
+	if problems := automation.ValidateForOrg(l.ctx, l.svcCtx.DB, orgID, req.RuleJson); len(problems) > 0 {
+		return nil, errorx.NewBadRequestError(strings.Join(problems, "; "))
+	}
+
+	category := req.Category
+	if category == "" {
+		category = "automation"
+	}
+	enabled := int64(0)
+	if req.Enabled {
+		enabled = 1
+	}
+
+	rule, err := l.svcCtx.DB.CreateOrgRule(l.ctx, db.CreateOrgRuleParams{
+		ID:              uuid.New().String(),
+		OrgID:           orgID,
+		Name:            name,
+		Description:     sql.NullString{String: req.Description, Valid: req.Description != ""},
+		Category:        category,
+		RuleJson:        req.RuleJson,
+		EntityType:      sql.NullString{String: req.EntityType, Valid: req.EntityType != ""},
+		EntityID:        sql.NullString{String: req.EntityId, Valid: req.EntityId != ""},
+		Enabled:         sql.NullInt64{Int64: enabled, Valid: true},
+		Salience:        sql.NullInt64{Int64: int64(req.Salience), Valid: true},
+		CompiledHash:    sql.NullString{String: automation.Hash(req.RuleJson), Valid: true},
+		LastValidatedAt: validatedAt(),
+		CreatedBy:       userIDFromContext(l.ctx),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return nil, errorx.NewBadRequestError("a rule with this name already exists")
+		}
+		l.Errorf("Failed to create rule: %v", err)
+		return nil, err
+	}
+
+	info := ruleToInfo(rule)
+	return &info, nil
+}
@@ -0,0 +1,48 @@
+package rules
+
+import (
+	"context"
+	"errors"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteRuleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteRuleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteRuleLogic {
+	return &DeleteRuleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeleteRuleLogic) DeleteRule(req *types.DeleteRuleRequest) (resp *types.Response, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	if _, err := getOrgRule(l.ctx, l.svcCtx, orgID, req.Id); err != nil {
+		return nil, err
+	}
+
+	if err := l.svcCtx.DB.DeleteOrgRule(l.ctx, db.DeleteOrgRuleParams{ID: req.Id, OrgID: orgID}); err != nil {
+		l.Errorf("Failed to delete rule: %v", err)
+		return nil, err
+	}
+
+	return &types.Response{
+		Success: true,
+		Message: "Rule deleted successfully",
+	}, nil
+}
@@ -0,0 +1,41 @@
+package rules
+
+import (
+	"context"
+	"errors"
+
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetRuleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetRuleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetRuleLogic {
+	return &GetRuleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetRuleLogic) GetRule(req *types.GetRuleRequest) (resp *types.RuleInfo, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	rule, err := getOrgRule(l.ctx, l.svcCtx, orgID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	info := ruleToInfo(rule)
+	return &info, nil
+}
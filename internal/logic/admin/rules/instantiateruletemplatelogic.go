@@ -0,0 +1,95 @@
+package rules
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/automation"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type InstantiateRuleTemplateLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewInstantiateRuleTemplateLogic(ctx context.Context, svcCtx *svc.ServiceContext) *InstantiateRuleTemplateLogic {
+	return &InstantiateRuleTemplateLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *InstantiateRuleTemplateLogic) InstantiateRuleTemplate(req *types.InstantiateRuleTemplateRequest) (resp *types.RuleInfo, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	template, err := l.svcCtx.DB.GetRuleTemplateById(l.ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errorx.NewNotFoundError("rule template not found")
+		}
+		return nil, err
+	}
+
+	params, err := automation.ParseTemplateParams(template.ConfigurableParams.String)
+	if err != nil {
+		l.Errorf("Rule template %s has invalid params: %v", template.ID, err)
+		return nil, err
+	}
+	ruleJSON, err := automation.Instantiate(template.RuleJson, params, req.Params)
+	if err != nil {
+		return nil, errorx.NewBadRequestError(err.Error())
+	}
+	if problems := automation.ValidateForOrg(l.ctx, l.svcCtx.DB, orgID, ruleJSON); len(problems) > 0 {
+		return nil, errorx.NewBadRequestError(strings.Join(problems, "; "))
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = template.Name
+	}
+	enabled := int64(0)
+	if req.Enabled {
+		enabled = 1
+	}
+
+	rule, err := l.svcCtx.DB.CreateOrgRule(l.ctx, db.CreateOrgRuleParams{
+		ID:              uuid.New().String(),
+		OrgID:           orgID,
+		Name:            name,
+		Description:     template.Description,
+		Category:        template.Category,
+		RuleJson:        ruleJSON,
+		EntityType:      sql.NullString{String: req.EntityType, Valid: req.EntityType != ""},
+		EntityID:        sql.NullString{String: req.EntityId, Valid: req.EntityId != ""},
+		Enabled:         sql.NullInt64{Int64: enabled, Valid: true},
+		Salience:        sql.NullInt64{Int64: int64(req.Salience), Valid: true},
+		CompiledHash:    sql.NullString{String: automation.Hash(ruleJSON), Valid: true},
+		LastValidatedAt: validatedAt(),
+		CreatedBy:       userIDFromContext(l.ctx),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return nil, errorx.NewBadRequestError("a rule with this name already exists")
+		}
+		l.Errorf("Failed to create rule from template: %v", err)
+		return nil, err
+	}
+
+	info := ruleToInfo(rule)
+	return &info, nil
+}
@@ -0,0 +1,111 @@
+package rules
+
+import (
+	"context"
+	"errors"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/outlet-sh/outlet/internal/utils"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListAutomationLogLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListAutomationLogLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListAutomationLogLogic {
+	return &ListAutomationLogLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListAutomationLogLogic) ListAutomationLog(req *types.ListAutomationLogRequest) (resp *types.ListAutomationLogResponse, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	page, limit := req.Page, req.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 200 {
+		limit = 50
+	}
+
+	success := ""
+	switch req.Success {
+	case "true", "1":
+		success = "1"
+	case "false", "0":
+		success = "0"
+	}
+
+	entries, err := l.svcCtx.DB.GetAutomationLogFiltered(l.ctx, db.GetAutomationLogFilteredParams{
+		OrgID:           orgID,
+		FilterEventType: req.EventType,
+		FilterRuleID:    req.RuleId,
+		FilterSuccess:   success,
+		PageOffset:      int64((page - 1) * limit),
+		PageSize:        int64(limit),
+	})
+	if err != nil {
+		l.Errorf("Failed to list automation log: %v", err)
+		return nil, err
+	}
+
+	total, err := l.svcCtx.DB.CountAutomationLog(l.ctx, db.CountAutomationLogParams{
+		OrgID:           orgID,
+		FilterEventType: req.EventType,
+		FilterRuleID:    req.RuleId,
+		FilterSuccess:   success,
+	})
+	if err != nil {
+		l.Errorf("Failed to count automation log: %v", err)
+		return nil, err
+	}
+
+	stats, err := l.svcCtx.DB.GetAutomationLogStats(l.ctx, orgID)
+	if err != nil {
+		l.Errorf("Failed to get automation log stats: %v", err)
+		return nil, err
+	}
+
+	infos := make([]types.AutomationLogInfo, 0, len(entries))
+	for _, e := range entries {
+		infos = append(infos, types.AutomationLogInfo{
+			Id:              e.ID,
+			EventId:         e.EventID,
+			EventType:       e.EventType,
+			EventPayload:    e.EventPayload.String,
+			RuleId:          e.RuleID.String,
+			RuleName:        e.RuleName,
+			ActionsExecuted: e.ActionsExecuted.String,
+			Success:         e.Success == 1,
+			ErrorMessage:    e.ErrorMessage.String,
+			ExecutionTimeMs: int(e.ExecutionTimeMs.Int64),
+			CreatedAt:       utils.FormatNullString(e.CreatedAt),
+		})
+	}
+
+	return &types.ListAutomationLogResponse{
+		Entries: infos,
+		Total:   int(total),
+		Page:    page,
+		Limit:   limit,
+		Stats: types.AutomationLogStats{
+			Total:              int(stats.Total),
+			Successful:         int(stats.Successful.Float64),
+			Failed:             int(stats.Failed.Float64),
+			AvgExecutionTimeMs: stats.AvgExecutionTimeMs.Float64,
+		},
+	}, nil
+}
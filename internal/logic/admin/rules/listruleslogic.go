@@ -0,0 +1,134 @@
+package rules
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/outlet-sh/outlet/internal/utils"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListRulesLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListRulesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListRulesLogic {
+	return &ListRulesLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListRulesLogic) ListRules(req *types.ListRulesRequest) (resp *types.ListRulesResponse, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	page, limit := req.Page, req.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	enabled := ""
+	switch req.Enabled {
+	case "true", "1":
+		enabled = "1"
+	case "false", "0":
+		enabled = "0"
+	}
+
+	rows, err := l.svcCtx.DB.GetOrgRulesFiltered(l.ctx, db.GetOrgRulesFilteredParams{
+		OrgID:          orgID,
+		FilterCategory: req.Category,
+		FilterEnabled:  enabled,
+		PageOffset:     int64((page - 1) * limit),
+		PageSize:       int64(limit),
+	})
+	if err != nil {
+		l.Errorf("Failed to list rules: %v", err)
+		return nil, err
+	}
+
+	total, err := l.svcCtx.DB.CountOrgRules(l.ctx, db.CountOrgRulesParams{
+		OrgID:          orgID,
+		FilterCategory: req.Category,
+		FilterEnabled:  enabled,
+	})
+	if err != nil {
+		l.Errorf("Failed to count rules: %v", err)
+		return nil, err
+	}
+
+	rules := make([]types.RuleInfo, 0, len(rows))
+	for _, r := range rows {
+		rules = append(rules, ruleToInfo(r))
+	}
+
+	return &types.ListRulesResponse{
+		Rules: rules,
+		Total: int(total),
+		Page:  page,
+		Limit: limit,
+	}, nil
+}
+
+// getOrgRule loads a rule scoped to the org, mapping a miss to a 404
+func getOrgRule(ctx context.Context, svcCtx *svc.ServiceContext, orgID, id string) (db.OrgRule, error) {
+	rule, err := svcCtx.DB.GetOrgRuleById(ctx, db.GetOrgRuleByIdParams{ID: id, OrgID: orgID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return rule, errorx.NewNotFoundError("rule not found")
+	}
+	return rule, err
+}
+
+// userIDFromContext returns the authenticated admin for created_by/updated_by
+func userIDFromContext(ctx context.Context) sql.NullString {
+	userID, _ := ctx.Value("userId").(string)
+	return sql.NullString{String: userID, Valid: userID != ""}
+}
+
+// validatedAt formats the current time the way SQLite's datetime('now') does
+func validatedAt() sql.NullString {
+	return sql.NullString{String: time.Now().UTC().Format("2006-01-02 15:04:05"), Valid: true}
+}
+
+func ruleToInfo(r db.OrgRule) types.RuleInfo {
+	validationErrors := []string{}
+	if r.ValidationErrors.Valid && r.ValidationErrors.String != "" {
+		json.Unmarshal([]byte(r.ValidationErrors.String), &validationErrors)
+	}
+
+	return types.RuleInfo{
+		Id:               r.ID,
+		OrgId:            r.OrgID,
+		Name:             r.Name,
+		Description:      r.Description.String,
+		Category:         r.Category,
+		RuleJson:         r.RuleJson,
+		EntityType:       r.EntityType.String,
+		EntityId:         r.EntityID.String,
+		Enabled:          !r.Enabled.Valid || r.Enabled.Int64 == 1,
+		Salience:         int(r.Salience.Int64),
+		CompiledHash:     r.CompiledHash.String,
+		ValidationErrors: validationErrors,
+		LastValidatedAt:  utils.FormatNullString(r.LastValidatedAt),
+		CreatedAt:        utils.FormatNullString(r.CreatedAt),
+		UpdatedAt:        utils.FormatNullString(r.UpdatedAt),
+	}
+}
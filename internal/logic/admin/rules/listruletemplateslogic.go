@@ -0,0 +1,75 @@
+package rules
+
+import (
+	"context"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/services/automation"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListRuleTemplatesLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListRuleTemplatesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListRuleTemplatesLogic {
+	return &ListRuleTemplatesLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListRuleTemplatesLogic) ListRuleTemplates(req *types.ListRuleTemplatesRequest) (resp *types.ListRuleTemplatesResponse, err error) {
+	var templates []db.RuleTemplate
+	if req.Category != "" {
+		templates, err = l.svcCtx.DB.GetRuleTemplatesByCategory(l.ctx, req.Category)
+	} else {
+		templates, err = l.svcCtx.DB.GetRuleTemplates(l.ctx)
+	}
+	if err != nil {
+		l.Errorf("Failed to list rule templates: %v", err)
+		return nil, err
+	}
+
+	infos := make([]types.RuleTemplateInfo, 0, len(templates))
+	for _, t := range templates {
+		infos = append(infos, templateToInfo(t))
+	}
+
+	return &types.ListRuleTemplatesResponse{Templates: infos}, nil
+}
+
+func templateToInfo(t db.RuleTemplate) types.RuleTemplateInfo {
+	params := []types.RuleTemplateParamInfo{}
+	parsed, _ := automation.ParseTemplateParams(t.ConfigurableParams.String)
+	for _, p := range parsed {
+		paramType := p.Type
+		if paramType == "" {
+			paramType = "string"
+		}
+		params = append(params, types.RuleTemplateParamInfo{
+			Name:     p.Name,
+			Label:    p.Label,
+			Type:     paramType,
+			Default:  p.Default,
+			Required: p.Required,
+		})
+	}
+
+	return types.RuleTemplateInfo{
+		Id:            t.ID,
+		Name:          t.Name,
+		Description:   t.Description.String,
+		Category:      t.Category,
+		RuleJson:      t.RuleJson,
+		Params:        params,
+		IsRecommended: t.IsRecommended.Int64 == 1,
+		IsDefault:     t.IsDefault.Int64 == 1,
+	}
+}
@@ -0,0 +1,51 @@
+package rules
+
+import (
+	"context"
+	"errors"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ToggleRuleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewToggleRuleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ToggleRuleLogic {
+	return &ToggleRuleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ToggleRuleLogic) ToggleRule(req *types.GetRuleRequest) (resp *types.RuleInfo, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	// ToggleOrgRule is keyed by id only, so check ownership first
+	if _, err := getOrgRule(l.ctx, l.svcCtx, orgID, req.Id); err != nil {
+		return nil, err
+	}
+
+	rule, err := l.svcCtx.DB.ToggleOrgRule(l.ctx, db.ToggleOrgRuleParams{
+		UpdatedBy: userIDFromContext(l.ctx),
+		ID:        req.Id,
+	})
+	if err != nil {
+		l.Errorf("Failed to toggle rule: %v", err)
+		return nil, err
+	}
+
+	info := ruleToInfo(rule)
+	return &info, nil
+}
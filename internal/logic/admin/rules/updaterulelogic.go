@@ -0,0 +1,101 @@
+package rules
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/automation"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpdateRuleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUpdateRuleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateRuleLogic {
+	return &UpdateRuleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UpdateRuleLogic) UpdateRule(req *types.UpdateRuleRequest) (resp *types.RuleInfo, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	existing, err := getOrgRule(l.ctx, l.svcCtx, orgID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	params := db.UpdateOrgRuleParams{
+		Name:             existing.Name,
+		Description:      existing.Description,
+		RuleJson:         existing.RuleJson,
+		EntityType:       existing.EntityType,
+		EntityID:         existing.EntityID,
+		Enabled:          existing.Enabled,
+		Salience:         existing.Salience,
+		CompiledHash:     existing.CompiledHash,
+		ValidationErrors: existing.ValidationErrors,
+		LastValidatedAt:  existing.LastValidatedAt,
+		UpdatedBy:        userIDFromContext(l.ctx),
+		ID:               existing.ID,
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		params.Name = name
+	}
+	if req.Description != "" {
+		params.Description = sql.NullString{String: req.Description, Valid: true}
+	}
+	if req.EntityType != "" {
+		params.EntityType = sql.NullString{String: req.EntityType, Valid: true}
+	}
+	if req.EntityId != "" {
+		params.EntityID = sql.NullString{String: req.EntityId, Valid: true}
+	}
+	if req.Enabled != nil {
+		params.Enabled = sql.NullInt64{Valid: true}
+		if *req.Enabled {
+			params.Enabled.Int64 = 1
+		}
+	}
+	if req.Salience != nil {
+		params.Salience = sql.NullInt64{Int64: int64(*req.Salience), Valid: true}
+	}
+	if req.RuleJson != "" {
+		if problems := automation.ValidateForOrg(l.ctx, l.svcCtx.DB, orgID, req.RuleJson); len(problems) > 0 {
+			return nil, errorx.NewBadRequestError(strings.Join(problems, "; "))
+		}
+		params.RuleJson = req.RuleJson
+		params.CompiledHash = sql.NullString{String: automation.Hash(req.RuleJson), Valid: true}
+		params.ValidationErrors = sql.NullString{}
+		params.LastValidatedAt = validatedAt()
+	}
+
+	rule, err := l.svcCtx.DB.UpdateOrgRule(l.ctx, params)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return nil, errorx.NewBadRequestError("a rule with this name already exists")
+		}
+		l.Errorf("Failed to update rule: %v", err)
+		return nil, err
+	}
+
+	info := ruleToInfo(rule)
+	return &info, nil
+}
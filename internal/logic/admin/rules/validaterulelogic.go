@@ -0,0 +1,69 @@
+package rules
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/automation"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ValidateRuleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewValidateRuleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ValidateRuleLogic {
+	return &ValidateRuleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ValidateRule re-checks a stored rule against the current org data (a
+// referenced sequence or list may have been deleted since it was saved) and
+// records the outcome in validation_errors and compiled_hash.
+func (l *ValidateRuleLogic) ValidateRule(req *types.GetRuleRequest) (resp *types.ValidateRuleResponse, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	rule, err := getOrgRule(l.ctx, l.svcCtx, orgID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := automation.ValidateForOrg(l.ctx, l.svcCtx.DB, orgID, rule.RuleJson)
+	err = l.svcCtx.DB.UpdateRuleValidation(l.ctx, db.UpdateRuleValidationParams{
+		CompiledHash:     sql.NullString{String: automation.Hash(rule.RuleJson), Valid: true},
+		ValidationErrors: automation.ValidationErrorsJSON(problems),
+		ID:               rule.ID,
+	})
+	if err != nil {
+		l.Errorf("Failed to store rule validation: %v", err)
+		return nil, err
+	}
+
+	rule, err = getOrgRule(l.ctx, l.svcCtx, orgID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if problems == nil {
+		problems = []string{}
+	}
+	return &types.ValidateRuleResponse{
+		Valid:  len(problems) == 0,
+		Errors: problems,
+		Rule:   ruleToInfo(rule),
+	}, nil
+}
@@ -0,0 +1,84 @@
+package segments
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/segment"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateSegmentLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateSegmentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateSegmentLogic {
+	return &CreateSegmentLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateSegmentLogic) CreateSegment(req *types.CreateSegmentRequest) (resp *types.SegmentInfo, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errorx.NewBadRequestError("name is required")
+	}
+	if _, err := segment.Parse(req.Filter); err != nil {
+		return nil, errorx.NewBadRequestError(err.Error())
+	}
+
+	filter := req.Filter
+	if strings.TrimSpace(filter) == "" {
+		filter = "{}"
+	}
+	listIds := req.ListIds
+	if listIds == nil {
+		listIds = []string{}
+	}
+	listIdsJSON, _ := json.Marshal(listIds)
+
+	s, err := l.svcCtx.DB.CreateSegment(l.ctx, db.CreateSegmentParams{
+		ID:          uuid.New().String(),
+		OrgID:       orgID,
+		Name:        name,
+		Description: sql.NullString{String: req.Description, Valid: req.Description != ""},
+		ListIds:     sql.NullString{String: string(listIdsJSON), Valid: true},
+		Filter:      filter,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return nil, errorx.NewBadRequestError("a segment with this name already exists")
+		}
+		l.Errorf("Failed to create segment: %v", err)
+		return nil, err
+	}
+
+	// Populate the cached count right away; the worker keeps it fresh afterwards
+	if count, err := segment.RefreshCount(l.ctx, l.svcCtx.DB.GetDB(), l.svcCtx.DB, s); err != nil {
+		l.Errorf("Failed to count segment %s: %v", s.ID, err)
+	} else {
+		s.ContactCount = sql.NullInt64{Int64: count, Valid: true}
+	}
+
+	info := segmentToInfo(s)
+	return &info, nil
+}
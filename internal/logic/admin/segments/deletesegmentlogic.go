@@ -0,0 +1,60 @@
+package segments
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/segment"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteSegmentLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteSegmentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteSegmentLogic {
+	return &DeleteSegmentLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeleteSegmentLogic) DeleteSegment(req *types.DeleteSegmentRequest) (resp *types.Response, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	_, err = l.svcCtx.DB.GetSegment(l.ctx, db.GetSegmentParams{ID: req.Id, OrgID: orgID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errorx.NewNotFoundError("segment not found")
+	}
+	if err != nil {
+		l.Errorf("Failed to get segment: %v", err)
+		return nil, err
+	}
+
+	err = segment.DeleteSaved(l.ctx, l.svcCtx.DB, orgID, req.Id)
+	if errors.Is(err, segment.ErrInUse) {
+		return nil, errorx.NewBadRequestError(err.Error())
+	}
+	if err != nil {
+		l.Errorf("Failed to delete segment: %v", err)
+		return nil, err
+	}
+
+	return &types.Response{
+		Success: true,
+		Message: "Segment deleted successfully",
+	}, nil
+}
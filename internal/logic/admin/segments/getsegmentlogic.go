@@ -0,0 +1,48 @@
+package segments
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetSegmentLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetSegmentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetSegmentLogic {
+	return &GetSegmentLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetSegmentLogic) GetSegment(req *types.GetSegmentRequest) (resp *types.SegmentInfo, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	s, err := l.svcCtx.DB.GetSegment(l.ctx, db.GetSegmentParams{ID: req.Id, OrgID: orgID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errorx.NewNotFoundError("segment not found")
+	}
+	if err != nil {
+		l.Errorf("Failed to get segment: %v", err)
+		return nil, err
+	}
+
+	info := segmentToInfo(s)
+	return &info, nil
+}
@@ -0,0 +1,69 @@
+package segments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/outlet-sh/outlet/internal/utils"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListSegmentsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListSegmentsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListSegmentsLogic {
+	return &ListSegmentsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListSegmentsLogic) ListSegments() (resp *types.ListSegmentsResponse, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	rows, err := l.svcCtx.DB.ListSegments(l.ctx, orgID)
+	if err != nil {
+		l.Errorf("Failed to list segments: %v", err)
+		return nil, err
+	}
+
+	segments := make([]types.SegmentInfo, 0, len(rows))
+	for _, s := range rows {
+		segments = append(segments, segmentToInfo(s))
+	}
+
+	return &types.ListSegmentsResponse{Segments: segments}, nil
+}
+
+func segmentToInfo(s db.Segment) types.SegmentInfo {
+	listIds := []string{}
+	if s.ListIds.Valid && s.ListIds.String != "" {
+		json.Unmarshal([]byte(s.ListIds.String), &listIds)
+	}
+
+	return types.SegmentInfo{
+		Id:               s.ID,
+		OrgId:            s.OrgID,
+		Name:             s.Name,
+		Description:      s.Description.String,
+		ListIds:          listIds,
+		Filter:           s.Filter,
+		ContactCount:     int(s.ContactCount.Int64),
+		CountRefreshedAt: utils.FormatNullString(s.CountRefreshedAt),
+		CreatedAt:        utils.FormatNullString(s.CreatedAt),
+		UpdatedAt:        utils.FormatNullString(s.UpdatedAt),
+	}
+}
@@ -0,0 +1,60 @@
+package segments
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/segment"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RefreshSegmentLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRefreshSegmentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RefreshSegmentLogic {
+	return &RefreshSegmentLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RefreshSegmentLogic) RefreshSegment(req *types.GetSegmentRequest) (resp *types.SegmentInfo, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	s, err := l.svcCtx.DB.GetSegment(l.ctx, db.GetSegmentParams{ID: req.Id, OrgID: orgID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errorx.NewNotFoundError("segment not found")
+	}
+	if err != nil {
+		l.Errorf("Failed to get segment: %v", err)
+		return nil, err
+	}
+
+	if _, err := segment.RefreshCount(l.ctx, l.svcCtx.DB.GetDB(), l.svcCtx.DB, s); err != nil {
+		l.Errorf("Failed to count segment %s: %v", s.ID, err)
+		return nil, err
+	}
+
+	// Re-read to pick up count_refreshed_at
+	s, err = l.svcCtx.DB.GetSegment(l.ctx, db.GetSegmentParams{ID: req.Id, OrgID: orgID})
+	if err != nil {
+		return nil, err
+	}
+
+	info := segmentToInfo(s)
+	return &info, nil
+}
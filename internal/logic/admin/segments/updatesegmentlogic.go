@@ -0,0 +1,80 @@
+package segments
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/segment"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpdateSegmentLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUpdateSegmentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateSegmentLogic {
+	return &UpdateSegmentLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UpdateSegmentLogic) UpdateSegment(req *types.UpdateSegmentRequest) (resp *types.SegmentInfo, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errors.New("org_id not found in context")
+	}
+
+	if _, err := segment.Parse(req.Filter); err != nil {
+		return nil, errorx.NewBadRequestError(err.Error())
+	}
+
+	var description, listIds interface{}
+	if req.Description != "" {
+		description = req.Description
+	}
+	if req.ListIds != nil {
+		data, _ := json.Marshal(req.ListIds)
+		listIds = string(data)
+	}
+
+	s, err := l.svcCtx.DB.UpdateSegment(l.ctx, db.UpdateSegmentParams{
+		ID:          req.Id,
+		OrgID:       orgID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: description,
+		ListIds:     listIds,
+		Filter:      req.Filter,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errorx.NewNotFoundError("segment not found")
+	}
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return nil, errorx.NewBadRequestError("a segment with this name already exists")
+		}
+		l.Errorf("Failed to update segment: %v", err)
+		return nil, err
+	}
+
+	if count, err := segment.RefreshCount(l.ctx, l.svcCtx.DB.GetDB(), l.svcCtx.DB, s); err != nil {
+		l.Errorf("Failed to count segment %s: %v", s.ID, err)
+	} else {
+		s.ContactCount = sql.NullInt64{Int64: count, Valid: true}
+	}
+
+	info := segmentToInfo(s)
+	return &info, nil
+}
@@ -56,6 +56,11 @@ func (l *CreateEntryRuleLogic) CreateEntryRule(req *types.CreateEntryRuleRequest
 			if err == nil {
 				sourceName = seq.Name
 			}
+		case "segment_enter":
+			seg, err := l.svcCtx.DB.GetSegmentByID(l.ctx, req.SourceId)
+			if err == nil {
+				sourceName = seg.Name
+			}
 		}
 	}
 
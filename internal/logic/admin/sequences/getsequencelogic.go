@@ -99,6 +99,8 @@ func (l *GetSequenceLogic) GetSequence(req *types.GetSequenceRequest) (resp *typ
 			sourceName = r.ListName.String
 		} else if r.SourceSequenceName.Valid {
 			sourceName = r.SourceSequenceName.String
+		} else if r.SegmentName.Valid {
+			sourceName = r.SegmentName.String
 		}
 		entryRules = append(entryRules, types.EntryRuleInfo{
 			Id:          r.ID,
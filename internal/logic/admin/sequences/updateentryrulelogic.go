@@ -52,8 +52,8 @@ func (l *UpdateEntryRuleLogic) UpdateEntryRule(req *types.UpdateEntryRuleRequest
 	}
 
 	var sourceName string
-	sourceID, _ := strconv.ParseInt(rule.SourceID, 10, 64)
-	if sourceID > 0 {
+	if rule.SourceID != "" {
+		sourceID, _ := strconv.ParseInt(rule.SourceID, 10, 64)
 		switch rule.TriggerType {
 		case "list_join":
 			list, err := l.svcCtx.DB.GetEmailList(l.ctx, sourceID)
@@ -65,6 +65,11 @@ func (l *UpdateEntryRuleLogic) UpdateEntryRule(req *types.UpdateEntryRuleRequest
 			if err == nil {
 				sourceName = seq.Name
 			}
+		case "segment_enter":
+			seg, err := l.svcCtx.DB.GetSegmentByID(l.ctx, rule.SourceID)
+			if err == nil {
+				sourceName = seg.Name
+			}
 		}
 	}
 
@@ -2,7 +2,9 @@ package subscribers
 
 import (
 	"context"
+	"time"
 
+	"github.com/outlet-sh/outlet/internal/events"
 	"github.com/outlet-sh/outlet/internal/svc"
 	"github.com/outlet-sh/outlet/internal/types"
 
@@ -38,5 +40,16 @@ func (l *UnsubscribeSubscriberLogic) UnsubscribeSubscriber(req *types.Subscriber
 		return nil, err
 	}
 
+	// Emit contact.unsubscribed event for rules engine
+	if l.svcCtx.Events != nil && contact.OrgID.Valid {
+		_ = events.Emit(l.svcCtx.Events, events.TopicContactUnsubscribed, events.ContactEvent{
+			OrgID:     contact.OrgID.String,
+			ContactID: contact.ID,
+			Email:     contact.Email,
+			Source:    "admin",
+			Timestamp: time.Now(),
+		})
+	}
+
 	return contactToSubscriberInfo(contact), nil
 }
@@ -0,0 +1,50 @@
+package system
+
+import (
+	"context"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetWorkerStatusLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetWorkerStatusLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetWorkerStatusLogic {
+	return &GetWorkerStatusLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetWorkerStatusLogic) GetWorkerStatus() (resp *types.WorkerStatusResponse, err error) {
+	resp = &types.WorkerStatusResponse{Workers: []types.WorkerStatusInfo{}}
+
+	// Workers are only supervised when running under serve
+	if l.svcCtx.Workers == nil {
+		return resp, nil
+	}
+
+	for _, w := range l.svcCtx.Workers.Stats() {
+		info := types.WorkerStatusInfo{
+			Name:      w.Name,
+			Status:    w.Status,
+			Restarts:  w.Restarts,
+			LastError: w.LastError,
+			Counters:  w.Counters,
+		}
+		if !w.StartedAt.IsZero() {
+			info.StartedAt = w.StartedAt.UTC().Format(time.RFC3339)
+		}
+		resp.Workers = append(resp.Workers, info)
+	}
+
+	return resp, nil
+}
@@ -3,8 +3,10 @@ package public
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/events"
 	"github.com/outlet-sh/outlet/internal/svc"
 	"github.com/outlet-sh/outlet/internal/types"
 
@@ -61,10 +63,20 @@ func (l *ConfirmEmailLogic) ConfirmEmail(req *types.ConfirmEmailRequest) (resp *
 	})
 
 	// Add verified tag
-	_, _ = l.svcCtx.DB.AddContactTag(l.ctx, db.AddContactTagParams{
+	_, err = l.svcCtx.DB.AddContactTag(l.ctx, db.AddContactTagParams{
 		ContactID: sql.NullString{String: contact.ID, Valid: true},
 		Tag:       "email_verified",
 	})
+	if err == nil && l.svcCtx.Events != nil && contact.OrgID.Valid {
+		_ = events.Emit(l.svcCtx.Events, events.TopicContactTagAdded, events.ContactEvent{
+			OrgID:     contact.OrgID.String,
+			ContactID: contact.ID,
+			Email:     contact.Email,
+			Tag:       "email_verified",
+			Source:    "email_confirmation",
+			Timestamp: time.Now(),
+		})
+	}
 
 	logx.Infof("Email verified for contact %s (%s)", contact.ID, contact.Email)
 
@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"strings"
+	"time"
 
 	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/events"
 	"github.com/outlet-sh/outlet/internal/middleware"
 	"github.com/outlet-sh/outlet/internal/svc"
 	"github.com/outlet-sh/outlet/internal/types"
@@ -55,10 +57,22 @@ func (l *AddContactTagsLogic) AddContactTags(req *types.AddContactTagsRequest) (
 
 	// Add each tag
 	for _, tag := range req.Tags {
-		_, _ = l.svcCtx.DB.AddContactTag(l.ctx, db.AddContactTagParams{
+		_, err := l.svcCtx.DB.AddContactTag(l.ctx, db.AddContactTagParams{
 			ContactID: sql.NullString{String: contact.ID, Valid: true},
 			Tag:       tag,
 		})
+
+		// Emit contact.tag_added for newly added tags (rules engine, tag_added entry rules)
+		if err == nil && l.svcCtx.Events != nil {
+			_ = events.Emit(l.svcCtx.Events, events.TopicContactTagAdded, events.ContactEvent{
+				OrgID:     orgID,
+				ContactID: contact.ID,
+				Email:     contact.Email,
+				Tag:       tag,
+				Source:    "api",
+				Timestamp: time.Now(),
+			})
+		}
 	}
 
 	l.Infof("Added tags to contact: org=%s id=%s tags=%v", orgID, contact.ID, req.Tags)
@@ -81,6 +81,7 @@ func (l *GetContactLogic) GetContact(req *types.GetContactRequest) (resp *types.
 		Tags:          tagList,
 		Lists:         []string{},
 		Source:        contact.Source.String,
+		Timezone:      contact.Timezone.String,
 		CreatedAt:     contact.CreatedAt.String,
 		UpdatedAt:     contact.UpdatedAt.String,
 	}, nil
@@ -3,8 +3,10 @@ package contacts
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/events"
 	"github.com/outlet-sh/outlet/internal/middleware"
 	"github.com/outlet-sh/outlet/internal/svc"
 	"github.com/outlet-sh/outlet/internal/types"
@@ -47,5 +49,22 @@ func (l *GlobalUnsubscribeLogic) GlobalUnsubscribe(req *types.GlobalUnsubscribeR
 
 	l.Infof("Global unsubscribe: org=%s email=%s reason=%s", orgID, req.Email, req.Reason)
 
+	// Emit contact.unsubscribed event for rules engine
+	if l.svcCtx.Events != nil {
+		contact, err := l.svcCtx.DB.GetContactByOrgAndEmail(l.ctx, db.GetContactByOrgAndEmailParams{
+			OrgID: sql.NullString{String: orgID, Valid: true},
+			Email: req.Email,
+		})
+		if err == nil {
+			_ = events.Emit(l.svcCtx.Events, events.TopicContactUnsubscribed, events.ContactEvent{
+				OrgID:     orgID,
+				ContactID: contact.ID,
+				Email:     contact.Email,
+				Source:    "api",
+				Timestamp: time.Now(),
+			})
+		}
+	}
+
 	return &types.Response{Success: true, Message: "unsubscribed"}, nil
 }
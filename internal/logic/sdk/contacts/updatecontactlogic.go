@@ -6,7 +6,9 @@ import (
 	"strings"
 
 	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
 	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/sendtime"
 	"github.com/outlet-sh/outlet/internal/svc"
 	"github.com/outlet-sh/outlet/internal/types"
 
@@ -53,6 +55,19 @@ func (l *UpdateContactLogic) UpdateContact(req *types.UpdateContactRequest) (res
 		return nil, err
 	}
 
+	if req.Timezone != "" {
+		if _, err := sendtime.LoadTimezone(req.Timezone); err != nil {
+			return nil, errorx.NewBadRequestError(err.Error())
+		}
+		if err := l.svcCtx.DB.SetContactTimezone(l.ctx, db.SetContactTimezoneParams{
+			Timezone: sql.NullString{String: req.Timezone, Valid: true},
+			ID:       contact.ID,
+		}); err != nil {
+			l.Errorf("Failed to set contact time zone: %v", err)
+			return nil, err
+		}
+	}
+
 	// Update contact name if provided
 	nameToUpdate := req.Name
 	if nameToUpdate == "" {
@@ -99,6 +114,7 @@ func (l *UpdateContactLogic) UpdateContact(req *types.UpdateContactRequest) (res
 		Tags:          tagList,
 		Lists:         []string{},
 		Source:        updated.Source.String,
+		Timezone:      updated.Timezone.String,
 		CreatedAt:     updated.CreatedAt.String,
 		UpdatedAt:     updated.UpdatedAt.String,
 	}, nil
@@ -6,8 +6,12 @@ import (
 	"time"
 
 	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
 	"github.com/outlet-sh/outlet/internal/events"
 	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/email"
+	"github.com/outlet-sh/outlet/internal/services/emailval"
+	"github.com/outlet-sh/outlet/internal/services/sendtime"
 	"github.com/outlet-sh/outlet/internal/svc"
 	"github.com/outlet-sh/outlet/internal/types"
 
@@ -40,6 +44,17 @@ func (l *CreateContactLogic) CreateContact(req *types.ContactRequest) (resp *typ
 	if req.Email == "" {
 		return nil, nil
 	}
+	if req.Timezone != "" {
+		if _, err := sendtime.LoadTimezone(req.Timezone); err != nil {
+			return nil, errorx.NewBadRequestError(err.Error())
+		}
+	}
+
+	// Validate against the org policy; validation errors fail open
+	validation, err := email.ValidateSignup(l.ctx, l.svcCtx.DB, orgID, sql.NullString{}, req.Email)
+	if err != nil {
+		l.Errorf("Failed to validate email %s: %v", req.Email, err)
+	}
 
 	// Check for existing contact by email within this org
 	existingContact, err := l.svcCtx.DB.GetContactByOrgAndEmail(l.ctx, db.GetContactByOrgAndEmailParams{
@@ -47,6 +62,12 @@ func (l *CreateContactLogic) CreateContact(req *types.ContactRequest) (resp *typ
 		Email: req.Email,
 	})
 	if err == nil {
+		if err := l.recordValidation(existingContact.ID, validation); err != nil {
+			return nil, err
+		}
+		if err := l.setTimezone(existingContact.ID, req.Timezone); err != nil {
+			return nil, err
+		}
 		// Contact exists - return their info
 		l.Infof("Contact already exists: %s (%s)", existingContact.ID, existingContact.Email)
 		return &types.ContactResponse{
@@ -70,12 +91,23 @@ func (l *CreateContactLogic) CreateContact(req *types.ContactRequest) (resp *typ
 		return nil, err
 	}
 
+	// Rejected contacts are kept so the validation outcome is auditable
+	if err := l.recordValidation(contact.ID, validation); err != nil {
+		return nil, err
+	}
+	if err := l.setTimezone(contact.ID, req.Timezone); err != nil {
+		return nil, err
+	}
+
 	// Add tags if provided
+	var addedTags []string
 	for _, tag := range req.Tags {
-		_, _ = l.svcCtx.DB.AddContactTag(l.ctx, db.AddContactTagParams{
+		if _, err := l.svcCtx.DB.AddContactTag(l.ctx, db.AddContactTagParams{
 			ContactID: sql.NullString{String: contact.ID, Valid: true},
 			Tag:       tag,
-		})
+		}); err == nil {
+			addedTags = append(addedTags, tag)
+		}
 	}
 
 	l.Infof("Created contact: org=%s id=%s email=%s", orgID, contact.ID, contact.Email)
@@ -88,6 +120,16 @@ func (l *CreateContactLogic) CreateContact(req *types.ContactRequest) (resp *typ
 			Email:     contact.Email,
 			Timestamp: time.Now(),
 		})
+		for _, tag := range addedTags {
+			_ = events.Emit(l.svcCtx.Events, events.TopicContactTagAdded, events.ContactEvent{
+				OrgID:     orgID,
+				ContactID: contact.ID,
+				Email:     contact.Email,
+				Tag:       tag,
+				Source:    "api",
+				Timestamp: time.Now(),
+			})
+		}
 	}
 
 	return &types.ContactResponse{
@@ -96,3 +138,34 @@ func (l *CreateContactLogic) CreateContact(req *types.ContactRequest) (resp *typ
 		Name:  contact.Name,
 	}, nil
 }
+
+// recordValidation stores the validation result on the contact and rejects
+// the request when the address failed the policy
+func (l *CreateContactLogic) recordValidation(contactID string, validation *emailval.Result) error {
+	if validation == nil {
+		return nil
+	}
+	if err := email.RecordValidation(l.ctx, l.svcCtx.DB, contactID, validation); err != nil {
+		l.Errorf("Failed to record validation for contact %s: %v", contactID, err)
+	}
+	if !validation.Valid() {
+		l.Infof("Contact rejected by validation policy: id=%s reason=%s", contactID, validation.Reason())
+		return errorx.NewBadRequestError("Email address rejected: " + validation.Reason())
+	}
+	return nil
+}
+
+// setTimezone stores the contact's time zone when the request has one
+func (l *CreateContactLogic) setTimezone(contactID, timezone string) error {
+	if timezone == "" {
+		return nil
+	}
+	if err := l.svcCtx.DB.SetContactTimezone(l.ctx, db.SetContactTimezoneParams{
+		Timezone: sql.NullString{String: timezone, Valid: true},
+		ID:       contactID,
+	}); err != nil {
+		l.Errorf("Failed to set time zone for contact %s: %v", contactID, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package emails
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CancelEmailLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCancelEmailLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CancelEmailLogic {
+	return &CancelEmailLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// CancelEmail removes a queued or scheduled send the dispatcher has not
+// picked up yet
+func (l *CancelEmailLogic) CancelEmail(req *types.CancelEmailSendRequest) (resp *types.Response, err error) {
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errorx.NewUnauthorizedError("Organization not found")
+	}
+
+	send, err := l.svcCtx.DB.GetTransactionalSendByTrackingAndOrg(l.ctx, db.GetTransactionalSendByTrackingAndOrgParams{
+		TrackingToken: sql.NullString{String: req.MessageId, Valid: true},
+		OrgID:         orgID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errorx.NewNotFoundError("Email not found")
+	}
+	if err != nil {
+		l.Errorf("Failed to get email %s: %v", req.MessageId, err)
+		return nil, errorx.NewInternalError("Failed to cancel email")
+	}
+
+	cancelled, err := cancelQueuedSend(l.ctx, l.svcCtx.DB, orgID, send.ID)
+	if err != nil {
+		l.Errorf("Failed to cancel email %s: %v", req.MessageId, err)
+		return nil, errorx.NewInternalError("Failed to cancel email")
+	}
+	if !cancelled {
+		return nil, errorx.NewBadRequestError("Email has already been sent or is being sent")
+	}
+
+	l.Infof("CancelEmail: org=%s messageId=%s cancelled", orgID, req.MessageId)
+
+	return &types.Response{
+		Success: true,
+		Message: "Email cancelled",
+	}, nil
+}
+
+// cancelQueuedSend deletes a send along with its queue row, attachments and
+// idempotency key, provided the dispatcher has not claimed it. Everything is
+// removed explicitly so cancelling does not depend on foreign key cascades.
+func cancelQueuedSend(ctx context.Context, store *db.Store, orgID, sendID string) (bool, error) {
+	cancelled := false
+	err := store.ExecTx(ctx, func(q *db.Queries) error {
+		dequeued, err := q.DequeueUnclaimedTransactionalSend(ctx, db.DequeueUnclaimedTransactionalSendParams{
+			SendID: sendID,
+			OrgID:  orgID,
+		})
+		if err != nil || dequeued == 0 {
+			return err
+		}
+		if err := q.DeleteTransactionalSendAttachments(ctx, sendID); err != nil {
+			return err
+		}
+		if err := q.DeleteTransactionalIdempotencyKeys(ctx, sendID); err != nil {
+			return err
+		}
+		if err := q.DeleteTransactionalSend(ctx, db.DeleteTransactionalSendParams{ID: sendID, OrgID: orgID}); err != nil {
+			return err
+		}
+		cancelled = true
+		return nil
+	})
+	return cancelled, err
+}
@@ -0,0 +1,96 @@
+package emails
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/db/dbtest"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+)
+
+// queuedSendFixture stores an org with one queued send that has an
+// attachment and an idempotency key
+func queuedSendFixture(t *testing.T, store *db.Store) {
+	t.Helper()
+	dbtest.Exec(t, store, `INSERT INTO organizations (id, name, slug, api_key) VALUES ('org', 'Org', 'org', 'key')`)
+	dbtest.Exec(t, store, `INSERT INTO transactional_emails (id, org_id, name, slug, subject, html_body) VALUES ('tpl', 'org', 'Ad-hoc', '_adhoc', 's', 'b')`)
+	dbtest.Exec(t, store, `INSERT INTO transactional_sends (id, template_id, org_id, to_email, status, tracking_token) VALUES ('send', 'tpl', 'org', 'to@example.com', 'pending', 'msg')`)
+	dbtest.Exec(t, store, `INSERT INTO transactional_attachments (id, send_id, org_id, filename, content_type) VALUES ('att', 'send', 'org', 'a.pdf', 'application/pdf')`)
+	dbtest.Exec(t, store, `INSERT INTO transactional_idempotency_keys (org_id, idempotency_key, send_id) VALUES ('org', 'retry-1', 'send')`)
+	if err := store.EnqueueTransactionalSend(context.Background(), db.EnqueueTransactionalSendParams{
+		SendID:   "send",
+		OrgID:    "org",
+		ToEmail:  "to@example.com",
+		Subject:  "s",
+		HtmlBody: "b",
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func countRows(t *testing.T, store *db.Store, table string) int {
+	t.Helper()
+	var n int
+	if err := store.GetDB().QueryRow(`SELECT COUNT(*) FROM ` + table).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestCancelEmail_NeverDispatched(t *testing.T) {
+	store := dbtest.New(t)
+	queuedSendFixture(t, store)
+
+	// Cancelling must not depend on cascades from transactional_sends
+	dbtest.Exec(t, store, `PRAGMA foreign_keys = OFF`)
+
+	ctx := context.WithValue(context.Background(), middleware.OrgIDKey, "org")
+	logic := NewCancelEmailLogic(ctx, &svc.ServiceContext{DB: store})
+	if _, err := logic.CancelEmail(&types.CancelEmailSendRequest{MessageId: "msg"}); err != nil {
+		t.Fatalf("CancelEmail() error = %v", err)
+	}
+
+	claimed, err := store.ClaimTransactionalQueue(ctx, db.ClaimTransactionalQueueParams{
+		LeaseUntil: time.Now().Add(time.Hour).UTC().Format(time.DateTime),
+		Now:        time.Now().Add(24 * time.Hour).UTC().Format(time.DateTime),
+		LimitCount: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 0 {
+		t.Fatalf("dispatcher claimed %d cancelled sends", len(claimed))
+	}
+
+	for _, table := range []string{"transactional_sends", "transactional_queue", "transactional_attachments", "transactional_idempotency_keys"} {
+		if n := countRows(t, store, table); n != 0 {
+			t.Errorf("%s has %d rows after cancel, want 0", table, n)
+		}
+	}
+}
+
+func TestCancelEmail_AlreadyClaimed(t *testing.T) {
+	store := dbtest.New(t)
+	queuedSendFixture(t, store)
+	dbtest.Exec(t, store, `UPDATE transactional_queue SET attempts = 1`)
+
+	ctx := context.WithValue(context.Background(), middleware.OrgIDKey, "org")
+	logic := NewCancelEmailLogic(ctx, &svc.ServiceContext{DB: store})
+	_, err := logic.CancelEmail(&types.CancelEmailSendRequest{MessageId: "msg"})
+	if ce, ok := err.(*errorx.CodeError); !ok || ce.Code != errorx.CodeBadRequest {
+		t.Fatalf("CancelEmail() error = %v, want a bad request", err)
+	}
+
+	if _, err := store.GetTransactionalSend(ctx, "send"); err == sql.ErrNoRows {
+		t.Error("claimed send was deleted")
+	}
+	if n := countRows(t, store, "transactional_queue"); n != 1 {
+		t.Errorf("transactional_queue has %d rows, want 1", n)
+	}
+}
@@ -45,9 +45,13 @@ func (l *GetEmailStatusLogic) GetEmailStatus(req *types.GetEmailStatusRequest) (
 		return nil, err
 	}
 
-	status := "queued"
-	if send.Status.Valid {
-		status = send.Status.String
+	status := sendStatus(send.Status)
+	scheduledAt, scheduled := "", false
+	if status == "queued" {
+		scheduledAt, scheduled = scheduledFor(l.ctx, l.svcCtx, send.ID)
+		if scheduled {
+			status = "scheduled"
+		}
 	}
 
 	opens := 0
@@ -60,12 +64,13 @@ func (l *GetEmailStatusLogic) GetEmailStatus(req *types.GetEmailStatusRequest) (
 	}
 
 	resp = &types.EmailStatusResponse{
-		MessageId: req.MessageId,
-		To:        send.ToEmail,
-		Subject:   send.Subject,
-		Status:    status,
-		Opens:     opens,
-		Clicks:    clicks,
+		MessageId:   req.MessageId,
+		To:          send.ToEmail,
+		Subject:     send.Subject,
+		Status:      status,
+		ScheduledAt: scheduledAt,
+		Opens:       opens,
+		Clicks:      clicks,
 	}
 
 	if send.SentAt.Valid {
@@ -86,6 +91,23 @@ func (l *GetEmailStatusLogic) GetEmailStatus(req *types.GetEmailStatusRequest) (
 		resp.BounceType = send.ErrorMessage.String
 	}
 
+	attachments, err := l.svcCtx.DB.ListTransactionalAttachmentsBySend(l.ctx, send.ID)
+	if err != nil {
+		l.Errorf("Failed to list attachments for send %s: %v", send.ID, err)
+		return nil, err
+	}
+	resp.Attachments = make([]types.EmailAttachmentInfo, 0, len(attachments))
+	for _, a := range attachments {
+		resp.Attachments = append(resp.Attachments, types.EmailAttachmentInfo{
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Size:        a.Size,
+			ContentId:   a.ContentID.String,
+			Stored:      a.ExpiresAt.Valid,
+			ExpiresAt:   a.ExpiresAt.String,
+		})
+	}
+
 	l.Infof("GetEmailStatus: org=%s messageId=%s status=%s", orgID, req.MessageId, status)
 
 	return resp, nil
@@ -69,14 +69,35 @@ func (l *ListEmailEventsLogic) ListEmailEvents(req *types.ListEmailEventsRequest
 		})
 	}
 
-	if send.OpenedAt.Valid {
+	// Every open and click is recorded; sends tracked before that only have
+	// the first of each
+	tracked, err := l.svcCtx.DB.ListTrackingEventsBySend(l.ctx, db.ListTrackingEventsBySendParams{
+		SendType: "transactional",
+		SendID:   send.ID,
+	})
+	if err != nil {
+		l.Errorf("Failed to list tracking events: %v", err)
+		return nil, err
+	}
+	opened, clicked := false, false
+	for _, event := range tracked {
+		events = append(events, types.EmailEventInfo{
+			Event:     event.Event,
+			Timestamp: event.CreatedAt.String,
+			Data:      event.LinkUrl.String,
+		})
+		opened = opened || event.Event == "opened"
+		clicked = clicked || event.Event == "clicked"
+	}
+
+	if send.OpenedAt.Valid && !opened {
 		events = append(events, types.EmailEventInfo{
 			Event:     "opened",
 			Timestamp: send.OpenedAt.String,
 		})
 	}
 
-	if send.ClickedAt.Valid {
+	if send.ClickedAt.Valid && !clicked {
 		events = append(events, types.EmailEventInfo{
 			Event:     "clicked",
 			Timestamp: send.ClickedAt.String,
@@ -108,7 +129,7 @@ func (l *ListEmailEventsLogic) ListEmailEvents(req *types.ListEmailEventsRequest
 	}
 
 	// Sort events by timestamp (oldest first)
-	sort.Slice(events, func(i, j int) bool {
+	sort.SliceStable(events, func(i, j int) bool {
 		return events[i].Timestamp < events[j].Timestamp
 	})
 
@@ -0,0 +1,138 @@
+package emails
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// maxBatchRecipients caps the recipients of a batch send
+const maxBatchRecipients = 500
+
+// maxBatchIdempotencyKeyLength leaves room for the /<index> suffix each
+// recipient's key gets
+const maxBatchIdempotencyKeyLength = maxIdempotencyKeyLength - len("/499")
+
+type SendBatchEmailLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSendBatchEmailLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SendBatchEmailLogic {
+	return &SendBatchEmailLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// SendBatchEmail queues the same email for many recipients, each with its
+// own variables, send record and message ID. A failed recipient does not
+// stop the rest of the batch.
+func (l *SendBatchEmailLogic) SendBatchEmail(req *types.SendBatchEmailRequest) (resp *types.SendBatchEmailResponse, err error) {
+	org, ok := l.ctx.Value(middleware.OrgKey).(db.Organization)
+	if !ok {
+		return nil, errorx.NewUnauthorizedError("Organization not found")
+	}
+
+	if len(req.Recipients) == 0 {
+		return nil, errorx.NewBadRequestError("At least one recipient is required")
+	}
+	if len(req.Recipients) > maxBatchRecipients {
+		return nil, errorx.NewBadRequestError(fmt.Sprintf("A batch is limited to %d recipients", maxBatchRecipients))
+	}
+
+	if len(req.IdempotencyKey) > maxBatchIdempotencyKeyLength {
+		return nil, errorx.NewBadRequestError(fmt.Sprintf("Idempotency-Key is longer than %d characters", maxBatchIdempotencyKeyLength))
+	}
+
+	sendAt, err := parseSendAt(req.SendAt, time.Now())
+	if err != nil {
+		return nil, errorx.NewBadRequestError(err.Error())
+	}
+
+	// Content and sender are resolved once for the whole batch
+	sender := NewSendEmailLogic(l.ctx, l.svcCtx)
+	content, err := sender.resolveContent(org.ID, req.TemplateSlug, req.Subject, req.Body, req.TextBody)
+	if err != nil {
+		return nil, errorx.NewBadRequestError(err.Error())
+	}
+	fromName, fromEmail := sender.orgSender(org.ID)
+
+	resp = &types.SendBatchEmailResponse{
+		Results: make([]types.BatchEmailResult, 0, len(req.Recipients)),
+	}
+	for i, recipient := range req.Recipients {
+		var result *types.SendEmailResponse
+		if recipient.To == "" {
+			result = failedSend("Recipient email (to) is required")
+		} else {
+			var key string
+			if req.IdempotencyKey != "" {
+				key = req.IdempotencyKey + "/" + strconv.Itoa(i)
+			}
+			result = l.sendRecipient(sender, org.ID, outgoingSend{
+				To:             recipient.To,
+				Content:        content.render(recipient.Variables),
+				ContextData:    sendContextData(req.Tags, mergeMeta(req.Meta, recipient.Meta)),
+				FromName:       fromName,
+				FromEmail:      fromEmail,
+				SendAt:         sendAt,
+				IdempotencyKey: key,
+			})
+		}
+
+		if result.Success {
+			resp.Queued++
+		} else {
+			resp.Failed++
+		}
+		resp.Results = append(resp.Results, types.BatchEmailResult{
+			To:        recipient.To,
+			MessageId: result.MessageId,
+			Status:    result.Status,
+			Message:   result.Message,
+		})
+	}
+	resp.Success = resp.Failed == 0
+
+	l.Infof("SendBatchEmail: org=%s recipients=%d queued=%d failed=%d", org.ID, len(req.Recipients), resp.Queued, resp.Failed)
+
+	return resp, nil
+}
+
+// sendRecipient queues one recipient, returning the original send when the
+// recipient's idempotency key was already used
+func (l *SendBatchEmailLogic) sendRecipient(sender *SendEmailLogic, orgID string, out outgoingSend) *types.SendEmailResponse {
+	if out.IdempotencyKey != "" {
+		if resp, ok := sender.existingSend(orgID, out.IdempotencyKey); ok {
+			return resp
+		}
+	}
+	return sender.queueSend(orgID, out)
+}
+
+// mergeMeta overlays a recipient's metadata on the batch metadata
+func mergeMeta(batch, recipient map[string]string) map[string]string {
+	if len(recipient) == 0 {
+		return batch
+	}
+	merged := make(map[string]string, len(batch)+len(recipient))
+	for k, v := range batch {
+		merged[k] = v
+	}
+	for k, v := range recipient {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,184 @@
+package emails
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/db/dbtest"
+	"github.com/outlet-sh/outlet/internal/types"
+)
+
+// newBatchLogic returns batch send logic sharing newSendLogic's org and database
+func newBatchLogic(t *testing.T) (*SendBatchEmailLogic, *db.Store) {
+	t.Helper()
+	sender, store := newSendLogic(t)
+	return NewSendBatchEmailLogic(sender.ctx, sender.svcCtx), store
+}
+
+func batchRequest(key string, to ...string) *types.SendBatchEmailRequest {
+	req := &types.SendBatchEmailRequest{
+		Subject:        "Hi {{name}}",
+		Body:           "<p>Hello {{name}}</p>",
+		IdempotencyKey: key,
+	}
+	for _, addr := range to {
+		req.Recipients = append(req.Recipients, types.BatchEmailRecipient{To: addr})
+	}
+	return req
+}
+
+func TestSendBatchEmail_PartialFailure(t *testing.T) {
+	logic, store := newBatchLogic(t)
+	dbtest.Exec(t, store, `CREATE TRIGGER bob_down BEFORE INSERT ON transactional_queue WHEN NEW.to_email = 'bob@example.com' BEGIN SELECT RAISE(ABORT, 'queue unavailable'); END`)
+
+	resp, err := logic.SendBatchEmail(batchRequest("", "ann@example.com", "", "bob@example.com", "cy@example.com"))
+	if err != nil {
+		t.Fatalf("SendBatchEmail() error = %v", err)
+	}
+
+	if resp.Success || resp.Queued != 2 || resp.Failed != 2 {
+		t.Errorf("SendBatchEmail() = success %v, %d queued, %d failed, want 2 and 2", resp.Success, resp.Queued, resp.Failed)
+	}
+	wantStatus := []string{"queued", "failed", "failed", "queued"}
+	if len(resp.Results) != len(wantStatus) {
+		t.Fatalf("got %d results, want %d", len(resp.Results), len(wantStatus))
+	}
+	for i, want := range wantStatus {
+		if got := resp.Results[i].Status; got != want {
+			t.Errorf("result %d (%s) status = %q, want %q", i, resp.Results[i].To, got, want)
+		}
+	}
+
+	// Failed recipients leave nothing behind; the rest of the batch is queued
+	if n := countRows(t, store, "transactional_sends"); n != 2 {
+		t.Errorf("transactional_sends has %d rows, want 2", n)
+	}
+	if n := countRows(t, store, "transactional_queue"); n != 2 {
+		t.Errorf("transactional_queue has %d rows, want 2", n)
+	}
+}
+
+func TestSendBatchEmail_PerRecipientIdempotencyKeys(t *testing.T) {
+	logic, store := newBatchLogic(t)
+	dbtest.Exec(t, store, `CREATE TRIGGER bob_down BEFORE INSERT ON transactional_queue WHEN NEW.to_email = 'bob@example.com' BEGIN SELECT RAISE(ABORT, 'queue unavailable'); END`)
+
+	first, err := logic.SendBatchEmail(batchRequest("batch-1", "ann@example.com", "bob@example.com"))
+	if err != nil || first.Queued != 1 || first.Failed != 1 {
+		t.Fatalf("SendBatchEmail() = %+v, %v, want bob to fail", first, err)
+	}
+
+	var keys []string
+	rows, err := store.GetDB().Query(`SELECT idempotency_key FROM transactional_idempotency_keys ORDER BY idempotency_key`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) != 1 || keys[0] != "batch-1/0" {
+		t.Errorf("idempotency keys = %v, want only ann's batch-1/0", keys)
+	}
+
+	// The retry replays ann's send and queues bob's
+	dbtest.Exec(t, store, `DROP TRIGGER bob_down`)
+	retry, err := logic.SendBatchEmail(batchRequest("batch-1", "ann@example.com", "bob@example.com"))
+	if err != nil || !retry.Success || retry.Queued != 2 {
+		t.Fatalf("retried SendBatchEmail() = %+v, %v", retry, err)
+	}
+	if retry.Results[0].MessageId != first.Results[0].MessageId {
+		t.Errorf("ann's retried message ID = %s, want the original %s", retry.Results[0].MessageId, first.Results[0].MessageId)
+	}
+	if n := countRows(t, store, "transactional_sends"); n != 2 {
+		t.Errorf("transactional_sends has %d rows, want 2", n)
+	}
+
+	// Replaying the whole batch creates nothing new
+	replay, err := logic.SendBatchEmail(batchRequest("batch-1", "ann@example.com", "bob@example.com"))
+	if err != nil || !replay.Success {
+		t.Fatalf("replayed SendBatchEmail() = %+v, %v", replay, err)
+	}
+	for i := range replay.Results {
+		if replay.Results[i].MessageId != retry.Results[i].MessageId {
+			t.Errorf("replayed result %d message ID = %s, want %s", i, replay.Results[i].MessageId, retry.Results[i].MessageId)
+		}
+	}
+	if n := countRows(t, store, "transactional_queue"); n != 2 {
+		t.Errorf("transactional_queue has %d rows, want 2", n)
+	}
+}
+
+func TestSendBatchEmail_SendAt(t *testing.T) {
+	tests := []struct {
+		name       string
+		sendAt     time.Time
+		wantStatus string
+		wantDue    bool
+	}{
+		{"past", time.Now().Add(-time.Hour), "queued", true},
+		{"future", time.Now().Add(2 * time.Hour), "scheduled", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logic, store := newBatchLogic(t)
+			req := batchRequest("", "ann@example.com")
+			req.SendAt = tt.sendAt.Format(time.RFC3339)
+
+			resp, err := logic.SendBatchEmail(req)
+			if err != nil || !resp.Success {
+				t.Fatalf("SendBatchEmail() = %+v, %v", resp, err)
+			}
+			if got := resp.Results[0].Status; got != tt.wantStatus {
+				t.Errorf("status = %q, want %q", got, tt.wantStatus)
+			}
+
+			now := time.Now().UTC()
+			claimed, err := store.ClaimTransactionalQueue(context.Background(), db.ClaimTransactionalQueueParams{
+				LeaseUntil: now.Add(time.Minute).Format(time.DateTime),
+				Now:        now.Format(time.DateTime),
+				LimitCount: 10,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if due := len(claimed) == 1; due != tt.wantDue {
+				t.Errorf("claimable now = %v, want %v", due, tt.wantDue)
+			}
+		})
+	}
+}
+
+func TestParseSendAt(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"", time.Time{}, false},
+		{"2026-03-10T11:00:00Z", time.Time{}, false},
+		{"2026-03-10T12:00:00Z", time.Time{}, false},
+		{"2026-03-10T14:30:00+01:00", time.Date(2026, 3, 10, 13, 30, 0, 0, time.UTC), false},
+		{"2026-04-20T12:00:00Z", time.Time{}, true},
+		{"tomorrow", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSendAt(tt.value, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSendAt(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseSendAt(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -4,12 +4,17 @@ import (
 	"context"
 	"crypto/rand"
 	"database/sql"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/outlet-sh/outlet/internal/db"
 	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/email"
 	"github.com/outlet-sh/outlet/internal/svc"
 	"github.com/outlet-sh/outlet/internal/types"
 
@@ -35,203 +40,368 @@ func (l *SendEmailLogic) SendEmail(req *types.SendEmailRequest) (resp *types.Sen
 	// Get org from context (set by API key middleware)
 	org, ok := l.ctx.Value(middleware.OrgKey).(db.Organization)
 	if !ok {
-		return &types.SendEmailResponse{
-			Success: false,
-			Status:  "failed",
-			Message: "Organization not found in context",
-		}, nil
+		return failedSend("Organization not found in context"), nil
 	}
 
 	// Validate required fields
 	if req.To == "" {
-		return &types.SendEmailResponse{
-			Success: false,
-			Status:  "failed",
-			Message: "Recipient email (to) is required",
-		}, nil
+		return failedSend("Recipient email (to) is required"), nil
 	}
 
-	var subject, htmlBody, plainText string
-	var templateID string
+	if len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+		return failedSend(fmt.Sprintf("Idempotency-Key is longer than %d characters", maxIdempotencyKeyLength)), nil
+	}
+
+	// A retried request returns the send created by the first one
+	if req.IdempotencyKey != "" {
+		if resp, ok := l.existingSend(org.ID, req.IdempotencyKey); ok {
+			return resp, nil
+		}
+	}
+
+	sendAt, err := parseSendAt(req.SendAt, time.Now())
+	if err != nil {
+		return failedSend(err.Error()), nil
+	}
+
+	content, err := l.resolveContent(org.ID, req.TemplateSlug, req.Subject, req.Body, req.TextBody)
+	if err != nil {
+		return failedSend(err.Error()), nil
+	}
+
+	attachments, err := decodeAttachments(req.Attachments)
+	if err != nil {
+		return failedSend(err.Error()), nil
+	}
 
-	if req.TemplateSlug != "" {
+	fromName, fromEmail := l.orgSender(org.ID)
+
+	return l.queueSend(org.ID, outgoingSend{
+		To:             req.To,
+		Content:        content.render(req.Variables),
+		Attachments:    attachments,
+		ContextData:    sendContextData(req.Tags, req.Meta),
+		FromName:       fromName,
+		FromEmail:      fromEmail,
+		SendAt:         sendAt,
+		IdempotencyKey: req.IdempotencyKey,
+	}), nil
+}
+
+// emailContent is the subject and bodies of a send
+type emailContent struct {
+	templateID string
+	templated  bool // Variables are applied
+	subject    string
+	htmlBody   string
+	plainText  string
+}
+
+// render applies template variables to the content
+func (c emailContent) render(variables map[string]string) emailContent {
+	if !c.templated {
+		return c
+	}
+	for key, value := range variables {
+		placeholder := "{{" + key + "}}"
+		c.subject = strings.ReplaceAll(c.subject, placeholder, value)
+		c.htmlBody = strings.ReplaceAll(c.htmlBody, placeholder, value)
+		if c.plainText != "" {
+			c.plainText = strings.ReplaceAll(c.plainText, placeholder, value)
+		}
+	}
+	return c
+}
+
+// resolveContent loads the template by slug, falling back to the direct
+// content when it is missing or inactive. Sends without a template are
+// recorded against the org's ad-hoc template.
+func (l *SendEmailLogic) resolveContent(orgID, templateSlug, subject, body, textBody string) (emailContent, error) {
+	var content emailContent
+
+	if templateSlug != "" {
 		// Load template by slug
 		template, err := l.svcCtx.DB.GetTransactionalEmailBySlug(l.ctx, db.GetTransactionalEmailBySlugParams{
-			Slug:  req.TemplateSlug,
-			OrgID: org.ID,
+			Slug:  templateSlug,
+			OrgID: orgID,
 		})
-		if err != nil {
-			// Template not found - fall back to direct body if provided
-			l.Infof("Template '%s' not found, falling back to direct body", req.TemplateSlug)
-			if req.Subject != "" && req.Body != "" {
-				subject = req.Subject
-				htmlBody = req.Body
-				plainText = req.TextBody
+		if err != nil || template.IsActive.Int64 != 1 {
+			// Template missing or inactive - fall back to direct body
+			if err != nil {
+				l.Infof("Template '%s' not found, falling back to direct body", templateSlug)
 			} else {
-				// No direct body provided either - log warning and use empty content
-				l.Infof("Warning: Template '%s' not found and no direct body provided", req.TemplateSlug)
-				subject = req.Subject
-				htmlBody = req.Body
-				if subject == "" {
-					subject = "(No Subject)"
-				}
-				if htmlBody == "" {
-					htmlBody = "<p>Email content not available</p>"
-				}
+				l.Infof("Template '%s' is inactive, falling back to direct body", templateSlug)
 			}
-		} else if template.IsActive.Int64 != 1 {
-			// Template exists but is inactive - fall back to direct body
-			l.Infof("Template '%s' is inactive, falling back to direct body", req.TemplateSlug)
-			if req.Subject != "" && req.Body != "" {
-				subject = req.Subject
-				htmlBody = req.Body
-				plainText = req.TextBody
+			content.subject = subject
+			content.htmlBody = body
+			if subject != "" && body != "" {
+				content.plainText = textBody
 			} else {
-				subject = req.Subject
-				htmlBody = req.Body
-				if subject == "" {
-					subject = "(No Subject)"
+				l.Infof("Warning: Template '%s' unavailable and no direct body provided", templateSlug)
+				if content.subject == "" {
+					content.subject = "(No Subject)"
 				}
-				if htmlBody == "" {
-					htmlBody = "<p>Email content not available</p>"
+				if content.htmlBody == "" {
+					content.htmlBody = "<p>Email content not available</p>"
 				}
 			}
 		} else {
-			templateID = template.ID
-			subject = template.Subject
-			htmlBody = template.HtmlBody
+			content.templateID = template.ID
+			content.templated = true
+			content.subject = template.Subject
+			content.htmlBody = template.HtmlBody
 			if template.PlainText.Valid {
-				plainText = template.PlainText.String
-			}
-
-			// Apply variable substitution if provided
-			if len(req.Variables) > 0 {
-				for key, value := range req.Variables {
-					placeholder := "{{" + key + "}}"
-					subject = strings.ReplaceAll(subject, placeholder, value)
-					htmlBody = strings.ReplaceAll(htmlBody, placeholder, value)
-					if plainText != "" {
-						plainText = strings.ReplaceAll(plainText, placeholder, value)
-					}
-				}
+				content.plainText = template.PlainText.String
 			}
 		}
 	} else {
 		// Use direct body
-		if req.Subject == "" {
-			return &types.SendEmailResponse{
-				Success: false,
-				Status:  "failed",
-				Message: "Subject is required when not using a template",
-			}, nil
+		if subject == "" {
+			return content, errors.New("Subject is required when not using a template")
 		}
-		if req.Body == "" {
-			return &types.SendEmailResponse{
-				Success: false,
-				Status:  "failed",
-				Message: "Body is required when not using a template",
-			}, nil
+		if body == "" {
+			return content, errors.New("Body is required when not using a template")
 		}
-		subject = req.Subject
-		htmlBody = req.Body
-		plainText = req.TextBody
+		content.subject = subject
+		content.htmlBody = body
+		content.plainText = textBody
 	}
 
-	trackingToken := generateTrackingToken()
-
-	var contextData sql.NullString
-	if len(req.Meta) > 0 || len(req.Tags) > 0 {
-		contextMap := make(map[string]interface{})
-		if len(req.Meta) > 0 {
-			contextMap["meta"] = req.Meta
-		}
-		if len(req.Tags) > 0 {
-			contextMap["tags"] = req.Tags
-		}
-		jsonBytes, _ := json.Marshal(contextMap)
-		contextData = sql.NullString{String: string(jsonBytes), Valid: true}
-	}
-
-	if templateID == "" {
+	if content.templateID == "" {
 		// For ad-hoc sends without a template, we need at least one template to exist
 		// Create or get a default "adhoc" template for the org
-		adhocTemplate, err := l.getOrCreateAdhocTemplate(org.ID)
+		adhocTemplate, err := l.getOrCreateAdhocTemplate(orgID)
 		if err != nil {
 			l.Errorf("Failed to get adhoc template: %v", err)
-			return &types.SendEmailResponse{
-				Success: false,
-				Status:  "failed",
-				Message: "Failed to prepare email for sending",
-			}, nil
+			return content, errors.New("Failed to prepare email for sending")
 		}
-		templateID = adhocTemplate.ID
-	}
-
-	send, err := l.svcCtx.DB.CreateTransactionalSend(l.ctx, db.CreateTransactionalSendParams{
-		ID:            uuid.New().String(),
-		TemplateID:    templateID,
-		OrgID:         org.ID,
-		ToEmail:       req.To,
-		ToName:        sql.NullString{},
-		ContactID:     sql.NullString{},
-		Status:        sql.NullString{String: "pending", Valid: true},
-		TrackingToken: sql.NullString{String: trackingToken, Valid: true},
-		ContextData:   contextData,
-	})
-	if err != nil {
-		l.Errorf("Failed to create send record: %v", err)
-		return &types.SendEmailResponse{
-			Success: false,
-			Status:  "failed",
-			Message: "Failed to queue email for sending",
-		}, nil
-	}
-
-	// Get org email settings for sending from org's configured address
-	orgSettings, _ := l.svcCtx.DB.GetOrgEmailSettings(l.ctx, org.ID)
-	fromEmail := ""
-	fromName := ""
+		content.templateID = adhocTemplate.ID
+	}
+
+	return content, nil
+}
+
+// orgSender returns the org's configured from name and address
+func (l *SendEmailLogic) orgSender(orgID string) (fromName, fromEmail string) {
+	orgSettings, _ := l.svcCtx.DB.GetOrgEmailSettings(l.ctx, orgID)
 	if orgSettings.FromEmail.Valid {
 		fromEmail = orgSettings.FromEmail.String
 	}
 	if orgSettings.FromName.Valid {
 		fromName = orgSettings.FromName.String
 	}
+	return fromName, fromEmail
+}
 
-	// Actually send the email via the email service
-	sendErr := l.svcCtx.EmailService.SendEmailFrom(l.ctx, fromEmail, fromName, req.To, subject, htmlBody)
+// outgoingSend is a single recipient's email ready to be queued
+type outgoingSend struct {
+	To             string
+	Content        emailContent
+	Attachments    []email.Attachment
+	ContextData    sql.NullString
+	FromName       string
+	FromEmail      string
+	SendAt         time.Time // Zero sends now
+	IdempotencyKey string
+}
+
+// queueSend records the send and hands it to the dispatcher's send queue
+func (l *SendEmailLogic) queueSend(orgID string, out outgoingSend) *types.SendEmailResponse {
+	trackingToken := generateTrackingToken()
 
-	if sendErr != nil {
-		// Update status to failed
-		_ = l.svcCtx.DB.UpdateTransactionalSendStatus(l.ctx, db.UpdateTransactionalSendStatusParams{
-			ID:           send.ID,
-			Status:       sql.NullString{String: "failed", Valid: true},
-			ErrorMessage: sql.NullString{String: sendErr.Error(), Valid: true},
+	// The send, its idempotency key and its queue entry are recorded
+	// together, so a concurrent retry either sees this send or creates none,
+	// and a key is never bound to a send that was not queued
+	err := l.svcCtx.DB.ExecTx(l.ctx, func(q *db.Queries) error {
+		send, err := q.CreateTransactionalSend(l.ctx, db.CreateTransactionalSendParams{
+			ID:            uuid.New().String(),
+			TemplateID:    out.Content.templateID,
+			OrgID:         orgID,
+			ToEmail:       out.To,
+			ToName:        sql.NullString{},
+			ContactID:     sql.NullString{},
+			Status:        sql.NullString{String: "pending", Valid: true},
+			TrackingToken: sql.NullString{String: trackingToken, Valid: true},
+			ContextData:   out.ContextData,
 		})
+		if err != nil {
+			return err
+		}
 
-		l.Errorf("Failed to send email: %v", sendErr)
-		return &types.SendEmailResponse{
-			Success:   false,
-			MessageId: trackingToken,
-			Status:    "failed",
-			Message:   "Failed to send email",
-		}, nil
-	}
+		if out.IdempotencyKey != "" {
+			created, err := q.CreateTransactionalIdempotencyKey(l.ctx, db.CreateTransactionalIdempotencyKeyParams{
+				OrgID:          orgID,
+				IdempotencyKey: out.IdempotencyKey,
+				SendID:         send.ID,
+			})
+			if err != nil {
+				return err
+			}
+			if created == 0 {
+				return errIdempotencyKeyUsed
+			}
+		}
 
-	// Update status to sent
-	_ = l.svcCtx.DB.UpdateTransactionalSendStatus(l.ctx, db.UpdateTransactionalSendStatusParams{
-		ID:           send.ID,
-		Status:       sql.NullString{String: "sent", Valid: true},
-		ErrorMessage: sql.NullString{},
+		return l.svcCtx.EmailService.EnqueueTransactional(l.ctx, q, send.ID, email.Message{
+			FromName:    out.FromName,
+			FromEmail:   out.FromEmail,
+			To:          out.To,
+			Subject:     out.Content.subject,
+			HTMLBody:    l.svcCtx.EmailService.AddTracking(out.Content.htmlBody, trackingToken),
+			TextBody:    out.Content.plainText,
+			Attachments: out.Attachments,
+			OrgID:       orgID,
+		}, out.SendAt)
 	})
+	if errors.Is(err, errIdempotencyKeyUsed) {
+		if resp, ok := l.existingSend(orgID, out.IdempotencyKey); ok {
+			return resp
+		}
+	}
+	if err != nil {
+		l.Errorf("Failed to queue email: %v", err)
+		return failedSend("Failed to queue email for sending")
+	}
+	l.svcCtx.EmailService.WakeTransactional()
 
-	l.Infof("SendEmail: org=%s to=%s messageId=%s status=sent", org.ID, req.To, trackingToken)
+	status := "queued"
+	if !out.SendAt.IsZero() {
+		status = "scheduled"
+	}
+	l.Infof("SendEmail: org=%s to=%s messageId=%s status=%s", orgID, out.To, trackingToken, status)
 
 	return &types.SendEmailResponse{
 		Success:   true,
 		MessageId: trackingToken,
-		Status:    "sent",
-	}, nil
+		Status:    status,
+	}
+}
+
+// failedSend is the response for a send that was not queued
+func failedSend(message string) *types.SendEmailResponse {
+	return &types.SendEmailResponse{
+		Success: false,
+		Status:  "failed",
+		Message: message,
+	}
+}
+
+// sendContextData records tags and metadata on the send
+func sendContextData(tags []string, meta map[string]string) sql.NullString {
+	if len(meta) == 0 && len(tags) == 0 {
+		return sql.NullString{}
+	}
+	contextMap := make(map[string]interface{})
+	if len(meta) > 0 {
+		contextMap["meta"] = meta
+	}
+	if len(tags) > 0 {
+		contextMap["tags"] = tags
+	}
+	jsonBytes, _ := json.Marshal(contextMap)
+	return sql.NullString{String: string(jsonBytes), Valid: true}
+}
+
+// maxScheduleAhead caps how far ahead send_at may be
+const maxScheduleAhead = 30 * 24 * time.Hour
+
+// parseSendAt parses a requested delivery time. Empty and past times send
+// now and return the zero time.
+func parseSendAt(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	sendAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.New("send_at must be an RFC 3339 timestamp")
+	}
+	if !sendAt.After(now) {
+		return time.Time{}, nil
+	}
+	if sendAt.Sub(now) > maxScheduleAhead {
+		return time.Time{}, fmt.Errorf("send_at must be within %d days", int(maxScheduleAhead.Hours()/24))
+	}
+	return sendAt.UTC(), nil
+}
+
+// maxIdempotencyKeyLength caps the Idempotency-Key header
+const maxIdempotencyKeyLength = 255
+
+// errIdempotencyKeyUsed reports that a concurrent request claimed the key
+var errIdempotencyKeyUsed = errors.New("idempotency key already used")
+
+// existingSend returns the response for a send already created with the
+// idempotency key
+func (l *SendEmailLogic) existingSend(orgID, key string) (*types.SendEmailResponse, bool) {
+	send, err := l.svcCtx.DB.GetTransactionalSendByIdempotencyKey(l.ctx, db.GetTransactionalSendByIdempotencyKeyParams{
+		OrgID:          orgID,
+		IdempotencyKey: key,
+	})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			l.Errorf("Failed to look up idempotency key: %v", err)
+		}
+		return nil, false
+	}
+
+	status := sendStatus(send.Status)
+	if _, ok := scheduledFor(l.ctx, l.svcCtx, send.ID); ok && status == "queued" {
+		status = "scheduled"
+	}
+	l.Infof("SendEmail: org=%s messageId=%s replayed for idempotency key", orgID, send.TrackingToken.String)
+
+	return &types.SendEmailResponse{
+		Success:   status != "failed",
+		MessageId: send.TrackingToken.String,
+		Status:    status,
+	}, true
+}
+
+// sendStatus reports a send's status as the API names it; pending sends
+// are queued
+func sendStatus(status sql.NullString) string {
+	if !status.Valid || status.String == "pending" {
+		return "queued"
+	}
+	return status.String
+}
+
+// scheduledFor returns the send_at of a queued send that is not due yet
+func scheduledFor(ctx context.Context, svcCtx *svc.ServiceContext, sendID string) (string, bool) {
+	item, err := svcCtx.DB.GetTransactionalQueueItem(ctx, sendID)
+	if err != nil || !item.SendAt.Valid {
+		return "", false
+	}
+	if item.SendAt.String <= time.Now().UTC().Format(time.DateTime) {
+		return "", false
+	}
+	return item.SendAt.String, true
+}
+
+// decodeAttachments decodes base64 attachments and checks them against the
+// size and content type limits
+func decodeAttachments(in []types.EmailAttachment) ([]email.Attachment, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	attachments := make([]email.Attachment, 0, len(in))
+	for _, a := range in {
+		if a.Filename == "" {
+			return nil, fmt.Errorf("attachment filename is required")
+		}
+		data, err := base64.StdEncoding.DecodeString(a.Content)
+		if err != nil {
+			return nil, fmt.Errorf("attachment %s is not valid base64", a.Filename)
+		}
+		attachments = append(attachments, email.Attachment{
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Data:        data,
+			ContentID:   a.ContentId,
+		})
+	}
+	return email.NormalizeAttachments(attachments)
 }
 
 // generateTrackingToken creates a unique tracking token for the email
@@ -0,0 +1,121 @@
+package emails
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/db/dbtest"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/email"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+)
+
+// newSendLogic returns send logic for an org in a fresh database
+func newSendLogic(t *testing.T) (*SendEmailLogic, *db.Store) {
+	t.Helper()
+	store := dbtest.New(t)
+	dbtest.Exec(t, store, `INSERT INTO organizations (id, name, slug, api_key) VALUES ('org', 'Org', 'org', 'key')`)
+
+	ctx := context.WithValue(context.Background(), middleware.OrgKey, db.Organization{ID: "org"})
+	svcCtx := &svc.ServiceContext{DB: store, EmailService: email.NewService(store, nil)}
+	return NewSendEmailLogic(ctx, svcCtx), store
+}
+
+func sendRequest(key, body string) *types.SendEmailRequest {
+	return &types.SendEmailRequest{
+		To:             "ann@example.com",
+		Subject:        "Receipt",
+		Body:           body,
+		IdempotencyKey: key,
+	}
+}
+
+func TestSendEmail_IdempotentReplay(t *testing.T) {
+	logic, store := newSendLogic(t)
+
+	first, err := logic.SendEmail(sendRequest("order-1", "<p>Thanks</p>"))
+	if err != nil || !first.Success || first.Status != "queued" {
+		t.Fatalf("SendEmail() = %+v, %v", first, err)
+	}
+
+	retry, err := logic.SendEmail(sendRequest("order-1", "<p>Thanks</p>"))
+	if err != nil || !retry.Success || retry.MessageId != first.MessageId || retry.Status != "queued" {
+		t.Fatalf("retried SendEmail() = %+v, want a replay of %s", retry, first.MessageId)
+	}
+
+	if n := countRows(t, store, "transactional_sends"); n != 1 {
+		t.Errorf("transactional_sends has %d rows, want 1", n)
+	}
+	if n := countRows(t, store, "transactional_queue"); n != 1 {
+		t.Errorf("transactional_queue has %d rows, want 1", n)
+	}
+}
+
+func TestSendEmail_KeyReusedWithDifferentBody(t *testing.T) {
+	logic, store := newSendLogic(t)
+
+	first, err := logic.SendEmail(sendRequest("order-1", "<p>Thanks</p>"))
+	if err != nil || !first.Success {
+		t.Fatalf("SendEmail() = %+v, %v", first, err)
+	}
+
+	// The key identifies the send; a different body does not send again
+	reused, err := logic.SendEmail(sendRequest("order-1", "<p>Something else</p>"))
+	if err != nil || reused.MessageId != first.MessageId {
+		t.Fatalf("SendEmail() with a reused key = %+v, want a replay of %s", reused, first.MessageId)
+	}
+
+	if n := countRows(t, store, "transactional_queue"); n != 1 {
+		t.Fatalf("transactional_queue has %d rows, want 1", n)
+	}
+	item, err := store.GetTransactionalQueueItem(context.Background(), firstSendID(t, store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(item.HtmlBody, "Thanks") {
+		t.Errorf("queued body = %q, want the original body", item.HtmlBody)
+	}
+
+	// A new key is a new send
+	other, err := logic.SendEmail(sendRequest("order-2", "<p>Something else</p>"))
+	if err != nil || !other.Success || other.MessageId == first.MessageId {
+		t.Fatalf("SendEmail() with a new key = %+v, want a new send", other)
+	}
+}
+
+func TestSendEmail_QueueFailureReleasesKey(t *testing.T) {
+	logic, store := newSendLogic(t)
+
+	dbtest.Exec(t, store, `CREATE TRIGGER queue_down BEFORE INSERT ON transactional_queue BEGIN SELECT RAISE(ABORT, 'queue unavailable'); END`)
+	failed, err := logic.SendEmail(sendRequest("order-1", "<p>Thanks</p>"))
+	if err != nil || failed.Success || failed.Status != "failed" {
+		t.Fatalf("SendEmail() = %+v, %v, want a failed send", failed, err)
+	}
+	for _, table := range []string{"transactional_sends", "transactional_idempotency_keys"} {
+		if n := countRows(t, store, table); n != 0 {
+			t.Errorf("%s has %d rows after a queue failure, want 0", table, n)
+		}
+	}
+
+	// The client's retry with the same key queues the email
+	dbtest.Exec(t, store, `DROP TRIGGER queue_down`)
+	retry, err := logic.SendEmail(sendRequest("order-1", "<p>Thanks</p>"))
+	if err != nil || !retry.Success || retry.Status != "queued" {
+		t.Fatalf("retried SendEmail() = %+v, %v, want queued", retry, err)
+	}
+	if n := countRows(t, store, "transactional_queue"); n != 1 {
+		t.Errorf("transactional_queue has %d rows, want 1", n)
+	}
+}
+
+func firstSendID(t *testing.T, store *db.Store) string {
+	t.Helper()
+	var id string
+	if err := store.GetDB().QueryRow(`SELECT id FROM transactional_sends ORDER BY created_at LIMIT 1`).Scan(&id); err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
@@ -0,0 +1,87 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/errorx"
+	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/segment"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type PreviewSegmentLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewPreviewSegmentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PreviewSegmentLogic {
+	return &PreviewSegmentLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *PreviewSegmentLogic) PreviewSegment(req *types.SegmentPreviewRequest) (resp *types.SegmentPreviewResponse, err error) {
+	// Get org ID from context (set by API key middleware)
+	orgID, ok := l.ctx.Value(middleware.OrgIDKey).(string)
+	if !ok {
+		return nil, errorx.NewUnauthorizedError("Organization not found")
+	}
+
+	audience, err := segment.Targeting{
+		OrgID:          orgID,
+		ListIDs:        l.resolveLists(orgID, req.ListIds),
+		ExcludeListIDs: l.resolveLists(orgID, req.ExcludeListIds),
+		Filter:         req.SegmentFilter,
+		SegmentID:      req.SegmentId,
+	}.Build(l.ctx, l.svcCtx.DB)
+	if err != nil {
+		switch {
+		case errors.Is(err, segment.ErrInvalidFilter):
+			return nil, errorx.NewBadRequestError(err.Error())
+		case errors.Is(err, segment.ErrNoLists):
+			return nil, errorx.NewBadRequestError("At least one valid list is required")
+		case errors.Is(err, segment.ErrNotFound):
+			return nil, errorx.NewNotFoundError("Segment not found")
+		}
+		l.Errorf("Failed to build segment: %v", err)
+		return nil, errorx.NewInternalError("Failed to count segment")
+	}
+
+	count, err := segment.Count(l.ctx, l.svcCtx.DB.GetDB(), audience)
+	if err != nil {
+		l.Errorf("Failed to count segment: %v", err)
+		return nil, errorx.NewInternalError("Failed to count segment")
+	}
+
+	return &types.SegmentPreviewResponse{Count: int(count)}, nil
+}
+
+// resolveLists accepts numeric list IDs or list slugs, skipping unknown lists
+func (l *PreviewSegmentLogic) resolveLists(orgID string, refs []string) []int64 {
+	var ids []int64
+	for _, ref := range refs {
+		if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+			ids = append(ids, id)
+			continue
+		}
+		list, err := l.svcCtx.DB.GetEmailListByOrgAndSlug(l.ctx, db.GetEmailListByOrgAndSlugParams{
+			OrgID: orgID,
+			Slug:  ref,
+		})
+		if err != nil {
+			l.Infof("List not found: %s", ref)
+			continue
+		}
+		ids = append(ids, list.ID)
+	}
+	return ids
+}
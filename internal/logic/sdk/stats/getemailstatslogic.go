@@ -2,7 +2,10 @@ package stats
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
+	"github.com/outlet-sh/outlet/internal/db"
 	"github.com/outlet-sh/outlet/internal/middleware"
 	"github.com/outlet-sh/outlet/internal/svc"
 	"github.com/outlet-sh/outlet/internal/types"
@@ -32,24 +35,40 @@ func (l *GetEmailStatsLogic) GetEmailStats(req *types.GetEmailStatsRequest) (res
 		return nil, nil
 	}
 
-	_ = orgID // Mark as used
-
 	// Parse date range
 	startDate, endDate := parseDateRange(req.StartDate, req.EndDate)
+	period := engagementPeriod(orgID, startDate, endDate)
+
+	days, err := l.svcCtx.DB.ListOrgEngagementByDay(l.ctx, db.ListOrgEngagementByDayParams(period))
+	if err != nil {
+		l.Errorf("Failed to get daily engagement: %v", err)
+	}
+	engagement := make(map[string]db.ListOrgEngagementByDayRow, len(days))
+	for _, day := range days {
+		engagement[day.Day] = day
+	}
+
+	total, err := l.svcCtx.DB.GetOrgEngagement(l.ctx, period)
+	if err != nil {
+		l.Errorf("Failed to get engagement: %v", err)
+	}
 
 	// Build stats points for the date range
 	stats := make([]types.EmailStatsPoint, 0)
 	for d := startDate; d.Before(endDate); d = d.AddDate(0, 0, 1) {
 		dateStr := d.Format("2006-01-02")
+		day := engagement[dateStr]
 		stats = append(stats, types.EmailStatsPoint{
-			Date:      dateStr,
-			Sent:      0,
-			Delivered: 0,
-			Opened:    0,
-			Clicked:   0,
-			Bounced:   0,
-			OpenRate:  0,
-			ClickRate: 0,
+			Date:         dateStr,
+			Sent:         0,
+			Delivered:    0,
+			Opened:       int(day.Opened),
+			Clicked:      int(day.Clicked),
+			HumanOpened:  int(day.HumanOpened),
+			HumanClicked: int(day.HumanClicked),
+			Bounced:      0,
+			OpenRate:     0,
+			ClickRate:    0,
 		})
 	}
 
@@ -57,10 +76,21 @@ func (l *GetEmailStatsLogic) GetEmailStats(req *types.GetEmailStatsRequest) (res
 		Stats:          stats,
 		TotalSent:      0,
 		TotalDelivered: 0,
-		TotalOpened:    0,
-		TotalClicked:   0,
+		TotalOpened:    int(total.Opened),
+		TotalClicked:   int(total.Clicked),
+		HumanOpened:    int(total.HumanOpened),
+		HumanClicked:   int(total.HumanClicked),
 		TotalBounced:   0,
 		AvgOpenRate:    0,
 		AvgClickRate:   0,
 	}, nil
 }
+
+// engagementPeriod selects an org's tracking events between two dates
+func engagementPeriod(orgID string, startDate, endDate time.Time) db.GetOrgEngagementParams {
+	return db.GetOrgEngagementParams{
+		OrgID:     orgID,
+		StartDate: sql.NullString{String: startDate.Format(time.DateTime), Valid: true},
+		EndDate:   sql.NullString{String: endDate.Format(time.DateTime), Valid: true},
+	}
+}
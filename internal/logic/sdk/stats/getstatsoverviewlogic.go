@@ -33,7 +33,7 @@ func (l *GetStatsOverviewLogic) GetStatsOverview(req *types.GetStatsOverviewRequ
 		return nil, nil
 	}
 
-	_ = req // Mark as used
+	startDate, endDate := parseDateRange(req.StartDate, req.EndDate)
 
 	// Get total contact count for this org
 	totalContacts, err := l.svcCtx.DB.CountContactsByOrg(l.ctx, sql.NullString{String: orgID, Valid: true})
@@ -42,14 +42,21 @@ func (l *GetStatsOverviewLogic) GetStatsOverview(req *types.GetStatsOverviewRequ
 		totalContacts = 0
 	}
 
+	engagement, err := l.svcCtx.DB.GetOrgEngagement(l.ctx, engagementPeriod(orgID, startDate, endDate))
+	if err != nil {
+		l.Errorf("Failed to get engagement: %v", err)
+	}
+
 	return &types.StatsOverviewResponse{
 		TotalContacts:   int(totalContacts),
 		NewContacts:     0,
 		ActiveContacts:  0,
 		EmailsSent:      0,
 		EmailsDelivered: 0,
-		EmailsOpened:    0,
-		EmailsClicked:   0,
+		EmailsOpened:    int(engagement.Opened),
+		EmailsClicked:   int(engagement.Clicked),
+		HumanOpened:     int(engagement.HumanOpened),
+		HumanClicked:    int(engagement.HumanClicked),
 		EmailsBounced:   0,
 		OpenRate:        0,
 		ClickRate:       0,
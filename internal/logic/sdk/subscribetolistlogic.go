@@ -7,10 +7,14 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/events"
 	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/email"
 	"github.com/outlet-sh/outlet/internal/svc"
 	"github.com/outlet-sh/outlet/internal/types"
 
@@ -57,6 +61,12 @@ func (l *SubscribeToListLogic) SubscribeToList(req *types.SubscribeRequest) (res
 		return &types.Response{Success: false, Message: "List not found"}, nil
 	}
 
+	// Validate against the list policy (or org policy); validation errors fail open
+	validation, err := email.ValidateSignup(l.ctx, l.svcCtx.DB, orgID, list.ValidationPolicy, req.Email)
+	if err != nil {
+		l.Errorf("Failed to validate email %s: %v", req.Email, err)
+	}
+
 	// Get or create contact
 	contact, err := l.svcCtx.DB.GetContactByOrgAndEmail(l.ctx, db.GetContactByOrgAndEmailParams{
 		OrgID: sql.NullString{String: orgID, Valid: true},
@@ -78,6 +88,17 @@ func (l *SubscribeToListLogic) SubscribeToList(req *types.SubscribeRequest) (res
 		}
 	}
 
+	// Record the outcome on the contact so rejected signups are auditable
+	if validation != nil {
+		if err := email.RecordValidation(l.ctx, l.svcCtx.DB, contact.ID, validation); err != nil {
+			l.Errorf("Failed to record validation for contact %s: %v", contact.ID, err)
+		}
+		if !validation.Valid() {
+			l.Infof("Signup rejected by validation policy: email=%s list=%s reason=%s", req.Email, req.Slug, validation.Reason())
+			return &types.Response{Success: false, Message: "Email address rejected: " + validation.Reason()}, nil
+		}
+	}
+
 	// Handle double opt-in if enabled
 	if list.DoubleOptin.Valid && list.DoubleOptin.Int64 == 1 {
 		// Get org from context (cached by API key middleware)
@@ -217,6 +238,7 @@ func (l *SubscribeToListLogic) SubscribeToList(req *types.SubscribeRequest) (res
 
 			err := l.svcCtx.EmailService.SendEmailFrom(
 				context.Background(),
+				orgID,
 				fromEmail,
 				fromName,
 				toEmail,
@@ -271,6 +293,18 @@ func (l *SubscribeToListLogic) SubscribeToList(req *types.SubscribeRequest) (res
 		}
 	}
 
+	// Emit contact.subscribed event for rules engine and list_join entry rules
+	if l.svcCtx.Events != nil {
+		_ = events.Emit(l.svcCtx.Events, events.TopicContactSubscribed, events.ContactEvent{
+			OrgID:     orgID,
+			ContactID: contact.ID,
+			Email:     contact.Email,
+			ListID:    strconv.FormatInt(list.ID, 10),
+			Source:    "api",
+			Timestamp: time.Now(),
+		})
+	}
+
 	l.Infof("Subscribed: org=%s email=%s list=%s", orgID, req.Email, req.Slug)
 	return &types.Response{Success: true, Message: "Subscribed"}, nil
 }
@@ -3,6 +3,7 @@ package tracking
 import (
 	"context"
 
+	"github.com/outlet-sh/outlet/internal/services/tracking"
 	"github.com/outlet-sh/outlet/internal/svc"
 	"github.com/outlet-sh/outlet/internal/types"
 
@@ -24,7 +25,7 @@ func NewTrackClickLogic(ctx context.Context, svcCtx *svc.ServiceContext) *TrackC
 }
 
 func (l *TrackClickLogic) TrackClick(req *types.TrackClickRequest) (resp *types.Response, err error) {
-	if err := l.svcCtx.Tracking.RecordClick(l.ctx, req.Token); err != nil {
+	if err := l.svcCtx.Tracking.RecordClick(l.ctx, req.Token, tracking.Click{URL: req.Url}); err != nil {
 		return &types.Response{Success: false, Message: "invalid token"}, nil
 	}
 
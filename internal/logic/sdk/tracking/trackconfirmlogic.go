@@ -3,7 +3,10 @@ package tracking
 import (
 	"context"
 	"database/sql"
+	"strconv"
+	"time"
 
+	"github.com/outlet-sh/outlet/internal/events"
 	"github.com/outlet-sh/outlet/internal/logic/public"
 	"github.com/outlet-sh/outlet/internal/svc"
 	"github.com/outlet-sh/outlet/internal/types"
@@ -30,6 +33,21 @@ func (l *TrackConfirmLogic) TrackConfirm(req *types.TrackConfirmRequest) (resp *
 	subscriber, err := l.svcCtx.DB.ConfirmListSubscription(l.ctx, sql.NullString{String: req.Token, Valid: true})
 	if err == nil {
 		l.Infof("List subscription confirmed: subscriber_id=%s", subscriber.ID)
+
+		// Emit contact.subscribed event for rules engine and list_join entry rules
+		if l.svcCtx.Events != nil {
+			if contact, err := l.svcCtx.DB.GetContact(l.ctx, subscriber.ContactID); err == nil && contact.OrgID.Valid {
+				_ = events.Emit(l.svcCtx.Events, events.TopicContactSubscribed, events.ContactEvent{
+					OrgID:     contact.OrgID.String,
+					ContactID: contact.ID,
+					Email:     contact.Email,
+					ListID:    strconv.FormatInt(subscriber.ListID, 10),
+					Source:    "confirmation",
+					Timestamp: time.Now(),
+				})
+			}
+		}
+
 		return &types.TrackConfirmResponse{
 			Success: true,
 			Message: "Subscription confirmed",
@@ -3,8 +3,11 @@ package sdk
 import (
 	"context"
 	"database/sql"
+	"strconv"
+	"time"
 
 	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/events"
 	"github.com/outlet-sh/outlet/internal/middleware"
 	"github.com/outlet-sh/outlet/internal/svc"
 	"github.com/outlet-sh/outlet/internal/types"
@@ -72,5 +75,18 @@ func (l *UnsubscribeFromListLogic) UnsubscribeFromList(req *types.SubscribeReque
 	}
 
 	l.Infof("Unsubscribed: org=%s email=%s list=%s", orgID, req.Email, req.Slug)
+
+	// Emit contact.unsubscribed event for rules engine
+	if l.svcCtx.Events != nil {
+		_ = events.Emit(l.svcCtx.Events, events.TopicContactUnsubscribed, events.ContactEvent{
+			OrgID:     orgID,
+			ContactID: contact.ID,
+			Email:     contact.Email,
+			ListID:    strconv.FormatInt(list.ID, 10),
+			Source:    "api",
+			Timestamp: time.Now(),
+		})
+	}
+
 	return &types.Response{Success: true, Message: "Successfully unsubscribed"}, nil
 }
@@ -82,6 +82,8 @@ func NewServerWithContext(svc *svc.ServiceContext, r *http.Request, onBrandSelec
 	tools.RegisterStatsTool(server, toolCtx)
 	tools.RegisterBlocklistTool(server, toolCtx)
 	tools.RegisterGDPRTool(server, toolCtx)
+	tools.RegisterSegmentTool(server, toolCtx)
+	tools.RegisterRuleTool(server, toolCtx)
 
 	return server, toolCtx
 }
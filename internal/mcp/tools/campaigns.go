@@ -4,26 +4,28 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
 
 	"github.com/outlet-sh/outlet/internal/db"
 	"github.com/outlet-sh/outlet/internal/mcp/mcpctx"
+	"github.com/outlet-sh/outlet/internal/services/sendtime"
 
 	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // campaignActions defines valid actions for campaigns.
-var campaignActions = []string{"create", "list", "get", "update", "delete", "schedule", "send", "stats"}
+var campaignActions = []string{"create", "list", "get", "update", "delete", "schedule", "send", "stats", "pick_winner"}
 
 // CampaignInput defines input for the campaign tool.
 type CampaignInput struct {
-	Action string `json:"action" jsonschema:"required,Action to perform: create, list, get, update, delete, schedule, send, stats"`
+	Action string `json:"action" jsonschema:"required,Action to perform: create, list, get, update, delete, schedule, send, stats, pick_winner"`
 
 	// Common
-	ID string `json:"id,omitempty" jsonschema:"Campaign ID (for get, update, delete, schedule, send, stats)"`
+	ID string `json:"id,omitempty" jsonschema:"Campaign ID (for get, update, delete, schedule, send, stats, pick_winner)"`
 
 	// List filter
 	Status string `json:"status,omitempty" jsonschema:"Filter by status: draft, scheduled, sending, sent (for list)"`
@@ -41,9 +43,13 @@ type CampaignInput struct {
 	ExcludeListIDs string `json:"exclude_list_ids,omitempty" jsonschema:"Comma-separated list IDs to exclude"`
 	TrackOpens     *bool  `json:"track_opens,omitempty" jsonschema:"Track email opens (default: true)"`
 	TrackClicks    *bool  `json:"track_clicks,omitempty" jsonschema:"Track link clicks (default: true)"`
+	DeliveryMode   string `json:"delivery_mode,omitempty" jsonschema:"When each contact is sent to: immediate (default), local_time (9am in their time zone), optimal_time (the hour they open most)"`
 
 	// Schedule fields
 	ScheduledAt string `json:"scheduled_at,omitempty" jsonschema:"ISO 8601 datetime to schedule the campaign (for schedule action)"`
+
+	// A/B test fields
+	VariantID string `json:"variant_id,omitempty" jsonschema:"Winning A/B test variant ID (for pick_winner action)"`
 }
 
 // CampaignItem represents a campaign in list output.
@@ -94,6 +100,7 @@ type CampaignGetOutput struct {
 	CompletedAt     string `json:"completed_at,omitempty"`
 	TrackOpens      bool   `json:"track_opens"`
 	TrackClicks     bool   `json:"track_clicks"`
+	DeliveryMode    string `json:"delivery_mode"`
 	RecipientsCount int64  `json:"recipients_count"`
 	SentCount       int64  `json:"sent_count"`
 	DeliveredCount  int64  `json:"delivered_count"`
@@ -130,18 +137,44 @@ type CampaignSendOutput struct {
 
 // CampaignStatsOutput defines output for campaign stats.
 type CampaignStatsOutput struct {
-	ID               string  `json:"id"`
-	RecipientsCount  int64   `json:"recipients_count"`
-	SentCount        int64   `json:"sent_count"`
-	DeliveredCount   int64   `json:"delivered_count"`
-	OpenedCount      int64   `json:"opened_count"`
-	ClickedCount     int64   `json:"clicked_count"`
-	BouncedCount     int64   `json:"bounced_count"`
-	ComplainedCount  int64   `json:"complained_count"`
-	UnsubscribedCnt  int64   `json:"unsubscribed_count"`
-	OpenRate         float64 `json:"open_rate"`
-	ClickRate        float64 `json:"click_rate"`
-	BounceRate       float64 `json:"bounce_rate"`
+	ID              string                `json:"id"`
+	RecipientsCount int64                 `json:"recipients_count"`
+	SentCount       int64                 `json:"sent_count"`
+	DeliveredCount  int64                 `json:"delivered_count"`
+	OpenedCount     int64                 `json:"opened_count"`
+	ClickedCount    int64                 `json:"clicked_count"`
+	BouncedCount    int64                 `json:"bounced_count"`
+	ComplainedCount int64                 `json:"complained_count"`
+	UnsubscribedCnt int64                 `json:"unsubscribed_count"`
+	OpenRate        float64               `json:"open_rate"`
+	ClickRate       float64               `json:"click_rate"`
+	BounceRate      float64               `json:"bounce_rate"`
+	ABTest          *CampaignABTestOutput `json:"ab_test,omitempty"`
+}
+
+// CampaignABTestOutput describes a campaign's A/B test in stats output.
+type CampaignABTestOutput struct {
+	Status          string                       `json:"status"`
+	WinnerMetric    string                       `json:"winner_metric"`
+	TestPercent     int64                        `json:"test_percent"`
+	WaitHours       int64                        `json:"wait_hours"`
+	WinnerVariantID string                       `json:"winner_variant_id,omitempty"`
+	Variants        []CampaignVariantStatsOutput `json:"variants"`
+}
+
+// CampaignVariantStatsOutput defines per-variant A/B test stats. Rates are
+// of human opens and clicks, as the winner is picked.
+type CampaignVariantStatsOutput struct {
+	ID           string  `json:"id"`
+	Name         string  `json:"name"`
+	Recipients   int64   `json:"recipients"`
+	SentCount    int64   `json:"sent_count"`
+	OpenedCount  int64   `json:"opened_count"`
+	ClickedCount int64   `json:"clicked_count"`
+	HumanOpened  int64   `json:"human_opened"`
+	HumanClicked int64   `json:"human_clicked"`
+	OpenRate     float64 `json:"open_rate"`
+	ClickRate    float64 `json:"click_rate"`
 }
 
 // RegisterCampaignTool registers the campaign tool.
@@ -161,7 +194,8 @@ Actions and Required Fields:
 - delete: Delete a draft campaign (requires: id)
 - schedule: Schedule a campaign for future sending (requires: id, scheduled_at)
 - send: Send a campaign immediately (requires: id)
-- stats: Get campaign statistics (requires: id)
+- stats: Get campaign statistics, with per-variant stats for A/B tests (requires: id)
+- pick_winner: Pick the winning variant of a running A/B test; the rest of the audience is sent it (requires: id, variant_id)
 
 Status Values:
 - draft: Campaign is being composed
@@ -169,14 +203,21 @@ Status Values:
 - sending: Campaign is currently being sent
 - sent: Campaign has been sent
 
+Delivery Modes:
+- immediate: Everyone is sent to as soon as the campaign starts (default)
+- local_time: Each contact at 9am in their time zone, within 24 hours
+- optimal_time: Each contact at the hour they have opened most, within 24 hours
+
 Examples:
   campaign(action: create, name: "January Newsletter", subject: "Happy New Year!", html_body: "<h1>Hello</h1>", list_ids: "1,2")
   campaign(action: list, status: "draft")
   campaign(action: get, id: "uuid")
   campaign(action: update, id: "uuid", subject: "Updated Subject")
+  campaign(action: update, id: "uuid", delivery_mode: "local_time")
   campaign(action: schedule, id: "uuid", scheduled_at: "2024-01-15T10:00:00Z")
   campaign(action: send, id: "uuid")
-  campaign(action: stats, id: "uuid")`,
+  campaign(action: stats, id: "uuid")
+  campaign(action: pick_winner, id: "uuid", variant_id: "uuid")`,
 	}, campaignHandler(toolCtx))
 }
 
@@ -206,6 +247,8 @@ func campaignHandler(toolCtx *mcpctx.ToolContext) func(ctx context.Context, req
 			return handleCampaignSend(ctx, toolCtx, input)
 		case "stats":
 			return handleCampaignStats(ctx, toolCtx, input)
+		case "pick_winner":
+			return handleCampaignPickWinner(ctx, toolCtx, input)
 		}
 		return nil, nil, nil
 	}
@@ -228,6 +271,9 @@ func handleCampaignCreate(ctx context.Context, toolCtx *mcpctx.ToolContext, inpu
 	if strings.TrimSpace(input.ListIDs) == "" {
 		return nil, nil, mcpctx.NewValidationError("list_ids is required", "list_ids")
 	}
+	if input.DeliveryMode != "" && !sendtime.ValidMode(input.DeliveryMode) {
+		return nil, nil, mcpctx.NewValidationError("delivery_mode must be immediate, local_time or optimal_time", "delivery_mode")
+	}
 
 	trackOpens := true
 	if input.TrackOpens != nil {
@@ -255,6 +301,7 @@ func handleCampaignCreate(ctx context.Context, toolCtx *mcpctx.ToolContext, inpu
 		Status:         sql.NullString{String: "draft", Valid: true},
 		TrackOpens:     sql.NullInt64{Int64: boolToInt64(trackOpens), Valid: true},
 		TrackClicks:    sql.NullInt64{Int64: boolToInt64(trackClicks), Valid: true},
+		DeliveryMode:   input.DeliveryMode,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create campaign: %w", err)
@@ -352,6 +399,7 @@ func handleCampaignGet(ctx context.Context, toolCtx *mcpctx.ToolContext, input C
 		CompletedAt:     campaign.CompletedAt.String,
 		TrackOpens:      int64ToBool(campaign.TrackOpens),
 		TrackClicks:     int64ToBool(campaign.TrackClicks),
+		DeliveryMode:    campaign.DeliveryMode,
 		RecipientsCount: campaign.RecipientsCount.Int64,
 		SentCount:       campaign.SentCount.Int64,
 		DeliveredCount:  campaign.DeliveredCount.Int64,
@@ -383,6 +431,9 @@ func handleCampaignUpdate(ctx context.Context, toolCtx *mcpctx.ToolContext, inpu
 	if campaign.Status.String != "draft" {
 		return nil, nil, mcpctx.NewValidationError("can only update draft campaigns", "id")
 	}
+	if input.DeliveryMode != "" && !sendtime.ValidMode(input.DeliveryMode) {
+		return nil, nil, mcpctx.NewValidationError("delivery_mode must be immediate, local_time or optimal_time", "delivery_mode")
+	}
 
 	var trackOpens, trackClicks sql.NullInt64
 	if input.TrackOpens != nil {
@@ -407,6 +458,7 @@ func handleCampaignUpdate(ctx context.Context, toolCtx *mcpctx.ToolContext, inpu
 		ExcludeListIds: input.ExcludeListIDs,
 		TrackOpens:     trackOpens,
 		TrackClicks:    trackClicks,
+		DeliveryMode:   input.DeliveryMode,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to update campaign: %w", err)
@@ -541,6 +593,11 @@ func handleCampaignStats(ctx context.Context, toolCtx *mcpctx.ToolContext, input
 		return nil, nil, mcpctx.NewNotFoundError(fmt.Sprintf("campaign %s not found", input.ID))
 	}
 
+	abTest, err := campaignABTest(ctx, toolCtx, campaign.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get A/B test: %w", err)
+	}
+
 	// Calculate rates
 	var openRate, clickRate, bounceRate float64
 	if campaign.SentCount.Int64 > 0 {
@@ -562,9 +619,91 @@ func handleCampaignStats(ctx context.Context, toolCtx *mcpctx.ToolContext, input
 		OpenRate:        openRate,
 		ClickRate:       clickRate,
 		BounceRate:      bounceRate,
+		ABTest:          abTest,
+	}, nil
+}
+
+// campaignABTest returns a campaign's A/B test with per-variant stats, or nil
+// when the campaign is not a test.
+func campaignABTest(ctx context.Context, toolCtx *mcpctx.ToolContext, campaignID string) (*CampaignABTestOutput, error) {
+	test, err := toolCtx.DB().GetCampaignABTest(ctx, campaignID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := toolCtx.DB().GetCampaignVariantStats(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	variants := make([]CampaignVariantStatsOutput, 0, len(stats))
+	for _, v := range stats {
+		var openRate, clickRate float64
+		if v.Sent > 0 {
+			openRate = float64(v.HumanOpened) / float64(v.Sent) * 100
+			clickRate = float64(v.HumanClicked) / float64(v.Sent) * 100
+		}
+		variants = append(variants, CampaignVariantStatsOutput{
+			ID:           v.ID,
+			Name:         v.Name,
+			Recipients:   v.Recipients,
+			SentCount:    v.Sent,
+			OpenedCount:  v.Opened,
+			ClickedCount: v.Clicked,
+			HumanOpened:  v.HumanOpened,
+			HumanClicked: v.HumanClicked,
+			OpenRate:     openRate,
+			ClickRate:    clickRate,
+		})
+	}
+
+	return &CampaignABTestOutput{
+		Status:          test.Status,
+		WinnerMetric:    test.WinnerMetric,
+		TestPercent:     test.TestPercent,
+		WaitHours:       test.WaitHours,
+		WinnerVariantID: test.WinnerVariantID.String,
+		Variants:        variants,
 	}, nil
 }
 
+func handleCampaignPickWinner(ctx context.Context, toolCtx *mcpctx.ToolContext, input CampaignInput) (*mcp.CallToolResult, any, error) {
+	if err := toolCtx.RequireBrand(); err != nil {
+		return nil, nil, err
+	}
+
+	if strings.TrimSpace(input.ID) == "" {
+		return nil, nil, mcpctx.NewValidationError("id is required", "id")
+	}
+	if strings.TrimSpace(input.VariantID) == "" {
+		return nil, nil, mcpctx.NewValidationError("variant_id is required", "variant_id")
+	}
+
+	if _, err := toolCtx.DB().GetCampaign(ctx, db.GetCampaignParams{
+		ID:    input.ID,
+		OrgID: toolCtx.BrandID(),
+	}); err != nil {
+		return nil, nil, mcpctx.NewNotFoundError(fmt.Sprintf("campaign %s not found", input.ID))
+	}
+
+	// The campaign scheduler sends the winner to the rest of the audience
+	picked, err := toolCtx.DB().SetCampaignABTestWinner(ctx, db.SetCampaignABTestWinnerParams{
+		WinnerVariantID: sql.NullString{String: input.VariantID, Valid: true},
+		CampaignID:      input.ID,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to pick winner: %w", err)
+	}
+	if picked == 0 {
+		return nil, nil, mcpctx.NewValidationError("campaign has no running A/B test with this variant", "variant_id")
+	}
+
+	return handleCampaignStats(ctx, toolCtx, input)
+}
+
 // registerCampaignToolToRegistry registers campaign tool to the direct-call registry.
 func registerCampaignToolToRegistry(registry *ToolRegistry, toolCtx *mcpctx.ToolContext) {
 	registry.Register("campaign", func(ctx context.Context, args json.RawMessage) (interface{}, error) {
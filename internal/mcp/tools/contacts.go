@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/events"
 	"github.com/outlet-sh/outlet/internal/mcp/mcpctx"
 
 	"github.com/google/uuid"
@@ -71,20 +73,23 @@ type ContactCreateOutput struct {
 
 // ContactGetOutput defines output for contact get.
 type ContactGetOutput struct {
-	ID             string   `json:"id"`
-	Email          string   `json:"email"`
-	Name           string   `json:"name,omitempty"`
-	Source         string   `json:"source,omitempty"`
-	EmailVerified  bool     `json:"email_verified"`
-	VerifiedAt     string   `json:"verified_at,omitempty"`
-	Status         string   `json:"status,omitempty"`
-	UnsubscribedAt string   `json:"unsubscribed_at,omitempty"`
-	BlockedAt      string   `json:"blocked_at,omitempty"`
-	GdprConsent    bool     `json:"gdpr_consent"`
-	GdprConsentAt  string   `json:"gdpr_consent_at,omitempty"`
-	Tags           []string `json:"tags"`
-	CreatedAt      string   `json:"created_at"`
-	UpdatedAt      string   `json:"updated_at"`
+	ID               string   `json:"id"`
+	Email            string   `json:"email"`
+	Name             string   `json:"name,omitempty"`
+	Source           string   `json:"source,omitempty"`
+	EmailVerified    bool     `json:"email_verified"`
+	VerifiedAt       string   `json:"verified_at,omitempty"`
+	Status           string   `json:"status,omitempty"`
+	UnsubscribedAt   string   `json:"unsubscribed_at,omitempty"`
+	BlockedAt        string   `json:"blocked_at,omitempty"`
+	GdprConsent      bool     `json:"gdpr_consent"`
+	GdprConsentAt    string   `json:"gdpr_consent_at,omitempty"`
+	ValidationStatus string   `json:"validation_status,omitempty"`
+	ValidationReason string   `json:"validation_reason,omitempty"`
+	ValidatedAt      string   `json:"validated_at,omitempty"`
+	Tags             []string `json:"tags"`
+	CreatedAt        string   `json:"created_at"`
+	UpdatedAt        string   `json:"updated_at"`
 }
 
 // ContactUpdateOutput defines output for contact update.
@@ -326,20 +331,23 @@ func handleContactGet(ctx context.Context, toolCtx *mcpctx.ToolContext, input Co
 	}
 
 	return nil, ContactGetOutput{
-		ID:             contact.ID,
-		Email:          contact.Email,
-		Name:           contact.Name,
-		Source:         contact.Source.String,
-		EmailVerified:  contact.EmailVerified == 1,
-		VerifiedAt:     contact.VerifiedAt.String,
-		Status:         contact.Status.String,
-		UnsubscribedAt: contact.UnsubscribedAt.String,
-		BlockedAt:      contact.BlockedAt.String,
-		GdprConsent:    int64ToBool(contact.GdprConsent),
-		GdprConsentAt:  contact.GdprConsentAt.String,
-		Tags:           tagNames,
-		CreatedAt:      contact.CreatedAt.String,
-		UpdatedAt:      contact.UpdatedAt.String,
+		ID:               contact.ID,
+		Email:            contact.Email,
+		Name:             contact.Name,
+		Source:           contact.Source.String,
+		EmailVerified:    contact.EmailVerified == 1,
+		VerifiedAt:       contact.VerifiedAt.String,
+		Status:           contact.Status.String,
+		UnsubscribedAt:   contact.UnsubscribedAt.String,
+		BlockedAt:        contact.BlockedAt.String,
+		GdprConsent:      int64ToBool(contact.GdprConsent),
+		GdprConsentAt:    contact.GdprConsentAt.String,
+		ValidationStatus: contact.ValidationStatus.String,
+		ValidationReason: contact.ValidationReason.String,
+		ValidatedAt:      contact.ValidatedAt.String,
+		Tags:             tagNames,
+		CreatedAt:        contact.CreatedAt.String,
+		UpdatedAt:        contact.UpdatedAt.String,
 	}, nil
 }
 
@@ -392,7 +400,7 @@ func handleContactAddTags(ctx context.Context, toolCtx *mcpctx.ToolContext, inpu
 	}
 
 	// Verify contact exists
-	_, err := toolCtx.DB().GetContactByOrgID(ctx, db.GetContactByOrgIDParams{
+	contact, err := toolCtx.DB().GetContactByOrgID(ctx, db.GetContactByOrgIDParams{
 		ID:    input.ID,
 		OrgID: sql.NullString{String: toolCtx.BrandID(), Valid: true},
 	})
@@ -407,10 +415,20 @@ func handleContactAddTags(ctx context.Context, toolCtx *mcpctx.ToolContext, inpu
 		if tag == "" {
 			continue
 		}
-		toolCtx.DB().AddContactTag(ctx, db.AddContactTagParams{
+		_, err := toolCtx.DB().AddContactTag(ctx, db.AddContactTagParams{
 			ContactID: sql.NullString{String: input.ID, Valid: true},
 			Tag:       tag,
 		})
+		if err == nil && toolCtx.Svc().Events != nil {
+			_ = events.Emit(toolCtx.Svc().Events, events.TopicContactTagAdded, events.ContactEvent{
+				OrgID:     toolCtx.BrandID(),
+				ContactID: contact.ID,
+				Email:     contact.Email,
+				Tag:       tag,
+				Source:    "mcp",
+				Timestamp: time.Now(),
+			})
+		}
 	}
 
 	// Get updated tags
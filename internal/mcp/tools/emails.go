@@ -8,8 +8,10 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/events"
 	"github.com/outlet-sh/outlet/internal/mcp/mcpctx"
 	"github.com/outlet-sh/outlet/internal/utils"
 
@@ -94,7 +96,7 @@ type EmailInput struct {
 	ContactID string `json:"contact_id,omitempty" jsonschema:"Contact ID (enrollment operations, queue.list filter)"`
 
 	// Entry rule fields
-	TriggerType string `json:"trigger_type,omitempty" jsonschema:"Trigger type: list_subscribe, sequence_complete, tag_added (entry_rule.create)"`
+	TriggerType string `json:"trigger_type,omitempty" jsonschema:"Trigger type: list_join, sequence_complete, tag_added, link_clicked, segment_enter (entry_rule.create)"`
 	SourceID    string `json:"source_id,omitempty" jsonschema:"Source ID (list ID or sequence ID) for the trigger (entry_rule.create)"`
 	Priority    int    `json:"priority,omitempty" jsonschema:"Rule priority (higher runs first, default: 10)"`
 }
@@ -167,7 +169,7 @@ Examples:
   email(resource: sequence, action: create, name: "Welcome Series", list_id: "1")
   email(resource: enrollment, action: enroll, sequence_id: "uuid", contact_id: "uuid")
   email(resource: enrollment, action: list, contact_id: "uuid")
-  email(resource: entry_rule, action: create, sequence_id: "uuid", trigger_type: "list_subscribe", source_id: "1")
+  email(resource: entry_rule, action: create, sequence_id: "uuid", trigger_type: "list_join", source_id: "1")
   email(resource: queue, action: list, status: "pending")`,
 	}, emailHandler(toolCtx))
 }
@@ -1497,6 +1499,17 @@ func handleListSubscribe(ctx context.Context, toolCtx *mcpctx.ToolContext, input
 		}, nil
 	}
 
+	if toolCtx.Svc().Events != nil {
+		_ = events.Emit(toolCtx.Svc().Events, events.TopicContactSubscribed, events.ContactEvent{
+			OrgID:     brandID,
+			ContactID: contact.ID,
+			Email:     contact.Email,
+			ListID:    strconv.FormatInt(listID, 10),
+			Source:    "mcp",
+			Timestamp: time.Now(),
+		})
+	}
+
 	return nil, ListSubscribeOutput{
 		ListID:    input.ID,
 		ContactID: contact.ID,
@@ -1910,7 +1923,11 @@ func handleEntryRuleCreate(ctx context.Context, toolCtx *mcpctx.ToolContext, inp
 	}
 
 	if strings.TrimSpace(input.TriggerType) == "" {
-		return nil, nil, mcpctx.NewValidationError("trigger_type is required (list_subscribe, sequence_complete, tag_added)", "trigger_type")
+		return nil, nil, mcpctx.NewValidationError("trigger_type is required (list_join, sequence_complete, tag_added, link_clicked, segment_enter)", "trigger_type")
+	}
+	if input.TriggerType == "list_subscribe" {
+		// Accept the old name for list_join
+		input.TriggerType = "list_join"
 	}
 
 	if strings.TrimSpace(input.SourceID) == "" {
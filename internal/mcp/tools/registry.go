@@ -87,6 +87,8 @@ func NewRegistryWithTools(toolCtx *mcpctx.ToolContext) *ToolRegistry {
 	registerStatsToolToRegistry(registry, toolCtx)
 	registerBlocklistToolToRegistry(registry, toolCtx)
 	registerGDPRToolToRegistry(registry, toolCtx)
+	registerSegmentToolToRegistry(registry, toolCtx)
+	registerRuleToolToRegistry(registry, toolCtx)
 
 	return registry
 }
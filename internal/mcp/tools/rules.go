@@ -0,0 +1,653 @@
+package tools
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/mcp/mcpctx"
+	"github.com/outlet-sh/outlet/internal/services/automation"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ruleActions defines valid actions for automation rules.
+var ruleActions = []string{"create", "list", "get", "update", "delete", "toggle", "validate", "templates", "instantiate", "log"}
+
+// RuleInput defines input for the rule tool.
+type RuleInput struct {
+	Action string `json:"action" jsonschema:"required,Action to perform: create, list, get, update, delete, toggle, validate, templates, instantiate, log"`
+
+	// Common
+	ID string `json:"id,omitempty" jsonschema:"Rule ID (for get, update, delete, toggle, validate) or template ID (for instantiate)"`
+
+	// Create/Update fields
+	Name        string `json:"name,omitempty" jsonschema:"Rule name (required for create, defaults to the template name for instantiate)"`
+	Description string `json:"description,omitempty" jsonschema:"Rule description"`
+	Category    string `json:"category,omitempty" jsonschema:"Rule category (default: automation); also filters list and templates"`
+	RuleJSON    string `json:"rule_json,omitempty" jsonschema:"Rule document as JSON (required for create)"`
+	EntityType  string `json:"entity_type,omitempty" jsonschema:"Bind the rule to an entity: email_list, sequence or campaign"`
+	EntityID    string `json:"entity_id,omitempty" jsonschema:"ID of the bound entity"`
+	Enabled     *bool  `json:"enabled,omitempty" jsonschema:"Whether the rule is enabled (default: true); also filters list"`
+	Salience    *int   `json:"salience,omitempty" jsonschema:"Priority, higher runs first (default: 0)"`
+
+	// Instantiate fields
+	Params map[string]string `json:"params,omitempty" jsonschema:"Template parameter values by name (instantiate)"`
+
+	// Log filters
+	EventType string `json:"event_type,omitempty" jsonschema:"Filter log by event type, e.g. email.clicked (log)"`
+	RuleID    string `json:"rule_id,omitempty" jsonschema:"Filter log by rule ID (log)"`
+	Success   string `json:"success,omitempty" jsonschema:"Filter log by outcome: true or false (log)"`
+
+	// Pagination
+	Page     int `json:"page,omitempty" jsonschema:"Page number (default: 1)"`
+	PageSize int `json:"page_size,omitempty" jsonschema:"Items per page (default: 20, max: 100)"`
+}
+
+// RuleItem represents a rule in output.
+type RuleItem struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	Description      string   `json:"description,omitempty"`
+	Category         string   `json:"category"`
+	RuleJSON         string   `json:"rule_json"`
+	EntityType       string   `json:"entity_type,omitempty"`
+	EntityID         string   `json:"entity_id,omitempty"`
+	Enabled          bool     `json:"enabled"`
+	Salience         int64    `json:"salience"`
+	ValidationErrors []string `json:"validation_errors,omitempty"`
+	LastValidatedAt  string   `json:"last_validated_at,omitempty"`
+	CreatedAt        string   `json:"created_at"`
+}
+
+// RuleListOutput defines output for rule list.
+type RuleListOutput struct {
+	Rules    []RuleItem `json:"rules"`
+	Total    int64      `json:"total"`
+	Page     int        `json:"page"`
+	PageSize int        `json:"page_size"`
+}
+
+// RuleDeleteOutput defines output for rule delete.
+type RuleDeleteOutput struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// RuleValidateOutput defines output for rule validate.
+type RuleValidateOutput struct {
+	ID     string   `json:"id"`
+	Valid  bool     `json:"valid"`
+	Errors []string `json:"errors"`
+}
+
+// RuleTemplateItem represents a rule template in output.
+type RuleTemplateItem struct {
+	ID            string                     `json:"id"`
+	Name          string                     `json:"name"`
+	Description   string                     `json:"description,omitempty"`
+	Category      string                     `json:"category"`
+	RuleJSON      string                     `json:"rule_json"`
+	Params        []automation.TemplateParam `json:"params"`
+	IsRecommended bool                       `json:"is_recommended"`
+}
+
+// RuleTemplateListOutput defines output for rule templates.
+type RuleTemplateListOutput struct {
+	Templates []RuleTemplateItem `json:"templates"`
+	Total     int                `json:"total"`
+}
+
+// AutomationLogItem represents an automation log entry in output.
+type AutomationLogItem struct {
+	ID              string `json:"id"`
+	EventType       string `json:"event_type"`
+	RuleID          string `json:"rule_id,omitempty"`
+	RuleName        string `json:"rule_name"`
+	ActionsExecuted string `json:"actions_executed,omitempty"`
+	Success         bool   `json:"success"`
+	ErrorMessage    string `json:"error_message,omitempty"`
+	ExecutionTimeMs int64  `json:"execution_time_ms"`
+	CreatedAt       string `json:"created_at"`
+}
+
+// AutomationLogOutput defines output for the automation log.
+type AutomationLogOutput struct {
+	Entries    []AutomationLogItem `json:"entries"`
+	Total      int64               `json:"total"`
+	Page       int                 `json:"page"`
+	PageSize   int                 `json:"page_size"`
+	Successful int64               `json:"successful_24h"`
+	Failed     int64               `json:"failed_24h"`
+}
+
+// RegisterRuleTool registers the rule tool.
+func RegisterRuleTool(server *mcp.Server, toolCtx *mcpctx.ToolContext) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "rule",
+		Title: "Automation Rules",
+		Description: `Manage automation rules that react to contact and email events.
+
+PREREQUISITE: You must first select a brand using brand(resource: brand, action: select).
+
+A rule names an event, optional conditions and a list of actions. Enabled rules run
+highest salience first; every run is recorded in the automation log.
+
+Actions and Required Fields:
+- create: Create a rule (requires: name, rule_json; optional: description, category, entity_type, entity_id, enabled, salience)
+- list: List rules (optional: category, enabled, page, page_size)
+- get: Get rule details (requires: id)
+- update: Update a rule (requires: id)
+- delete: Delete a rule (requires: id)
+- toggle: Enable or disable a rule (requires: id)
+- validate: Re-check a rule against current lists, sequences and templates (requires: id)
+- templates: List rule templates and their parameters (optional: category)
+- instantiate: Create a rule from a template (requires: id of the template; optional: name, params)
+- log: Show recent rule runs (optional: event_type, rule_id, success, page, page_size)
+
+Events: contact.created, contact.subscribed, contact.unsubscribed, contact.tag_added,
+email.sent, email.delivered, email.bounced, email.complained, email.opened, email.clicked, or * for all
+Condition ops: eq, neq, contains, not_contains, starts_with, in, not_in, exists, not_exists
+Action types: add_tag, remove_tag, enroll_sequence, unenroll_sequence, move_list, send_email, webhook
+
+Examples:
+  rule(action: create, name: "Tag pricing clicks", rule_json: "{\"event\":\"email.clicked\",\"conditions\":[{\"field\":\"clicked_url\",\"op\":\"contains\",\"value\":\"/pricing\"}],\"actions\":[{\"type\":\"add_tag\",\"tag\":\"hot-lead\"}]}")
+  rule(action: instantiate, id: "template-id", params: {"tag": "vip"})
+  rule(action: log, success: "false")`,
+	}, ruleHandler(toolCtx))
+}
+
+func ruleHandler(toolCtx *mcpctx.ToolContext) func(ctx context.Context, req *mcp.CallToolRequest, input RuleInput) (*mcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input RuleInput) (*mcp.CallToolResult, any, error) {
+		// Validate action
+		if !slices.Contains(ruleActions, input.Action) {
+			return nil, nil, mcpctx.NewValidationError(
+				fmt.Sprintf("invalid action '%s', must be: %s", input.Action, strings.Join(ruleActions, ", ")),
+				"action")
+		}
+
+		switch input.Action {
+		case "create":
+			return handleRuleCreate(ctx, toolCtx, input)
+		case "list":
+			return handleRuleList(ctx, toolCtx, input)
+		case "get":
+			return handleRuleGet(ctx, toolCtx, input)
+		case "update":
+			return handleRuleUpdate(ctx, toolCtx, input)
+		case "delete":
+			return handleRuleDelete(ctx, toolCtx, input)
+		case "toggle":
+			return handleRuleToggle(ctx, toolCtx, input)
+		case "validate":
+			return handleRuleValidate(ctx, toolCtx, input)
+		case "templates":
+			return handleRuleTemplates(ctx, toolCtx, input)
+		case "instantiate":
+			return handleRuleInstantiate(ctx, toolCtx, input)
+		case "log":
+			return handleRuleLog(ctx, toolCtx, input)
+		}
+		return nil, nil, nil
+	}
+}
+
+func handleRuleCreate(ctx context.Context, toolCtx *mcpctx.ToolContext, input RuleInput) (*mcp.CallToolResult, any, error) {
+	if err := toolCtx.RequireBrand(); err != nil {
+		return nil, nil, err
+	}
+
+	if strings.TrimSpace(input.Name) == "" {
+		return nil, nil, mcpctx.NewValidationError("name is required", "name")
+	}
+	if problems := automation.ValidateForOrg(ctx, toolCtx.DB(), toolCtx.BrandID(), input.RuleJSON); len(problems) > 0 {
+		return nil, nil, mcpctx.NewValidationError(strings.Join(problems, "; "), "rule_json")
+	}
+
+	category := input.Category
+	if category == "" {
+		category = "automation"
+	}
+
+	r, err := createBrandRule(ctx, toolCtx, db.CreateOrgRuleParams{
+		Name:        strings.TrimSpace(input.Name),
+		Description: sql.NullString{String: input.Description, Valid: input.Description != ""},
+		Category:    category,
+		RuleJson:    input.RuleJSON,
+	}, input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nil, ruleToItem(r), nil
+}
+
+func handleRuleList(ctx context.Context, toolCtx *mcpctx.ToolContext, input RuleInput) (*mcp.CallToolResult, any, error) {
+	if err := toolCtx.RequireBrand(); err != nil {
+		return nil, nil, err
+	}
+
+	page, pageSize := rulePagination(input)
+	enabled := ""
+	if input.Enabled != nil {
+		enabled = "0"
+		if *input.Enabled {
+			enabled = "1"
+		}
+	}
+
+	rules, err := toolCtx.DB().GetOrgRulesFiltered(ctx, db.GetOrgRulesFilteredParams{
+		OrgID:          toolCtx.BrandID(),
+		FilterCategory: input.Category,
+		FilterEnabled:  enabled,
+		PageOffset:     int64((page - 1) * pageSize),
+		PageSize:       int64(pageSize),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list rules: %w", err)
+	}
+
+	total, err := toolCtx.DB().CountOrgRules(ctx, db.CountOrgRulesParams{
+		OrgID:          toolCtx.BrandID(),
+		FilterCategory: input.Category,
+		FilterEnabled:  enabled,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count rules: %w", err)
+	}
+
+	items := make([]RuleItem, 0, len(rules))
+	for _, r := range rules {
+		items = append(items, ruleToItem(r))
+	}
+
+	return nil, RuleListOutput{
+		Rules:    items,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+func handleRuleGet(ctx context.Context, toolCtx *mcpctx.ToolContext, input RuleInput) (*mcp.CallToolResult, any, error) {
+	r, err := getBrandRule(ctx, toolCtx, input.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nil, ruleToItem(r), nil
+}
+
+func handleRuleUpdate(ctx context.Context, toolCtx *mcpctx.ToolContext, input RuleInput) (*mcp.CallToolResult, any, error) {
+	existing, err := getBrandRule(ctx, toolCtx, input.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := db.UpdateOrgRuleParams{
+		Name:             existing.Name,
+		Description:      existing.Description,
+		RuleJson:         existing.RuleJson,
+		EntityType:       existing.EntityType,
+		EntityID:         existing.EntityID,
+		Enabled:          existing.Enabled,
+		Salience:         existing.Salience,
+		CompiledHash:     existing.CompiledHash,
+		ValidationErrors: existing.ValidationErrors,
+		LastValidatedAt:  existing.LastValidatedAt,
+		UpdatedBy:        existing.UpdatedBy,
+		ID:               existing.ID,
+	}
+
+	if name := strings.TrimSpace(input.Name); name != "" {
+		params.Name = name
+	}
+	if input.Description != "" {
+		params.Description = sql.NullString{String: input.Description, Valid: true}
+	}
+	if input.EntityType != "" {
+		params.EntityType = sql.NullString{String: input.EntityType, Valid: true}
+	}
+	if input.EntityID != "" {
+		params.EntityID = sql.NullString{String: input.EntityID, Valid: true}
+	}
+	if input.Enabled != nil {
+		params.Enabled = sql.NullInt64{Int64: boolToInt64(*input.Enabled), Valid: true}
+	}
+	if input.Salience != nil {
+		params.Salience = sql.NullInt64{Int64: int64(*input.Salience), Valid: true}
+	}
+	if input.RuleJSON != "" {
+		if problems := automation.ValidateForOrg(ctx, toolCtx.DB(), toolCtx.BrandID(), input.RuleJSON); len(problems) > 0 {
+			return nil, nil, mcpctx.NewValidationError(strings.Join(problems, "; "), "rule_json")
+		}
+		params.RuleJson = input.RuleJSON
+		params.CompiledHash = sql.NullString{String: automation.Hash(input.RuleJSON), Valid: true}
+		params.ValidationErrors = sql.NullString{}
+		params.LastValidatedAt = ruleValidatedAt()
+	}
+
+	r, err := toolCtx.DB().UpdateOrgRule(ctx, params)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return nil, nil, mcpctx.NewConflictError(fmt.Sprintf("rule %q already exists", params.Name))
+		}
+		return nil, nil, fmt.Errorf("failed to update rule: %w", err)
+	}
+
+	return nil, ruleToItem(r), nil
+}
+
+func handleRuleDelete(ctx context.Context, toolCtx *mcpctx.ToolContext, input RuleInput) (*mcp.CallToolResult, any, error) {
+	if _, err := getBrandRule(ctx, toolCtx, input.ID); err != nil {
+		return nil, nil, err
+	}
+
+	err := toolCtx.DB().DeleteOrgRule(ctx, db.DeleteOrgRuleParams{
+		ID:    input.ID,
+		OrgID: toolCtx.BrandID(),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to delete rule: %w", err)
+	}
+
+	return nil, RuleDeleteOutput{
+		ID:      input.ID,
+		Deleted: true,
+	}, nil
+}
+
+func handleRuleToggle(ctx context.Context, toolCtx *mcpctx.ToolContext, input RuleInput) (*mcp.CallToolResult, any, error) {
+	existing, err := getBrandRule(ctx, toolCtx, input.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := toolCtx.DB().ToggleOrgRule(ctx, db.ToggleOrgRuleParams{
+		UpdatedBy: existing.UpdatedBy,
+		ID:        existing.ID,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to toggle rule: %w", err)
+	}
+
+	return nil, ruleToItem(r), nil
+}
+
+func handleRuleValidate(ctx context.Context, toolCtx *mcpctx.ToolContext, input RuleInput) (*mcp.CallToolResult, any, error) {
+	r, err := getBrandRule(ctx, toolCtx, input.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	problems := automation.ValidateForOrg(ctx, toolCtx.DB(), toolCtx.BrandID(), r.RuleJson)
+	err = toolCtx.DB().UpdateRuleValidation(ctx, db.UpdateRuleValidationParams{
+		CompiledHash:     sql.NullString{String: automation.Hash(r.RuleJson), Valid: true},
+		ValidationErrors: automation.ValidationErrorsJSON(problems),
+		ID:               r.ID,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to store rule validation: %w", err)
+	}
+
+	if problems == nil {
+		problems = []string{}
+	}
+	return nil, RuleValidateOutput{
+		ID:     r.ID,
+		Valid:  len(problems) == 0,
+		Errors: problems,
+	}, nil
+}
+
+func handleRuleTemplates(ctx context.Context, toolCtx *mcpctx.ToolContext, input RuleInput) (*mcp.CallToolResult, any, error) {
+	var templates []db.RuleTemplate
+	var err error
+	if input.Category != "" {
+		templates, err = toolCtx.DB().GetRuleTemplatesByCategory(ctx, input.Category)
+	} else {
+		templates, err = toolCtx.DB().GetRuleTemplates(ctx)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list rule templates: %w", err)
+	}
+
+	items := make([]RuleTemplateItem, 0, len(templates))
+	for _, t := range templates {
+		params, _ := automation.ParseTemplateParams(t.ConfigurableParams.String)
+		if params == nil {
+			params = []automation.TemplateParam{}
+		}
+		items = append(items, RuleTemplateItem{
+			ID:            t.ID,
+			Name:          t.Name,
+			Description:   t.Description.String,
+			Category:      t.Category,
+			RuleJSON:      t.RuleJson,
+			Params:        params,
+			IsRecommended: t.IsRecommended.Int64 == 1,
+		})
+	}
+
+	return nil, RuleTemplateListOutput{
+		Templates: items,
+		Total:     len(items),
+	}, nil
+}
+
+func handleRuleInstantiate(ctx context.Context, toolCtx *mcpctx.ToolContext, input RuleInput) (*mcp.CallToolResult, any, error) {
+	if err := toolCtx.RequireBrand(); err != nil {
+		return nil, nil, err
+	}
+	if strings.TrimSpace(input.ID) == "" {
+		return nil, nil, mcpctx.NewValidationError("id is required", "id")
+	}
+
+	template, err := toolCtx.DB().GetRuleTemplateById(ctx, input.ID)
+	if err != nil {
+		return nil, nil, mcpctx.NewNotFoundError(fmt.Sprintf("rule template %s not found", input.ID))
+	}
+
+	params, err := automation.ParseTemplateParams(template.ConfigurableParams.String)
+	if err != nil {
+		return nil, nil, fmt.Errorf("rule template %s: %w", template.ID, err)
+	}
+	ruleJSON, err := automation.Instantiate(template.RuleJson, params, input.Params)
+	if err != nil {
+		return nil, nil, mcpctx.NewValidationError(err.Error(), "params")
+	}
+	if problems := automation.ValidateForOrg(ctx, toolCtx.DB(), toolCtx.BrandID(), ruleJSON); len(problems) > 0 {
+		return nil, nil, mcpctx.NewValidationError(strings.Join(problems, "; "), "params")
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		name = template.Name
+	}
+
+	r, err := createBrandRule(ctx, toolCtx, db.CreateOrgRuleParams{
+		Name:        name,
+		Description: template.Description,
+		Category:    template.Category,
+		RuleJson:    ruleJSON,
+	}, input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nil, ruleToItem(r), nil
+}
+
+func handleRuleLog(ctx context.Context, toolCtx *mcpctx.ToolContext, input RuleInput) (*mcp.CallToolResult, any, error) {
+	if err := toolCtx.RequireBrand(); err != nil {
+		return nil, nil, err
+	}
+
+	page, pageSize := rulePagination(input)
+	success := ""
+	switch input.Success {
+	case "true", "1":
+		success = "1"
+	case "false", "0":
+		success = "0"
+	}
+
+	entries, err := toolCtx.DB().GetAutomationLogFiltered(ctx, db.GetAutomationLogFilteredParams{
+		OrgID:           toolCtx.BrandID(),
+		FilterEventType: input.EventType,
+		FilterRuleID:    input.RuleID,
+		FilterSuccess:   success,
+		PageOffset:      int64((page - 1) * pageSize),
+		PageSize:        int64(pageSize),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list automation log: %w", err)
+	}
+
+	total, err := toolCtx.DB().CountAutomationLog(ctx, db.CountAutomationLogParams{
+		OrgID:           toolCtx.BrandID(),
+		FilterEventType: input.EventType,
+		FilterRuleID:    input.RuleID,
+		FilterSuccess:   success,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count automation log: %w", err)
+	}
+
+	stats, err := toolCtx.DB().GetAutomationLogStats(ctx, toolCtx.BrandID())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get automation log stats: %w", err)
+	}
+
+	items := make([]AutomationLogItem, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, AutomationLogItem{
+			ID:              e.ID,
+			EventType:       e.EventType,
+			RuleID:          e.RuleID.String,
+			RuleName:        e.RuleName,
+			ActionsExecuted: e.ActionsExecuted.String,
+			Success:         e.Success == 1,
+			ErrorMessage:    e.ErrorMessage.String,
+			ExecutionTimeMs: e.ExecutionTimeMs.Int64,
+			CreatedAt:       e.CreatedAt.String,
+		})
+	}
+
+	return nil, AutomationLogOutput{
+		Entries:    items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		Successful: int64(stats.Successful.Float64),
+		Failed:     int64(stats.Failed.Float64),
+	}, nil
+}
+
+// createBrandRule inserts a validated rule for the selected brand, taking
+// entity binding, enabled and salience from the tool input
+func createBrandRule(ctx context.Context, toolCtx *mcpctx.ToolContext, params db.CreateOrgRuleParams, input RuleInput) (db.OrgRule, error) {
+	enabled := true
+	if input.Enabled != nil {
+		enabled = *input.Enabled
+	}
+	salience := 0
+	if input.Salience != nil {
+		salience = *input.Salience
+	}
+
+	params.ID = uuid.New().String()
+	params.OrgID = toolCtx.BrandID()
+	params.EntityType = sql.NullString{String: input.EntityType, Valid: input.EntityType != ""}
+	params.EntityID = sql.NullString{String: input.EntityID, Valid: input.EntityID != ""}
+	params.Enabled = sql.NullInt64{Int64: boolToInt64(enabled), Valid: true}
+	params.Salience = sql.NullInt64{Int64: int64(salience), Valid: true}
+	params.CompiledHash = sql.NullString{String: automation.Hash(params.RuleJson), Valid: true}
+	params.LastValidatedAt = ruleValidatedAt()
+
+	r, err := toolCtx.DB().CreateOrgRule(ctx, params)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return db.OrgRule{}, mcpctx.NewConflictError(fmt.Sprintf("rule %q already exists", params.Name))
+		}
+		return db.OrgRule{}, fmt.Errorf("failed to create rule: %w", err)
+	}
+	return r, nil
+}
+
+// getBrandRule loads a rule scoped to the selected brand
+func getBrandRule(ctx context.Context, toolCtx *mcpctx.ToolContext, id string) (db.OrgRule, error) {
+	if err := toolCtx.RequireBrand(); err != nil {
+		return db.OrgRule{}, err
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return db.OrgRule{}, mcpctx.NewValidationError("id is required", "id")
+	}
+
+	r, err := toolCtx.DB().GetOrgRuleById(ctx, db.GetOrgRuleByIdParams{
+		ID:    id,
+		OrgID: toolCtx.BrandID(),
+	})
+	if err != nil {
+		return db.OrgRule{}, mcpctx.NewNotFoundError(fmt.Sprintf("rule %s not found", id))
+	}
+	return r, nil
+}
+
+func rulePagination(input RuleInput) (page, pageSize int) {
+	page, pageSize = input.Page, input.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	return page, pageSize
+}
+
+// ruleValidatedAt formats the current time the way SQLite's datetime('now') does
+func ruleValidatedAt() sql.NullString {
+	return sql.NullString{String: time.Now().UTC().Format("2006-01-02 15:04:05"), Valid: true}
+}
+
+func ruleToItem(r db.OrgRule) RuleItem {
+	var validationErrors []string
+	if r.ValidationErrors.Valid && r.ValidationErrors.String != "" {
+		json.Unmarshal([]byte(r.ValidationErrors.String), &validationErrors)
+	}
+
+	return RuleItem{
+		ID:               r.ID,
+		Name:             r.Name,
+		Description:      r.Description.String,
+		Category:         r.Category,
+		RuleJSON:         r.RuleJson,
+		EntityType:       r.EntityType.String,
+		EntityID:         r.EntityID.String,
+		Enabled:          !r.Enabled.Valid || r.Enabled.Int64 == 1,
+		Salience:         r.Salience.Int64,
+		ValidationErrors: validationErrors,
+		LastValidatedAt:  r.LastValidatedAt.String,
+		CreatedAt:        r.CreatedAt.String,
+	}
+}
+
+// registerRuleToolToRegistry registers rule tool to the direct-call registry.
+func registerRuleToolToRegistry(registry *ToolRegistry, toolCtx *mcpctx.ToolContext) {
+	registry.Register("rule", func(ctx context.Context, args json.RawMessage) (interface{}, error) {
+		var input RuleInput
+		if err := json.Unmarshal(args, &input); err != nil {
+			return nil, fmt.Errorf("invalid input: %w", err)
+		}
+		handler := ruleHandler(toolCtx)
+		_, output, err := handler(ctx, nil, input)
+		return output, err
+	})
+}
@@ -0,0 +1,325 @@
+package tools
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/mcp/mcpctx"
+	"github.com/outlet-sh/outlet/internal/services/segment"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// segmentActions defines valid actions for segments.
+var segmentActions = []string{"create", "list", "get", "update", "delete", "count"}
+
+// SegmentInput defines input for the segment tool.
+type SegmentInput struct {
+	Action string `json:"action" jsonschema:"required,Action to perform: create, list, get, update, delete, count"`
+
+	// Common
+	ID string `json:"id,omitempty" jsonschema:"Segment ID (for get, update, delete, count)"`
+
+	// Create/Update fields
+	Name        string `json:"name,omitempty" jsonschema:"Segment name (required for create)"`
+	Description string `json:"description,omitempty" jsonschema:"Segment description"`
+	ListIDs     string `json:"list_ids,omitempty" jsonschema:"Comma-separated list IDs the segment draws from (empty means all lists)"`
+	Filter      string `json:"filter,omitempty" jsonschema:"Segment filter as JSON, e.g. {\"conditions\":[{\"field\":\"tag\",\"op\":\"has\",\"value\":\"vip\"}]}"`
+}
+
+// SegmentItem represents a segment in output.
+type SegmentItem struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	Description      string   `json:"description,omitempty"`
+	ListIDs          []string `json:"list_ids"`
+	Filter           string   `json:"filter"`
+	ContactCount     int64    `json:"contact_count"`
+	CountRefreshedAt string   `json:"count_refreshed_at,omitempty"`
+	CreatedAt        string   `json:"created_at"`
+}
+
+// SegmentListOutput defines output for segment list.
+type SegmentListOutput struct {
+	Segments []SegmentItem `json:"segments"`
+	Total    int           `json:"total"`
+}
+
+// SegmentCountOutput defines output for segment count.
+type SegmentCountOutput struct {
+	ID           string `json:"id"`
+	ContactCount int64  `json:"contact_count"`
+}
+
+// SegmentDeleteOutput defines output for segment delete.
+type SegmentDeleteOutput struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// RegisterSegmentTool registers the segment tool.
+func RegisterSegmentTool(server *mcp.Server, toolCtx *mcpctx.ToolContext) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "segment",
+		Title: "Saved Segments",
+		Description: `Manage saved, reusable audience segments.
+
+PREREQUISITE: You must first select a brand using brand(resource: brand, action: select).
+
+A segment combines optional lists with a JSON filter. Segments can be targeted by
+campaigns (segment_id), used as sequence entry rules (trigger_type: segment_enter)
+and used for housekeeping.
+
+Actions and Required Fields:
+- create: Create a segment (requires: name; optional: description, list_ids, filter)
+- list: List all segments with cached counts
+- get: Get segment details (requires: id)
+- update: Update a segment (requires: id)
+- delete: Delete a segment (requires: id)
+- count: Recount segment members now (requires: id)
+
+Filter fields: tag, custom_field, subscribed_at, opened, clicked, source, gdpr_consent
+
+Examples:
+  segment(action: create, name: "VIP customers", filter: "{\"conditions\":[{\"field\":\"tag\",\"op\":\"has\",\"value\":\"vip\"}]}")
+  segment(action: list)
+  segment(action: count, id: "uuid")`,
+	}, segmentHandler(toolCtx))
+}
+
+func segmentHandler(toolCtx *mcpctx.ToolContext) func(ctx context.Context, req *mcp.CallToolRequest, input SegmentInput) (*mcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input SegmentInput) (*mcp.CallToolResult, any, error) {
+		// Validate action
+		if !slices.Contains(segmentActions, input.Action) {
+			return nil, nil, mcpctx.NewValidationError(
+				fmt.Sprintf("invalid action '%s', must be: %s", input.Action, strings.Join(segmentActions, ", ")),
+				"action")
+		}
+
+		switch input.Action {
+		case "create":
+			return handleSegmentCreate(ctx, toolCtx, input)
+		case "list":
+			return handleSegmentList(ctx, toolCtx, input)
+		case "get":
+			return handleSegmentGet(ctx, toolCtx, input)
+		case "update":
+			return handleSegmentUpdate(ctx, toolCtx, input)
+		case "delete":
+			return handleSegmentDelete(ctx, toolCtx, input)
+		case "count":
+			return handleSegmentCount(ctx, toolCtx, input)
+		}
+		return nil, nil, nil
+	}
+}
+
+func handleSegmentCreate(ctx context.Context, toolCtx *mcpctx.ToolContext, input SegmentInput) (*mcp.CallToolResult, any, error) {
+	if err := toolCtx.RequireBrand(); err != nil {
+		return nil, nil, err
+	}
+
+	if strings.TrimSpace(input.Name) == "" {
+		return nil, nil, mcpctx.NewValidationError("name is required", "name")
+	}
+	if _, err := segment.Parse(input.Filter); err != nil {
+		return nil, nil, mcpctx.NewValidationError(err.Error(), "filter")
+	}
+
+	filter := input.Filter
+	if strings.TrimSpace(filter) == "" {
+		filter = "{}"
+	}
+
+	s, err := toolCtx.DB().CreateSegment(ctx, db.CreateSegmentParams{
+		ID:          uuid.New().String(),
+		OrgID:       toolCtx.BrandID(),
+		Name:        strings.TrimSpace(input.Name),
+		Description: sql.NullString{String: input.Description, Valid: input.Description != ""},
+		ListIds:     sql.NullString{String: segmentListIDsJSON(input.ListIDs), Valid: true},
+		Filter:      filter,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return nil, nil, mcpctx.NewConflictError(fmt.Sprintf("segment %q already exists", input.Name))
+		}
+		return nil, nil, fmt.Errorf("failed to create segment: %w", err)
+	}
+
+	if count, err := segment.RefreshCount(ctx, toolCtx.DB().GetDB(), toolCtx.DB(), s); err == nil {
+		s.ContactCount = sql.NullInt64{Int64: count, Valid: true}
+	}
+
+	return nil, segmentToItem(s), nil
+}
+
+func handleSegmentList(ctx context.Context, toolCtx *mcpctx.ToolContext, input SegmentInput) (*mcp.CallToolResult, any, error) {
+	if err := toolCtx.RequireBrand(); err != nil {
+		return nil, nil, err
+	}
+
+	segments, err := toolCtx.DB().ListSegments(ctx, toolCtx.BrandID())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list segments: %w", err)
+	}
+
+	items := make([]SegmentItem, 0, len(segments))
+	for _, s := range segments {
+		items = append(items, segmentToItem(s))
+	}
+
+	return nil, SegmentListOutput{
+		Segments: items,
+		Total:    len(items),
+	}, nil
+}
+
+func handleSegmentGet(ctx context.Context, toolCtx *mcpctx.ToolContext, input SegmentInput) (*mcp.CallToolResult, any, error) {
+	s, err := getBrandSegment(ctx, toolCtx, input.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nil, segmentToItem(s), nil
+}
+
+func handleSegmentUpdate(ctx context.Context, toolCtx *mcpctx.ToolContext, input SegmentInput) (*mcp.CallToolResult, any, error) {
+	if _, err := getBrandSegment(ctx, toolCtx, input.ID); err != nil {
+		return nil, nil, err
+	}
+	if _, err := segment.Parse(input.Filter); err != nil {
+		return nil, nil, mcpctx.NewValidationError(err.Error(), "filter")
+	}
+
+	var description, listIDs interface{}
+	if input.Description != "" {
+		description = input.Description
+	}
+	if input.ListIDs != "" {
+		listIDs = segmentListIDsJSON(input.ListIDs)
+	}
+
+	s, err := toolCtx.DB().UpdateSegment(ctx, db.UpdateSegmentParams{
+		ID:          input.ID,
+		OrgID:       toolCtx.BrandID(),
+		Name:        strings.TrimSpace(input.Name),
+		Description: description,
+		ListIds:     listIDs,
+		Filter:      input.Filter,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update segment: %w", err)
+	}
+
+	if count, err := segment.RefreshCount(ctx, toolCtx.DB().GetDB(), toolCtx.DB(), s); err == nil {
+		s.ContactCount = sql.NullInt64{Int64: count, Valid: true}
+	}
+
+	return nil, segmentToItem(s), nil
+}
+
+func handleSegmentDelete(ctx context.Context, toolCtx *mcpctx.ToolContext, input SegmentInput) (*mcp.CallToolResult, any, error) {
+	if _, err := getBrandSegment(ctx, toolCtx, input.ID); err != nil {
+		return nil, nil, err
+	}
+
+	err := segment.DeleteSaved(ctx, toolCtx.DB(), toolCtx.BrandID(), input.ID)
+	if errors.Is(err, segment.ErrInUse) {
+		return nil, nil, mcpctx.NewValidationError(err.Error(), "id")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to delete segment: %w", err)
+	}
+
+	return nil, SegmentDeleteOutput{
+		ID:      input.ID,
+		Deleted: true,
+	}, nil
+}
+
+func handleSegmentCount(ctx context.Context, toolCtx *mcpctx.ToolContext, input SegmentInput) (*mcp.CallToolResult, any, error) {
+	s, err := getBrandSegment(ctx, toolCtx, input.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	count, err := segment.RefreshCount(ctx, toolCtx.DB().GetDB(), toolCtx.DB(), s)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count segment: %w", err)
+	}
+
+	return nil, SegmentCountOutput{
+		ID:           s.ID,
+		ContactCount: count,
+	}, nil
+}
+
+// getBrandSegment loads a segment scoped to the selected brand
+func getBrandSegment(ctx context.Context, toolCtx *mcpctx.ToolContext, id string) (db.Segment, error) {
+	if err := toolCtx.RequireBrand(); err != nil {
+		return db.Segment{}, err
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return db.Segment{}, mcpctx.NewValidationError("id is required", "id")
+	}
+
+	s, err := toolCtx.DB().GetSegment(ctx, db.GetSegmentParams{
+		ID:    id,
+		OrgID: toolCtx.BrandID(),
+	})
+	if err != nil {
+		return db.Segment{}, mcpctx.NewNotFoundError(fmt.Sprintf("segment %s not found", id))
+	}
+	return s, nil
+}
+
+// segmentListIDsJSON converts comma-separated list IDs to the stored JSON array
+func segmentListIDsJSON(listIDs string) string {
+	ids := []string{}
+	for _, id := range strings.Split(listIDs, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	data, _ := json.Marshal(ids)
+	return string(data)
+}
+
+func segmentToItem(s db.Segment) SegmentItem {
+	listIDs := []string{}
+	if s.ListIds.Valid && s.ListIds.String != "" {
+		json.Unmarshal([]byte(s.ListIds.String), &listIDs)
+	}
+
+	return SegmentItem{
+		ID:               s.ID,
+		Name:             s.Name,
+		Description:      s.Description.String,
+		ListIDs:          listIDs,
+		Filter:           s.Filter,
+		ContactCount:     s.ContactCount.Int64,
+		CountRefreshedAt: s.CountRefreshedAt.String,
+		CreatedAt:        s.CreatedAt.String,
+	}
+}
+
+// registerSegmentToolToRegistry registers segment tool to the direct-call registry.
+func registerSegmentToolToRegistry(registry *ToolRegistry, toolCtx *mcpctx.ToolContext) {
+	registry.Register("segment", func(ctx context.Context, args json.RawMessage) (interface{}, error) {
+		var input SegmentInput
+		if err := json.Unmarshal(args, &input); err != nil {
+			return nil, fmt.Errorf("invalid input: %w", err)
+		}
+		handler := segmentHandler(toolCtx)
+		_, output, err := handler(ctx, nil, input)
+		return output, err
+	})
+}
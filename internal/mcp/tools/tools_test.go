@@ -468,3 +468,33 @@ func TestBrandActions_ValidActions(t *testing.T) {
 	assert.Contains(t, actions, "get")
 	assert.Contains(t, actions, "update")
 }
+
+func TestSegmentActions_ValidActions(t *testing.T) {
+	assert.Contains(t, segmentActions, "create")
+	assert.Contains(t, segmentActions, "list")
+	assert.Contains(t, segmentActions, "get")
+	assert.Contains(t, segmentActions, "update")
+	assert.Contains(t, segmentActions, "delete")
+	assert.Contains(t, segmentActions, "count")
+}
+
+func TestSegmentListIDsJSON(t *testing.T) {
+	assert.Equal(t, `["1","2"]`, segmentListIDsJSON("1, 2,"))
+	assert.Equal(t, `[]`, segmentListIDsJSON(""))
+}
+
+func TestRuleActions_ValidActions(t *testing.T) {
+	for _, action := range []string{"create", "list", "get", "update", "delete", "toggle", "validate", "templates", "instantiate", "log"} {
+		assert.Contains(t, ruleActions, action)
+	}
+}
+
+func TestRulePagination_Defaults(t *testing.T) {
+	page, pageSize := rulePagination(RuleInput{})
+	assert.Equal(t, 1, page)
+	assert.Equal(t, 20, pageSize)
+
+	page, pageSize = rulePagination(RuleInput{Page: 3, PageSize: 500})
+	assert.Equal(t, 3, page)
+	assert.Equal(t, 20, pageSize)
+}
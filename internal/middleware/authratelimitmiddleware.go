@@ -61,6 +61,11 @@ func (m *AuthRateLimitMiddleware) cleanup() {
 
 // getClientIP extracts the client IP from the request, checking proxy headers
 func (m *AuthRateLimitMiddleware) getClientIP(r *http.Request) string {
+	return ClientIP(r)
+}
+
+// ClientIP extracts the client IP from the request, checking proxy headers
+func ClientIP(r *http.Request) string {
 	// Check X-Forwarded-For header first (for reverse proxies)
 	xff := r.Header.Get("X-Forwarded-For")
 	if xff != "" {
@@ -7,9 +7,12 @@ import (
 	"html/template"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/events"
 	"github.com/outlet-sh/outlet/internal/services/email"
 	"github.com/outlet-sh/outlet/internal/svc"
 
@@ -141,6 +144,12 @@ func (h *Handler) HandleSubscribe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Validate against the list policy (or org policy); validation errors fail open
+	validation, err := email.ValidateSignup(r.Context(), h.svcCtx.DB, list.OrgID, list.ValidationPolicy, emailAddr)
+	if err != nil {
+		log.Printf("Error validating email %s: %v", emailAddr, err)
+	}
+
 	// Check if contact already exists for this org
 	existingContact, err := h.svcCtx.DB.GetContactByOrgAndEmail(r.Context(), db.GetContactByOrgAndEmailParams{
 		OrgID: sql.NullString{String: list.OrgID, Valid: true},
@@ -174,6 +183,18 @@ func (h *Handler) HandleSubscribe(w http.ResponseWriter, r *http.Request) {
 		contactID = existingContact.ID
 	}
 
+	// Record the outcome on the contact so rejected signups are auditable
+	if validation != nil {
+		if err := email.RecordValidation(r.Context(), h.svcCtx.DB, contactID, validation); err != nil {
+			log.Printf("Error recording validation for contact %s: %v", contactID, err)
+		}
+		if !validation.Valid() {
+			data["Error"] = "This email address can't be used to subscribe. Please use a different address."
+			h.renderTemplate(w, "subscribe.html", data)
+			return
+		}
+	}
+
 	// Check double opt-in setting
 	requiresConfirmation := list.DoubleOptin.Valid && list.DoubleOptin.Int64 == 1
 
@@ -233,6 +254,7 @@ func (h *Handler) HandleSubscribe(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		subscriberID = subscriber.ID
+		h.emitSubscribed(list.OrgID, contactID, emailAddr, list.ID, "public_page")
 	}
 
 	// Save custom field values if any
@@ -293,6 +315,8 @@ func (h *Handler) HandleConfirm(w http.ResponseWriter, r *http.Request) {
 	contact, err := h.svcCtx.DB.GetContact(r.Context(), confirmedSub.ContactID)
 	if err != nil {
 		log.Printf("Error getting contact: %v", err)
+	} else if contact.OrgID.Valid {
+		h.emitSubscribed(contact.OrgID.String, contact.ID, contact.Email, confirmedSub.ListID, "confirmation")
 	}
 
 	// Get list info for redirect URL
@@ -401,6 +425,19 @@ func (h *Handler) HandleUnsubscribe(w http.ResponseWriter, r *http.Request) {
 		if err := h.svcCtx.DB.CancelEmailsForContact(r.Context(), sql.NullString{String: contactID, Valid: true}); err != nil {
 			log.Printf("Error canceling emails for contact: %v", err)
 		}
+
+		// Emit contact.unsubscribed event for rules engine
+		if h.svcCtx.Events != nil {
+			if contact, err := h.svcCtx.DB.GetContactByID(r.Context(), contactID); err == nil && contact.OrgID.Valid {
+				_ = events.Emit(h.svcCtx.Events, events.TopicContactUnsubscribed, events.ContactEvent{
+					OrgID:     contact.OrgID.String,
+					ContactID: contact.ID,
+					Email:     contact.Email,
+					Source:    "unsubscribe_page",
+					Timestamp: time.Now(),
+				})
+			}
+		}
 	}
 
 	// Redirect to unsubscribe redirect URL if configured
@@ -412,6 +449,22 @@ func (h *Handler) HandleUnsubscribe(w http.ResponseWriter, r *http.Request) {
 	h.renderTemplate(w, "unsubscribed.html", data)
 }
 
+// emitSubscribed emits contact.subscribed for a subscription that just became
+// active; the automation engine starts list_join sequences from it.
+func (h *Handler) emitSubscribed(orgID, contactID, emailAddr string, listID int64, source string) {
+	if h.svcCtx.Events == nil {
+		return
+	}
+	_ = events.Emit(h.svcCtx.Events, events.TopicContactSubscribed, events.ContactEvent{
+		OrgID:     orgID,
+		ContactID: contactID,
+		Email:     emailAddr,
+		ListID:    strconv.FormatInt(listID, 10),
+		Source:    source,
+		Timestamp: time.Now(),
+	})
+}
+
 // HandleWebView handles GET for /w/{token} - view email in browser
 func (h *Handler) HandleWebView(w http.ResponseWriter, r *http.Request) {
 	// Extract token from URL path: /w/{token}
@@ -485,7 +538,7 @@ func (h *Handler) sendConfirmationEmail(ctx context.Context, list db.GetListByPu
 		htmlBody = strings.ReplaceAll(list.ConfirmationEmailBody.String, "{{confirm_url}}", confirmURL)
 	}
 
-	// Use list's from address if configured, otherwise let email service get defaults from platform_settings
+	// Use list's from address if configured, otherwise let email service get defaults from org and platform settings
 	fromEmail := ""
 	fromName := ""
 	if list.FromEmail.Valid && list.FromEmail.String != "" {
@@ -495,7 +548,7 @@ func (h *Handler) sendConfirmationEmail(ctx context.Context, list db.GetListByPu
 		fromName = list.FromName.String
 	}
 
-	return h.emailService.SendEmailFrom(ctx, fromEmail, fromName, toEmail, subject, htmlBody)
+	return h.emailService.SendEmailFrom(ctx, list.OrgID, fromEmail, fromName, toEmail, subject, htmlBody)
 }
 
 func conditionalName(name string) string {
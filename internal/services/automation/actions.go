@@ -0,0 +1,267 @@
+package automation
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/services/email"
+
+	"github.com/google/uuid"
+)
+
+var errNoContact = errors.New("event has no contact")
+
+// runAction performs a single rule action for the event's contact
+func (e *Engine) runAction(ctx context.Context, rule db.OrgRule, action Action, topic string, payload map[string]any) error {
+	if action.Type == ActionWebhook {
+		return e.fireWebhook(ctx, rule, action, topic, payload)
+	}
+
+	contactID, _ := payload["contact_id"].(string)
+	if contactID == "" {
+		return errNoContact
+	}
+
+	contact, err := e.db.GetContact(ctx, contactID)
+	if err != nil {
+		return fmt.Errorf("contact not found: %w", err)
+	}
+	if contact.OrgID.String != rule.OrgID {
+		return errors.New("contact belongs to another organization")
+	}
+
+	switch action.Type {
+	case ActionAddTag:
+		_, err := e.db.AddContactTag(ctx, db.AddContactTagParams{
+			ContactID: sql.NullString{String: contactID, Valid: true},
+			Tag:       action.Tag,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			// Tag already present
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := e.sequences.EnrollOnTagAdded(ctx, rule.OrgID, contactID, action.Tag); err != nil {
+			fmt.Printf("[Automation] Failed to apply entry rules for tag %q: %v\n", action.Tag, err)
+		}
+		return nil
+
+	case ActionRemoveTag:
+		return e.db.RemoveContactTag(ctx, db.RemoveContactTagParams{
+			ContactID: sql.NullString{String: contactID, Valid: true},
+			Tag:       action.Tag,
+		})
+
+	case ActionEnrollSequence:
+		if err := e.checkSequence(ctx, rule.OrgID, action.SequenceID); err != nil {
+			return err
+		}
+		return e.sequences.StartSequenceByID(ctx, contactID, action.SequenceID)
+
+	case ActionUnenrollSequence:
+		if err := e.checkSequence(ctx, rule.OrgID, action.SequenceID); err != nil {
+			return err
+		}
+		params := db.CancelContactSequenceParams{
+			ContactID:  sql.NullString{String: contactID, Valid: true},
+			SequenceID: sql.NullString{String: action.SequenceID, Valid: true},
+		}
+		if err := e.db.CancelContactSequence(ctx, params); err != nil {
+			return err
+		}
+		return e.db.CancelPendingEmailsForContactSequence(ctx, db.CancelPendingEmailsForContactSequenceParams{
+			ContactID:  params.ContactID,
+			SequenceID: params.SequenceID,
+		})
+
+	case ActionMoveList:
+		return e.moveList(ctx, rule.OrgID, contactID, action, payload)
+
+	case ActionSendEmail:
+		return e.sendEmail(ctx, rule.OrgID, contact, action)
+	}
+
+	return fmt.Errorf("unknown action type %q", action.Type)
+}
+
+func (e *Engine) checkSequence(ctx context.Context, orgID, sequenceID string) error {
+	sequence, err := e.db.GetSequenceByID(ctx, sequenceID)
+	if err != nil || sequence.OrgID.String != orgID {
+		return fmt.Errorf("sequence %s not found", sequenceID)
+	}
+	return nil
+}
+
+func (e *Engine) checkList(ctx context.Context, orgID string, listID int64) error {
+	list, err := e.db.GetEmailList(ctx, listID)
+	if err != nil || list.OrgID != orgID {
+		return fmt.Errorf("list %d not found", listID)
+	}
+	return nil
+}
+
+// moveList subscribes the contact to the target list and unsubscribes it from
+// the source list, which defaults to the list the event refers to.
+func (e *Engine) moveList(ctx context.Context, orgID, contactID string, action Action, payload map[string]any) error {
+	if err := e.checkList(ctx, orgID, action.ListID); err != nil {
+		return err
+	}
+
+	fromListID := action.FromListID
+	if fromListID == 0 {
+		if raw, ok := payload["list_id"]; ok {
+			fromListID, _ = strconv.ParseInt(fmt.Sprint(raw), 10, 64)
+		}
+	}
+
+	_, err := e.db.SubscribeToList(ctx, db.SubscribeToListParams{
+		ID:        uuid.NewString(),
+		ListID:    action.ListID,
+		ContactID: contactID,
+	})
+	if err != nil {
+		return fmt.Errorf("subscribe to list %d: %w", action.ListID, err)
+	}
+	if err := e.sequences.EnrollOnListJoin(ctx, contactID, action.ListID); err != nil {
+		fmt.Printf("[Automation] Failed to apply entry rules for list %d: %v\n", action.ListID, err)
+	}
+
+	if fromListID > 0 && fromListID != action.ListID {
+		if err := e.checkList(ctx, orgID, fromListID); err != nil {
+			return err
+		}
+		if err := e.db.UnsubscribeFromList(ctx, db.UnsubscribeFromListParams{
+			ListID:    fromListID,
+			ContactID: contactID,
+		}); err != nil {
+			return fmt.Errorf("unsubscribe from list %d: %w", fromListID, err)
+		}
+	}
+
+	return nil
+}
+
+// sendEmail queues a transactional template for the contact. The send is
+// recorded in transactional_sends and queued in one transaction, as the send
+// API does, and the dispatcher delivers it.
+func (e *Engine) sendEmail(ctx context.Context, orgID string, contact db.Contact, action Action) error {
+	if e.sender == nil {
+		return errors.New("email service not configured")
+	}
+
+	template, err := e.db.GetTransactionalEmailBySlug(ctx, db.GetTransactionalEmailBySlugParams{
+		Slug:  action.Template,
+		OrgID: orgID,
+	})
+	if err != nil {
+		return fmt.Errorf("transactional email %q not found", action.Template)
+	}
+	if template.IsActive.Valid && template.IsActive.Int64 != 1 {
+		return fmt.Errorf("transactional email %q is inactive", action.Template)
+	}
+
+	replacer := strings.NewReplacer("{{name}}", contact.Name, "{{email}}", contact.Email)
+	subject := replacer.Replace(template.Subject)
+	htmlBody := replacer.Replace(template.HtmlBody)
+	textBody := replacer.Replace(template.PlainText.String)
+
+	// Template sender overrides the org default
+	fromEmail, fromName := template.FromEmail.String, template.FromName.String
+	if fromEmail == "" {
+		if settings, err := e.db.GetOrgEmailSettings(ctx, orgID); err == nil {
+			fromEmail, fromName = settings.FromEmail.String, settings.FromName.String
+		}
+	}
+
+	tokenBytes := make([]byte, 32)
+	rand.Read(tokenBytes)
+	trackingToken := hex.EncodeToString(tokenBytes)
+
+	err = e.db.ExecTx(ctx, func(q *db.Queries) error {
+		send, err := q.CreateTransactionalSend(ctx, db.CreateTransactionalSendParams{
+			ID:            uuid.NewString(),
+			TemplateID:    template.ID,
+			OrgID:         orgID,
+			ToEmail:       contact.Email,
+			ToName:        sql.NullString{String: contact.Name, Valid: contact.Name != ""},
+			ContactID:     sql.NullString{String: contact.ID, Valid: true},
+			Status:        sql.NullString{String: "pending", Valid: true},
+			TrackingToken: sql.NullString{String: trackingToken, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("create send record: %w", err)
+		}
+
+		return e.sender.EnqueueTransactional(ctx, q, send.ID, email.Message{
+			FromName:  fromName,
+			FromEmail: fromEmail,
+			To:        contact.Email,
+			ReplyTo:   template.ReplyTo.String,
+			Subject:   subject,
+			HTMLBody:  e.sender.AddTracking(htmlBody, trackingToken),
+			TextBody:  textBody,
+			OrgID:     orgID,
+		}, time.Time{})
+	})
+	if err != nil {
+		return err
+	}
+
+	e.sender.WakeTransactional()
+	return nil
+}
+
+// fireWebhook posts the event to the action URL, signed with the action secret when set
+func (e *Engine) fireWebhook(ctx context.Context, rule db.OrgRule, action Action, topic string, payload map[string]any) error {
+	body, err := json.Marshal(map[string]any{
+		"event":     topic,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+		"rule_id":   rule.ID,
+		"rule_name": rule.Name,
+		"data":      payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, action.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", topic)
+	req.Header.Set("User-Agent", "Outlet-Webhook/1.0")
+	if action.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(action.Secret))
+		mac.Write(body)
+		req.Header.Set("X-Webhook-Signature", hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, respBody)
+	}
+	return nil
+}
@@ -0,0 +1,316 @@
+package automation
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/events"
+	"github.com/outlet-sh/outlet/internal/services/email"
+
+	"github.com/google/uuid"
+)
+
+// Engine evaluates org_rules against events from the event bus and runs the
+// actions of matching rules. Every execution is recorded in automation_log,
+// which also guarantees a rule runs at most once per event.
+type Engine struct {
+	db         *db.Store
+	events     *events.Subject
+	sender     *email.Service
+	sequences  *email.SequenceService
+	httpClient *http.Client
+
+	// Subscription management
+	mu      sync.Mutex
+	running bool
+	cancel  context.CancelFunc
+}
+
+// ActionResult is the per-action outcome stored in automation_log.actions_executed
+type ActionResult struct {
+	Type    string `json:"type"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// NewEngine creates a new automation engine.
+func NewEngine(store *db.Store, sender *email.Service, baseURL string, eventBus *events.Subject) *Engine {
+	return &Engine{
+		db:        store,
+		events:    eventBus,
+		sender:    sender,
+		sequences: email.NewSequenceServiceWithBaseURL(store, sender, baseURL),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// Start begins listening for events and evaluating rules.
+func (e *Engine) Start(ctx context.Context) error {
+	e.mu.Lock()
+	if e.running {
+		e.mu.Unlock()
+		return nil
+	}
+	e.running = true
+	ctx, e.cancel = context.WithCancel(ctx)
+	e.mu.Unlock()
+
+	// Subscribe to every event rules can be written against
+	eventTopics := []string{
+		// Contact events
+		events.TopicContactCreated,
+		events.TopicContactSubscribed,
+		events.TopicContactUnsubscribed,
+		events.TopicContactTagAdded,
+
+		// Email events
+		events.TopicEmailSent,
+		events.TopicEmailDelivered,
+		events.TopicEmailBounced,
+		events.TopicEmailComplained,
+		events.TopicEmailOpened,
+		events.TopicEmailClicked,
+	}
+
+	for _, topic := range eventTopics {
+		topic := topic // capture for closure
+		events.Subscribe[any](e.events, topic, func(_ context.Context, data any) error {
+			e.HandleEvent(ctx, topic, data)
+			return nil
+		})
+	}
+
+	fmt.Println("[Automation] Started, listening for events")
+	return nil
+}
+
+// Stop stops the engine.
+func (e *Engine) Stop() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.cancel != nil {
+		e.cancel()
+	}
+	e.running = false
+	fmt.Println("[Automation] Stopped")
+}
+
+// HandleEvent applies sequence entry rules for the event and then evaluates
+// the org's enabled rules, highest salience first. Machine opens and clicks
+// are ignored.
+func (e *Engine) HandleEvent(ctx context.Context, topic string, data any) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	payloadBytes, err := json.Marshal(data)
+	if err != nil {
+		fmt.Printf("[Automation] Failed to marshal %s payload: %v\n", topic, err)
+		return
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		fmt.Printf("[Automation] Event %s payload is not an object: %v\n", topic, err)
+		return
+	}
+
+	orgID, _ := payload["org_id"].(string)
+	if orgID == "" {
+		return
+	}
+	// Opens and clicks by proxies, scanners and bots are not engagement, so
+	// they neither enroll contacts nor fire rules
+	if machine, _ := payload["machine"].(bool); machine {
+		return
+	}
+	contactID, _ := payload["contact_id"].(string)
+
+	e.enrollSequences(ctx, topic, orgID, contactID, payload)
+
+	rules, err := e.db.GetOrgRules(ctx, orgID)
+	if err != nil {
+		fmt.Printf("[Automation] Failed to load rules for org %s: %v\n", orgID, err)
+		return
+	}
+	if len(rules) == 0 {
+		return
+	}
+
+	eventID := eventID(topic, payloadBytes)
+
+	// Tag lookups are cached per event since several rules may check the same tag
+	tags := map[string]bool{}
+	hasTag := func(tag string) bool {
+		if contactID == "" {
+			return false
+		}
+		if has, ok := tags[tag]; ok {
+			return has
+		}
+		found, err := e.db.HasContactTag(ctx, db.HasContactTagParams{
+			ContactID: sql.NullString{String: contactID, Valid: true},
+			Tag:       tag,
+		})
+		tags[tag] = err == nil && found == 1
+		return tags[tag]
+	}
+
+	for _, orgRule := range rules {
+		rule, err := Parse(orgRule.RuleJson)
+		if err != nil {
+			fmt.Printf("[Automation] Skipping rule %s (%s): %v\n", orgRule.ID, orgRule.Name, err)
+			continue
+		}
+		if !rule.MatchesEvent(topic) || !inScope(orgRule, payload) || !rule.Matches(payload, hasTag) {
+			continue
+		}
+
+		executed := e.execute(ctx, orgRule, rule, topic, eventID, string(payloadBytes), payload)
+		if executed && rule.StopProcessing {
+			break
+		}
+	}
+}
+
+// execute claims the (event, rule) pair in automation_log, runs the rule's
+// actions and records the outcome. It returns false when the pair was
+// already processed.
+func (e *Engine) execute(ctx context.Context, orgRule db.OrgRule, rule *Rule, topic, eventID, rawPayload string, payload map[string]any) bool {
+	logEntry, err := e.db.LogAutomation(ctx, db.LogAutomationParams{
+		ID:           uuid.NewString(),
+		OrgID:        orgRule.OrgID,
+		EventID:      eventID,
+		EventType:    topic,
+		EventPayload: sql.NullString{String: rawPayload, Valid: true},
+		RuleID:       sql.NullString{String: orgRule.ID, Valid: true},
+		RuleName:     orgRule.Name,
+		RuleSnapshot: sql.NullString{String: orgRule.RuleJson, Valid: true},
+		Success:      0,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		// Already processed (e.g. replayed event)
+		return false
+	}
+	if err != nil {
+		fmt.Printf("[Automation] Failed to log rule %s for event %s: %v\n", orgRule.ID, topic, err)
+		return false
+	}
+
+	start := time.Now()
+	results := make([]ActionResult, 0, len(rule.Actions))
+	var failures []string
+
+	for _, action := range rule.Actions {
+		result := ActionResult{Type: action.Type, Success: true}
+		if err := e.runAction(ctx, orgRule, action, topic, payload); err != nil {
+			result.Success = false
+			result.Error = err.Error()
+			failures = append(failures, fmt.Sprintf("%s: %v", action.Type, err))
+		}
+		results = append(results, result)
+	}
+
+	actionsJSON, _ := json.Marshal(results)
+	success := int64(1)
+	var errMsg sql.NullString
+	if len(failures) > 0 {
+		success = 0
+		errMsg = sql.NullString{String: strings.Join(failures, "; "), Valid: true}
+	}
+
+	err = e.db.UpdateAutomationLogResult(ctx, db.UpdateAutomationLogResultParams{
+		ActionsExecuted: sql.NullString{String: string(actionsJSON), Valid: true},
+		Success:         success,
+		ErrorMessage:    errMsg,
+		ExecutionTimeMs: sql.NullInt64{Int64: time.Since(start).Milliseconds(), Valid: true},
+		ID:              logEntry.ID,
+	})
+	if err != nil {
+		fmt.Printf("[Automation] Failed to record result for rule %s: %v\n", orgRule.ID, err)
+	}
+
+	if success == 1 {
+		fmt.Printf("[Automation] Rule %q ran %d action(s) for %s\n", orgRule.Name, len(results), topic)
+	} else {
+		fmt.Printf("[Automation] Rule %q failed for %s: %s\n", orgRule.Name, topic, errMsg.String)
+	}
+	return true
+}
+
+// enrollSequences applies the sequence entry rules triggered by an event:
+// list_join on contact.subscribed, tag_added on contact.tag_added and
+// link_clicked on email.clicked.
+func (e *Engine) enrollSequences(ctx context.Context, topic, orgID, contactID string, payload map[string]any) {
+	if contactID == "" {
+		return
+	}
+
+	var err error
+	switch topic {
+	case events.TopicContactSubscribed:
+		listID, _ := strconv.ParseInt(fmt.Sprint(payload["list_id"]), 10, 64)
+		if listID == 0 {
+			return
+		}
+		err = e.sequences.EnrollOnListJoin(ctx, contactID, listID)
+	case events.TopicContactTagAdded:
+		tag, _ := payload["tag"].(string)
+		err = e.sequences.EnrollOnTagAdded(ctx, orgID, contactID, tag)
+	case events.TopicEmailClicked:
+		clickedURL, _ := payload["clicked_url"].(string)
+		err = e.sequences.EnrollOnLinkClicked(ctx, orgID, contactID, clickedURL)
+	default:
+		return
+	}
+
+	if err != nil {
+		fmt.Printf("[Automation] Failed to apply sequence entry rules for %s: %v\n", topic, err)
+	}
+}
+
+// inScope checks a rule's optional entity binding against the event.
+// Rules bound to a list, sequence or campaign only fire for events about that entity.
+func inScope(rule db.OrgRule, payload map[string]any) bool {
+	if !rule.EntityType.Valid || !rule.EntityID.Valid || rule.EntityID.String == "" {
+		return true
+	}
+
+	var key string
+	switch rule.EntityType.String {
+	case "email_list", "list":
+		key = "list_id"
+	case "sequence":
+		key = "sequence_id"
+	case "campaign":
+		key = "campaign_id"
+	default:
+		return true
+	}
+
+	value, ok := payload[key]
+	return ok && fmt.Sprint(value) == rule.EntityID.String
+}
+
+// eventID derives a deterministic ID for an event so that redelivery of the
+// same event is recognised by the automation_log dedupe index.
+func eventID(topic string, payload []byte) string {
+	h := sha256.New()
+	h.Write([]byte(topic))
+	h.Write([]byte{0})
+	h.Write(payload)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,88 @@
+package automation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/db/dbtest"
+	"github.com/outlet-sh/outlet/internal/events"
+	"github.com/outlet-sh/outlet/internal/services/email"
+)
+
+// linkClickFixture stores a contact and a sequence entered by clicking a
+// link under https://example.com/offer
+func linkClickFixture(t *testing.T, store *db.Store) {
+	t.Helper()
+	dbtest.Exec(t, store, `INSERT INTO organizations (id, name, slug, api_key) VALUES ('org', 'Org', 'org', 'key')`)
+	dbtest.Exec(t, store, `INSERT INTO contacts (id, org_id, name, email, status) VALUES ('contact', 'org', 'Ann', 'ann@example.com', 'active')`)
+	dbtest.Exec(t, store, `INSERT INTO email_sequences (id, org_id, slug, name, trigger_event) VALUES ('seq', 'org', 'offer', 'Offer', 'link_clicked')`)
+	dbtest.Exec(t, store, `INSERT INTO sequence_entry_rules (id, sequence_id, trigger_type, source_id) VALUES ('rule', 'seq', 'link_clicked', 'https://example.com/offer')`)
+}
+
+func enrolled(t *testing.T, store *db.Store) int {
+	t.Helper()
+	var n int
+	if err := store.GetDB().QueryRow(`SELECT COUNT(*) FROM contact_sequence_state WHERE contact_id = 'contact'`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestHandleEvent_ScannerClickDoesNotEnroll(t *testing.T) {
+	store := dbtest.New(t)
+	linkClickFixture(t, store)
+	engine := NewEngine(store, nil, "", nil)
+
+	click := events.EmailEvent{
+		OrgID:      "org",
+		EmailID:    "send",
+		ContactID:  "contact",
+		Status:     "clicked",
+		ClickedURL: "https://example.com/offer?ref=mail",
+		Timestamp:  time.Now(),
+	}
+
+	scanned := click
+	scanned.Machine = true
+	engine.HandleEvent(context.Background(), events.TopicEmailClicked, scanned)
+	if n := enrolled(t, store); n != 0 {
+		t.Fatalf("scanner click enrolled the contact in %d sequences", n)
+	}
+
+	engine.HandleEvent(context.Background(), events.TopicEmailClicked, click)
+	if n := enrolled(t, store); n != 1 {
+		t.Fatalf("human click enrolled the contact in %d sequences, want 1", n)
+	}
+}
+
+func TestRunAction_SendEmailQueuesForDispatcher(t *testing.T) {
+	store := dbtest.New(t)
+	linkClickFixture(t, store)
+	dbtest.Exec(t, store, `INSERT INTO transactional_emails (id, org_id, name, slug, subject, html_body, plain_text) VALUES ('tpl', 'org', 'Welcome', 'welcome', 'Hi {{name}}', '<p>Hello {{email}}</p>', 'Hello {{email}}')`)
+	sender := email.NewService(store, nil)
+	engine := NewEngine(store, sender, "", nil)
+
+	rule := db.OrgRule{ID: "rule", OrgID: "org"}
+	action := Action{Type: ActionSendEmail, Template: "welcome"}
+	if err := engine.runAction(context.Background(), rule, action, events.TopicContactCreated, map[string]any{"contact_id": "contact"}); err != nil {
+		t.Fatalf("runAction() error = %v", err)
+	}
+
+	// Nothing is sent inline: the send is pending and queued for the dispatcher
+	var status, subject, textBody string
+	err := store.GetDB().QueryRow(`SELECT ts.status, q.subject, q.text_body FROM transactional_sends ts JOIN transactional_queue q ON q.send_id = ts.id WHERE ts.contact_id = 'contact'`).Scan(&status, &subject, &textBody)
+	if err != nil {
+		t.Fatalf("queued send not found: %v", err)
+	}
+	if status != "pending" || subject != "Hi Ann" || textBody != "Hello ann@example.com" {
+		t.Errorf("queued send = %q %q %q", status, subject, textBody)
+	}
+
+	select {
+	case <-sender.TransactionalQueued():
+	default:
+		t.Error("dispatcher was not woken for the queued send")
+	}
+}
@@ -0,0 +1,259 @@
+package automation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var ErrInvalidRule = errors.New("invalid rule")
+
+// Supported action types
+const (
+	ActionAddTag           = "add_tag"
+	ActionRemoveTag        = "remove_tag"
+	ActionEnrollSequence   = "enroll_sequence"
+	ActionUnenrollSequence = "unenroll_sequence"
+	ActionMoveList         = "move_list"
+	ActionSendEmail        = "send_email"
+	ActionWebhook          = "webhook"
+)
+
+// FieldTag is the condition field that checks the event's contact tags
+// rather than a key in the event payload.
+const FieldTag = "tag"
+
+var conditionOps = []string{"eq", "neq", "contains", "not_contains", "starts_with", "in", "not_in", "exists", "not_exists"}
+
+// Rule is the JSON document stored in org_rules.rule_json.
+//
+// Example:
+//
+//	{
+//	  "event": "email.clicked",
+//	  "match": "all",
+//	  "conditions": [
+//	    {"field": "clicked_url", "op": "contains", "value": "/pricing"},
+//	    {"field": "tag", "op": "neq", "value": "customer"}
+//	  ],
+//	  "actions": [
+//	    {"type": "add_tag", "tag": "hot-lead"},
+//	    {"type": "enroll_sequence", "sequence_id": "..."}
+//	  ],
+//	  "stop_processing": true
+//	}
+type Rule struct {
+	Event          string      `json:"event"`           // event topic, or "*" for every topic
+	Match          string      `json:"match,omitempty"` // all (default) or any
+	Conditions     []Condition `json:"conditions,omitempty"`
+	Actions        []Action    `json:"actions"`
+	StopProcessing bool        `json:"stop_processing,omitempty"` // skip lower-salience rules once this one runs
+}
+
+// Condition is a single predicate against the event payload. Field names are
+// the payload's JSON keys (org_id, contact_id, list_id, clicked_url, ...), plus
+// "tag" which checks the contact's tags.
+type Condition struct {
+	Field  string   `json:"field"`
+	Op     string   `json:"op"`
+	Value  string   `json:"value,omitempty"`
+	Values []string `json:"values,omitempty"` // in / not_in
+}
+
+// Action is a single side effect run when a rule matches
+type Action struct {
+	Type       string `json:"type"`
+	Tag        string `json:"tag,omitempty"`          // add_tag / remove_tag
+	SequenceID string `json:"sequence_id,omitempty"`  // enroll_sequence / unenroll_sequence
+	ListID     int64  `json:"list_id,omitempty"`      // move_list target
+	FromListID int64  `json:"from_list_id,omitempty"` // move_list source, defaults to the event's list
+	Template   string `json:"template,omitempty"`     // send_email transactional template slug
+	URL        string `json:"url,omitempty"`          // webhook
+	Secret     string `json:"secret,omitempty"`       // webhook HMAC secret
+}
+
+// Parse decodes and validates a rule
+func Parse(raw string) (*Rule, error) {
+	var r Rule
+	if err := json.Unmarshal([]byte(raw), &r); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+	}
+	if problems := r.problems(); len(problems) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRule, strings.Join(problems, "; "))
+	}
+	return &r, nil
+}
+
+// Validate returns every problem found in a rule document, or nil if it is valid
+func Validate(raw string) []string {
+	var r Rule
+	if err := json.Unmarshal([]byte(raw), &r); err != nil {
+		return []string{fmt.Sprintf("invalid JSON: %v", err)}
+	}
+	return r.problems()
+}
+
+// Hash returns a stable fingerprint of a rule document, ignoring formatting
+func Hash(raw string) string {
+	normalized := []byte(raw)
+	var v any
+	if err := json.Unmarshal(normalized, &v); err == nil {
+		normalized, _ = json.Marshal(v)
+	}
+	sum := sha256.Sum256(normalized)
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *Rule) problems() []string {
+	var problems []string
+
+	if strings.TrimSpace(r.Event) == "" {
+		problems = append(problems, "event is required")
+	}
+
+	switch strings.ToLower(r.Match) {
+	case "", "all", "any":
+	default:
+		problems = append(problems, fmt.Sprintf("unknown match %q", r.Match))
+	}
+
+	for i, c := range r.Conditions {
+		if c.Field == "" {
+			problems = append(problems, fmt.Sprintf("conditions[%d]: field is required", i))
+		}
+		if !slices.Contains(conditionOps, c.Op) {
+			problems = append(problems, fmt.Sprintf("conditions[%d]: unknown op %q", i, c.Op))
+			continue
+		}
+		if c.Field == FieldTag && slices.Contains([]string{"starts_with", "exists", "not_exists"}, c.Op) {
+			problems = append(problems, fmt.Sprintf("conditions[%d]: op %q is not supported for tag", i, c.Op))
+			continue
+		}
+		switch c.Op {
+		case "in", "not_in":
+			if len(c.Values) == 0 {
+				problems = append(problems, fmt.Sprintf("conditions[%d]: %s requires values", i, c.Op))
+			}
+		case "exists", "not_exists":
+		default:
+			if c.Value == "" {
+				problems = append(problems, fmt.Sprintf("conditions[%d]: %s requires a value", i, c.Op))
+			}
+		}
+	}
+
+	if len(r.Actions) == 0 {
+		problems = append(problems, "at least one action is required")
+	}
+
+	for i, a := range r.Actions {
+		var missing string
+		switch a.Type {
+		case ActionAddTag, ActionRemoveTag:
+			if a.Tag == "" {
+				missing = "tag"
+			}
+		case ActionEnrollSequence, ActionUnenrollSequence:
+			if a.SequenceID == "" {
+				missing = "sequence_id"
+			}
+		case ActionMoveList:
+			if a.ListID <= 0 {
+				missing = "list_id"
+			}
+		case ActionSendEmail:
+			if a.Template == "" {
+				missing = "template"
+			}
+		case ActionWebhook:
+			if !strings.HasPrefix(a.URL, "http://") && !strings.HasPrefix(a.URL, "https://") {
+				missing = "an http(s) url"
+			}
+		default:
+			problems = append(problems, fmt.Sprintf("actions[%d]: unknown type %q", i, a.Type))
+			continue
+		}
+		if missing != "" {
+			problems = append(problems, fmt.Sprintf("actions[%d]: %s requires %s", i, a.Type, missing))
+		}
+	}
+
+	return problems
+}
+
+// MatchesEvent reports whether the rule listens for the given topic
+func (r *Rule) MatchesEvent(topic string) bool {
+	return r.Event == "*" || r.Event == topic
+}
+
+// Matches evaluates the rule conditions against an event payload. hasTag is
+// consulted for "tag" conditions and may be nil when the event has no contact.
+func (r *Rule) Matches(payload map[string]any, hasTag func(tag string) bool) bool {
+	if len(r.Conditions) == 0 {
+		return true
+	}
+
+	matchAny := strings.ToLower(r.Match) == "any"
+	for _, c := range r.Conditions {
+		ok := c.matches(payload, hasTag)
+		if matchAny && ok {
+			return true
+		}
+		if !matchAny && !ok {
+			return false
+		}
+	}
+	return !matchAny
+}
+
+func (c Condition) matches(payload map[string]any, hasTag func(tag string) bool) bool {
+	if c.Field == FieldTag {
+		has := func(tag string) bool { return hasTag != nil && hasTag(tag) }
+		switch c.Op {
+		case "eq", "contains":
+			return has(c.Value)
+		case "neq", "not_contains":
+			return !has(c.Value)
+		case "in":
+			return slices.ContainsFunc(c.Values, has)
+		case "not_in":
+			return !slices.ContainsFunc(c.Values, has)
+		}
+		return false
+	}
+
+	raw, present := payload[c.Field]
+	if raw == nil {
+		present = false
+	}
+	value := ""
+	if present {
+		value = fmt.Sprint(raw)
+	}
+
+	switch c.Op {
+	case "exists":
+		return present && value != ""
+	case "not_exists":
+		return !present || value == ""
+	case "eq":
+		return strings.EqualFold(value, c.Value)
+	case "neq":
+		return !strings.EqualFold(value, c.Value)
+	case "contains":
+		return strings.Contains(strings.ToLower(value), strings.ToLower(c.Value))
+	case "not_contains":
+		return !strings.Contains(strings.ToLower(value), strings.ToLower(c.Value))
+	case "starts_with":
+		return strings.HasPrefix(strings.ToLower(value), strings.ToLower(c.Value))
+	case "in":
+		return slices.ContainsFunc(c.Values, func(v string) bool { return strings.EqualFold(value, v) })
+	case "not_in":
+		return !slices.ContainsFunc(c.Values, func(v string) bool { return strings.EqualFold(value, v) })
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package automation
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/outlet-sh/outlet/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Valid(t *testing.T) {
+	r, err := Parse(`{
+		"event": "email.clicked",
+		"conditions": [{"field": "clicked_url", "op": "contains", "value": "/pricing"}],
+		"actions": [{"type": "add_tag", "tag": "hot-lead"}],
+		"stop_processing": true
+	}`)
+	require.NoError(t, err)
+	assert.Equal(t, "email.clicked", r.Event)
+	assert.True(t, r.StopProcessing)
+	assert.Len(t, r.Actions, 1)
+}
+
+func TestParse_InvalidJSON(t *testing.T) {
+	_, err := Parse(`{"event": `)
+	assert.True(t, errors.Is(err, ErrInvalidRule))
+}
+
+func TestValidate_CollectsProblems(t *testing.T) {
+	problems := Validate(`{
+		"match": "some",
+		"conditions": [{"field": "tag", "op": "starts_with", "value": "v"}, {"field": "list_id", "op": "in"}],
+		"actions": [{"type": "add_tag"}, {"type": "webhook", "url": "ftp://example.com"}, {"type": "explode"}]
+	}`)
+	assert.Len(t, problems, 7)
+	assert.Contains(t, problems, "event is required")
+
+	assert.Empty(t, Validate(`{"event": "*", "actions": [{"type": "move_list", "list_id": 3}]}`))
+	assert.Equal(t, []string{"event is required", "at least one action is required"}, Validate(`{}`))
+}
+
+func TestHash_IgnoresFormatting(t *testing.T) {
+	a := Hash(`{"event": "contact.created", "actions": [{"type": "add_tag", "tag": "new"}]}`)
+	b := Hash("{\n  \"actions\": [{\"tag\": \"new\", \"type\": \"add_tag\"}],\n  \"event\": \"contact.created\"\n}")
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, Hash(`{"event": "contact.created", "actions": [{"type": "add_tag", "tag": "old"}]}`))
+}
+
+func TestMatchesEvent(t *testing.T) {
+	assert.True(t, (&Rule{Event: "email.opened"}).MatchesEvent("email.opened"))
+	assert.False(t, (&Rule{Event: "email.opened"}).MatchesEvent("email.clicked"))
+	assert.True(t, (&Rule{Event: "*"}).MatchesEvent("contact.created"))
+}
+
+func TestMatches_PayloadConditions(t *testing.T) {
+	payload := map[string]any{
+		"org_id":      "org-1",
+		"contact_id":  "c-1",
+		"clicked_url": "https://example.com/Pricing?plan=pro",
+		"source":      "api",
+	}
+
+	cases := []struct {
+		cond Condition
+		want bool
+	}{
+		{Condition{Field: "source", Op: "eq", Value: "API"}, true},
+		{Condition{Field: "source", Op: "neq", Value: "api"}, false},
+		{Condition{Field: "clicked_url", Op: "contains", Value: "/pricing"}, true},
+		{Condition{Field: "clicked_url", Op: "not_contains", Value: "/pricing"}, false},
+		{Condition{Field: "clicked_url", Op: "starts_with", Value: "https://example.com"}, true},
+		{Condition{Field: "source", Op: "in", Values: []string{"form", "api"}}, true},
+		{Condition{Field: "source", Op: "not_in", Values: []string{"form", "api"}}, false},
+		{Condition{Field: "list_id", Op: "exists"}, false},
+		{Condition{Field: "list_id", Op: "not_exists"}, true},
+	}
+	for _, tc := range cases {
+		r := &Rule{Conditions: []Condition{tc.cond}}
+		assert.Equal(t, tc.want, r.Matches(payload, nil), "%s %s", tc.cond.Field, tc.cond.Op)
+	}
+}
+
+func TestMatches_MatchAnyAndAll(t *testing.T) {
+	payload := map[string]any{"source": "api"}
+	conditions := []Condition{
+		{Field: "source", Op: "eq", Value: "api"},
+		{Field: "source", Op: "eq", Value: "form"},
+	}
+
+	assert.False(t, (&Rule{Conditions: conditions}).Matches(payload, nil))
+	assert.True(t, (&Rule{Match: "any", Conditions: conditions}).Matches(payload, nil))
+	assert.True(t, (&Rule{}).Matches(payload, nil))
+}
+
+func TestMatches_TagConditions(t *testing.T) {
+	hasTag := func(tag string) bool { return tag == "vip" }
+
+	assert.True(t, (&Rule{Conditions: []Condition{{Field: "tag", Op: "eq", Value: "vip"}}}).Matches(nil, hasTag))
+	assert.True(t, (&Rule{Conditions: []Condition{{Field: "tag", Op: "neq", Value: "customer"}}}).Matches(nil, hasTag))
+	assert.True(t, (&Rule{Conditions: []Condition{{Field: "tag", Op: "in", Values: []string{"a", "vip"}}}}).Matches(nil, hasTag))
+	assert.False(t, (&Rule{Conditions: []Condition{{Field: "tag", Op: "not_in", Values: []string{"a", "vip"}}}}).Matches(nil, hasTag))
+
+	// Without a contact no tag is present
+	assert.False(t, (&Rule{Conditions: []Condition{{Field: "tag", Op: "eq", Value: "vip"}}}).Matches(nil, nil))
+}
+
+func TestInScope(t *testing.T) {
+	payload := map[string]any{"list_id": "5", "sequence_id": "seq-1"}
+
+	assert.True(t, inScope(db.OrgRule{}, payload))
+	assert.True(t, inScope(db.OrgRule{
+		EntityType: sql.NullString{String: "email_list", Valid: true},
+		EntityID:   sql.NullString{String: "5", Valid: true},
+	}, payload))
+	assert.False(t, inScope(db.OrgRule{
+		EntityType: sql.NullString{String: "sequence", Valid: true},
+		EntityID:   sql.NullString{String: "seq-2", Valid: true},
+	}, payload))
+	assert.False(t, inScope(db.OrgRule{
+		EntityType: sql.NullString{String: "campaign", Valid: true},
+		EntityID:   sql.NullString{String: "camp-1", Valid: true},
+	}, payload))
+}
+
+func TestEventID_Deterministic(t *testing.T) {
+	payload := []byte(`{"org_id":"org-1","contact_id":"c-1"}`)
+	assert.Equal(t, eventID("contact.created", payload), eventID("contact.created", payload))
+	assert.NotEqual(t, eventID("contact.created", payload), eventID("contact.unsubscribed", payload))
+}
+
+func TestInstantiate_FillsParams(t *testing.T) {
+	params, err := ParseTemplateParams(`[
+		{"name": "tag", "required": true},
+		{"name": "list_id", "type": "number", "default": "7"}
+	]`)
+	require.NoError(t, err)
+
+	out, err := Instantiate(
+		`{"event": "contact.created", "actions": [{"type": "add_tag", "tag": "{{tag}}"}, {"type": "move_list", "list_id": {{list_id}}}]}`,
+		params, map[string]string{"tag": `say "hi"`})
+	require.NoError(t, err)
+
+	r, err := Parse(out)
+	require.NoError(t, err)
+	assert.Equal(t, `say "hi"`, r.Actions[0].Tag)
+	assert.Equal(t, int64(7), r.Actions[1].ListID)
+}
+
+func TestInstantiate_RejectsBadParams(t *testing.T) {
+	params := []TemplateParam{{Name: "tag", Required: true}, {Name: "list_id", Type: "number"}}
+	ruleJSON := `{"event": "*", "actions": [{"type": "add_tag", "tag": "{{tag}}"}, {"type": "move_list", "list_id": {{list_id}}}]}`
+
+	_, err := Instantiate(ruleJSON, params, nil)
+	assert.True(t, errors.Is(err, ErrInvalidRule))
+
+	_, err = Instantiate(ruleJSON, params, map[string]string{"tag": "x", "list_id": "abc"})
+	assert.True(t, errors.Is(err, ErrInvalidRule))
+}
+
+func TestParseTemplateParams_Empty(t *testing.T) {
+	params, err := ParseTemplateParams("")
+	require.NoError(t, err)
+	assert.Empty(t, params)
+}
@@ -0,0 +1,77 @@
+package automation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TemplateParam describes one entry of rule_templates.configurable_params.
+// Template rule_json refers to params as {{name}} placeholders; string params
+// are written inside JSON strings ("tag": "{{tag}}") and number params bare
+// ("list_id": {{list_id}}).
+//
+// Example:
+//
+//	[
+//	  {"name": "tag", "label": "Tag to add", "type": "string", "required": true},
+//	  {"name": "list_id", "label": "Destination list", "type": "number"}
+//	]
+type TemplateParam struct {
+	Name     string `json:"name"`
+	Label    string `json:"label,omitempty"`
+	Type     string `json:"type,omitempty"` // string (default) or number
+	Default  string `json:"default,omitempty"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// ParseTemplateParams decodes rule_templates.configurable_params. Empty input yields no params.
+func ParseTemplateParams(raw string) ([]TemplateParam, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || raw == "null" {
+		return nil, nil
+	}
+
+	var params []TemplateParam
+	if err := json.Unmarshal([]byte(raw), &params); err != nil {
+		return nil, fmt.Errorf("invalid configurable_params: %w", err)
+	}
+	return params, nil
+}
+
+// Instantiate fills a template's placeholders with the given values and
+// returns the resulting rule_json, which is validated before returning.
+func Instantiate(ruleJSON string, params []TemplateParam, values map[string]string) (string, error) {
+	out := ruleJSON
+	for _, p := range params {
+		value, ok := values[p.Name]
+		if !ok || value == "" {
+			value = p.Default
+		}
+		if value == "" && p.Required {
+			return "", fmt.Errorf("%w: parameter %q is required", ErrInvalidRule, p.Name)
+		}
+
+		switch p.Type {
+		case "number":
+			if value == "" {
+				value = "0"
+			}
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return "", fmt.Errorf("%w: parameter %q must be a number", ErrInvalidRule, p.Name)
+			}
+		default:
+			// Escape for embedding inside a JSON string
+			b, _ := json.Marshal(value)
+			value = string(b[1 : len(b)-1])
+		}
+
+		out = strings.ReplaceAll(out, "{{"+p.Name+"}}", value)
+	}
+
+	if _, err := Parse(out); err != nil {
+		return "", err
+	}
+	return out, nil
+}
@@ -0,0 +1,64 @@
+package automation
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/outlet-sh/outlet/internal/db"
+)
+
+// ValidateForOrg runs the structural checks of Validate and additionally
+// verifies that sequences, lists and transactional templates referenced by
+// the rule's actions exist in the organization.
+func ValidateForOrg(ctx context.Context, store *db.Store, orgID, raw string) []string {
+	problems := Validate(raw)
+	if len(problems) > 0 {
+		return problems
+	}
+
+	r, err := Parse(raw)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	for i, a := range r.Actions {
+		switch a.Type {
+		case ActionEnrollSequence, ActionUnenrollSequence:
+			seq, err := store.GetSequenceByID(ctx, a.SequenceID)
+			if err != nil || seq.OrgID.String != orgID {
+				problems = append(problems, fmt.Sprintf("actions[%d]: sequence %s not found", i, a.SequenceID))
+			}
+		case ActionMoveList:
+			for _, listID := range []int64{a.ListID, a.FromListID} {
+				if listID == 0 {
+					continue
+				}
+				list, err := store.GetEmailList(ctx, listID)
+				if err != nil || list.OrgID != orgID {
+					problems = append(problems, fmt.Sprintf("actions[%d]: list %d not found", i, listID))
+				}
+			}
+		case ActionSendEmail:
+			_, err := store.GetTransactionalEmailBySlug(ctx, db.GetTransactionalEmailBySlugParams{
+				Slug:  a.Template,
+				OrgID: orgID,
+			})
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("actions[%d]: transactional email %q not found", i, a.Template))
+			}
+		}
+	}
+
+	return problems
+}
+
+// ValidationErrorsJSON encodes problems for org_rules.validation_errors, which is NULL for a valid rule
+func ValidationErrorsJSON(problems []string) sql.NullString {
+	if len(problems) == 0 {
+		return sql.NullString{}
+	}
+	b, _ := json.Marshal(problems)
+	return sql.NullString{String: string(b), Valid: true}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -186,3 +187,44 @@ func DeleteFromS3(ctx context.Context, cfg S3Config, s3Key string) error {
 
 	return nil
 }
+
+// PresignS3URL returns a time-limited GET URL for an S3 object
+func PresignS3URL(ctx context.Context, cfg S3Config, s3Key string, expires time.Duration) (string, error) {
+	if cfg.Bucket == "" {
+		return "", fmt.Errorf("S3 bucket not configured")
+	}
+
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
+	// Load AWS config
+	var awsCfg aws.Config
+	var err error
+
+	if cfg.AccessKey != "" && cfg.SecretKey != "" {
+		awsCfg, err = config.LoadDefaultConfig(ctx,
+			config.WithRegion(cfg.Region),
+			config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretKey, "")),
+		)
+	} else {
+		awsCfg, err = config.LoadDefaultConfig(ctx, config.WithRegion(cfg.Region))
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	// Create presign client
+	client := s3.NewPresignClient(s3.NewFromConfig(awsCfg))
+
+	req, err := client.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(cfg.Bucket),
+		Key:    aws.String(s3Key),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign S3 URL: %w", err)
+	}
+
+	return req.URL, nil
+}
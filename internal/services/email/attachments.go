@@ -0,0 +1,150 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/outlet-sh/outlet/internal/db"
+)
+
+const (
+	// MaxAttachments caps the number of attachments on one message
+	MaxAttachments = 10
+
+	// MaxAttachmentBytes caps the decoded size of all attachments on one
+	// message, keeping the base64-encoded message under the 10MB SES limit
+	MaxAttachmentBytes = 7 << 20
+)
+
+// allowedAttachmentTypes lists the content types that may be attached.
+// Executables and scripts are rejected.
+var allowedAttachmentTypes = map[string]bool{
+	"application/pdf":               true,
+	"application/json":              true,
+	"application/xml":               true,
+	"application/zip":               true,
+	"application/msword":            true,
+	"application/vnd.ms-excel":      true,
+	"application/vnd.ms-powerpoint": true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         true,
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": true,
+	"application/vnd.oasis.opendocument.text":                                   true,
+	"application/vnd.oasis.opendocument.spreadsheet":                            true,
+	"image/png":      true,
+	"image/jpeg":     true,
+	"image/gif":      true,
+	"image/webp":     true,
+	"text/plain":     true,
+	"text/csv":       true,
+	"text/calendar":  true,
+	"text/xml":       true,
+	"message/rfc822": true,
+}
+
+// NormalizeAttachments validates attachments against the count, size and
+// content type limits. Content types are lowercased without parameters and
+// guessed from the filename when missing; unnamed parts get a generic name.
+func NormalizeAttachments(attachments []Attachment) ([]Attachment, error) {
+	if len(attachments) > MaxAttachments {
+		return nil, fmt.Errorf("too many attachments: %d (max %d)", len(attachments), MaxAttachments)
+	}
+
+	total := 0
+	out := make([]Attachment, 0, len(attachments))
+	for i, a := range attachments {
+		total += len(a.Data)
+		if total > MaxAttachmentBytes {
+			return nil, fmt.Errorf("attachments exceed %d MB", MaxAttachmentBytes>>20)
+		}
+
+		contentType := ""
+		if a.ContentType != "" {
+			mediaType, _, err := mime.ParseMediaType(a.ContentType)
+			if err != nil {
+				return nil, fmt.Errorf("attachment %d has an invalid content type %q", i+1, a.ContentType)
+			}
+			contentType = strings.ToLower(mediaType)
+		}
+
+		filename := path.Base(strings.ReplaceAll(stripHeaderBreaks(a.Filename), "\\", "/"))
+		if filename == "." || filename == "/" {
+			filename = ""
+		}
+
+		if (contentType == "" || contentType == "application/octet-stream") && filename != "" {
+			if guessed, _, err := mime.ParseMediaType(mime.TypeByExtension(path.Ext(filename))); err == nil {
+				contentType = guessed
+			}
+		}
+		if contentType == "" {
+			return nil, fmt.Errorf("attachment %d has no content type", i+1)
+		}
+		if !allowedAttachmentTypes[contentType] {
+			return nil, fmt.Errorf("attachment type %s is not allowed", contentType)
+		}
+
+		if filename == "" {
+			filename = fmt.Sprintf("attachment-%d", i+1)
+			if exts, _ := mime.ExtensionsByType(contentType); len(exts) > 0 {
+				filename += exts[0]
+			}
+		}
+
+		out = append(out, Attachment{
+			Filename:    filename,
+			ContentType: contentType,
+			Data:        a.Data,
+			ContentID:   strings.Trim(a.ContentID, "<> "),
+		})
+	}
+	return out, nil
+}
+
+// SetAttachmentRetention sets how many days attachment content is kept for
+// transactional sends. Zero keeps metadata only.
+func (s *Service) SetAttachmentRetention(days int) {
+	s.attachmentRetentionDays = days
+}
+
+// StoreAttachments records the attachments of a transactional send. Content
+// is kept until the send is processed, or for the retention window when one
+// is configured. The window counts from sendAt; zero means now. Rows are
+// written with q, so they can join the transaction that creates the send.
+func (s *Service) StoreAttachments(ctx context.Context, q *db.Queries, orgID, sendID string, attachments []Attachment, sendAt time.Time) error {
+	var expiresAt sql.NullString
+	if s.attachmentRetentionDays > 0 {
+		if sendAt.IsZero() {
+			sendAt = time.Now()
+		}
+		expiresAt = sql.NullString{
+			String: sendAt.UTC().AddDate(0, 0, s.attachmentRetentionDays).Format("2006-01-02 15:04:05"),
+			Valid:  true,
+		}
+	}
+
+	for i, a := range attachments {
+		err := q.CreateTransactionalAttachment(ctx, db.CreateTransactionalAttachmentParams{
+			ID:          uuid.New().String(),
+			SendID:      sendID,
+			OrgID:       orgID,
+			Position:    int64(i),
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Size:        int64(len(a.Data)),
+			ContentID:   sql.NullString{String: a.ContentID, Valid: a.ContentID != ""},
+			Content:     a.Data,
+			ExpiresAt:   expiresAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store attachment %s: %w", a.Filename, err)
+		}
+	}
+	return nil
+}
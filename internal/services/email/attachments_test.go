@@ -0,0 +1,69 @@
+package email
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeAttachments(t *testing.T) {
+	got, err := NormalizeAttachments([]Attachment{
+		{Filename: "../../etc/invoice.pdf", Data: []byte("%PDF")},
+		{Filename: "logo.png", ContentType: "IMAGE/PNG; charset=binary", Data: []byte("png"), ContentID: "<logo>"},
+		{ContentType: "text/csv", Data: []byte("a,b")},
+	})
+	if err != nil {
+		t.Fatalf("NormalizeAttachments() error = %v", err)
+	}
+
+	if got[0].Filename != "invoice.pdf" || got[0].ContentType != "application/pdf" {
+		t.Errorf("first attachment = %q %q, want invoice.pdf application/pdf", got[0].Filename, got[0].ContentType)
+	}
+	if got[1].ContentType != "image/png" || got[1].ContentID != "logo" {
+		t.Errorf("second attachment = %q cid %q", got[1].ContentType, got[1].ContentID)
+	}
+	if !strings.HasPrefix(got[2].Filename, "attachment-3") {
+		t.Errorf("unnamed attachment got filename %q", got[2].Filename)
+	}
+}
+
+func TestNormalizeAttachments_Limits(t *testing.T) {
+	tests := []struct {
+		name        string
+		attachments []Attachment
+		wantErr     string
+	}{
+		{
+			name:        "disallowed type",
+			attachments: []Attachment{{Filename: "run.exe", ContentType: "application/x-msdownload", Data: []byte("MZ")}},
+			wantErr:     "not allowed",
+		},
+		{
+			name:        "unknown type",
+			attachments: []Attachment{{Filename: "blob", Data: []byte("x")}},
+			wantErr:     "no content type",
+		},
+		{
+			name: "too large",
+			attachments: []Attachment{
+				{Filename: "a.pdf", Data: bytes.Repeat([]byte("x"), MaxAttachmentBytes/2+1)},
+				{Filename: "b.pdf", Data: bytes.Repeat([]byte("x"), MaxAttachmentBytes/2+1)},
+			},
+			wantErr: "exceed",
+		},
+		{
+			name:        "too many",
+			attachments: make([]Attachment, MaxAttachments+1),
+			wantErr:     "too many",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NormalizeAttachments(tt.attachments)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NormalizeAttachments() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package email
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"math"
 	"sync"
@@ -12,7 +13,6 @@ import (
 	"github.com/outlet-sh/outlet/internal/db"
 
 	"github.com/zeromicro/go-zero/core/logx"
-	"golang.org/x/time/rate"
 )
 
 // DispatcherConfig configures the email dispatcher
@@ -20,7 +20,8 @@ type DispatcherConfig struct {
 	// Worker pool settings
 	Workers int // Number of concurrent workers (default: 10)
 
-	// Rate limiting (token bucket)
+	// Rate limiting (token bucket) for mail without an organization;
+	// organizations are limited by their own SES rate and daily quota
 	RateLimit       float64 // Emails per second (default: 14 for SES)
 	RateBurst       int     // Max burst size (default: 50)
 
@@ -56,11 +57,19 @@ func DefaultDispatcherConfig() DispatcherConfig {
 	}
 }
 
+// transactionalLease is how long a claimed transactional send is hidden
+// from other claims; a send whose worker died is retried after it
+const transactionalLease = 5 * time.Minute
+
 // EmailJob represents an email to be sent
 type EmailJob struct {
 	Email       db.GetPendingEmailsRow
 	Attempt     int
 	NextAttempt time.Time
+
+	// Transactional is set for sends from the transactional queue, which
+	// retry through the database instead of the in-memory retry queue
+	Transactional *db.TransactionalQueue
 }
 
 // circuitState represents circuit breaker state
@@ -73,34 +82,34 @@ const (
 )
 
 // Dispatcher handles high-volume email sending with worker pools,
-// rate limiting, retries, and circuit breaker
+// rate limiting, retries, and circuit breaker. Queued transactional mail is
+// sent ahead of sequence and marketing mail.
 type Dispatcher struct {
 	config          DispatcherConfig
 	sequenceService *SequenceService
 	db              *db.Store
 
-	// Rate limiter
-	limiter *rate.Limiter
-
 	// Circuit breaker state
 	circuit          atomic.Int32
 	consecutiveFails atomic.Int32
 	circuitOpenedAt  atomic.Int64
 
 	// Worker pool
-	jobs    chan EmailJob
-	retries chan EmailJob
+	jobs     chan EmailJob
+	priority chan EmailJob // Transactional sends, taken before jobs
+	retries  chan EmailJob
 
 	// Lifecycle
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
+	guard  func(name string) // Deferred at the top of every goroutine
 
 	// Metrics
 	sent       atomic.Int64
 	failed     atomic.Int64
 	retried    atomic.Int64
-	rateErrors atomic.Int64
+	deferred   atomic.Int64 // Left pending because the org hit its daily quota
 }
 
 // NewDispatcher creates a new email dispatcher
@@ -111,16 +120,28 @@ func NewDispatcher(sequenceService *SequenceService, db *db.Store, config Dispat
 		config:          config,
 		sequenceService: sequenceService,
 		db:              db,
-		limiter:         rate.NewLimiter(rate.Limit(config.RateLimit), config.RateBurst),
 		jobs:            make(chan EmailJob, config.BatchSize*2),
+		priority:        make(chan EmailJob, config.BatchSize),
 		retries:         make(chan EmailJob, config.BatchSize),
 		ctx:             ctx,
 		cancel:          cancel,
+		guard:           func(string) {},
+	}
+
+	if sequenceService != nil && sequenceService.sender != nil && sequenceService.sender.throttle != nil {
+		sequenceService.sender.throttle.SetPlatformRate(config.RateLimit, config.RateBurst)
 	}
 
 	return d
 }
 
+// SetGuard sets a function deferred at the top of each dispatcher
+// goroutine, normally one that recovers and records a panic so the owner
+// can restart the dispatcher. Call it before Start.
+func (d *Dispatcher) SetGuard(guard func(name string)) {
+	d.guard = guard
+}
+
 // Start begins the dispatcher with worker pool
 func (d *Dispatcher) Start() {
 	logx.Infof("Starting email dispatcher: %d workers, %.1f emails/sec, batch size %d",
@@ -173,13 +194,18 @@ func (d *Dispatcher) Stop() {
 // worker processes email jobs from the channel
 func (d *Dispatcher) worker(id int) {
 	defer d.wg.Done()
+	defer d.guard("Email dispatcher")
 	logx.Infof("Email worker %d started", id)
 
-	for job := range d.jobs {
-		select {
-		case <-d.ctx.Done():
-			return
-		default:
+	for {
+		job, ok := d.nextJob()
+		if !ok {
+			break
+		}
+
+		if job.Transactional != nil {
+			d.processTransactional(*job.Transactional)
+			continue
 		}
 
 		// Check circuit breaker
@@ -195,17 +221,20 @@ func (d *Dispatcher) worker(id int) {
 			continue
 		}
 
-		// Wait for rate limiter
-		if err := d.limiter.Wait(d.ctx); err != nil {
-			if d.ctx.Err() != nil {
-				return // Shutting down
-			}
-			d.rateErrors.Add(1)
+		// Send the email; without a free token for the org the job is
+		// deferred so the worker moves on to other organizations' mail
+		err := d.sendEmail(job)
+		var limited *RateLimitError
+		if errors.As(err, &limited) {
+			d.deferEmail(job.Email, limited.RetryAt)
+			continue
+		}
+		if errors.Is(err, ErrDailyQuotaReached) {
+			// Stays pending and is fetched again after the quota resets
+			d.deferEmail(job.Email, QuotaResetTime(time.Now()))
+			d.deferred.Add(1)
 			continue
 		}
-
-		// Send the email
-		err := d.sendEmail(job)
 		if err != nil {
 			d.handleSendError(job, err)
 		} else {
@@ -216,6 +245,116 @@ func (d *Dispatcher) worker(id int) {
 	logx.Infof("Email worker %d stopped", id)
 }
 
+// nextJob returns the next job, preferring transactional sends
+func (d *Dispatcher) nextJob() (EmailJob, bool) {
+	select {
+	case job := <-d.priority:
+		return job, true
+	default:
+	}
+
+	select {
+	case job := <-d.priority:
+		return job, true
+	case job, ok := <-d.jobs:
+		return job, ok
+	case <-d.ctx.Done():
+		return EmailJob{}, false
+	}
+}
+
+// processTransactional sends a claimed transactional send and records the
+// outcome in the queue
+func (d *Dispatcher) processTransactional(item db.TransactionalQueue) {
+	ctx := context.Background()
+
+	if d.isCircuitOpen() {
+		d.deferTransactional(item, time.Now().Add(d.config.CircuitTimeout/2))
+		return
+	}
+
+	sender := d.sequenceService.sender
+	msg, err := sender.queuedMessage(ctx, item)
+	if err == nil {
+		err = sender.SendMessage(ctx, msg)
+	}
+
+	var limited *RateLimitError
+	switch {
+	case err == nil:
+		d.recordSuccess()
+		d.finishTransactional(item, "sent", "")
+		d.sent.Add(1)
+
+	case errors.As(err, &limited):
+		d.deferTransactional(item, limited.RetryAt)
+
+	case errors.Is(err, ErrDailyQuotaReached):
+		d.deferTransactional(item, QuotaResetTime(time.Now()))
+		d.deferred.Add(1)
+
+	case int(item.Attempts) >= d.config.MaxRetries:
+		d.recordFailure()
+		d.finishTransactional(item, "failed", fmt.Sprintf("max retries (%d) exceeded: %v", d.config.MaxRetries, err))
+		d.failed.Add(1)
+		logx.Errorf("Transactional send %s to %s permanently failed: %v", item.SendID, item.ToEmail, err)
+
+	default:
+		d.recordFailure()
+		backoff := d.calculateBackoff(int(item.Attempts))
+		if err := d.db.RetryTransactionalQueueItem(ctx, db.RetryTransactionalQueueItemParams{
+			NextAttemptAt: time.Now().Add(backoff).UTC().Format(time.DateTime),
+			LastError:     sql.NullString{String: err.Error(), Valid: true},
+			SendID:        item.SendID,
+		}); err != nil {
+			logx.Errorf("Failed to reschedule transactional send %s: %v", item.SendID, err)
+		}
+		d.retried.Add(1)
+		logx.Infof("Transactional send %s queued for retry %d/%d in %v: %v",
+			item.SendID, item.Attempts, d.config.MaxRetries, backoff, err)
+	}
+}
+
+// finishTransactional records the final status of a transactional send and
+// removes it from the queue
+func (d *Dispatcher) finishTransactional(item db.TransactionalQueue, status, errMsg string) {
+	ctx := context.Background()
+
+	if err := d.db.UpdateTransactionalSendStatus(ctx, db.UpdateTransactionalSendStatusParams{
+		ID:           item.SendID,
+		Status:       sql.NullString{String: status, Valid: true},
+		ErrorMessage: sql.NullString{String: errMsg, Valid: errMsg != ""},
+	}); err != nil {
+		logx.Errorf("Failed to mark transactional send %s as %s: %v", item.SendID, status, err)
+	}
+	if err := d.db.DeleteTransactionalQueueItem(ctx, item.SendID); err != nil {
+		logx.Errorf("Failed to dequeue transactional send %s: %v", item.SendID, err)
+	}
+	if err := d.db.ReleaseTransactionalAttachmentContent(ctx, item.SendID); err != nil {
+		logx.Errorf("Failed to release attachments of send %s: %v", item.SendID, err)
+	}
+}
+
+// deferTransactional puts a transactional send back without using an attempt
+func (d *Dispatcher) deferTransactional(item db.TransactionalQueue, until time.Time) {
+	if err := d.db.DeferTransactionalQueueItem(context.Background(), db.DeferTransactionalQueueItemParams{
+		NextAttemptAt: until.UTC().Format(time.DateTime),
+		SendID:        item.SendID,
+	}); err != nil {
+		logx.Errorf("Failed to defer transactional send %s: %v", item.SendID, err)
+	}
+}
+
+// deferEmail reschedules a pending email without using an attempt
+func (d *Dispatcher) deferEmail(email db.GetPendingEmailsRow, until time.Time) {
+	if err := d.db.DeferEmail(context.Background(), db.DeferEmailParams{
+		ScheduledFor: until.Local().Format(time.RFC3339),
+		ID:           email.ID,
+	}); err != nil {
+		logx.Errorf("Failed to defer email %s: %v", email.ID, err)
+	}
+}
+
 // sendEmail processes and sends a single email
 func (d *Dispatcher) sendEmail(job EmailJob) error {
 	email := job.Email
@@ -267,8 +406,13 @@ func (d *Dispatcher) sendEmail(job EmailJob) error {
 		htmlBody = d.sequenceService.wrapWithSimpleTemplate(htmlBody, isTransactional, email.TrackingToken.String)
 	}
 
-	// Send via SMTP
-	return d.sequenceService.sender.sendEmail(email.Email, subject, htmlBody)
+	// Send via SES or SMTP; marketing mail carries List-Unsubscribe headers
+	listToken := ""
+	if !isTransactional {
+		listToken = email.TrackingToken.String
+	}
+	textBody := d.sequenceService.plainTextBody(email.PlainText, tplCtx, isTransactional)
+	return d.sequenceService.sender.sendListEmail(email.OrgID.String, email.Email, subject, htmlBody, textBody, listToken)
 }
 
 // handleSendSuccess processes a successful send
@@ -280,13 +424,7 @@ func (d *Dispatcher) handleSendSuccess(job EmailJob) {
 		logx.Errorf("Failed to mark email %s as sent: %v", email.ID, err)
 	}
 
-	// Reset circuit breaker on success
-	d.consecutiveFails.Store(0)
-	if d.circuit.Load() == int32(circuitHalfOpen) {
-		d.circuit.Store(int32(circuitClosed))
-		logx.Info("Circuit breaker closed - email sending recovered")
-	}
-
+	d.recordSuccess()
 	d.sent.Add(1)
 
 	// Update sequence position and queue next email
@@ -298,13 +436,7 @@ func (d *Dispatcher) handleSendError(job EmailJob, err error) {
 	email := job.Email
 	job.Attempt++
 
-	// Increment consecutive failures for circuit breaker
-	fails := d.consecutiveFails.Add(1)
-	if fails >= int32(d.config.CircuitThreshold) && d.circuit.Load() == int32(circuitClosed) {
-		d.circuit.Store(int32(circuitOpen))
-		d.circuitOpenedAt.Store(time.Now().UnixNano())
-		logx.Errorf("Circuit breaker OPENED after %d consecutive failures", fails)
-	}
+	d.recordFailure()
 
 	// Check if we should retry
 	if job.Attempt < d.config.MaxRetries {
@@ -327,6 +459,25 @@ func (d *Dispatcher) handleSendError(job EmailJob, err error) {
 	}
 }
 
+// recordSuccess resets the circuit breaker after a successful send
+func (d *Dispatcher) recordSuccess() {
+	d.consecutiveFails.Store(0)
+	if d.circuit.Load() == int32(circuitHalfOpen) {
+		d.circuit.Store(int32(circuitClosed))
+		logx.Info("Circuit breaker closed - email sending recovered")
+	}
+}
+
+// recordFailure counts a failed send toward opening the circuit breaker
+func (d *Dispatcher) recordFailure() {
+	fails := d.consecutiveFails.Add(1)
+	if fails >= int32(d.config.CircuitThreshold) && d.circuit.Load() == int32(circuitClosed) {
+		d.circuit.Store(int32(circuitOpen))
+		d.circuitOpenedAt.Store(time.Now().UnixNano())
+		logx.Errorf("Circuit breaker OPENED after %d consecutive failures", fails)
+	}
+}
+
 // markFailed marks an email as permanently failed
 func (d *Dispatcher) markFailed(email db.GetPendingEmailsRow, errMsg string) {
 	if err := d.db.MarkEmailFailed(d.ctx, db.MarkEmailFailedParams{
@@ -377,6 +528,7 @@ func (d *Dispatcher) isCircuitOpen() bool {
 // retryProcessor handles the retry queue
 func (d *Dispatcher) retryProcessor() {
 	defer d.wg.Done()
+	defer d.guard("Email dispatcher")
 	ticker := time.NewTicker(500 * time.Millisecond)
 	defer ticker.Stop()
 
@@ -415,6 +567,7 @@ func (d *Dispatcher) retryProcessor() {
 // batchFetcher periodically fetches pending emails from the database
 func (d *Dispatcher) batchFetcher() {
 	defer d.wg.Done()
+	defer d.guard("Email dispatcher")
 	ticker := time.NewTicker(d.config.PollInterval)
 	defer ticker.Stop()
 
@@ -425,12 +578,46 @@ func (d *Dispatcher) batchFetcher() {
 		select {
 		case <-d.ctx.Done():
 			return
+		case <-d.sequenceService.sender.TransactionalQueued():
+			d.fetchTransactional()
 		case <-ticker.C:
 			d.fetchBatch()
 		}
 	}
 }
 
+// fetchTransactional claims due transactional sends and queues them ahead
+// of other mail
+func (d *Dispatcher) fetchTransactional() {
+	if circuitState(d.circuit.Load()) == circuitOpen {
+		return
+	}
+
+	free := cap(d.priority) - len(d.priority)
+	if free <= 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	items, err := d.db.ClaimTransactionalQueue(d.ctx, db.ClaimTransactionalQueueParams{
+		LeaseUntil: now.Add(transactionalLease).Format(time.DateTime),
+		Now:        now.Format(time.DateTime),
+		LimitCount: int64(free),
+	})
+	if err != nil {
+		logx.Errorf("Failed to claim transactional sends: %v", err)
+		return
+	}
+
+	for i := range items {
+		select {
+		case d.priority <- EmailJob{Transactional: &items[i]}:
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
+
 // fetchBatch loads pending emails and queues them for sending
 func (d *Dispatcher) fetchBatch() {
 	// Skip if circuit is fully open
@@ -438,6 +625,8 @@ func (d *Dispatcher) fetchBatch() {
 		return
 	}
 
+	d.fetchTransactional()
+
 	pendingEmails, err := d.db.GetPendingEmails(d.ctx, db.GetPendingEmailsParams{
 		ScheduledBefore: time.Now().Format(time.RFC3339),
 		LimitCount:      int64(d.config.BatchSize),
@@ -497,11 +686,7 @@ func (d *Dispatcher) updateSequenceState(email db.GetPendingEmailsRow) {
 			Position:   template.Position + 1,
 		})
 		if err != nil || nextTemplate.ID == "" {
-			_ = d.db.CompleteContactSequence(d.ctx, db.CompleteContactSequenceParams{
-				ContactID:  email.ContactID,
-				SequenceID: template.SequenceID,
-			})
-			logx.Infof("Completed sequence for contact %s", email.ContactID.String)
+			d.sequenceService.completeSequence(d.ctx, email.ContactID.String, template.SequenceID.String)
 		}
 	}
 }
@@ -509,6 +694,7 @@ func (d *Dispatcher) updateSequenceState(email db.GetPendingEmailsRow) {
 // metricsReporter periodically logs metrics
 func (d *Dispatcher) metricsReporter() {
 	defer d.wg.Done()
+	defer d.guard("Email dispatcher")
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
 
@@ -520,6 +706,7 @@ func (d *Dispatcher) metricsReporter() {
 			sent := d.sent.Load()
 			failed := d.failed.Load()
 			retried := d.retried.Load()
+			deferred := d.deferred.Load()
 			circuitState := d.circuit.Load()
 
 			stateStr := "closed"
@@ -529,9 +716,9 @@ func (d *Dispatcher) metricsReporter() {
 				stateStr = "half-open"
 			}
 
-			if sent > 0 || failed > 0 || retried > 0 {
-				logx.Infof("Email dispatcher stats - sent: %d, failed: %d, retried: %d, circuit: %s",
-					sent, failed, retried, stateStr)
+			if sent > 0 || failed > 0 || retried > 0 || deferred > 0 {
+				logx.Infof("Email dispatcher stats - sent: %d, failed: %d, retried: %d, deferred: %d, circuit: %s",
+					sent, failed, retried, deferred, stateStr)
 			}
 		}
 	}
@@ -0,0 +1,95 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/db/dbtest"
+)
+
+func TestDispatcherNextJobPrefersTransactional(t *testing.T) {
+	d := NewDispatcher(nil, nil, DefaultDispatcherConfig())
+	defer d.cancel()
+
+	d.jobs <- EmailJob{Email: db.GetPendingEmailsRow{ID: "sequence"}}
+	d.priority <- EmailJob{Transactional: &db.TransactionalQueue{SendID: "transactional"}}
+
+	job, ok := d.nextJob()
+	if !ok || job.Transactional == nil || job.Transactional.SendID != "transactional" {
+		t.Fatalf("nextJob() = %+v, want the transactional send first", job)
+	}
+
+	job, ok = d.nextJob()
+	if !ok || job.Email.ID != "sequence" {
+		t.Fatalf("nextJob() = %+v, want the sequence email", job)
+	}
+
+	close(d.jobs)
+	if _, ok := d.nextJob(); ok {
+		t.Error("nextJob() returned a job after the queue closed")
+	}
+}
+
+func TestDispatcherCompletionAppliesEntryRules(t *testing.T) {
+	s, store := newEntryRuleFixture(t, []entryRule{
+		{sequenceID: "onboarding", sequenceType: "lifecycle"},
+		{sequenceID: "followup", sequenceType: "lifecycle", trigger: TriggerSequenceComplete, source: "onboarding"},
+	})
+	dbtest.Exec(t, store, `INSERT INTO email_templates (id, org_id, sequence_id, position, subject, html_body) VALUES ('last', 'org', 'onboarding', 1, 's', 'b'), ('next', 'org', 'followup', 1, 's', 'b')`)
+	dbtest.Exec(t, store, `INSERT INTO contact_sequence_state (id, contact_id, sequence_id) VALUES ('state', 'ann', 'onboarding')`)
+
+	d := NewDispatcher(s, store, DefaultDispatcherConfig())
+	defer d.cancel()
+
+	// The last email of onboarding was sent
+	d.updateSequenceState(db.GetPendingEmailsRow{
+		ContactID:  sql.NullString{String: "ann", Valid: true},
+		TemplateID: sql.NullString{String: "last", Valid: true},
+	})
+
+	if got := enrolledSequences(t, store, "ann"); len(got) != 1 || got[0] != "followup" {
+		t.Fatalf("enrolled in %v after completing onboarding, want [followup]", got)
+	}
+	var queued int
+	if err := store.GetDB().QueryRow(`SELECT COUNT(*) FROM email_queue WHERE template_id = 'next'`).Scan(&queued); err != nil {
+		t.Fatal(err)
+	}
+	if queued != 1 {
+		t.Errorf("queued %d follow-up emails, want 1", queued)
+	}
+}
+
+func TestDispatcherDeferEmail(t *testing.T) {
+	s, store := newEntryRuleFixture(t, []entryRule{{sequenceID: "onboarding", sequenceType: "lifecycle"}})
+	dbtest.Exec(t, store, `INSERT INTO email_templates (id, org_id, sequence_id, position, subject, html_body) VALUES ('first', 'org', 'onboarding', 1, 's', 'b')`)
+	dbtest.Exec(t, store, `INSERT INTO email_queue (id, contact_id, template_id, scheduled_for, status) VALUES ('email', 'ann', 'first', ?, 'pending')`, time.Now().Add(-time.Minute).Format(time.RFC3339))
+
+	d := NewDispatcher(s, store, DefaultDispatcherConfig())
+	defer d.cancel()
+
+	pending := func(at time.Time) int {
+		t.Helper()
+		emails, err := store.GetPendingEmails(context.Background(), db.GetPendingEmailsParams{
+			ScheduledBefore: at.Format(time.RFC3339),
+			LimitCount:      10,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(emails)
+	}
+
+	// A quota-deferred email is not fetched again until the quota resets
+	reset := QuotaResetTime(time.Now())
+	d.deferEmail(db.GetPendingEmailsRow{ID: "email"}, reset)
+
+	if n := pending(time.Now()); n != 0 {
+		t.Errorf("fetched %d emails before the quota reset, want 0", n)
+	}
+	if n := pending(reset); n != 1 {
+		t.Errorf("fetched %d emails at the quota reset, want 1", n)
+	}
+}
@@ -0,0 +1,453 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/outlet-sh/outlet/internal/db"
+)
+
+const (
+	DKIMAlgorithmRSA     = "rsa-sha256"
+	DKIMAlgorithmEd25519 = "ed25519-sha256"
+
+	// dkimCacheTTL is how long loaded signing keys are reused before the
+	// database is checked again for rotations
+	dkimCacheTTL = 5 * time.Minute
+)
+
+// dkimSignedHeaders are signed when present, in this order. From is always
+// present on messages built by Message.Build.
+var dkimSignedHeaders = []string{
+	"From", "To", "Reply-To", "Subject", "Date", "Message-ID",
+	"List-Unsubscribe", "List-Unsubscribe-Post", "MIME-Version", "Content-Type",
+}
+
+// lookupTXT resolves TXT records; replaced in tests
+var lookupTXT = net.LookupTXT
+
+// dkimSigner is a decrypted key ready to sign for one selector
+type dkimSigner struct {
+	domain    string
+	selector  string
+	algorithm string
+	key       crypto.Signer
+}
+
+// dkimCacheEntry holds the signers loaded for a sending domain
+type dkimCacheEntry struct {
+	signers  []dkimSigner
+	loadedAt time.Time
+}
+
+// dkimKeyCache caches signers per sending domain so each message does not
+// decrypt and parse its keys again
+type dkimKeyCache struct {
+	mu      sync.Mutex
+	entries map[string]dkimCacheEntry
+}
+
+// dkimSelector names the DNS selector for a key version, e.g. outlet2-rsa
+func dkimSelector(version int64, algorithm string) string {
+	if algorithm == DKIMAlgorithmEd25519 {
+		return fmt.Sprintf("outlet%d-ed25519", version)
+	}
+	return fmt.Sprintf("outlet%d-rsa", version)
+}
+
+// GenerateDKIMKeys creates a new key version for a domain identity: one
+// RSA-2048 and one Ed25519 key. The keys stay pending, and are not used for
+// signing, until their DNS records are published and ActivatePublishedDKIMKeys
+// promotes them. Any older pending version is dropped.
+func (s *Service) GenerateDKIMKeys(ctx context.Context, identity db.DomainIdentity) ([]db.DkimKey, error) {
+	if s.cryptoSvc == nil {
+		return nil, fmt.Errorf("encryption key not configured - DKIM private keys cannot be stored")
+	}
+
+	maxVersion, err := s.db.GetMaxDKIMKeyVersion(ctx, identity.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DKIM key version: %w", err)
+	}
+	version := maxVersion + 1
+
+	if err := s.db.RetirePendingDKIMKeys(ctx, identity.ID); err != nil {
+		return nil, fmt.Errorf("failed to retire pending DKIM keys: %w", err)
+	}
+
+	keys := make([]db.DkimKey, 0, 2)
+	for _, algorithm := range []string{DKIMAlgorithmRSA, DKIMAlgorithmEd25519} {
+		privateKey, publicKey, err := generateDKIMKey(algorithm)
+		if err != nil {
+			return nil, err
+		}
+
+		encrypted, err := s.cryptoSvc.Encrypt(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt DKIM key: %w", err)
+		}
+
+		key, err := s.db.CreateDKIMKey(ctx, db.CreateDKIMKeyParams{
+			ID:               uuid.New().String(),
+			OrgID:            identity.OrgID,
+			DomainIdentityID: identity.ID,
+			Domain:           strings.ToLower(identity.Domain),
+			Selector:         dkimSelector(version, algorithm),
+			Algorithm:        algorithm,
+			Version:          version,
+			PrivateKey:       encrypted,
+			PublicKey:        publicKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to save DKIM key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// generateDKIMKey returns a PKCS#8 private key and the base64 public key
+// for the DNS p= tag: SubjectPublicKeyInfo for RSA, the raw key for Ed25519
+// (RFC 8463)
+func generateDKIMKey(algorithm string) (privateKey []byte, publicKey string, err error) {
+	var private any
+	var public []byte
+
+	switch algorithm {
+	case DKIMAlgorithmRSA:
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to generate RSA key: %w", err)
+		}
+		public, err = x509.MarshalPKIXPublicKey(&key.PublicKey)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode RSA public key: %w", err)
+		}
+		private = key
+	case DKIMAlgorithmEd25519:
+		pub, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to generate Ed25519 key: %w", err)
+		}
+		public = pub
+		private = key
+	default:
+		return nil, "", fmt.Errorf("unsupported DKIM algorithm %q", algorithm)
+	}
+
+	privateKey, err = x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode DKIM private key: %w", err)
+	}
+	return privateKey, base64.StdEncoding.EncodeToString(public), nil
+}
+
+// DKIMDNSRecords returns the TXT records that publish the given keys. RSA
+// values are longer than 255 characters; most DNS providers split them into
+// multiple strings automatically.
+func DKIMDNSRecords(keys []db.DkimKey) []*DNSRecord {
+	records := make([]*DNSRecord, 0, len(keys))
+	for _, key := range keys {
+		records = append(records, &DNSRecord{
+			Type:    "TXT",
+			Name:    fmt.Sprintf("%s._domainkey.%s", key.Selector, key.Domain),
+			Value:   dkimRecordValue(key),
+			Purpose: "dkim",
+		})
+	}
+	return records
+}
+
+// dkimRecordValue formats the DKIM key record for a key
+func dkimRecordValue(key db.DkimKey) string {
+	keyType := "rsa"
+	if key.Algorithm == DKIMAlgorithmEd25519 {
+		keyType = "ed25519"
+	}
+	return fmt.Sprintf("v=DKIM1; k=%s; p=%s", keyType, key.PublicKey)
+}
+
+// ActivatePublishedDKIMKeys checks DNS for the newest pending key version of
+// a domain identity. Once every key of that version is published it becomes
+// active and all older versions are retired, completing a rotation. Reports
+// whether a version was activated.
+func (s *Service) ActivatePublishedDKIMKeys(ctx context.Context, identityID string) (bool, error) {
+	keys, err := s.db.ListDKIMKeysByIdentity(ctx, identityID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list DKIM keys: %w", err)
+	}
+
+	// Keys are ordered newest version first
+	var pending []db.DkimKey
+	for _, key := range keys {
+		if key.Status != "pending" || (len(pending) > 0 && key.Version != pending[0].Version) {
+			continue
+		}
+		pending = append(pending, key)
+	}
+	if len(pending) == 0 {
+		return false, nil
+	}
+
+	for _, key := range pending {
+		if !dkimRecordPublished(key) {
+			return false, nil
+		}
+	}
+
+	version := pending[0].Version
+	if err := s.db.ActivateDKIMKeyVersion(ctx, db.ActivateDKIMKeyVersionParams{
+		DomainIdentityID: identityID,
+		Version:          version,
+	}); err != nil {
+		return false, fmt.Errorf("failed to activate DKIM keys: %w", err)
+	}
+	if err := s.db.RetireDKIMKeysBeforeVersion(ctx, db.RetireDKIMKeysBeforeVersionParams{
+		DomainIdentityID: identityID,
+		Version:          version,
+	}); err != nil {
+		return false, fmt.Errorf("failed to retire DKIM keys: %w", err)
+	}
+
+	s.dkimCache.invalidate(pending[0].Domain)
+	return true, nil
+}
+
+// dkimRecordPublished reports whether the key's selector publishes its
+// public key
+func dkimRecordPublished(key db.DkimKey) bool {
+	records, err := lookupTXT(key.Selector + "._domainkey." + key.Domain)
+	if err != nil {
+		return false
+	}
+	for _, record := range records {
+		for _, tag := range strings.Split(record, ";") {
+			name, value, ok := strings.Cut(strings.TrimSpace(tag), "=")
+			if ok && strings.TrimSpace(name) == "p" && strings.Join(strings.Fields(value), "") == key.PublicKey {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// signDKIM adds a DKIM-Signature for each active key of the sender's domain.
+// Messages from domains without active keys, or that fail to sign, are
+// returned unchanged.
+func (s *Service) signDKIM(ctx context.Context, fromEmail string, message []byte) []byte {
+	domain := ExtractDomainFromEmail(fromEmail)
+	if domain == "" || s.cryptoSvc == nil {
+		return message
+	}
+
+	signers, err := s.dkimSigners(ctx, domain)
+	if err != nil {
+		log.Printf("DKIM: failed to load keys for %s: %v", domain, err)
+		return message
+	}
+
+	var headers []byte
+	for _, signer := range signers {
+		header, err := signer.sign(message, time.Now())
+		if err != nil {
+			log.Printf("DKIM: failed to sign with %s._domainkey.%s: %v", signer.selector, domain, err)
+			continue
+		}
+		headers = append(headers, header...)
+	}
+	if len(headers) == 0 {
+		return message
+	}
+	return append(headers, message...)
+}
+
+// dkimSigners returns the cached signers for a domain, loading active keys
+// from the database when the cache is empty or stale
+func (s *Service) dkimSigners(ctx context.Context, domain string) ([]dkimSigner, error) {
+	if signers, ok := s.dkimCache.get(domain); ok {
+		return signers, nil
+	}
+
+	keys, err := s.db.ListActiveDKIMKeysByDomain(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign with the newest version only; the same domain may be set up by
+	// more than one organization
+	var signers []dkimSigner
+	for _, key := range keys {
+		if key.DomainIdentityID != keys[0].DomainIdentityID || key.Version != keys[0].Version {
+			continue
+		}
+		der, err := s.cryptoSvc.Decrypt(key.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt key %s: %w", key.Selector, err)
+		}
+		parsed, err := x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key %s: %w", key.Selector, err)
+		}
+		signer, ok := parsed.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("key %s cannot sign", key.Selector)
+		}
+		signers = append(signers, dkimSigner{
+			domain:    domain,
+			selector:  key.Selector,
+			algorithm: key.Algorithm,
+			key:       signer,
+		})
+	}
+
+	s.dkimCache.put(domain, signers)
+	return signers, nil
+}
+
+func (c *dkimKeyCache) get(domain string) ([]dkimSigner, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[domain]
+	if !ok || time.Since(entry.loadedAt) > dkimCacheTTL {
+		return nil, false
+	}
+	return entry.signers, true
+}
+
+func (c *dkimKeyCache) put(domain string, signers []dkimSigner) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]dkimCacheEntry)
+	}
+	c.entries[domain] = dkimCacheEntry{signers: signers, loadedAt: time.Now()}
+}
+
+func (c *dkimKeyCache) invalidate(domain string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, domain)
+}
+
+// sign returns the DKIM-Signature header line for a message using
+// relaxed/relaxed canonicalization (RFC 6376)
+func (d dkimSigner) sign(message []byte, now time.Time) ([]byte, error) {
+	headerBlock, body, found := bytes.Cut(message, []byte("\r\n\r\n"))
+	if !found {
+		return nil, fmt.Errorf("message has no header/body separator")
+	}
+	fields := splitHeaderFields(string(headerBlock) + "\r\n")
+
+	bodyHash := sha256.Sum256(relaxedBody(body))
+
+	// Sign the last instance of each header, which is the one verifiers
+	// pick first
+	var names []string
+	var signed strings.Builder
+	for _, name := range dkimSignedHeaders {
+		for i := len(fields) - 1; i >= 0; i-- {
+			if fieldName, _, _ := strings.Cut(fields[i], ":"); strings.EqualFold(strings.TrimSpace(fieldName), name) {
+				signed.WriteString(relaxedHeader(fields[i]))
+				names = append(names, strings.ToLower(name))
+				break
+			}
+		}
+	}
+
+	tags := []string{
+		"v=1",
+		"a=" + d.algorithm,
+		"c=relaxed/relaxed",
+		"d=" + d.domain,
+		"s=" + d.selector,
+		"t=" + strconv.FormatInt(now.Unix(), 10),
+		"h=" + strings.Join(names, ":"),
+		"bh=" + base64.StdEncoding.EncodeToString(bodyHash[:]),
+		"b=",
+	}
+	value := strings.Join(tags, "; ")
+
+	// The signature covers its own header with an empty b= and no
+	// trailing CRLF
+	signed.WriteString(strings.TrimSuffix(relaxedHeader("DKIM-Signature: "+value), "\r\n"))
+	digest := sha256.Sum256([]byte(signed.String()))
+
+	var opts crypto.SignerOpts = crypto.SHA256
+	if d.algorithm == DKIMAlgorithmEd25519 {
+		// Ed25519 signs the SHA-256 digest itself (RFC 8463)
+		opts = crypto.Hash(0)
+	}
+	signature, err := d.key.Sign(rand.Reader, digest[:], opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Folding at tag boundaries leaves the relaxed form unchanged
+	folded := strings.Join(tags, ";\r\n\t") + base64.StdEncoding.EncodeToString(signature)
+	return []byte("DKIM-Signature: " + folded + "\r\n"), nil
+}
+
+// splitHeaderFields splits a header block into fields, keeping folded
+// continuation lines with their field
+func splitHeaderFields(block string) []string {
+	var fields []string
+	for _, line := range strings.SplitAfter(block, "\r\n") {
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(fields) > 0 {
+			fields[len(fields)-1] += line
+			continue
+		}
+		fields = append(fields, line)
+	}
+	return fields
+}
+
+// relaxedHeader canonicalizes one header field: lowercase name, unfolded
+// value with whitespace runs collapsed and trimmed (RFC 6376 3.4.2)
+func relaxedHeader(field string) string {
+	name, value, _ := strings.Cut(field, ":")
+	value = strings.NewReplacer("\r\n", "").Replace(value)
+	return strings.ToLower(strings.TrimSpace(name)) + ":" + strings.Join(strings.FieldsFunc(value, isWSP), " ") + "\r\n"
+}
+
+// relaxedBody canonicalizes a body: whitespace runs collapse to one space,
+// trailing whitespace and trailing empty lines are removed (RFC 6376 3.4.4)
+func relaxedBody(body []byte) []byte {
+	lines := strings.Split(string(body), "\r\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.FieldsFunc(line, isWSP), " ")
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			if lines[i] != "" {
+				lines[i] = " " + lines[i]
+			}
+		}
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+func isWSP(r rune) bool {
+	return r == ' ' || r == '\t'
+}
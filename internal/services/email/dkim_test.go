@@ -0,0 +1,158 @@
+package email
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+)
+
+func TestDKIMRelaxedCanonicalization(t *testing.T) {
+	// Example from RFC 6376 section 3.4.5
+	fields := splitHeaderFields("A: X\r\nB : Y\t\r\n\tZ  \r\n")
+	var got string
+	for _, f := range fields {
+		got += relaxedHeader(f)
+	}
+	if got != "a:X\r\nb:Y Z\r\n" {
+		t.Errorf("relaxed headers = %q", got)
+	}
+
+	if body := string(relaxedBody([]byte(" C \r\nD \t E\r\n\r\n\r\n"))); body != " C\r\nD E\r\n" {
+		t.Errorf("relaxed body = %q", body)
+	}
+	if body := relaxedBody(nil); len(body) != 0 {
+		t.Errorf("empty body = %q", body)
+	}
+}
+
+func TestDKIMSign(t *testing.T) {
+	for _, algorithm := range []string{DKIMAlgorithmRSA, DKIMAlgorithmEd25519} {
+		t.Run(algorithm, func(t *testing.T) {
+			der, publicKey, err := generateDKIMKey(algorithm)
+			if err != nil {
+				t.Fatalf("generateDKIMKey: %v", err)
+			}
+			parsed, err := x509.ParsePKCS8PrivateKey(der)
+			if err != nil {
+				t.Fatalf("ParsePKCS8PrivateKey: %v", err)
+			}
+			signer := dkimSigner{
+				domain:    "example.com",
+				selector:  dkimSelector(3, algorithm),
+				algorithm: algorithm,
+				key:       parsed.(crypto.Signer),
+			}
+
+			msg := Message{
+				FromName:  "Shop",
+				FromEmail: "news@example.com",
+				To:        "a@example.org",
+				Subject:   "Grüße aus Köln, a subject long enough to be folded over lines",
+				HTMLBody:  "<p>Hello</p>",
+			}
+			raw := msg.Build()
+			header, err := signer.sign(raw, time.Unix(1700000000, 0))
+			if err != nil {
+				t.Fatalf("sign: %v", err)
+			}
+
+			verifyDKIM(t, append(header, raw...), algorithm, publicKey)
+		})
+	}
+}
+
+// verifyDKIM checks the first DKIM-Signature of a message against a public
+// key published as a DNS p= value
+func verifyDKIM(t *testing.T, message []byte, algorithm, publicKey string) {
+	t.Helper()
+	headerBlock, body, _ := bytes.Cut(message, []byte("\r\n\r\n"))
+	fields := splitHeaderFields(string(headerBlock) + "\r\n")
+
+	sigField := fields[0]
+	if !strings.HasPrefix(sigField, "DKIM-Signature:") {
+		t.Fatalf("first header = %q", sigField)
+	}
+	_, value, _ := strings.Cut(sigField, ":")
+	tags := map[string]string{}
+	for _, tag := range strings.Split(strings.NewReplacer("\r\n", "", "\t", "", " ", "").Replace(value), ";") {
+		k, v, _ := strings.Cut(tag, "=")
+		tags[k] = v
+	}
+	if tags["a"] != algorithm || tags["d"] != "example.com" || tags["c"] != "relaxed/relaxed" {
+		t.Errorf("tags = %v", tags)
+	}
+
+	bodyHash := sha256.Sum256(relaxedBody(body))
+	if tags["bh"] != base64.StdEncoding.EncodeToString(bodyHash[:]) {
+		t.Errorf("bh = %s", tags["bh"])
+	}
+
+	var signed strings.Builder
+	for _, name := range strings.Split(tags["h"], ":") {
+		for _, f := range fields[1:] {
+			if fieldName, _, _ := strings.Cut(f, ":"); strings.EqualFold(fieldName, name) {
+				signed.WriteString(relaxedHeader(f))
+			}
+		}
+	}
+	unsigned := sigField[:strings.Index(sigField, "\tb=")+3]
+	signed.WriteString(strings.TrimSuffix(relaxedHeader(unsigned), "\r\n"))
+	digest := sha256.Sum256([]byte(signed.String()))
+
+	signature, _ := base64.StdEncoding.DecodeString(tags["b"])
+	key, _ := base64.StdEncoding.DecodeString(publicKey)
+	if algorithm == DKIMAlgorithmEd25519 {
+		if !ed25519.Verify(ed25519.PublicKey(key), digest[:], signature) {
+			t.Error("ed25519 signature does not verify")
+		}
+		return
+	}
+	pub, err := x509.ParsePKIXPublicKey(key)
+	if err != nil {
+		t.Fatalf("ParsePKIXPublicKey: %v", err)
+	}
+	if err := rsa.VerifyPKCS1v15(pub.(*rsa.PublicKey), crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("rsa signature does not verify: %v", err)
+	}
+}
+
+func TestDKIMDNSRecords(t *testing.T) {
+	key := db.DkimKey{
+		Domain:    "example.com",
+		Selector:  "outlet2-ed25519",
+		Algorithm: DKIMAlgorithmEd25519,
+		PublicKey: "11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo=",
+	}
+
+	records := DKIMDNSRecords([]db.DkimKey{key})
+	if len(records) != 1 {
+		t.Fatalf("got %d records", len(records))
+	}
+	if records[0].Name != "outlet2-ed25519._domainkey.example.com" || records[0].Type != "TXT" {
+		t.Errorf("record = %+v", records[0])
+	}
+	if records[0].Value != "v=DKIM1; k=ed25519; p=11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo=" {
+		t.Errorf("value = %q", records[0].Value)
+	}
+
+	defer func(orig func(string) ([]string, error)) { lookupTXT = orig }(lookupTXT)
+	lookupTXT = func(name string) ([]string, error) {
+		return []string{"v=DKIM1; k=ed25519; p=11qYAYKxCrfVS/7TyWQHOg7hc vPapiMlrwIaaPcHURo="}, nil
+	}
+	if !dkimRecordPublished(key) {
+		t.Error("published record not found")
+	}
+	key.PublicKey = "other"
+	if dkimRecordPublished(key) {
+		t.Error("mismatched key reported as published")
+	}
+}
@@ -0,0 +1,152 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/outlet-sh/outlet/internal/db"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// Entry rule trigger types (sequence_entry_rules.trigger_type)
+const (
+	TriggerListJoin         = "list_join"         // source_id: list ID
+	TriggerSequenceComplete = "sequence_complete" // source_id: completed sequence ID
+	TriggerSegmentEnter     = "segment_enter"     // source_id: segment ID
+	TriggerTagAdded         = "tag_added"         // source_id: tag name
+	TriggerLinkClicked      = "link_clicked"      // source_id: URL or URL prefix
+)
+
+// entryCandidate is a sequence an entry rule would enroll the contact into
+type entryCandidate struct {
+	SequenceID   string
+	SequenceName string
+	SequenceType string
+}
+
+// EnrollOnListJoin starts the sequences for a contact whose list subscription
+// became active. Lists without list_join entry rules fall back to the list's
+// on_subscribe sequence.
+func (s *SequenceService) EnrollOnListJoin(ctx context.Context, contactID string, listID int64) error {
+	rules, err := s.db.GetActiveEntryRuleForTrigger(ctx, db.GetActiveEntryRuleForTriggerParams{
+		TriggerType: TriggerListJoin,
+		SourceID:    strconv.FormatInt(listID, 10),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get entry rules: %w", err)
+	}
+
+	candidates := make([]entryCandidate, 0, len(rules))
+	for _, r := range rules {
+		candidates = append(candidates, entryCandidate{r.SequenceID, r.SequenceName, r.SequenceType.String})
+	}
+	if len(rules) == 0 {
+		sequence, err := s.db.GetSequenceByListAndTrigger(ctx, db.GetSequenceByListAndTriggerParams{
+			ListID:       sql.NullInt64{Int64: listID, Valid: true},
+			TriggerEvent: "on_subscribe",
+		})
+		if err != nil {
+			return nil // No sequence configured for this list
+		}
+		candidates = append(candidates, entryCandidate{sequence.ID, sequence.Name, sequence.SequenceType.String})
+	}
+
+	_, err = s.enterSequences(ctx, contactID, candidates)
+	return err
+}
+
+// enrollOnSequenceComplete starts the sequences with a sequence_complete entry
+// rule for the sequence the contact just finished.
+func (s *SequenceService) enrollOnSequenceComplete(ctx context.Context, contactID, sequenceID string) error {
+	rules, err := s.db.GetActiveEntryRuleForTrigger(ctx, db.GetActiveEntryRuleForTriggerParams{
+		TriggerType: TriggerSequenceComplete,
+		SourceID:    sequenceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get entry rules: %w", err)
+	}
+
+	candidates := make([]entryCandidate, 0, len(rules))
+	for _, r := range rules {
+		candidates = append(candidates, entryCandidate{r.SequenceID, r.SequenceName, r.SequenceType.String})
+	}
+	_, err = s.enterSequences(ctx, contactID, candidates)
+	return err
+}
+
+// EnrollOnTagAdded starts the org's tag_added sequences whose source matches the tag (case-insensitive).
+func (s *SequenceService) EnrollOnTagAdded(ctx context.Context, orgID, contactID, tag string) error {
+	return s.enrollOnOrgTrigger(ctx, orgID, contactID, TriggerTagAdded, func(source string) bool {
+		return strings.EqualFold(strings.TrimSpace(source), strings.TrimSpace(tag))
+	})
+}
+
+// EnrollOnLinkClicked starts the org's link_clicked sequences whose source is
+// the clicked URL or a prefix of it, so a rule for https://example.com/pricing
+// also matches links with query parameters.
+func (s *SequenceService) EnrollOnLinkClicked(ctx context.Context, orgID, contactID, clickedURL string) error {
+	if clickedURL == "" {
+		return nil
+	}
+	return s.enrollOnOrgTrigger(ctx, orgID, contactID, TriggerLinkClicked, func(source string) bool {
+		return source != "" && strings.HasPrefix(clickedURL, source)
+	})
+}
+
+func (s *SequenceService) enrollOnOrgTrigger(ctx context.Context, orgID, contactID, triggerType string, matches func(source string) bool) error {
+	rules, err := s.db.GetActiveEntryRulesForOrgTrigger(ctx, db.GetActiveEntryRulesForOrgTriggerParams{
+		OrgID:       sql.NullString{String: orgID, Valid: true},
+		TriggerType: triggerType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get entry rules: %w", err)
+	}
+
+	var candidates []entryCandidate
+	for _, r := range rules {
+		if matches(r.SourceID) {
+			candidates = append(candidates, entryCandidate{r.SequenceID, r.SequenceName, r.SequenceType.String})
+		}
+	}
+	_, err = s.enterSequences(ctx, contactID, candidates)
+	return err
+}
+
+// enterSequences starts candidate sequences in entry rule priority order
+// (lowest value first) and returns how many were started. Lifecycle sequences
+// are exclusive: one is only started while the contact has no other active
+// lifecycle sequence, so the highest-priority match wins. Transactional
+// sequences always start. Sequences the contact has been in before are skipped.
+func (s *SequenceService) enterSequences(ctx context.Context, contactID string, candidates []entryCandidate) (int, error) {
+	started := 0
+	for _, c := range candidates {
+		_, err := s.db.GetContactSequenceState(ctx, db.GetContactSequenceStateParams{
+			ContactID:  sql.NullString{String: contactID, Valid: true},
+			SequenceID: sql.NullString{String: c.SequenceID, Valid: true},
+		})
+		if err == nil {
+			continue
+		}
+
+		if c.SequenceType == "" || c.SequenceType == "lifecycle" {
+			active, err := s.db.CountActiveSequencesForContact(ctx, sql.NullString{String: contactID, Valid: true})
+			if err != nil {
+				return started, fmt.Errorf("failed to count active sequences: %w", err)
+			}
+			if active > 0 {
+				logx.Infof("Contact %s already in a lifecycle sequence, not entering %s", contactID, c.SequenceName)
+				continue
+			}
+		}
+
+		if err := s.StartSequenceByID(ctx, contactID, c.SequenceID); err != nil {
+			return started, err
+		}
+		started++
+	}
+	return started, nil
+}
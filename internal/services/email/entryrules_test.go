@@ -0,0 +1,193 @@
+package email
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/db/dbtest"
+)
+
+// entryRule is a sequence with one entry rule
+type entryRule struct {
+	sequenceID   string
+	sequenceType string
+	trigger      string
+	source       string
+	priority     int
+}
+
+// newEntryRuleFixture stores an org with one contact on list 1 and a sequence
+// and entry rule per rule
+func newEntryRuleFixture(t *testing.T, rules []entryRule) (*SequenceService, *db.Store) {
+	t.Helper()
+	store := dbtest.New(t)
+	dbtest.Exec(t, store, `INSERT INTO organizations (id, name, slug, api_key) VALUES ('org', 'Org', 'org', 'key')`)
+	dbtest.Exec(t, store, `INSERT INTO email_lists (id, public_id, org_id, name, slug) VALUES (1, 'news', 'org', 'News', 'news')`)
+	dbtest.Exec(t, store, `INSERT INTO contacts (id, org_id, name, email, status) VALUES ('ann', 'org', 'Ann', 'ann@example.com', 'active')`)
+	for i, r := range rules {
+		dbtest.Exec(t, store, `INSERT OR IGNORE INTO email_sequences (id, org_id, slug, name, trigger_event, sequence_type) VALUES (?, 'org', ?, ?, 'manual', ?)`,
+			r.sequenceID, r.sequenceID, r.sequenceID, r.sequenceType)
+		if r.trigger != "" {
+			dbtest.Exec(t, store, `INSERT INTO sequence_entry_rules (id, sequence_id, trigger_type, source_id, priority) VALUES (?, ?, ?, ?, ?)`,
+				i, r.sequenceID, r.trigger, r.source, r.priority)
+		}
+	}
+	return NewSequenceServiceWithBaseURL(store, nil, "https://mail.example.com"), store
+}
+
+// enrolledSequences returns the sequences the contact is active in, by ID
+func enrolledSequences(t *testing.T, store *db.Store, contactID string) []string {
+	t.Helper()
+	rows, err := store.GetDB().Query(`SELECT sequence_id FROM contact_sequence_state WHERE contact_id = ? AND completed_at IS NULL ORDER BY sequence_id`, contactID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestEntryRules(t *testing.T) {
+	ctx := context.Background()
+	listJoin := func(s *SequenceService) error { return s.EnrollOnListJoin(ctx, "ann", 1) }
+	tagVIP := func(s *SequenceService) error { return s.EnrollOnTagAdded(ctx, "org", "ann", "vip") }
+
+	tests := []struct {
+		name  string
+		rules []entryRule
+		setup string // fixture statement run before the trigger fires
+		fire  func(s *SequenceService) error
+		want  []string
+	}{
+		{
+			name:  "list_join",
+			rules: []entryRule{{sequenceID: "welcome", sequenceType: "lifecycle", trigger: TriggerListJoin, source: "1"}},
+			fire:  listJoin,
+			want:  []string{"welcome"},
+		},
+		{
+			name:  "list_join for another list",
+			rules: []entryRule{{sequenceID: "welcome", sequenceType: "lifecycle", trigger: TriggerListJoin, source: "2"}},
+			fire:  listJoin,
+		},
+		{
+			name:  "list_join falls back to the list's on_subscribe sequence",
+			rules: []entryRule{{sequenceID: "welcome", sequenceType: "lifecycle"}},
+			setup: `UPDATE email_sequences SET list_id = 1, trigger_event = 'on_subscribe' WHERE id = 'welcome'`,
+			fire:  listJoin,
+			want:  []string{"welcome"},
+		},
+		{
+			name: "sequence_complete",
+			rules: []entryRule{
+				{sequenceID: "onboarding", sequenceType: "lifecycle"},
+				{sequenceID: "followup", sequenceType: "lifecycle", trigger: TriggerSequenceComplete, source: "onboarding"},
+			},
+			fire: func(s *SequenceService) error { return s.enrollOnSequenceComplete(ctx, "ann", "onboarding") },
+			want: []string{"followup"},
+		},
+		{
+			name:  "tag_added matches case-insensitively",
+			rules: []entryRule{{sequenceID: "vip", sequenceType: "lifecycle", trigger: TriggerTagAdded, source: "VIP"}},
+			fire:  tagVIP,
+			want:  []string{"vip"},
+		},
+		{
+			name:  "tag_added for another tag",
+			rules: []entryRule{{sequenceID: "vip", sequenceType: "lifecycle", trigger: TriggerTagAdded, source: "customer"}},
+			fire:  tagVIP,
+		},
+		{
+			name:  "link_clicked matches a URL prefix",
+			rules: []entryRule{{sequenceID: "pricing", sequenceType: "lifecycle", trigger: TriggerLinkClicked, source: "https://example.com/pricing"}},
+			fire: func(s *SequenceService) error {
+				return s.EnrollOnLinkClicked(ctx, "org", "ann", "https://example.com/pricing?plan=pro")
+			},
+			want: []string{"pricing"},
+		},
+		{
+			name:  "link_clicked for another URL",
+			rules: []entryRule{{sequenceID: "pricing", sequenceType: "lifecycle", trigger: TriggerLinkClicked, source: "https://example.com/pricing"}},
+			fire: func(s *SequenceService) error {
+				return s.EnrollOnLinkClicked(ctx, "org", "ann", "https://example.com/docs")
+			},
+		},
+		{
+			name: "lowest priority value wins among lifecycle sequences",
+			rules: []entryRule{
+				{sequenceID: "general", sequenceType: "lifecycle", trigger: TriggerListJoin, source: "1", priority: 2},
+				{sequenceID: "premium", sequenceType: "lifecycle", trigger: TriggerListJoin, source: "1", priority: 1},
+			},
+			fire: listJoin,
+			want: []string{"premium"},
+		},
+		{
+			name: "transactional sequences are not exclusive",
+			rules: []entryRule{
+				{sequenceID: "nurture", sequenceType: "lifecycle", trigger: TriggerTagAdded, source: "vip", priority: 1},
+				{sequenceID: "receipt", sequenceType: "transactional", trigger: TriggerTagAdded, source: "vip", priority: 2},
+			},
+			fire: tagVIP,
+			want: []string{"nurture", "receipt"},
+		},
+		{
+			name: "a second lifecycle sequence waits for the active one",
+			rules: []entryRule{
+				{sequenceID: "current", sequenceType: "lifecycle"},
+				{sequenceID: "vip", sequenceType: "lifecycle", trigger: TriggerTagAdded, source: "vip"},
+			},
+			setup: `INSERT INTO contact_sequence_state (id, contact_id, sequence_id) VALUES ('state', 'ann', 'current')`,
+			fire:  tagVIP,
+			want:  []string{"current"},
+		},
+		{
+			name: "a completed lifecycle sequence does not block the next",
+			rules: []entryRule{
+				{sequenceID: "current", sequenceType: "lifecycle"},
+				{sequenceID: "vip", sequenceType: "lifecycle", trigger: TriggerTagAdded, source: "vip"},
+			},
+			setup: `INSERT INTO contact_sequence_state (id, contact_id, sequence_id, completed_at) VALUES ('state', 'ann', 'current', datetime('now'))`,
+			fire:  tagVIP,
+			want:  []string{"vip"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, store := newEntryRuleFixture(t, tt.rules)
+			if tt.setup != "" {
+				dbtest.Exec(t, store, tt.setup)
+			}
+			if err := tt.fire(s); err != nil {
+				t.Fatalf("trigger error = %v", err)
+			}
+			if got := enrolledSequences(t, store, "ann"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("enrolled in %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntryRules_NotEnteredTwice(t *testing.T) {
+	s, store := newEntryRuleFixture(t, []entryRule{
+		{sequenceID: "vip", sequenceType: "lifecycle", trigger: TriggerTagAdded, source: "vip"},
+	})
+	dbtest.Exec(t, store, `INSERT INTO contact_sequence_state (id, contact_id, sequence_id, completed_at) VALUES ('state', 'ann', 'vip', datetime('now'))`)
+
+	if err := s.EnrollOnTagAdded(context.Background(), "org", "ann", "vip"); err != nil {
+		t.Fatal(err)
+	}
+	if got := enrolledSequences(t, store, "ann"); len(got) != 0 {
+		t.Errorf("re-entered %v", got)
+	}
+}
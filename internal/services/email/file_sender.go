@@ -0,0 +1,66 @@
+package email
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileProvider writes each message to a .eml file instead of sending it,
+// for tests and staging environments
+type FileProvider struct {
+	dir string
+}
+
+// NewFileProvider creates a provider that writes to dir, creating it on
+// first send
+func NewFileProvider(dir string) *FileProvider {
+	return &FileProvider{dir: dir}
+}
+
+// Name implements Provider
+func (p *FileProvider) Name() string {
+	return ProviderFile
+}
+
+// Send implements Provider. The returned ID is the file name.
+func (p *FileProvider) Send(ctx context.Context, msg *Message) (string, error) {
+	if err := os.MkdirAll(p.dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create outbox: %w", err)
+	}
+
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	name := time.Now().UTC().Format("20060102T150405.000000000") + "-" + hex.EncodeToString(suffix) + ".eml"
+
+	// Write to a temp file first so readers never see partial messages
+	tmp := filepath.Join(p.dir, "."+name)
+	if err := os.WriteFile(tmp, msg.Build(), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(p.dir, name)); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("failed to write message: %w", err)
+	}
+	return name, nil
+}
+
+// Capabilities implements Provider
+func (p *FileProvider) Capabilities() Capabilities {
+	return Capabilities{}
+}
+
+// Quota implements Provider; the outbox has no limits
+func (p *FileProvider) Quota(ctx context.Context) (*Quota, error) {
+	return &Quota{}, nil
+}
+
+// ParseWebhook implements Provider
+func (p *FileProvider) ParseWebhook(r *http.Request) ([]WebhookEvent, error) {
+	return nil, ErrNotSupported
+}
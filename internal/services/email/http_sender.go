@@ -0,0 +1,668 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTP API services supported by HTTPProvider
+const (
+	ServicePostmark = "postmark"
+	ServiceMailgun  = "mailgun"
+	ServiceSendGrid = "sendgrid"
+	ServiceResend   = "resend"
+)
+
+// Default API endpoints, overridable with HTTPProviderConfig.BaseURL
+var httpServiceBaseURLs = map[string]string{
+	ServicePostmark: "https://api.postmarkapp.com",
+	ServiceMailgun:  "https://api.mailgun.net",
+	ServiceSendGrid: "https://api.sendgrid.com",
+	ServiceResend:   "https://api.resend.com",
+}
+
+// maxWebhookBytes caps the size of provider webhook bodies
+const maxWebhookBytes = 1 << 20
+
+// HTTPProviderConfig configures a JSON API email service
+type HTTPProviderConfig struct {
+	Service string `json:"service"` // postmark, mailgun, sendgrid or resend
+	APIKey  string `json:"api_key"`
+	BaseURL string `json:"base_url,omitempty"` // e.g. https://api.eu.mailgun.net
+	Domain  string `json:"domain,omitempty"`   // Mailgun sending domain
+
+	// Verifies webhooks: Postmark basic auth password, Mailgun signing key,
+	// SendGrid verification public key or Resend signing secret
+	WebhookSecret string `json:"webhook_secret,omitempty"`
+}
+
+// HTTPProvider sends through a provider's HTTP API. These services sign with
+// their own DKIM keys and report bounces and complaints through webhooks.
+type HTTPProvider struct {
+	config  HTTPProviderConfig
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPProvider creates a provider for the configured service
+func NewHTTPProvider(config HTTPProviderConfig) (*HTTPProvider, error) {
+	baseURL, ok := httpServiceBaseURLs[config.Service]
+	if !ok {
+		return nil, fmt.Errorf("unknown HTTP email service %q", config.Service)
+	}
+	if config.APIKey == "" {
+		return nil, fmt.Errorf("%s API key is not configured", config.Service)
+	}
+	if config.Service == ServiceMailgun && config.Domain == "" {
+		return nil, fmt.Errorf("mailgun sending domain is not configured")
+	}
+	if config.BaseURL != "" {
+		baseURL = strings.TrimRight(config.BaseURL, "/")
+	}
+
+	return &HTTPProvider{
+		config:  config,
+		baseURL: baseURL,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Name implements Provider
+func (p *HTTPProvider) Name() string {
+	return ProviderHTTP + ":" + p.config.Service
+}
+
+// Capabilities implements Provider
+func (p *HTTPProvider) Capabilities() Capabilities {
+	return Capabilities{SignsDKIM: true, Webhooks: true}
+}
+
+// Quota implements Provider; none of the services expose account limits
+func (p *HTTPProvider) Quota(ctx context.Context) (*Quota, error) {
+	return nil, ErrNotSupported
+}
+
+// Send implements Provider
+func (p *HTTPProvider) Send(ctx context.Context, msg *Message) (string, error) {
+	switch p.config.Service {
+	case ServicePostmark:
+		return p.sendPostmark(ctx, msg)
+	case ServiceMailgun:
+		return p.sendMailgun(ctx, msg)
+	case ServiceSendGrid:
+		return p.sendSendGrid(ctx, msg)
+	default:
+		return p.sendResend(ctx, msg)
+	}
+}
+
+// ParseWebhook implements Provider
+func (p *HTTPProvider) ParseWebhook(r *http.Request) ([]WebhookEvent, error) {
+	if p.config.WebhookSecret == "" {
+		return nil, fmt.Errorf("%s webhook secret is not configured", p.config.Service)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook: %w", err)
+	}
+
+	switch p.config.Service {
+	case ServicePostmark:
+		return p.parsePostmark(r, body)
+	case ServiceMailgun:
+		return p.parseMailgun(body)
+	case ServiceSendGrid:
+		return p.parseSendGrid(r, body)
+	default:
+		return p.parseResend(r, body)
+	}
+}
+
+// postJSON sends a JSON request and decodes a JSON response into out
+func (p *HTTPProvider) postJSON(ctx context.Context, path string, payload any, setAuth func(*http.Request), out any) (http.Header, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	setAuth(req)
+
+	return p.do(req, out)
+}
+
+// do performs a request, turning non-2xx responses into errors
+func (p *HTTPProvider) do(req *http.Request, out any) (http.Header, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s request failed: %w", p.config.Service, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookBytes))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s API returned %d: %s", p.config.Service, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if out != nil && len(body) > 0 {
+		if err := json.Unmarshal(body, out); err != nil {
+			return nil, fmt.Errorf("invalid %s response: %w", p.config.Service, err)
+		}
+	}
+	return resp.Header, nil
+}
+
+// sendPostmark sends through Postmark's /email endpoint
+func (p *HTTPProvider) sendPostmark(ctx context.Context, msg *Message) (string, error) {
+	type header struct {
+		Name  string
+		Value string
+	}
+	type attachment struct {
+		Name        string
+		Content     string
+		ContentType string
+		ContentID   string `json:",omitempty"`
+	}
+	payload := struct {
+		From        string
+		To          string
+		ReplyTo     string `json:",omitempty"`
+		Subject     string
+		HtmlBody    string `json:",omitempty"`
+		TextBody    string
+		Headers     []header     `json:",omitempty"`
+		Attachments []attachment `json:",omitempty"`
+	}{
+		From:     formatAddress(msg.FromName, msg.FromEmail),
+		To:       msg.To,
+		ReplyTo:  msg.ReplyTo,
+		Subject:  msg.Subject,
+		HtmlBody: msg.HTMLBody,
+		TextBody: msg.plainText(),
+	}
+	for _, h := range msg.Headers {
+		payload.Headers = append(payload.Headers, header{h.Name, h.Value})
+	}
+	for _, a := range msg.Attachments {
+		contentID := ""
+		if a.ContentID != "" {
+			contentID = "cid:" + a.ContentID
+		}
+		payload.Attachments = append(payload.Attachments, attachment{
+			Name:        a.Filename,
+			Content:     base64.StdEncoding.EncodeToString(a.Data),
+			ContentType: a.ContentType,
+			ContentID:   contentID,
+		})
+	}
+
+	var resp struct {
+		MessageID string
+	}
+	_, err := p.postJSON(ctx, "/email", payload, func(req *http.Request) {
+		req.Header.Set("X-Postmark-Server-Token", p.config.APIKey)
+	}, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.MessageID, nil
+}
+
+// sendMailgun sends the MIME message built by Message.Build unchanged
+func (p *HTTPProvider) sendMailgun(ctx context.Context, msg *Message) (string, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	form.WriteField("to", msg.To)
+	part, err := form.CreateFormFile("message", "message.eml")
+	if err != nil {
+		return "", err
+	}
+	part.Write(msg.Build())
+	if err := form.Close(); err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/v3/%s/messages.mime", p.baseURL, p.config.Domain)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.SetBasicAuth("api", p.config.APIKey)
+
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if _, err := p.do(req, &resp); err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// sendSendGrid sends through SendGrid's v3 mail/send endpoint
+func (p *HTTPProvider) sendSendGrid(ctx context.Context, msg *Message) (string, error) {
+	type address struct {
+		Email string `json:"email"`
+		Name  string `json:"name,omitempty"`
+	}
+	type content struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}
+	type attachment struct {
+		Content     string `json:"content"`
+		Type        string `json:"type,omitempty"`
+		Filename    string `json:"filename"`
+		Disposition string `json:"disposition"`
+		ContentID   string `json:"content_id,omitempty"`
+	}
+	type personalization struct {
+		To []address `json:"to"`
+	}
+	payload := struct {
+		Personalizations []personalization `json:"personalizations"`
+		From             address           `json:"from"`
+		ReplyTo          *address          `json:"reply_to,omitempty"`
+		Subject          string            `json:"subject"`
+		Content          []content         `json:"content"`
+		Headers          map[string]string `json:"headers,omitempty"`
+		Attachments      []attachment      `json:"attachments,omitempty"`
+	}{
+		Personalizations: []personalization{{To: []address{{Email: msg.To}}}},
+		From:             address{Email: msg.FromEmail, Name: msg.FromName},
+		Subject:          msg.Subject,
+		Content:          []content{{Type: "text/plain", Value: msg.plainText()}},
+		Headers:          headerMap(msg.Headers),
+	}
+	if msg.HTMLBody != "" {
+		payload.Content = append(payload.Content, content{Type: "text/html", Value: msg.HTMLBody})
+	}
+	if msg.ReplyTo != "" {
+		payload.ReplyTo = &address{Email: msg.ReplyTo}
+	}
+	for _, a := range msg.Attachments {
+		disposition := "attachment"
+		if a.ContentID != "" {
+			disposition = "inline"
+		}
+		payload.Attachments = append(payload.Attachments, attachment{
+			Content:     base64.StdEncoding.EncodeToString(a.Data),
+			Type:        a.ContentType,
+			Filename:    a.Filename,
+			Disposition: disposition,
+			ContentID:   a.ContentID,
+		})
+	}
+
+	header, err := p.postJSON(ctx, "/v3/mail/send", payload, func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+	}, nil)
+	if err != nil {
+		return "", err
+	}
+	return header.Get("X-Message-Id"), nil
+}
+
+// sendResend sends through Resend's /emails endpoint
+func (p *HTTPProvider) sendResend(ctx context.Context, msg *Message) (string, error) {
+	type attachment struct {
+		Filename    string `json:"filename"`
+		Content     string `json:"content"`
+		ContentType string `json:"content_type,omitempty"`
+		ContentID   string `json:"content_id,omitempty"`
+	}
+	payload := struct {
+		From        string            `json:"from"`
+		To          []string          `json:"to"`
+		ReplyTo     string            `json:"reply_to,omitempty"`
+		Subject     string            `json:"subject"`
+		HTML        string            `json:"html,omitempty"`
+		Text        string            `json:"text"`
+		Headers     map[string]string `json:"headers,omitempty"`
+		Attachments []attachment      `json:"attachments,omitempty"`
+	}{
+		From:    formatAddress(msg.FromName, msg.FromEmail),
+		To:      []string{msg.To},
+		ReplyTo: msg.ReplyTo,
+		Subject: msg.Subject,
+		HTML:    msg.HTMLBody,
+		Text:    msg.plainText(),
+		Headers: headerMap(msg.Headers),
+	}
+	for _, a := range msg.Attachments {
+		payload.Attachments = append(payload.Attachments, attachment{
+			Filename:    a.Filename,
+			Content:     base64.StdEncoding.EncodeToString(a.Data),
+			ContentType: a.ContentType,
+			ContentID:   a.ContentID,
+		})
+	}
+
+	var resp struct {
+		ID string `json:"id"`
+	}
+	_, err := p.postJSON(ctx, "/emails", payload, func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+	}, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// headerMap converts extra headers to the map form JSON APIs take
+func headerMap(headers []Header) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(headers))
+	for _, h := range headers {
+		m[h.Name] = h.Value
+	}
+	return m
+}
+
+// errWebhookSignature is returned when a webhook fails verification
+var errWebhookSignature = errors.New("invalid webhook signature")
+
+// parsePostmark handles Postmark webhooks, which authenticate with the basic
+// auth credentials embedded in the webhook URL
+func (p *HTTPProvider) parsePostmark(r *http.Request, body []byte) ([]WebhookEvent, error) {
+	_, password, ok := r.BasicAuth()
+	if !ok || subtle.ConstantTimeCompare([]byte(password), []byte(p.config.WebhookSecret)) != 1 {
+		return nil, errWebhookSignature
+	}
+
+	var record struct {
+		RecordType  string
+		Type        string
+		TypeCode    int
+		Email       string
+		Recipient   string
+		MessageID   string
+		Description string
+		Details     string
+		BouncedAt   time.Time
+		DeliveredAt time.Time
+	}
+	if err := json.Unmarshal(body, &record); err != nil {
+		return nil, fmt.Errorf("invalid postmark webhook: %w", err)
+	}
+
+	switch record.RecordType {
+	case "Bounce":
+		bounceType := "soft"
+		if record.Type == "HardBounce" || record.Type == "BadEmailAddress" {
+			bounceType = "hard"
+		}
+		return []WebhookEvent{{
+			Type:       EventBounced,
+			Email:      record.Email,
+			MessageID:  record.MessageID,
+			BounceType: bounceType,
+			Reason:     record.Description,
+			Timestamp:  record.BouncedAt,
+		}}, nil
+	case "SpamComplaint":
+		return []WebhookEvent{{
+			Type:      EventComplained,
+			Email:     record.Email,
+			MessageID: record.MessageID,
+			Timestamp: record.BouncedAt,
+		}}, nil
+	case "Delivery":
+		return []WebhookEvent{{
+			Type:      EventDelivered,
+			Email:     record.Recipient,
+			MessageID: record.MessageID,
+			Timestamp: record.DeliveredAt,
+		}}, nil
+	}
+	return nil, nil
+}
+
+// webhookMaxAge is how far a signed webhook's timestamp may be from now,
+// so a captured payload cannot be replayed later
+const webhookMaxAge = 5 * time.Minute
+
+// freshWebhookTimestamp reports whether a Unix timestamp in seconds is
+// within webhookMaxAge of now
+func freshWebhookTimestamp(timestamp string) bool {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	return err == nil && time.Since(time.Unix(ts, 0)).Abs() <= webhookMaxAge
+}
+
+// parseMailgun handles Mailgun webhooks, signed with HMAC-SHA256 of the
+// timestamp and token using the webhook signing key
+func (p *HTTPProvider) parseMailgun(body []byte) ([]WebhookEvent, error) {
+	var payload struct {
+		Signature struct {
+			Timestamp string `json:"timestamp"`
+			Token     string `json:"token"`
+			Signature string `json:"signature"`
+		} `json:"signature"`
+		EventData struct {
+			Event     string  `json:"event"`
+			Severity  string  `json:"severity"`
+			Recipient string  `json:"recipient"`
+			Timestamp float64 `json:"timestamp"`
+			Message   struct {
+				Headers struct {
+					MessageID string `json:"message-id"`
+				} `json:"headers"`
+			} `json:"message"`
+			DeliveryStatus struct {
+				Description string `json:"description"`
+				Message     string `json:"message"`
+			} `json:"delivery-status"`
+		} `json:"event-data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid mailgun webhook: %w", err)
+	}
+
+	if !freshWebhookTimestamp(payload.Signature.Timestamp) {
+		return nil, errWebhookSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(p.config.WebhookSecret))
+	mac.Write([]byte(payload.Signature.Timestamp + payload.Signature.Token))
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(payload.Signature.Signature)) {
+		return nil, errWebhookSignature
+	}
+
+	data := payload.EventData
+	event := WebhookEvent{
+		Email:     data.Recipient,
+		MessageID: data.Message.Headers.MessageID,
+		Timestamp: time.Unix(int64(data.Timestamp), 0),
+	}
+	switch data.Event {
+	case "failed":
+		event.Type = EventBounced
+		event.BounceType = "soft"
+		if data.Severity == "permanent" {
+			event.BounceType = "hard"
+		}
+		event.Reason = data.DeliveryStatus.Description
+		if event.Reason == "" {
+			event.Reason = data.DeliveryStatus.Message
+		}
+	case "complained":
+		event.Type = EventComplained
+	case "delivered":
+		event.Type = EventDelivered
+	default:
+		return nil, nil
+	}
+	return []WebhookEvent{event}, nil
+}
+
+// parseSendGrid handles SendGrid signed event webhooks, verified with the
+// ECDSA public key from the SendGrid settings
+func (p *HTTPProvider) parseSendGrid(r *http.Request, body []byte) ([]WebhookEvent, error) {
+	signature, err := base64.StdEncoding.DecodeString(r.Header.Get("X-Twilio-Email-Event-Webhook-Signature"))
+	if err != nil {
+		return nil, errWebhookSignature
+	}
+	der, err := base64.StdEncoding.DecodeString(p.config.WebhookSecret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sendgrid verification key: %w", err)
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sendgrid verification key: %w", err)
+	}
+	publicKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("sendgrid verification key is not an ECDSA key")
+	}
+
+	timestamp := r.Header.Get("X-Twilio-Email-Event-Webhook-Timestamp")
+	if !freshWebhookTimestamp(timestamp) {
+		return nil, errWebhookSignature
+	}
+
+	digest := sha256.Sum256(append([]byte(timestamp), body...))
+	if !ecdsa.VerifyASN1(publicKey, digest[:], signature) {
+		return nil, errWebhookSignature
+	}
+
+	var records []struct {
+		Email       string `json:"email"`
+		Event       string `json:"event"`
+		Type        string `json:"type"`
+		Reason      string `json:"reason"`
+		SGMessageID string `json:"sg_message_id"`
+		Timestamp   int64  `json:"timestamp"`
+	}
+	if err := json.Unmarshal(body, &records); err != nil {
+		return nil, fmt.Errorf("invalid sendgrid webhook: %w", err)
+	}
+
+	var events []WebhookEvent
+	for _, rec := range records {
+		// sg_message_id extends the X-Message-Id returned by Send
+		messageID, _, _ := strings.Cut(rec.SGMessageID, ".")
+		event := WebhookEvent{
+			Email:     rec.Email,
+			MessageID: messageID,
+			Timestamp: time.Unix(rec.Timestamp, 0),
+		}
+		switch rec.Event {
+		case "bounce":
+			event.Type = EventBounced
+			event.BounceType = "hard"
+			if rec.Type == "blocked" {
+				event.BounceType = "soft"
+			}
+			event.Reason = rec.Reason
+		case "spamreport":
+			event.Type = EventComplained
+		case "delivered":
+			event.Type = EventDelivered
+		default:
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// parseResend handles Resend webhooks, signed in the Svix format with a
+// whsec_ secret
+func (p *HTTPProvider) parseResend(r *http.Request, body []byte) ([]WebhookEvent, error) {
+	secret, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(p.config.WebhookSecret, "whsec_"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid resend signing secret: %w", err)
+	}
+
+	id := r.Header.Get("svix-id")
+	timestamp := r.Header.Get("svix-timestamp")
+	if !freshWebhookTimestamp(timestamp) {
+		return nil, errWebhookSignature
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(id + "." + timestamp + "."))
+	mac.Write(body)
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	verified := false
+	for _, sig := range strings.Fields(r.Header.Get("svix-signature")) {
+		if version, value, ok := strings.Cut(sig, ","); ok && version == "v1" && hmac.Equal([]byte(value), []byte(expected)) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errWebhookSignature
+	}
+
+	var payload struct {
+		Type      string    `json:"type"`
+		CreatedAt time.Time `json:"created_at"`
+		Data      struct {
+			EmailID string   `json:"email_id"`
+			To      []string `json:"to"`
+			Bounce  struct {
+				Type    string `json:"type"`
+				Message string `json:"message"`
+			} `json:"bounce"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid resend webhook: %w", err)
+	}
+
+	template := WebhookEvent{
+		MessageID: payload.Data.EmailID,
+		Timestamp: payload.CreatedAt,
+	}
+	switch payload.Type {
+	case "email.bounced":
+		template.Type = EventBounced
+		template.BounceType = "soft"
+		if payload.Data.Bounce.Type == "Permanent" {
+			template.BounceType = "hard"
+		}
+		template.Reason = payload.Data.Bounce.Message
+	case "email.complained":
+		template.Type = EventComplained
+	case "email.delivered":
+		template.Type = EventDelivered
+	default:
+		return nil, nil
+	}
+
+	events := make([]WebhookEvent, 0, len(payload.Data.To))
+	for _, email := range payload.Data.To {
+		event := template
+		event.Email = email
+		events = append(events, event)
+	}
+	return events, nil
+}
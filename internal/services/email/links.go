@@ -0,0 +1,85 @@
+package email
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/outlet-sh/outlet/internal/services/tracking"
+)
+
+// maxLinkNameLength caps the link text recorded as a click's link name
+const maxLinkNameLength = 100
+
+var (
+	// anchorPattern matches a link's opening tag up to its href value, the
+	// href value, the rest of the opening tag, and the link text
+	anchorPattern = regexp.MustCompile(`(?is)(<a\s[^>]*?\bhref=")([^"]*)("[^>]*>)(.*?)</a>`)
+	tagPattern    = regexp.MustCompile(`<[^>]*>`)
+)
+
+// SetLinkSigner sets the signer for click tracking links. Without one,
+// links are left as they are.
+func (s *Service) SetLinkSigner(links *tracking.LinkSigner) {
+	s.links = links
+}
+
+// RewriteLinksForTracking replaces http(s) links with signed click tracking
+// URLs. The link text is carried along as the link name.
+func (s *Service) RewriteLinksForTracking(htmlBody, trackingToken string) string {
+	return rewriteLinks(htmlBody, s.baseURL, trackingToken, s.links)
+}
+
+// AddTracking adds the open pixel to an HTML body and rewrites its links
+// for click tracking
+func (s *Service) AddTracking(htmlBody, trackingToken string) string {
+	if htmlBody == "" || trackingToken == "" {
+		return htmlBody
+	}
+
+	pixel := `<img src="` + s.GetTrackingPixelURL(trackingToken) + `" width="1" height="1" style="display:none" />`
+	if i := strings.LastIndex(strings.ToLower(htmlBody), "</body>"); i >= 0 {
+		htmlBody = htmlBody[:i] + pixel + htmlBody[i:]
+	} else {
+		htmlBody += pixel
+	}
+	return s.RewriteLinksForTracking(htmlBody, trackingToken)
+}
+
+func rewriteLinks(htmlBody, baseURL, trackingToken string, links *tracking.LinkSigner) string {
+	if links == nil || trackingToken == "" {
+		return htmlBody
+	}
+
+	return anchorPattern.ReplaceAllStringFunc(htmlBody, func(match string) string {
+		parts := anchorPattern.FindStringSubmatch(match)
+		target := html.UnescapeString(parts[2])
+		if !isTrackableURL(target) {
+			return match
+		}
+
+		clickURL := links.ClickURL(baseURL, trackingToken, target, linkName(parts[4]))
+		return parts[1] + html.EscapeString(clickURL) + parts[3] + parts[4] + "</a>"
+	})
+}
+
+// isTrackableURL reports whether a link goes to a web page. Our own
+// tracking and unsubscribe links are left alone.
+func isTrackableURL(target string) bool {
+	lower := strings.ToLower(target)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		return false
+	}
+	return !strings.Contains(target, "/api/e/")
+}
+
+// linkName returns the visible text of a link
+func linkName(inner string) string {
+	text := html.UnescapeString(tagPattern.ReplaceAllString(inner, " "))
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) > maxLinkNameLength {
+		text = string([]rune(text)[:maxLinkNameLength])
+	}
+	return text
+}
@@ -0,0 +1,75 @@
+package email
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/outlet-sh/outlet/internal/services/tracking"
+)
+
+func TestRewriteLinksForTracking(t *testing.T) {
+	signer := tracking.NewLinkSigner([]byte("test-key"))
+	s := &Service{baseURL: "https://outlet.test", links: signer}
+
+	body := `<p><a href="https://example.com/?a=1&amp;b=2" class="btn"><strong>Read</strong>  more</a>` +
+		` <a href="mailto:hi@example.com">Mail</a>` +
+		` <a href="https://outlet.test/api/e/u/tok">Unsubscribe</a></p>`
+	got := s.RewriteLinksForTracking(body, "tok")
+
+	hrefs := regexp.MustCompile(`href="([^"]*)"`).FindAllStringSubmatch(got, -1)
+	if len(hrefs) != 3 {
+		t.Fatalf("got %d links, want 3: %s", len(hrefs), got)
+	}
+
+	tracked, err := url.Parse(html.UnescapeString(hrefs[0][1]))
+	if err != nil {
+		t.Fatalf("tracked link is not a URL: %v", err)
+	}
+	q := tracked.Query()
+	if q.Get("url") != "https://example.com/?a=1&b=2" {
+		t.Errorf("target = %q, want the unescaped href", q.Get("url"))
+	}
+	if q.Get("n") != "Read more" {
+		t.Errorf("link name = %q, want the link text", q.Get("n"))
+	}
+	if err := signer.Verify("tok", q.Get("url"), q.Get("n"), q.Get("s")); err != nil {
+		t.Errorf("tracked link does not verify: %v", err)
+	}
+
+	if hrefs[1][1] != "mailto:hi@example.com" {
+		t.Errorf("mailto link rewritten: %s", hrefs[1][1])
+	}
+	if hrefs[2][1] != "https://outlet.test/api/e/u/tok" {
+		t.Errorf("unsubscribe link rewritten: %s", hrefs[2][1])
+	}
+}
+
+func TestRewriteLinksWithoutSigner(t *testing.T) {
+	s := &Service{baseURL: "https://outlet.test"}
+	body := `<a href="https://example.com/">Home</a>`
+	if got := s.RewriteLinksForTracking(body, "tok"); got != body {
+		t.Errorf("links rewritten without a signer: %s", got)
+	}
+}
+
+func TestAddTracking(t *testing.T) {
+	s := &Service{baseURL: "https://outlet.test"}
+	pixel := `<img src="https://outlet.test/api/e/o/tok"`
+
+	got := s.AddTracking("<html><body><p>Hi</p></BODY></html>", "tok")
+	if !strings.Contains(got, pixel) || !strings.HasSuffix(got, "</BODY></html>") {
+		t.Errorf("pixel not added before </body>: %s", got)
+	}
+
+	got = s.AddTracking("<p>Hi</p>", "tok")
+	if !strings.HasPrefix(got, "<p>Hi</p>"+pixel) {
+		t.Errorf("pixel not appended to a body without </body>: %s", got)
+	}
+
+	if got := s.AddTracking("", "tok"); got != "" {
+		t.Errorf("pixel added to an empty body: %s", got)
+	}
+}
@@ -0,0 +1,244 @@
+package email
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Message is an outgoing email. Every send path renders it with Build, so
+// SES and SMTP deliver identical MIME output.
+type Message struct {
+	FromName  string
+	FromEmail string
+	To        string
+	ReplyTo   string
+	Subject   string
+	HTMLBody  string
+	TextBody  string // Generated from HTMLBody when empty
+
+	// Headers are added after the standard headers, e.g. List-Unsubscribe
+	Headers []Header
+
+	// Attachments with a ContentID are embedded as inline images and
+	// referenced from the HTML as cid:<ContentID>
+	Attachments []Attachment
+
+	// MessageID is the Message-ID header, generated by Build when empty
+	MessageID string
+
+	// OrgID selects the organization's provider; empty uses the platform's
+	OrgID string
+}
+
+// Header is an extra message header. Values must be ASCII.
+type Header struct {
+	Name  string
+	Value string
+}
+
+// Attachment is a file attached to a message
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+	ContentID   string
+}
+
+// mimePart is a rendered MIME entity
+type mimePart struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+// applyDefaults fills empty sender fields from the configured defaults
+func (m *Message) applyDefaults(fromName, fromEmail, replyTo string) {
+	if m.FromEmail == "" {
+		m.FromEmail = fromEmail
+	}
+	if m.FromName == "" {
+		m.FromName = fromName
+	}
+	if m.ReplyTo == "" {
+		m.ReplyTo = replyTo
+	}
+}
+
+// Build renders the message as RFC 5322 bytes. The body is
+// multipart/alternative (text and HTML), wrapped in multipart/related when
+// there are inline images and in multipart/mixed when there are attachments.
+func (m *Message) Build() []byte {
+	var buf bytes.Buffer
+
+	writeHeader(&buf, "From", formatAddress(m.FromName, m.FromEmail))
+	writeHeader(&buf, "To", formatAddress("", m.To))
+	if m.ReplyTo != "" {
+		writeHeader(&buf, "Reply-To", formatAddress("", m.ReplyTo))
+	}
+	buf.WriteString("Subject: " + encodeHeader(m.Subject) + "\r\n")
+	writeHeader(&buf, "Date", time.Now().Format(time.RFC1123Z))
+	if m.MessageID == "" {
+		m.MessageID = newMessageID(m.FromEmail)
+	}
+	writeHeader(&buf, "Message-ID", m.MessageID)
+	for _, h := range m.Headers {
+		writeHeader(&buf, h.Name, h.Value)
+	}
+	writeHeader(&buf, "MIME-Version", "1.0")
+
+	body := m.body()
+	for _, key := range []string{"Content-Type", "Content-Transfer-Encoding"} {
+		if v := body.header.Get(key); v != "" {
+			writeHeader(&buf, key, v)
+		}
+	}
+	buf.WriteString("\r\n")
+	buf.Write(body.body)
+
+	return buf.Bytes()
+}
+
+// body assembles the MIME tree for the message content
+func (m *Message) body() mimePart {
+	content := textPart("text/plain", m.plainText())
+	if m.HTMLBody != "" {
+		content = multipartPart("alternative", content, textPart("text/html", m.HTMLBody))
+	}
+
+	var inline, attached []mimePart
+	for _, a := range m.Attachments {
+		if a.ContentID != "" {
+			inline = append(inline, attachmentPart(a))
+		} else {
+			attached = append(attached, attachmentPart(a))
+		}
+	}
+
+	if len(inline) > 0 {
+		content = multipartPart("related", append([]mimePart{content}, inline...)...)
+	}
+	if len(attached) > 0 {
+		content = multipartPart("mixed", append([]mimePart{content}, attached...)...)
+	}
+	return content
+}
+
+// plainText returns the text body, generating it from the HTML when empty
+func (m *Message) plainText() string {
+	if m.TextBody == "" && m.HTMLBody != "" {
+		return htmlToText(m.HTMLBody)
+	}
+	return m.TextBody
+}
+
+// textPart renders a UTF-8 quoted-printable text part
+func textPart(contentType, content string) mimePart {
+	var buf bytes.Buffer
+	qp := quotedprintable.NewWriter(&buf)
+	qp.Write([]byte(content))
+	qp.Close()
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType+"; charset=UTF-8")
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+	return mimePart{header: header, body: buf.Bytes()}
+}
+
+// attachmentPart renders a base64 attachment or inline image
+func attachmentPart(a Attachment) mimePart {
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	header := textproto.MIMEHeader{}
+	disposition := "attachment"
+	if a.ContentID != "" {
+		disposition = "inline"
+		header.Set("Content-ID", "<"+stripHeaderBreaks(a.ContentID)+">")
+	}
+	if a.Filename != "" {
+		header.Set("Content-Type", mime.FormatMediaType(contentType, map[string]string{"name": a.Filename}))
+		header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": a.Filename}))
+	} else {
+		header.Set("Content-Type", contentType)
+		header.Set("Content-Disposition", disposition)
+	}
+	header.Set("Content-Transfer-Encoding", "base64")
+
+	// Base64 lines are wrapped at 76 characters (RFC 2045)
+	encoded := base64.StdEncoding.EncodeToString(a.Data)
+	var buf bytes.Buffer
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76])
+		buf.WriteString("\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded)
+	return mimePart{header: header, body: buf.Bytes()}
+}
+
+// multipartPart combines parts into a multipart entity of the given subtype
+func multipartPart(subtype string, parts ...mimePart) mimePart {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for _, p := range parts {
+		pw, _ := w.CreatePart(p.header)
+		pw.Write(p.body)
+	}
+	w.Close()
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", fmt.Sprintf("multipart/%s; boundary=%q", subtype, w.Boundary()))
+	return mimePart{header: header, body: buf.Bytes()}
+}
+
+// writeHeader writes one header line, dropping any line breaks in the value
+func writeHeader(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	buf.WriteString(": ")
+	buf.WriteString(stripHeaderBreaks(value))
+	buf.WriteString("\r\n")
+}
+
+// stripHeaderBreaks removes CR and LF so values cannot inject headers
+func stripHeaderBreaks(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
+
+// formatAddress formats a mailbox, encoding non-ASCII display names (RFC 2047)
+func formatAddress(name, address string) string {
+	address = stripHeaderBreaks(address)
+	if name == "" {
+		return address
+	}
+	return (&mail.Address{Name: stripHeaderBreaks(name), Address: address}).String()
+}
+
+// encodeHeader encodes a header value as RFC 2047 words when it is not ASCII,
+// folding between encoded words to keep long subjects within line limits
+func encodeHeader(value string) string {
+	encoded := mime.QEncoding.Encode("UTF-8", stripHeaderBreaks(value))
+	return strings.ReplaceAll(encoded, "?= =?", "?=\r\n =?")
+}
+
+// newMessageID returns a unique Message-ID in the sender's domain
+func newMessageID(fromEmail string) string {
+	b := make([]byte, 16)
+	rand.Read(b)
+
+	domain := "localhost"
+	if i := strings.LastIndex(fromEmail, "@"); i >= 0 && i < len(fromEmail)-1 {
+		domain = fromEmail[i+1:]
+	}
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">"
+}
@@ -0,0 +1,197 @@
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+type testPart struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+// readParts reads a multipart body with the expected subtype. The reader
+// decodes quoted-printable parts; base64 parts are decoded here.
+func readParts(t *testing.T, contentType string, body io.Reader, subtype string) []testPart {
+	t.Helper()
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatalf("parse %q: %v", contentType, err)
+	}
+	if mediaType != "multipart/"+subtype {
+		t.Fatalf("Content-Type = %q, want multipart/%s", mediaType, subtype)
+	}
+
+	var out []testPart
+	r := multipart.NewReader(body, params["boundary"])
+	for {
+		p, err := r.NextPart()
+		if err == io.EOF {
+			return out
+		}
+		if err != nil {
+			t.Fatalf("next part: %v", err)
+		}
+		var data io.Reader = p
+		if p.Header.Get("Content-Transfer-Encoding") == "base64" {
+			data = base64.NewDecoder(base64.StdEncoding, p)
+		}
+		b, err := io.ReadAll(data)
+		if err != nil {
+			t.Fatalf("read part: %v", err)
+		}
+		out = append(out, testPart{header: p.Header, body: b})
+	}
+}
+
+func TestMessageBuild_Alternative(t *testing.T) {
+	msg := Message{
+		FromName:  "Zoë's Shop",
+		FromEmail: "news@example.com",
+		To:        "a@example.com",
+		Subject:   "Grüße aus Köln",
+		HTMLBody:  `<html><head><style>p{}</style></head><body><p>Hello <a href="https://example.com/x">there</a></p></body></html>`,
+		Headers:   listUnsubscribeHeaders("https://mail.example.com", "tok123", ""),
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(msg.Build()))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+
+	dec := new(mime.WordDecoder)
+	if subject, _ := dec.DecodeHeader(parsed.Header.Get("Subject")); subject != msg.Subject {
+		t.Errorf("Subject = %q, want %q", subject, msg.Subject)
+	}
+	from, err := parsed.Header.AddressList("From")
+	if err != nil || len(from) != 1 || from[0].Name != msg.FromName || from[0].Address != msg.FromEmail {
+		t.Errorf("From = %v (%v)", from, err)
+	}
+	if !strings.HasSuffix(parsed.Header.Get("Message-ID"), "@example.com>") {
+		t.Errorf("Message-ID = %q", parsed.Header.Get("Message-ID"))
+	}
+	if parsed.Header.Get("List-Unsubscribe-Post") != "List-Unsubscribe=One-Click" {
+		t.Errorf("List-Unsubscribe-Post = %q", parsed.Header.Get("List-Unsubscribe-Post"))
+	}
+	if parsed.Header.Get("Reply-To") != "" {
+		t.Errorf("unexpected Reply-To %q", parsed.Header.Get("Reply-To"))
+	}
+
+	alt := readParts(t, parsed.Header.Get("Content-Type"), parsed.Body, "alternative")
+	if len(alt) != 2 {
+		t.Fatalf("got %d alternative parts, want 2", len(alt))
+	}
+	if ct := alt[0].header.Get("Content-Type"); ct != "text/plain; charset=UTF-8" {
+		t.Errorf("first part Content-Type = %q", ct)
+	}
+	if text := string(alt[0].body); text != "Hello there (https://example.com/x)" {
+		t.Errorf("text part = %q", text)
+	}
+	if html := string(alt[1].body); html != msg.HTMLBody {
+		t.Errorf("html part = %q", html)
+	}
+}
+
+func TestMessageBuild_Attachments(t *testing.T) {
+	msg := Message{
+		FromEmail: "news@example.com",
+		To:        "a@example.com",
+		Subject:   "Report",
+		HTMLBody:  `<p><img src="cid:logo"></p>`,
+		TextBody:  "See attached",
+		Attachments: []Attachment{
+			{Filename: "logo.png", ContentType: "image/png", Data: []byte("png-data"), ContentID: "logo"},
+			{Filename: "report.pdf", ContentType: "application/pdf", Data: bytes.Repeat([]byte("x"), 200)},
+		},
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(msg.Build()))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+
+	mixed := readParts(t, parsed.Header.Get("Content-Type"), parsed.Body, "mixed")
+	if len(mixed) != 2 {
+		t.Fatalf("got %d mixed parts, want 2", len(mixed))
+	}
+
+	attachment := mixed[1]
+	if d := attachment.header.Get("Content-Disposition"); d != "attachment; filename=report.pdf" {
+		t.Errorf("Content-Disposition = %q", d)
+	}
+	if data := attachment.body; !bytes.Equal(data, bytes.Repeat([]byte("x"), 200)) {
+		t.Errorf("attachment data = %q", data)
+	}
+
+	related := readParts(t, mixed[0].header.Get("Content-Type"), bytes.NewReader(mixed[0].body), "related")
+	if len(related) != 2 {
+		t.Fatalf("got %d related parts, want 2", len(related))
+	}
+	if cid := related[1].header.Get("Content-ID"); cid != "<logo>" {
+		t.Errorf("Content-ID = %q", cid)
+	}
+
+	alt := readParts(t, related[0].header.Get("Content-Type"), bytes.NewReader(related[0].body), "alternative")
+	if text := string(alt[0].body); text != "See attached" {
+		t.Errorf("text part = %q", text)
+	}
+}
+
+func TestMessageBuild_StripsHeaderInjection(t *testing.T) {
+	msg := Message{
+		FromEmail: "news@example.com",
+		To:        "a@example.com\r\nBcc: victim@example.com",
+		Subject:   "Hi\r\nBcc: victim@example.com",
+		TextBody:  "plain",
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(msg.Build()))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	if bcc := parsed.Header.Get("Bcc"); bcc != "" {
+		t.Errorf("injected Bcc header %q", bcc)
+	}
+	if ct := parsed.Header.Get("Content-Type"); ct != "text/plain; charset=UTF-8" {
+		t.Errorf("text-only Content-Type = %q", ct)
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "blocks and lists",
+			html: "<h1>Title</h1><p>First\n   line</p><ul><li>one</li><li>two</li></ul>",
+			want: "Title\n\nFirst line\n\n- one\n- two",
+		},
+		{
+			name: "links",
+			html: `<a href="https://a.example">Docs</a> <a href="https://b.example">https://b.example</a> <a href="#top">top</a> <a href="mailto:x@example.com">x@example.com</a>`,
+			want: "Docs (https://a.example) https://b.example top x@example.com",
+		},
+		{
+			name: "hidden content and entities",
+			html: "<head><title>T</title><style>.a{}</style></head><body><script>x()</script>Tom &amp; Jerry<br>Bye</body>",
+			want: "Tom & Jerry\nBye",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := htmlToText(tt.html); got != tt.want {
+				t.Errorf("htmlToText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
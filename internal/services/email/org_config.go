@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/services/emailval"
 )
 
 // OrgEmailConfig holds per-organization email configuration
@@ -31,11 +32,24 @@ type OrgEmailConfig struct {
 	FromEmail string `json:"from_email,omitempty"`
 	FromName  string `json:"from_name,omitempty"`
 	ReplyTo   string `json:"reply_to,omitempty"`
+
+	// Sending provider: ses, smtp, http or file. Empty uses the platform's.
+	Provider string `json:"provider,omitempty"`
+
+	// Optional: Org-specific SMTP relay, used when Provider is smtp
+	SMTPHost     string `json:"smtp_host,omitempty"`
+	SMTPPort     int    `json:"smtp_port,omitempty"`
+	SMTPUser     string `json:"smtp_user,omitempty"`
+	SMTPPassword string `json:"smtp_password,omitempty"`
+
+	// HTTP API provider settings, used when Provider is http
+	HTTPAPI *HTTPProviderConfig `json:"http_api,omitempty"`
 }
 
 // OrgSettings wraps the full org settings JSON structure
 type OrgSettings struct {
-	Email *OrgEmailConfig `json:"email,omitempty"`
+	Email      *OrgEmailConfig  `json:"email,omitempty"`
+	Validation *emailval.Policy `json:"validation,omitempty"`
 }
 
 // GetOrgEmailConfig retrieves email configuration for an organization
@@ -97,6 +111,12 @@ func GetOrgEmailConfig(ctx context.Context, store *db.Store, orgID string) (*Org
 			if settings.Email.ReplyTo != "" {
 				config.ReplyTo = settings.Email.ReplyTo
 			}
+			config.Provider = settings.Email.Provider
+			config.SMTPHost = settings.Email.SMTPHost
+			config.SMTPPort = settings.Email.SMTPPort
+			config.SMTPUser = settings.Email.SMTPUser
+			config.SMTPPassword = settings.Email.SMTPPassword
+			config.HTTPAPI = settings.Email.HTTPAPI
 		}
 	}
 
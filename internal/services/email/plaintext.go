@@ -0,0 +1,125 @@
+package email
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	// blankLines collapses runs of empty lines left by nested blocks
+	blankLines = regexp.MustCompile(`\n{3,}`)
+
+	// textBlockTags start a new line in the text rendering
+	textBlockTags = map[string]bool{
+		"p": true, "div": true, "table": true, "tr": true, "ul": true, "ol": true,
+		"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+		"blockquote": true, "pre": true, "section": true, "header": true, "footer": true,
+	}
+
+	// textSkipTags have content that is never shown
+	textSkipTags = map[string]bool{
+		"head": true, "style": true, "script": true, "title": true,
+	}
+)
+
+// htmlToText renders an HTML body as plain text for the text/plain part.
+// Block elements become line breaks, list items are bulleted and links keep
+// their target in brackets so they stay clickable in text-only clients.
+func htmlToText(body string) string {
+	var sb strings.Builder
+	z := html.NewTokenizer(strings.NewReader(body))
+
+	skip := 0
+	var links []textLink
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		tok := z.Token()
+
+		switch tt {
+		case html.TextToken:
+			// Whitespace is collapsed when lines are trimmed below
+			if skip == 0 {
+				sb.WriteString(strings.ReplaceAll(tok.Data, "\n", " "))
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name := tok.Data
+			switch {
+			case textSkipTags[name]:
+				if tt == html.StartTagToken {
+					skip++
+				}
+			case name == "br":
+				sb.WriteString("\n")
+			case name == "hr":
+				sb.WriteString("\n---\n")
+			case name == "li":
+				sb.WriteString("\n- ")
+			case name == "td" || name == "th":
+				sb.WriteString(" ")
+			case name == "a":
+				links = append(links, textLink{href: linkTarget(tok), start: sb.Len()})
+			case textBlockTags[name]:
+				sb.WriteString("\n\n")
+			}
+
+		case html.EndTagToken:
+			name := tok.Data
+			switch {
+			case textSkipTags[name]:
+				if skip > 0 {
+					skip--
+				}
+			case name == "a":
+				if len(links) == 0 {
+					continue
+				}
+				link := links[len(links)-1]
+				links = links[:len(links)-1]
+				// Bare URLs already show their target
+				label := strings.TrimSpace(sb.String()[link.start:])
+				if link.href != "" && skip == 0 && label != link.href {
+					sb.WriteString(" (" + link.href + ")")
+				}
+			case textBlockTags[name]:
+				sb.WriteString("\n\n")
+			}
+		}
+	}
+
+	// Trim each line, then collapse blank runs
+	lines := strings.Split(sb.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	text := blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text)
+}
+
+// textLink is an open <a> element and where its text starts
+type textLink struct {
+	href  string
+	start int
+}
+
+// linkTarget returns the href of a link worth showing in text, skipping
+// anchors and template placeholders
+func linkTarget(tok html.Token) string {
+	for _, attr := range tok.Attr {
+		if attr.Key != "href" {
+			continue
+		}
+		href := strings.TrimSpace(attr.Val)
+		if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(href, "{{") {
+			return ""
+		}
+		return strings.TrimPrefix(href, "mailto:")
+	}
+	return ""
+}
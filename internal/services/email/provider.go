@@ -0,0 +1,207 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+)
+
+// Provider names selectable in OrgEmailConfig.Provider
+const (
+	ProviderSES  = "ses"
+	ProviderSMTP = "smtp"
+	ProviderHTTP = "http"
+	ProviderFile = "file"
+)
+
+// Webhook event types reported by providers
+const (
+	EventDelivered  = "delivered"
+	EventBounced    = "bounced"
+	EventComplained = "complained"
+)
+
+// ErrNotSupported is returned by providers for operations they do not offer
+var ErrNotSupported = errors.New("not supported by this provider")
+
+// Provider delivers messages through one email service provider
+type Provider interface {
+	// Name returns the provider name, e.g. ses or http:postmark
+	Name() string
+
+	// Send delivers a message and returns the provider's message ID
+	Send(ctx context.Context, msg *Message) (string, error)
+
+	// Capabilities describes what the provider supports
+	Capabilities() Capabilities
+
+	// Quota reports the account's sending limits, or ErrNotSupported
+	Quota(ctx context.Context) (*Quota, error)
+
+	// ParseWebhook authenticates a delivery event callback and returns its
+	// events, or ErrNotSupported
+	ParseWebhook(r *http.Request) ([]WebhookEvent, error)
+}
+
+// Capabilities describes optional provider features
+type Capabilities struct {
+	SignsDKIM bool // The provider signs mail with its own DKIM keys
+	Webhooks  bool // Bounces and complaints arrive through ParseWebhook
+	Quota     bool // Quota reports real account limits
+}
+
+// Quota holds provider sending limits. Zero values mean no known limit.
+type Quota struct {
+	Max24HourSend   float64
+	MaxSendRate     float64
+	SentLast24Hours float64
+}
+
+// WebhookEvent is a delivery event reported by a provider
+type WebhookEvent struct {
+	Type       string // delivered, bounced, complained
+	Email      string
+	MessageID  string
+	BounceType string // hard or soft, for bounces
+	Reason     string
+	Timestamp  time.Time
+}
+
+// SetOutbox configures the file provider directory. With captureAll set,
+// every message is written there instead of being sent, for staging.
+func (s *Service) SetOutbox(dir string, captureAll bool) {
+	s.outboxDir = dir
+	s.captureAll = captureAll && dir != ""
+}
+
+// Provider returns the provider that sends mail for an organization. An
+// empty orgID returns the platform provider.
+func (s *Service) Provider(ctx context.Context, orgID string) (Provider, error) {
+	if orgID == "" || s.captureAll {
+		return s.orgProvider(ctx, orgID, nil)
+	}
+
+	config, err := GetOrgEmailConfig(ctx, s.db, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return s.orgProvider(ctx, orgID, config)
+}
+
+// orgProvider selects the provider for a loaded org config. A nil config
+// returns the platform provider.
+func (s *Service) orgProvider(ctx context.Context, orgID string, config *OrgEmailConfig) (Provider, error) {
+	if s.captureAll {
+		return s.fileProvider(orgID)
+	}
+	if config == nil {
+		return s.platformProvider(ctx)
+	}
+
+	switch config.Provider {
+	case "":
+		// Orgs with their own AWS account send through it by default
+		if config.HasOwnAWSCredentials() {
+			return s.orgSESProvider(ctx, config)
+		}
+		return s.platformProvider(ctx)
+	case ProviderSES:
+		if config.HasOwnAWSCredentials() {
+			return s.orgSESProvider(ctx, config)
+		}
+		sesConfig, err := s.getSESConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !s.hasSESConfig(sesConfig) {
+			return nil, fmt.Errorf("SES selected but no AWS credentials are configured")
+		}
+		return s.sesProvider(ctx, sesConfig)
+	case ProviderSMTP:
+		if config.SMTPHost == "" {
+			return s.platformSMTPProvider(ctx)
+		}
+		return &SMTPProvider{
+			config: &SMTPConfig{
+				Host:     config.SMTPHost,
+				Port:     config.SMTPPort,
+				User:     config.SMTPUser,
+				Password: config.SMTPPassword,
+			},
+			sign: s.signDKIM,
+		}, nil
+	case ProviderHTTP:
+		if config.HTTPAPI == nil {
+			return nil, fmt.Errorf("HTTP API provider selected but not configured")
+		}
+		return NewHTTPProvider(*config.HTTPAPI)
+	case ProviderFile:
+		return s.fileProvider(orgID)
+	default:
+		return nil, fmt.Errorf("unknown email provider %q", config.Provider)
+	}
+}
+
+// platformProvider returns AWS SES when platform credentials are configured,
+// else the platform SMTP relay
+func (s *Service) platformProvider(ctx context.Context) (Provider, error) {
+	sesConfig, err := s.getSESConfig(ctx)
+	if err == nil && s.hasSESConfig(sesConfig) {
+		return s.sesProvider(ctx, sesConfig)
+	}
+	return s.platformSMTPProvider(ctx)
+}
+
+// orgSESProvider sends through the organization's own AWS account
+func (s *Service) orgSESProvider(ctx context.Context, config *OrgEmailConfig) (Provider, error) {
+	return s.sesProvider(ctx, &SESConfig{
+		Region:    config.AWSRegion,
+		AccessKey: config.AWSAccessKey,
+		SecretKey: config.AWSSecretKey,
+	})
+}
+
+// sesProvider returns an SES provider using the cached client for the
+// credentials
+func (s *Service) sesProvider(ctx context.Context, sesConfig *SESConfig) (Provider, error) {
+	client, err := s.sesClients.get(ctx, sesConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &SESProvider{config: sesConfig, client: client}, nil
+}
+
+// platformSMTPProvider returns the SMTP relay from platform settings, using
+// the connection pool when it is enabled
+func (s *Service) platformSMTPProvider(ctx context.Context) (Provider, error) {
+	smtpConfig, err := s.getGlobalSMTPConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email config: %w", err)
+	}
+
+	if smtpConfig.Host == "" || smtpConfig.User == "" || smtpConfig.Password == "" {
+		// Neither SES nor SMTP configured
+		return nil, fmt.Errorf("email not configured - set AWS SES credentials or SMTP settings in platform settings")
+	}
+
+	provider := &SMTPProvider{config: smtpConfig, sign: s.signDKIM}
+	if s.poolEnabled && s.pool != nil {
+		provider.pool = s.pool
+	}
+	return provider, nil
+}
+
+// fileProvider writes an organization's mail to its own outbox subdirectory
+func (s *Service) fileProvider(orgID string) (Provider, error) {
+	if s.outboxDir == "" {
+		return nil, fmt.Errorf("file provider selected but Email.OutboxDir is not configured")
+	}
+	dir := s.outboxDir
+	if orgID != "" {
+		dir = filepath.Join(dir, filepath.Base(orgID))
+	}
+	return NewFileProvider(dir), nil
+}
@@ -0,0 +1,209 @@
+package email
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileProviderWritesEML(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+	p := NewFileProvider(dir)
+
+	msg := &Message{FromEmail: "news@example.com", To: "ann@example.com", Subject: "Hello", HTMLBody: "<p>Hi</p>"}
+	name, err := p.Send(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if !strings.HasSuffix(name, ".eml") {
+		t.Errorf("Send() id = %q, want .eml file name", name)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatalf("outbox file missing: %v", err)
+	}
+	if !strings.Contains(string(data), "Message-ID: "+msg.MessageID) {
+		t.Errorf("written message lacks Message-ID %s", msg.MessageID)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("outbox has %d entries, want 1 (no temp files)", len(entries))
+	}
+}
+
+func TestHTTPProviderSendResend(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/emails" || r.Header.Get("Authorization") != "Bearer re_key" {
+			t.Errorf("unexpected request %s with auth %q", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"id":"re_123"}`))
+	}))
+	defer server.Close()
+
+	p, err := NewHTTPProvider(HTTPProviderConfig{Service: ServiceResend, APIKey: "re_key", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewHTTPProvider() error = %v", err)
+	}
+
+	id, err := p.Send(context.Background(), &Message{
+		FromName:  "News",
+		FromEmail: "news@example.com",
+		To:        "ann@example.com",
+		Subject:   "Hello",
+		HTMLBody:  "<p>Hi</p>",
+		Headers:   []Header{{Name: "List-Unsubscribe", Value: "<https://example.com/u>"}},
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if id != "re_123" {
+		t.Errorf("Send() id = %q, want re_123", id)
+	}
+	if got["from"] != `"News" <news@example.com>` || got["text"] != "Hi" {
+		t.Errorf("payload from=%v text=%v", got["from"], got["text"])
+	}
+	if headers, _ := got["headers"].(map[string]any); headers["List-Unsubscribe"] != "<https://example.com/u>" {
+		t.Errorf("payload headers = %v", got["headers"])
+	}
+}
+
+func TestHTTPProviderSendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"ErrorCode":300,"Message":"Invalid email"}`, http.StatusUnprocessableEntity)
+	}))
+	defer server.Close()
+
+	p, _ := NewHTTPProvider(HTTPProviderConfig{Service: ServicePostmark, APIKey: "pm", BaseURL: server.URL})
+	if _, err := p.Send(context.Background(), &Message{To: "bad"}); err == nil || !strings.Contains(err.Error(), "422") {
+		t.Errorf("Send() error = %v, want API status error", err)
+	}
+}
+
+func TestNewHTTPProviderValidation(t *testing.T) {
+	tests := []HTTPProviderConfig{
+		{Service: "sparkpost", APIKey: "k"},
+		{Service: ServicePostmark},
+		{Service: ServiceMailgun, APIKey: "k"},
+	}
+	for _, config := range tests {
+		if _, err := NewHTTPProvider(config); err == nil {
+			t.Errorf("NewHTTPProvider(%+v) succeeded, want error", config)
+		}
+	}
+}
+
+func TestHTTPProviderMailgunWebhook(t *testing.T) {
+	p, _ := NewHTTPProvider(HTTPProviderConfig{Service: ServiceMailgun, APIKey: "k", Domain: "mg.example.com", WebhookSecret: "signing-key"})
+
+	sign := func(timestamp string) string {
+		mac := hmac.New(sha256.New, []byte("signing-key"))
+		mac.Write([]byte(timestamp + "tok"))
+		return `{"signature":{"timestamp":"` + timestamp + `","token":"tok","signature":"` + hex.EncodeToString(mac.Sum(nil)) + `"},
+		"event-data":{"event":"failed","severity":"permanent","recipient":"ann@example.com","timestamp":1700000000.5,
+		"message":{"headers":{"message-id":"abc@mg.example.com"}},"delivery-status":{"description":"No such user"}}}`
+	}
+	body := sign(strconv.FormatInt(time.Now().Unix(), 10))
+
+	events, err := p.ParseWebhook(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	if err != nil {
+		t.Fatalf("ParseWebhook() error = %v", err)
+	}
+	want := WebhookEvent{Type: EventBounced, Email: "ann@example.com", MessageID: "abc@mg.example.com", BounceType: "hard", Reason: "No such user", Timestamp: time.Unix(1700000000, 0)}
+	if len(events) != 1 || events[0] != want {
+		t.Errorf("ParseWebhook() = %+v, want %+v", events, want)
+	}
+
+	tampered := strings.Replace(body, "ann@", "bob@", 1)
+	tampered = strings.Replace(tampered, `"token":"tok"`, `"token":"other"`, 1)
+	if _, err := p.ParseWebhook(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tampered))); err == nil {
+		t.Error("ParseWebhook() accepted a bad signature")
+	}
+
+	replayed := sign("1700000000")
+	if _, err := p.ParseWebhook(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(replayed))); err == nil {
+		t.Error("ParseWebhook() accepted a stale timestamp")
+	}
+}
+
+func TestHTTPProviderResendWebhook(t *testing.T) {
+	key := []byte("resend-secret-key")
+	p, _ := NewHTTPProvider(HTTPProviderConfig{Service: ServiceResend, APIKey: "k", WebhookSecret: "whsec_" + base64.StdEncoding.EncodeToString(key)})
+
+	body := `{"type":"email.complained","created_at":"2024-01-02T03:04:05Z","data":{"email_id":"re_1","to":["ann@example.com"]}}`
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("msg_1." + timestamp + "." + body))
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("svix-id", "msg_1")
+	req.Header.Set("svix-timestamp", timestamp)
+	req.Header.Set("svix-signature", "v1,bogus v1,"+base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+
+	events, err := p.ParseWebhook(req)
+	if err != nil {
+		t.Fatalf("ParseWebhook() error = %v", err)
+	}
+	if len(events) != 1 || events[0].Type != EventComplained || events[0].Email != "ann@example.com" || events[0].MessageID != "re_1" {
+		t.Errorf("ParseWebhook() = %+v", events)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("svix-id", "msg_1")
+	req.Header.Set("svix-timestamp", timestamp)
+	req.Header.Set("svix-signature", "v1,bogus")
+	if _, err := p.ParseWebhook(req); err == nil {
+		t.Error("ParseWebhook() accepted a bad signature")
+	}
+}
+
+func TestSESClientCacheReusesClients(t *testing.T) {
+	var cache sesClientCache
+	ctx := context.Background()
+
+	a, err := cache.get(ctx, &SESConfig{Region: "eu-west-1", AccessKey: "AKIA1", SecretKey: "s1"})
+	if err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	b, _ := cache.get(ctx, &SESConfig{Region: "eu-west-1", AccessKey: "AKIA1", SecretKey: "s1"})
+	if a != b {
+		t.Error("get() built a new client for the same credentials")
+	}
+
+	// Another org's account, or rotated credentials, get their own client
+	c, _ := cache.get(ctx, &SESConfig{Region: "eu-west-1", AccessKey: "AKIA2", SecretKey: "s2"})
+	if c == a {
+		t.Error("get() shared a client across credentials")
+	}
+}
+
+func TestFreshWebhookTimestamp(t *testing.T) {
+	now := time.Now()
+	tests := map[string]bool{
+		strconv.FormatInt(now.Unix(), 10):                      true,
+		strconv.FormatInt(now.Add(-4*time.Minute).Unix(), 10):  true,
+		strconv.FormatInt(now.Add(-10*time.Minute).Unix(), 10): false,
+		strconv.FormatInt(now.Add(10*time.Minute).Unix(), 10):  false,
+		"":                false,
+		"not-a-timestamp": false,
+	}
+	for timestamp, want := range tests {
+		if got := freshWebhookTimestamp(timestamp); got != want {
+			t.Errorf("freshWebhookTimestamp(%q) = %v, want %v", timestamp, got, want)
+		}
+	}
+}
@@ -3,12 +3,12 @@ package email
 import (
 	"context"
 	"fmt"
-	"net/smtp"
 	"strconv"
 	"time"
 
 	"github.com/outlet-sh/outlet/internal/db"
 	"github.com/outlet-sh/outlet/internal/services/crypto"
+	"github.com/outlet-sh/outlet/internal/services/tracking"
 )
 
 // SMTPConfig holds SMTP configuration loaded from database
@@ -32,6 +32,28 @@ type Service struct {
 	// Connection pool for high-throughput sending (optional)
 	pool        *SMTPPool
 	poolEnabled bool
+
+	// Days attachment content is kept for transactional sends (0 = metadata only)
+	attachmentRetentionDays int
+
+	// Signing keys for mail sent over SMTP, by sending domain
+	dkimCache dkimKeyCache
+
+	// SES clients by credentials, shared by platform and org sends
+	sesClients sesClientCache
+
+	// File provider outbox; captureAll diverts all mail there
+	outboxDir  string
+	captureAll bool
+
+	// Per-org rate limits and daily quotas (optional)
+	throttle *OrgThrottle
+
+	// Signalled when transactional mail is queued
+	queued chan struct{}
+
+	// Signs click tracking links (optional; links are untracked without it)
+	links *tracking.LinkSigner
 }
 
 // NewService creates a new email service that loads SMTP config from database
@@ -40,6 +62,7 @@ func NewService(store *db.Store, cryptoSvc *crypto.Service) *Service {
 		db:        store,
 		cryptoSvc: cryptoSvc,
 		baseURL:   "", // Set via SetBaseURL from config
+		queued:    make(chan struct{}, 1),
 	}
 }
 
@@ -205,48 +228,92 @@ func (s *Service) PoolStats() (pooled, created int) {
 // sendEmail sends an HTML email via AWS SES (preferred) or SMTP (fallback)
 // Loads config from platform_settings database
 func (s *Service) sendEmail(to, subject, htmlBody string) error {
-	ctx := context.Background()
+	return s.deliverNow(context.Background(), Message{
+		To:       to,
+		Subject:  subject,
+		HTMLBody: htmlBody,
+	}, "")
+}
+
+// sendListEmail sends like sendEmail with an optional text body, through the
+// organization's provider when orgID is set. A non-empty tracking token
+// marks the message as list mail, which carries List-Unsubscribe headers.
+func (s *Service) sendListEmail(orgID, to, subject, htmlBody, textBody, trackingToken string) error {
+	return s.deliver(context.Background(), Message{
+		To:       to,
+		Subject:  subject,
+		HTMLBody: htmlBody,
+		TextBody: textBody,
+		OrgID:    orgID,
+	}, trackingToken)
+}
 
-	// Try AWS SES first (preferred for high-volume sending)
-	sesConfig, err := s.getSESConfig(ctx)
-	if err == nil && s.hasSESConfig(sesConfig) {
-		return SendEmailViaSES(ctx, sesConfig, to, subject, htmlBody)
+// deliver builds the message and sends it through the provider of the
+// message's organization, falling back to the platform's AWS SES or SMTP.
+// Empty sender fields are filled from the organization's settings, then the
+// platform's. It is used from queues: when the rate limit has no token free
+// it returns a *RateLimitError instead of waiting.
+func (s *Service) deliver(ctx context.Context, msg Message, trackingToken string) error {
+	return s.send(ctx, msg, trackingToken, false)
+}
+
+// deliverNow is deliver for callers waiting on the send, which waits for the
+// rate limit instead of returning a *RateLimitError
+func (s *Service) deliverNow(ctx context.Context, msg Message, trackingToken string) error {
+	return s.send(ctx, msg, trackingToken, true)
+}
+
+func (s *Service) send(ctx context.Context, msg Message, trackingToken string, wait bool) error {
+	var orgConfig *OrgEmailConfig
+	if msg.OrgID != "" {
+		config, err := GetOrgEmailConfig(ctx, s.db, msg.OrgID)
+		if err != nil {
+			return err
+		}
+		orgConfig = config
+		msg.applyDefaults(config.FromName, config.FromEmail, config.ReplyTo)
 	}
 
-	// Fall back to SMTP if SES not configured
-	smtpConfig, err := s.getGlobalSMTPConfig(ctx)
+	provider, err := s.orgProvider(ctx, msg.OrgID, orgConfig)
 	if err != nil {
-		return fmt.Errorf("failed to get email config: %w", err)
+		return err
 	}
 
-	if smtpConfig.Host == "" || smtpConfig.User == "" || smtpConfig.Password == "" {
-		// Neither SES nor SMTP configured
-		return fmt.Errorf("email not configured - set AWS SES credentials or SMTP settings in platform settings")
+	if defaults, err := s.getGlobalSMTPConfig(ctx); err == nil {
+		msg.applyDefaults(defaults.FromName, defaults.FromAddress, defaults.ReplyTo)
 	}
+	msg.Headers = append(listUnsubscribeHeaders(s.baseURL, trackingToken, unsubscribeMailbox(msg.ReplyTo, msg.FromEmail)), msg.Headers...)
 
-	// Build email message for SMTP
-	headers := fmt.Sprintf("From: %s <%s>\r\n", smtpConfig.FromName, smtpConfig.FromAddress)
-	headers += fmt.Sprintf("To: %s\r\n", to)
-	if smtpConfig.ReplyTo != "" {
-		headers += fmt.Sprintf("Reply-To: %s\r\n", smtpConfig.ReplyTo)
+	release := func() {}
+	if s.throttle != nil {
+		acquire := s.throttle.Acquire
+		if wait {
+			acquire = s.throttle.Wait
+		}
+		release, err = acquire(ctx, msg.OrgID, orgConfig)
+		if err != nil {
+			return err
+		}
 	}
-	headers += fmt.Sprintf("Subject: %s\r\n", subject)
-	headers += "MIME-Version: 1.0\r\n"
-	headers += "Content-Type: text/html; charset=UTF-8\r\n"
-	headers += "\r\n"
 
-	message := []byte(headers + htmlBody)
+	if _, err := provider.Send(ctx, &msg); err != nil {
+		release()
+		return err
+	}
+	return nil
+}
 
-	// SMTP auth
-	auth := smtp.PlainAuth("", smtpConfig.User, smtpConfig.Password, smtpConfig.Host)
+// SetThrottle enables per-organization rate limits and daily quotas
+func (s *Service) SetThrottle(throttle *OrgThrottle) {
+	s.throttle = throttle
+}
 
-	addr := fmt.Sprintf("%s:%d", smtpConfig.Host, smtpConfig.Port)
-	err = smtp.SendMail(addr, auth, smtpConfig.FromAddress, []string{to}, message)
-	if err != nil {
-		return fmt.Errorf("failed to send email via SMTP: %w", err)
+// unsubscribeMailbox picks the address mailto unsubscribes are sent to
+func unsubscribeMailbox(replyTo, fromEmail string) string {
+	if replyTo != "" {
+		return replyTo
 	}
-
-	return nil
+	return fromEmail
 }
 
 // SendConfirmationEmail sends a meeting confirmation email to the lead
@@ -404,124 +471,41 @@ func (s *Service) SendEmail(ctx context.Context, to, subject, htmlBody string) e
 	return s.sendEmail(to, subject, htmlBody)
 }
 
-// SendEmailFrom sends an HTML email with a custom from address
-// Uses AWS SES (preferred) or falls back to SMTP
-func (s *Service) SendEmailFrom(ctx context.Context, fromEmail, fromName, to, subject, htmlBody string) error {
-	// Try AWS SES first (preferred for high-volume sending)
-	sesConfig, err := s.getSESConfig(ctx)
-	if err == nil && s.hasSESConfig(sesConfig) {
-		// Override from address if provided
-		if fromEmail != "" {
-			sesConfig.FromAddress = fromEmail
-		}
-		if fromName != "" {
-			sesConfig.FromName = fromName
-		}
-		return SendEmailViaSES(ctx, sesConfig, to, subject, htmlBody)
-	}
-
-	// Fall back to SMTP
-	smtpConfig, err := s.getGlobalSMTPConfig(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get email config: %w", err)
-	}
-
-	if smtpConfig.Host == "" || smtpConfig.User == "" || smtpConfig.Password == "" {
-		return fmt.Errorf("email not configured - set AWS SES credentials or SMTP settings in platform settings")
-	}
-
-	// Use provided from or fall back to defaults
-	from := fromEmail
-	name := fromName
-	if from == "" {
-		from = smtpConfig.FromAddress
-	}
-	if name == "" {
-		name = smtpConfig.FromName
-	}
-
-	headers := fmt.Sprintf("From: %s <%s>\r\n", name, from)
-	headers += fmt.Sprintf("To: %s\r\n", to)
-	if smtpConfig.ReplyTo != "" {
-		headers += fmt.Sprintf("Reply-To: %s\r\n", smtpConfig.ReplyTo)
-	}
-	headers += fmt.Sprintf("Subject: %s\r\n", subject)
-	headers += "MIME-Version: 1.0\r\n"
-	headers += "Content-Type: text/html; charset=UTF-8\r\n"
-	headers += "\r\n"
-
-	message := []byte(headers + htmlBody)
-
-	auth := smtp.PlainAuth("", smtpConfig.User, smtpConfig.Password, smtpConfig.Host)
-	addr := fmt.Sprintf("%s:%d", smtpConfig.Host, smtpConfig.Port)
-
-	return smtp.SendMail(addr, auth, from, []string{to}, message)
+// SendEmailFrom sends an HTML email with a custom from address through the
+// organization's provider; an empty orgID uses the platform's
+func (s *Service) SendEmailFrom(ctx context.Context, orgID, fromEmail, fromName, to, subject, htmlBody string) error {
+	return s.deliverNow(ctx, Message{
+		FromName:  fromName,
+		FromEmail: fromEmail,
+		To:        to,
+		Subject:   subject,
+		HTMLBody:  htmlBody,
+		OrgID:     orgID,
+	}, "")
 }
 
-// SendCampaignEmail sends a campaign email with custom from/reply-to
-// Uses AWS SES (preferred), SMTP connection pooling, or standard SMTP as fallback
-func (s *Service) SendCampaignEmail(to, subject, htmlBody, fromName, fromEmail, replyTo string) error {
-	ctx := context.Background()
-
-	// Try AWS SES first (preferred for high-volume sending)
-	sesConfig, err := s.getSESConfig(ctx)
-	if err == nil && s.hasSESConfig(sesConfig) {
-		// Override from address if provided
-		if fromEmail != "" {
-			sesConfig.FromAddress = fromEmail
-		}
-		if fromName != "" {
-			sesConfig.FromName = fromName
-		}
-		if replyTo != "" {
-			sesConfig.ReplyTo = replyTo
-		}
-		return SendEmailViaSES(ctx, sesConfig, to, subject, htmlBody)
-	}
-
-	// Fall back to SMTP
-	smtpConfig, err := s.getGlobalSMTPConfig(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get email config: %w", err)
-	}
-
-	if smtpConfig.Host == "" || smtpConfig.User == "" || smtpConfig.Password == "" {
-		return fmt.Errorf("email not configured - set AWS SES credentials or SMTP settings in platform settings")
-	}
-
-	// Use provided or fall back to defaults
-	if fromEmail == "" {
-		fromEmail = smtpConfig.FromAddress
-	}
-	if fromName == "" {
-		fromName = smtpConfig.FromName
-	}
-	if replyTo == "" {
-		replyTo = smtpConfig.ReplyTo
-	}
-
-	headers := fmt.Sprintf("From: %s <%s>\r\n", fromName, fromEmail)
-	headers += fmt.Sprintf("To: %s\r\n", to)
-	if replyTo != "" {
-		headers += fmt.Sprintf("Reply-To: %s\r\n", replyTo)
-	}
-	headers += fmt.Sprintf("Subject: %s\r\n", subject)
-	headers += "MIME-Version: 1.0\r\n"
-	headers += "Content-Type: text/html; charset=UTF-8\r\n"
-	headers += "\r\n"
-
-	message := []byte(headers + htmlBody)
-
-	// Use pooled SMTP connection if available
-	if s.poolEnabled && s.pool != nil {
-		return s.pool.SendWithPool(fromEmail, []string{to}, message)
-	}
-
-	// Fallback to standard SMTP sending
-	auth := smtp.PlainAuth("", smtpConfig.User, smtpConfig.Password, smtpConfig.Host)
-	addr := fmt.Sprintf("%s:%d", smtpConfig.Host, smtpConfig.Port)
+// SendMessage sends a message with text body, headers or attachments.
+// Empty sender fields fall back to the platform settings. Returns a
+// *RateLimitError when the organization's rate limit has no token free.
+func (s *Service) SendMessage(ctx context.Context, msg Message) error {
+	return s.deliver(ctx, msg, "")
+}
 
-	return smtp.SendMail(addr, auth, fromEmail, []string{to}, message)
+// SendCampaignEmail sends a campaign email with custom from/reply-to and
+// List-Unsubscribe headers for the send's tracking token, through the
+// campaign organization's provider. Returns a *RateLimitError when the
+// organization's rate limit has no token free.
+func (s *Service) SendCampaignEmail(orgID, to, subject, htmlBody, textBody, fromName, fromEmail, replyTo, trackingToken string) error {
+	return s.deliver(context.Background(), Message{
+		FromName:  fromName,
+		FromEmail: fromEmail,
+		To:        to,
+		ReplyTo:   replyTo,
+		Subject:   subject,
+		HTMLBody:  htmlBody,
+		TextBody:  textBody,
+		OrgID:     orgID,
+	}, trackingToken)
 }
 
 // GetTrackingPixelURL returns the URL for a tracking pixel
@@ -529,114 +513,3 @@ func (s *Service) GetTrackingPixelURL(trackingToken string) string {
 	return fmt.Sprintf("%s/api/e/o/%s", s.baseURL, trackingToken)
 }
 
-// RewriteLinksForTracking replaces href URLs with tracked redirect URLs
-func (s *Service) RewriteLinksForTracking(htmlBody, trackingToken string) string {
-	// Simple link rewriting using string replacement
-	// This is a basic implementation - for production, consider using a proper HTML parser
-
-	result := htmlBody
-	searchStart := 0
-
-	for {
-		// Find next href="
-		hrefIdx := findNextHref(result, searchStart)
-		if hrefIdx == -1 {
-			break
-		}
-
-		// Find the URL start (after href=")
-		urlStart := hrefIdx + 6 // len(`href="`)
-		if urlStart >= len(result) {
-			break
-		}
-
-		// Find the closing quote
-		urlEnd := findClosingQuote(result, urlStart)
-		if urlEnd == -1 {
-			searchStart = urlStart
-			continue
-		}
-
-		originalURL := result[urlStart:urlEnd]
-
-		// Skip special URLs
-		if shouldSkipURL(originalURL) {
-			searchStart = urlEnd
-			continue
-		}
-
-		// Build tracked URL
-		trackedURL := fmt.Sprintf("%s/api/e/c/%s?url=%s",
-			s.baseURL,
-			trackingToken,
-			urlEncode(originalURL),
-		)
-
-		// Replace the URL
-		result = result[:urlStart] + trackedURL + result[urlEnd:]
-		searchStart = urlStart + len(trackedURL)
-	}
-
-	return result
-}
-
-func findNextHref(s string, start int) int {
-	for i := start; i < len(s)-5; i++ {
-		if s[i:i+6] == `href="` {
-			return i
-		}
-	}
-	return -1
-}
-
-func findClosingQuote(s string, start int) int {
-	for i := start; i < len(s); i++ {
-		if s[i] == '"' {
-			return i
-		}
-	}
-	return -1
-}
-
-func shouldSkipURL(url string) bool {
-	return len(url) > 7 && url[:7] == "mailto:" ||
-		len(url) > 4 && url[:4] == "tel:" ||
-		len(url) > 0 && url[0] == '#' ||
-		len(url) > 2 && url[:2] == "{{" ||
-		containsSubstring(url, "/api/e/c/")
-}
-
-func containsSubstring(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
-}
-
-func urlEncode(s string) string {
-	var result []byte
-	for i := 0; i < len(s); i++ {
-		c := s[i]
-		if isAlphaNum(c) || c == '-' || c == '_' || c == '.' || c == '~' {
-			result = append(result, c)
-		} else {
-			result = append(result, '%')
-			result = append(result, hexDigit(c>>4))
-			result = append(result, hexDigit(c&0xf))
-		}
-	}
-	return string(result)
-}
-
-func isAlphaNum(c byte) bool {
-	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
-}
-
-func hexDigit(n byte) byte {
-	if n < 10 {
-		return '0' + n
-	}
-	return 'A' + n - 10
-}
@@ -2,12 +2,12 @@ package email
 
 import (
 	"context"
+	"errors"
 	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
 	"fmt"
 	"net/url"
-	"regexp"
 	"strings"
 	"time"
 
@@ -383,8 +383,18 @@ func (s *SequenceService) ProcessPendingEmails(ctx context.Context, batchSize in
 			htmlBody = s.wrapWithSimpleTemplate(htmlBody, isTransactional, email.TrackingToken.String)
 		}
 
-		// Send the email
-		err = s.sender.sendEmail(email.Email, subject, htmlBody)
+		// Send the email; marketing mail carries List-Unsubscribe headers
+		listToken := ""
+		if !isTransactional {
+			listToken = email.TrackingToken.String
+		}
+		textBody := s.plainTextBody(email.PlainText, tplCtx, isTransactional)
+		err = s.sender.sendListEmail(email.OrgID.String, email.Email, subject, htmlBody, textBody, listToken)
+		var limited *RateLimitError
+		if errors.As(err, &limited) || errors.Is(err, ErrDailyQuotaReached) {
+			// Left pending for a later run, like the dispatcher defers it
+			continue
+		}
 		if err != nil {
 			logx.Errorf("Failed to send email %s to %s: %v", email.ID, email.Email, err)
 			_ = s.db.MarkEmailFailed(ctx, db.MarkEmailFailedParams{
@@ -429,24 +439,7 @@ func (s *SequenceService) ProcessPendingEmails(ctx context.Context, batchSize in
 					})
 					if err != nil || nextTemplate.ID == "" {
 						// No more templates - mark sequence complete
-						_ = s.db.CompleteContactSequence(ctx, db.CompleteContactSequenceParams{
-							ContactID:  email.ContactID,
-							SequenceID: template.SequenceID,
-						})
-						logx.Infof("Completed sequence for contact %s", email.ContactID.String)
-
-						// Check for sequence chaining - auto-enroll in next sequence if configured
-						seq, seqErr := s.db.GetSequenceByID(ctx, template.SequenceID.String)
-						if seqErr == nil && seq.OnCompletionSequenceID.Valid && seq.OnCompletionSequenceID.String != "" {
-							chainErr := s.StartSequenceByID(ctx, email.ContactID.String, seq.OnCompletionSequenceID.String)
-							if chainErr != nil {
-								logx.Errorf("Failed to start chained sequence %s for contact %s: %v",
-									seq.OnCompletionSequenceID.String, email.ContactID.String, chainErr)
-							} else {
-								logx.Infof("Chained contact %s from sequence %s to sequence %s",
-									email.ContactID.String, template.SequenceID.String, seq.OnCompletionSequenceID.String)
-							}
-						}
+						s.completeSequence(ctx, email.ContactID.String, template.SequenceID.String)
 					}
 				}
 			}
@@ -456,6 +449,36 @@ func (s *SequenceService) ProcessPendingEmails(ctx context.Context, batchSize in
 	return sent, nil
 }
 
+// completeSequence marks the contact's sequence complete, then starts the
+// sequence chained to it and the sequences whose entry rules fire on its
+// completion.
+func (s *SequenceService) completeSequence(ctx context.Context, contactID, sequenceID string) {
+	_ = s.db.CompleteContactSequence(ctx, db.CompleteContactSequenceParams{
+		ContactID:  sql.NullString{String: contactID, Valid: true},
+		SequenceID: sql.NullString{String: sequenceID, Valid: true},
+	})
+	logx.Infof("Completed sequence for contact %s", contactID)
+
+	// Check for sequence chaining - auto-enroll in next sequence if configured
+	seq, seqErr := s.db.GetSequenceByID(ctx, sequenceID)
+	if seqErr == nil && seq.OnCompletionSequenceID.Valid && seq.OnCompletionSequenceID.String != "" {
+		chainErr := s.StartSequenceByID(ctx, contactID, seq.OnCompletionSequenceID.String)
+		if chainErr != nil {
+			logx.Errorf("Failed to start chained sequence %s for contact %s: %v",
+				seq.OnCompletionSequenceID.String, contactID, chainErr)
+		} else {
+			logx.Infof("Chained contact %s from sequence %s to sequence %s",
+				contactID, sequenceID, seq.OnCompletionSequenceID.String)
+		}
+	}
+
+	// Entry rules triggered by completing this sequence
+	if err := s.enrollOnSequenceComplete(ctx, contactID, sequenceID); err != nil {
+		logx.Errorf("Failed to apply entry rules for contact %s completing sequence %s: %v",
+			contactID, sequenceID, err)
+	}
+}
+
 // TemplateContext holds variables for template processing
 type TemplateContext struct {
 	Name              string
@@ -494,6 +517,24 @@ func (s *SequenceService) processTemplateVariables(content string, ctx TemplateC
 	return content
 }
 
+// plainTextBody returns the template's text version with variables filled in,
+// or "" to have it generated from the final HTML. Marketing mail gets the
+// unsubscribe link appended when the template text leaves it out.
+func (s *SequenceService) plainTextBody(plainText sql.NullString, ctx TemplateContext, isTransactional bool) string {
+	if !plainText.Valid || strings.TrimSpace(plainText.String) == "" {
+		return ""
+	}
+
+	text := s.processTemplateVariables(plainText.String, ctx)
+	if !isTransactional && ctx.TrackingToken != "" {
+		unsubscribeURL := UnsubscribeURL(s.baseURL, ctx.TrackingToken)
+		if !strings.Contains(text, unsubscribeURL) {
+			text += "\n\nUnsubscribe: " + unsubscribeURL
+		}
+	}
+	return text
+}
+
 // wrapWithSimpleTemplate wraps email content with a simple template (just footer)
 func (s *SequenceService) wrapWithSimpleTemplate(content string, isTransactional bool, trackingToken string) string {
 	unsubscribeSection := ""
@@ -575,40 +616,10 @@ func (s *SequenceService) wrapWithBrandedTemplate(content string, isTransactiona
 </html>`, content, footerLink, unsubscribeSection)
 }
 
-// rewriteLinksForTracking replaces href URLs with tracked redirect URLs
+// rewriteLinksForTracking replaces http(s) links with signed click tracking URLs
 func (s *SequenceService) rewriteLinksForTracking(htmlBody, trackingToken string) string {
-	// Match href="..." patterns
-	linkRegex := regexp.MustCompile(`href="([^"]+)"`)
-
-	return linkRegex.ReplaceAllStringFunc(htmlBody, func(match string) string {
-		// Extract the URL from href="URL"
-		urlMatch := linkRegex.FindStringSubmatch(match)
-		if len(urlMatch) < 2 {
-			return match
-		}
-
-		originalURL := urlMatch[1]
-
-		// Skip mailto:, tel:, and anchor links
-		if strings.HasPrefix(originalURL, "mailto:") ||
-			strings.HasPrefix(originalURL, "tel:") ||
-			strings.HasPrefix(originalURL, "#") ||
-			strings.HasPrefix(originalURL, "{{") {
-			return match
-		}
-
-		// Skip if already a tracking URL
-		if strings.Contains(originalURL, "/api/e/c/") {
-			return match
-		}
-
-		// Build tracked URL (using /api/e/c/:token format)
-		trackedURL := fmt.Sprintf("%s/api/e/c/%s?url=%s",
-			s.baseURL,
-			trackingToken,
-			url.QueryEscape(originalURL),
-		)
-
-		return fmt.Sprintf(`href="%s"`, trackedURL)
-	})
+	if s.sender == nil {
+		return htmlBody
+	}
+	return rewriteLinks(htmlBody, s.baseURL, trackingToken, s.sender.links)
 }
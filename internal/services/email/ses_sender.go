@@ -2,7 +2,11 @@ package email
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -21,9 +25,169 @@ type SESConfig struct {
 	ReplyTo     string
 }
 
-// SendEmailViaSES sends an email using the AWS SES API directly
+// SendEmailViaSES sends an HTML email using the AWS SES API directly
 // This is the preferred method when AWS credentials are configured
 func SendEmailViaSES(ctx context.Context, sesConfig *SESConfig, to, subject, htmlBody string) error {
+	msg := Message{
+		FromName:  sesConfig.FromName,
+		FromEmail: sesConfig.FromAddress,
+		To:        to,
+		ReplyTo:   sesConfig.ReplyTo,
+		Subject:   subject,
+		HTMLBody:  htmlBody,
+	}
+	return SendRawEmailViaSES(ctx, sesConfig, to, msg.Build())
+}
+
+// SendRawEmailViaSES sends a message built with Message.Build
+func SendRawEmailViaSES(ctx context.Context, sesConfig *SESConfig, to string, message []byte) error {
+	_, err := sendRawSES(ctx, sesConfig, to, message)
+	return err
+}
+
+// sendRawSES sends a raw message and returns the SES message ID
+func sendRawSES(ctx context.Context, sesConfig *SESConfig, to string, message []byte) (string, error) {
+	client, err := newSESClient(ctx, sesConfig)
+	if err != nil {
+		return "", err
+	}
+	return sendRawWithClient(ctx, client, to, message)
+}
+
+// sendRawWithClient sends a raw message with an existing SES client
+func sendRawWithClient(ctx context.Context, client *ses.Client, to string, message []byte) (string, error) {
+	out, err := client.SendRawEmail(ctx, &ses.SendRawEmailInput{
+		Destinations: []string{to},
+		RawMessage: &types.RawMessage{
+			Data: message,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("SES SendRawEmail failed: %w", err)
+	}
+
+	return aws.ToString(out.MessageId), nil
+}
+
+// SESProvider sends through AWS SES. SES signs with Easy DKIM and reports
+// bounces and complaints through SNS.
+type SESProvider struct {
+	config *SESConfig
+	client *ses.Client
+}
+
+// Name implements Provider
+func (p *SESProvider) Name() string {
+	return ProviderSES
+}
+
+// Send implements Provider
+func (p *SESProvider) Send(ctx context.Context, msg *Message) (string, error) {
+	return sendRawWithClient(ctx, p.client, msg.To, msg.Build())
+}
+
+// Capabilities implements Provider
+func (p *SESProvider) Capabilities() Capabilities {
+	return Capabilities{SignsDKIM: true, Webhooks: true, Quota: true}
+}
+
+// Quota implements Provider
+func (p *SESProvider) Quota(ctx context.Context) (*Quota, error) {
+	quota, err := GetSESQuota(ctx, p.config.Region, p.config.AccessKey, p.config.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Quota{
+		Max24HourSend:   quota.Max24HourSend,
+		MaxSendRate:     quota.MaxSendRate,
+		SentLast24Hours: quota.SentLast24Hours,
+	}, nil
+}
+
+// ParseWebhook implements Provider for SNS notifications. Subscription
+// confirmations carry no events; the SES webhook handler confirms them.
+func (p *SESProvider) ParseWebhook(r *http.Request) ([]WebhookEvent, error) {
+	var envelope struct {
+		Type    string `json:"Type"`
+		Message string `json:"Message"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("invalid SNS message: %w", err)
+	}
+	if envelope.Type != "Notification" {
+		return nil, nil
+	}
+
+	var notification struct {
+		NotificationType string `json:"notificationType"`
+		Mail             struct {
+			MessageID string `json:"messageId"`
+		} `json:"mail"`
+		Bounce struct {
+			BounceType        string    `json:"bounceType"`
+			Timestamp         time.Time `json:"timestamp"`
+			BouncedRecipients []struct {
+				EmailAddress   string `json:"emailAddress"`
+				DiagnosticCode string `json:"diagnosticCode"`
+			} `json:"bouncedRecipients"`
+		} `json:"bounce"`
+		Complaint struct {
+			Timestamp            time.Time `json:"timestamp"`
+			ComplainedRecipients []struct {
+				EmailAddress string `json:"emailAddress"`
+			} `json:"complainedRecipients"`
+		} `json:"complaint"`
+		Delivery struct {
+			Timestamp  time.Time `json:"timestamp"`
+			Recipients []string  `json:"recipients"`
+		} `json:"delivery"`
+	}
+	if err := json.Unmarshal([]byte(envelope.Message), &notification); err != nil {
+		return nil, fmt.Errorf("invalid SES notification: %w", err)
+	}
+
+	var events []WebhookEvent
+	messageID := notification.Mail.MessageID
+	switch notification.NotificationType {
+	case "Bounce":
+		bounceType := "soft"
+		if notification.Bounce.BounceType == "Permanent" {
+			bounceType = "hard"
+		}
+		for _, r := range notification.Bounce.BouncedRecipients {
+			events = append(events, WebhookEvent{
+				Type:       EventBounced,
+				Email:      r.EmailAddress,
+				MessageID:  messageID,
+				BounceType: bounceType,
+				Reason:     r.DiagnosticCode,
+				Timestamp:  notification.Bounce.Timestamp,
+			})
+		}
+	case "Complaint":
+		for _, r := range notification.Complaint.ComplainedRecipients {
+			events = append(events, WebhookEvent{
+				Type:      EventComplained,
+				Email:     r.EmailAddress,
+				MessageID: messageID,
+				Timestamp: notification.Complaint.Timestamp,
+			})
+		}
+	case "Delivery":
+		for _, email := range notification.Delivery.Recipients {
+			events = append(events, WebhookEvent{
+				Type:      EventDelivered,
+				Email:     email,
+				MessageID: messageID,
+				Timestamp: notification.Delivery.Timestamp,
+			})
+		}
+	}
+	return events, nil
+}
+
+// newSESClient creates an SES client from static credentials or the default chain
+func newSESClient(ctx context.Context, sesConfig *SESConfig) (*ses.Client, error) {
 	if sesConfig.Region == "" {
 		sesConfig.Region = "us-east-1"
 	}
@@ -45,47 +209,49 @@ func SendEmailViaSES(ctx context.Context, sesConfig *SESConfig, to, subject, htm
 	}
 
 	if err != nil {
-		return fmt.Errorf("failed to load AWS config: %w", err)
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
-	client := ses.NewFromConfig(cfg)
+	return ses.NewFromConfig(cfg), nil
+}
 
-	// Build the from address
-	from := sesConfig.FromAddress
-	if sesConfig.FromName != "" {
-		from = fmt.Sprintf("%s <%s>", sesConfig.FromName, sesConfig.FromAddress)
-	}
+// sesClientKey identifies the credentials a cached SES client uses
+type sesClientKey struct {
+	region    string
+	accessKey string
+	secretKey string
+}
+
+// sesClientCache keeps one SES client per credential set so each message
+// does not load AWS config and build a client again. Rotated credentials
+// get a new entry.
+type sesClientCache struct {
+	mu      sync.Mutex
+	clients map[sesClientKey]*ses.Client
+}
 
-	// Build reply-to addresses
-	var replyToAddresses []string
-	if sesConfig.ReplyTo != "" {
-		replyToAddresses = []string{sesConfig.ReplyTo}
+// get returns the cached client for the credentials, creating it on first use
+func (c *sesClientCache) get(ctx context.Context, sesConfig *SESConfig) (*ses.Client, error) {
+	region := sesConfig.Region
+	if region == "" {
+		region = "us-east-1"
 	}
+	key := sesClientKey{region: region, accessKey: sesConfig.AccessKey, secretKey: sesConfig.SecretKey}
 
-	input := &ses.SendEmailInput{
-		Destination: &types.Destination{
-			ToAddresses: []string{to},
-		},
-		Message: &types.Message{
-			Body: &types.Body{
-				Html: &types.Content{
-					Charset: aws.String("UTF-8"),
-					Data:    aws.String(htmlBody),
-				},
-			},
-			Subject: &types.Content{
-				Charset: aws.String("UTF-8"),
-				Data:    aws.String(subject),
-			},
-		},
-		Source:           aws.String(from),
-		ReplyToAddresses: replyToAddresses,
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if client, ok := c.clients[key]; ok {
+		return client, nil
 	}
 
-	_, err = client.SendEmail(ctx, input)
+	client, err := newSESClient(ctx, sesConfig)
 	if err != nil {
-		return fmt.Errorf("SES SendEmail failed: %w", err)
+		return nil, err
 	}
-
-	return nil
+	if c.clients == nil {
+		c.clients = make(map[sesClientKey]*ses.Client)
+	}
+	c.clients[key] = client
+	return client, nil
 }
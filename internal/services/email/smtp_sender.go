@@ -0,0 +1,68 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/smtp"
+)
+
+// SMTPProvider sends through an SMTP relay, signing with the sending
+// domain's DKIM keys since the relay does not
+type SMTPProvider struct {
+	config *SMTPConfig
+	pool   *SMTPPool // optional pooled connections to the same relay
+	sign   func(ctx context.Context, fromEmail string, message []byte) []byte
+}
+
+// Name implements Provider
+func (p *SMTPProvider) Name() string {
+	return ProviderSMTP
+}
+
+// Send implements Provider
+func (p *SMTPProvider) Send(ctx context.Context, msg *Message) (string, error) {
+	message := msg.Build()
+	if p.sign != nil {
+		message = p.sign(ctx, msg.FromEmail, message)
+	}
+
+	// Use pooled SMTP connection if available
+	if p.pool != nil {
+		if err := p.pool.SendWithPool(msg.FromEmail, []string{msg.To}, message); err != nil {
+			return "", err
+		}
+		return msg.MessageID, nil
+	}
+
+	port := p.config.Port
+	if port == 0 {
+		port = 587
+	}
+
+	var auth smtp.Auth
+	if p.config.User != "" {
+		auth = smtp.PlainAuth("", p.config.User, p.config.Password, p.config.Host)
+	}
+
+	addr := fmt.Sprintf("%s:%d", p.config.Host, port)
+	if err := smtp.SendMail(addr, auth, msg.FromEmail, []string{msg.To}, message); err != nil {
+		return "", fmt.Errorf("failed to send email via SMTP: %w", err)
+	}
+	return msg.MessageID, nil
+}
+
+// Capabilities implements Provider
+func (p *SMTPProvider) Capabilities() Capabilities {
+	return Capabilities{}
+}
+
+// Quota implements Provider
+func (p *SMTPProvider) Quota(ctx context.Context) (*Quota, error) {
+	return nil, ErrNotSupported
+}
+
+// ParseWebhook implements Provider
+func (p *SMTPProvider) ParseWebhook(r *http.Request) ([]WebhookEvent, error) {
+	return nil, ErrNotSupported
+}
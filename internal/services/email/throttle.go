@@ -0,0 +1,245 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"golang.org/x/time/rate"
+)
+
+// ErrDailyQuotaReached is returned when an organization has used its daily
+// sending quota. Sending resumes at QuotaResetTime.
+var ErrDailyQuotaReached = errors.New("daily sending quota reached")
+
+// RateLimitError is returned by OrgThrottle.Acquire when the rate limit has
+// no token free. The send should be deferred until RetryAt.
+type RateLimitError struct {
+	RetryAt time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("send rate limit reached, retry at %s", e.RetryAt.Format(time.RFC3339))
+}
+
+// counterRetentionDays is how long daily send counters are kept
+const counterRetentionDays = 30
+
+// OrgThrottle enforces each organization's send rate and daily quota from
+// OrgEmailConfig. Every organization has its own token bucket, so a large
+// campaign only spends its own organization's rate. Sends are counted per
+// UTC day in org_daily_sends so the quota holds across restarts.
+type OrgThrottle struct {
+	store *db.Store
+
+	mu        sync.Mutex
+	limiters  map[string]*rate.Limiter // by org ID; "" is platform mail
+	exhausted map[string]time.Time     // orgs at quota, until the reset
+	pruned    string                   // day counters were last pruned
+
+	platformRate  float64
+	platformBurst int
+
+	now func() time.Time
+}
+
+// NewOrgThrottle creates a throttle. Mail without an organization is limited
+// to platformRate emails per second.
+func NewOrgThrottle(store *db.Store, platformRate float64, platformBurst int) *OrgThrottle {
+	return &OrgThrottle{
+		store:         store,
+		limiters:      make(map[string]*rate.Limiter),
+		exhausted:     make(map[string]time.Time),
+		platformRate:  platformRate,
+		platformBurst: platformBurst,
+		now:           time.Now,
+	}
+}
+
+// QuotaResetTime returns when daily quotas next reset (UTC midnight)
+func QuotaResetTime(now time.Time) time.Time {
+	return quotaDayStart(now).AddDate(0, 0, 1)
+}
+
+// quotaDayStart returns the start of the UTC day containing now
+func quotaDayStart(now time.Time) time.Time {
+	y, m, d := now.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// quotaDay formats the counter key for the UTC day containing now
+func quotaDay(now time.Time) string {
+	return now.UTC().Format("2006-01-02")
+}
+
+// Acquire takes a token from the organization's rate limit and counts one
+// send against its daily quota. It does not wait: without a free token it
+// returns a *RateLimitError, so queue workers defer the job and move on to
+// other organizations' mail. Call release when the send fails so the send is
+// not counted. Mail without an organization is only rate limited.
+func (t *OrgThrottle) Acquire(ctx context.Context, orgID string, config *OrgEmailConfig) (release func(), err error) {
+	noop := func() {}
+
+	if orgID == "" || config == nil {
+		return noop, reserve(t.limiter("", t.platformRate, t.platformBurst))
+	}
+
+	now := t.now()
+	if t.isExhausted(orgID, now) {
+		return noop, ErrDailyQuotaReached
+	}
+
+	if err := reserve(t.limiter(orgID, config.SESRateLimit, config.SESRateBurst)); err != nil {
+		return noop, err
+	}
+
+	quota := config.SESDailyQuota
+	if quota <= 0 {
+		quota = math.MaxInt64
+	}
+
+	day := quotaDay(now)
+	t.pruneCounters(ctx, day)
+
+	_, err = t.store.ReserveOrgDailySend(ctx, db.ReserveOrgDailySendParams{
+		OrgID:      orgID,
+		Day:        day,
+		DailyQuota: quota,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		t.markExhausted(orgID, QuotaResetTime(now))
+		logx.Infof("Organization %s reached its daily quota of %d emails", orgID, quota)
+		return noop, ErrDailyQuotaReached
+	}
+	if err != nil {
+		return noop, fmt.Errorf("failed to count send against quota: %w", err)
+	}
+
+	return func() {
+		if err := t.store.ReleaseOrgDailySend(context.Background(), db.ReleaseOrgDailySendParams{
+			OrgID: orgID,
+			Day:   day,
+		}); err != nil {
+			logx.Errorf("Failed to release quota for org %s: %v", orgID, err)
+		}
+	}, nil
+}
+
+// Wait is Acquire for callers that send right away instead of from a queue,
+// such as signup confirmations: it waits for a free token rather than
+// returning a *RateLimitError
+func (t *OrgThrottle) Wait(ctx context.Context, orgID string, config *OrgEmailConfig) (release func(), err error) {
+	for {
+		release, err := t.Acquire(ctx, orgID, config)
+		var limited *RateLimitError
+		if !errors.As(err, &limited) {
+			return release, err
+		}
+
+		timer := time.NewTimer(time.Until(limited.RetryAt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return func() {}, ctx.Err()
+		}
+	}
+}
+
+// reserve takes a token from l if one is free now. Otherwise the
+// reservation is cancelled so the token stays available to later sends.
+func reserve(l *rate.Limiter) error {
+	now := time.Now()
+	r := l.ReserveN(now, 1)
+	if !r.OK() {
+		return &RateLimitError{RetryAt: now.Add(time.Second)}
+	}
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return &RateLimitError{RetryAt: now.Add(delay)}
+	}
+	return nil
+}
+
+// SetPlatformRate updates the rate for mail without an organization
+func (t *OrgThrottle) SetPlatformRate(perSecond float64, burst int) {
+	t.mu.Lock()
+	t.platformRate = perSecond
+	t.platformBurst = burst
+	t.mu.Unlock()
+	t.limiter("", perSecond, burst)
+}
+
+// limiter returns the organization's token bucket, updating its rate when
+// the configuration changed
+func (t *OrgThrottle) limiter(orgID string, perSecond float64, burst int) *rate.Limiter {
+	if perSecond <= 0 {
+		perSecond = DefaultDispatcherConfig().RateLimit
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	l, ok := t.limiters[orgID]
+	if !ok {
+		l = rate.NewLimiter(rate.Limit(perSecond), burst)
+		t.limiters[orgID] = l
+		return l
+	}
+	if l.Limit() != rate.Limit(perSecond) {
+		l.SetLimit(rate.Limit(perSecond))
+	}
+	if l.Burst() != burst {
+		l.SetBurst(burst)
+	}
+	return l
+}
+
+// isExhausted reports whether the org is known to be at quota
+func (t *OrgThrottle) isExhausted(orgID string, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	until, ok := t.exhausted[orgID]
+	if !ok {
+		return false
+	}
+	if !now.Before(until) {
+		delete(t.exhausted, orgID)
+		return false
+	}
+	return true
+}
+
+// markExhausted skips the quota query for the org until the reset
+func (t *OrgThrottle) markExhausted(orgID string, until time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.exhausted[orgID] = until
+}
+
+// pruneCounters deletes old daily counters once per day
+func (t *OrgThrottle) pruneCounters(ctx context.Context, day string) {
+	t.mu.Lock()
+	if t.pruned == day {
+		t.mu.Unlock()
+		return
+	}
+	t.pruned = day
+	t.mu.Unlock()
+
+	cutoff := quotaDay(t.now().AddDate(0, 0, -counterRetentionDays))
+	if err := t.store.DeleteOrgDailySendsBefore(ctx, cutoff); err != nil {
+		logx.Errorf("Failed to prune daily send counters: %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/db/dbtest"
+)
+
+func TestQuotaResetTime(t *testing.T) {
+	est := time.FixedZone("EST", -5*3600)
+	tests := []struct {
+		now  time.Time
+		want time.Time
+	}{
+		{time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 3, 9, 23, 59, 59, 0, time.UTC), time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// 21:00 EST is already the next UTC day
+		{time.Date(2024, 3, 9, 21, 0, 0, 0, est), time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := QuotaResetTime(tt.now); !got.Equal(tt.want) {
+			t.Errorf("QuotaResetTime(%v) = %v, want %v", tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestOrgThrottleExhaustedUntilReset(t *testing.T) {
+	now := time.Date(2024, 3, 9, 15, 0, 0, 0, time.UTC)
+	throttle := NewOrgThrottle(nil, 100, 1)
+	throttle.now = func() time.Time { return now }
+	throttle.markExhausted("org-a", QuotaResetTime(now))
+
+	config := &OrgEmailConfig{SESRateLimit: 100, SESRateBurst: 1, SESDailyQuota: 10}
+	if _, err := throttle.Acquire(context.Background(), "org-a", config); !errors.Is(err, ErrDailyQuotaReached) {
+		t.Fatalf("Acquire() error = %v, want ErrDailyQuotaReached", err)
+	}
+
+	// Other organizations and platform mail are unaffected
+	if throttle.isExhausted("org-b", now) {
+		t.Error("org-b marked exhausted")
+	}
+	if _, err := throttle.Acquire(context.Background(), "", nil); err != nil {
+		t.Errorf("Acquire() platform error = %v", err)
+	}
+
+	if throttle.isExhausted("org-a", QuotaResetTime(now)) {
+		t.Error("org-a still exhausted after the quota reset")
+	}
+}
+
+func TestOrgThrottleSeparateBuckets(t *testing.T) {
+	throttle := NewOrgThrottle(nil, 1, 1)
+
+	a := throttle.limiter("org-a", 1, 1)
+	b := throttle.limiter("org-b", 1, 1)
+	if a == b {
+		t.Fatal("organizations share a token bucket")
+	}
+
+	// Spending org-a's burst leaves org-b's intact
+	a.Allow()
+	if !b.Allow() {
+		t.Error("org-b limited by org-a's sends")
+	}
+
+	// Updated org settings apply to the existing bucket
+	if got := throttle.limiter("org-a", 20, 40); got != a || got.Burst() != 40 {
+		t.Errorf("limiter not updated in place: burst = %d", got.Burst())
+	}
+}
+
+func TestOrgThrottleAcquireDoesNotWait(t *testing.T) {
+	store := dbtest.New(t)
+	dbtest.Exec(t, store, `INSERT INTO organizations (id, name, slug, api_key) VALUES ('org-a', 'A', 'a', 'key-a'), ('org-b', 'B', 'b', 'key-b')`)
+	throttle := NewOrgThrottle(store, 100, 1)
+	config := &OrgEmailConfig{SESRateLimit: 1, SESRateBurst: 1, SESDailyQuota: 10}
+	ctx := context.Background()
+
+	if _, err := throttle.Acquire(ctx, "org-a", config); err != nil {
+		t.Fatalf("first Acquire() error = %v", err)
+	}
+
+	// org-a's bucket is empty: Acquire returns instead of blocking the worker
+	start := time.Now()
+	_, err := throttle.Acquire(ctx, "org-a", config)
+	var limited *RateLimitError
+	if !errors.As(err, &limited) {
+		t.Fatalf("Acquire() error = %v, want a RateLimitError", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Acquire() waited %v", elapsed)
+	}
+	if wait := time.Until(limited.RetryAt); wait <= 0 || wait > time.Second {
+		t.Errorf("RetryAt is %v away, want within the next second", wait)
+	}
+
+	// The limited attempt is not counted against the quota
+	sent, err := store.GetOrgDailySends(ctx, db.GetOrgDailySendsParams{OrgID: "org-a", Day: quotaDay(time.Now())})
+	if err != nil || sent != 1 {
+		t.Errorf("org-a daily sends = %d (%v), want 1", sent, err)
+	}
+
+	// Other organizations keep sending
+	if _, err := throttle.Acquire(ctx, "org-b", config); err != nil {
+		t.Errorf("org-b Acquire() error = %v", err)
+	}
+}
+
+func TestOrgThrottleWait(t *testing.T) {
+	store := dbtest.New(t)
+	dbtest.Exec(t, store, `INSERT INTO organizations (id, name, slug, api_key) VALUES ('org-a', 'A', 'a', 'key-a')`)
+	throttle := NewOrgThrottle(store, 100, 1)
+	config := &OrgEmailConfig{SESRateLimit: 20, SESRateBurst: 1, SESDailyQuota: 10}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := throttle.Wait(ctx, "org-a", config); err != nil {
+			t.Fatalf("Wait() #%d error = %v", i+1, err)
+		}
+	}
+
+	// At one email per 10s the next token is far off; Wait gives up with ctx
+	config.SESRateLimit = 0.1
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := throttle.Wait(cancelled, "org-a", config); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() with cancelled context error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,98 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+)
+
+// QueueTransactional stores a transactional message for its send in the
+// durable send queue. The dispatcher sends queued mail ahead of marketing
+// traffic and retries failures. Attachment content is kept until the send
+// has been processed. A non-zero sendAt holds the message until that time.
+func (s *Service) QueueTransactional(ctx context.Context, sendID string, msg Message, sendAt time.Time) error {
+	if err := s.EnqueueTransactional(ctx, s.db.Queries, sendID, msg, sendAt); err != nil {
+		return err
+	}
+	s.WakeTransactional()
+	return nil
+}
+
+// EnqueueTransactional writes a message's attachments and queue row with q,
+// so a caller can queue a send in the transaction that creates it. Call
+// WakeTransactional once that transaction commits.
+func (s *Service) EnqueueTransactional(ctx context.Context, q *db.Queries, sendID string, msg Message, sendAt time.Time) error {
+	if len(msg.Attachments) > 0 {
+		if err := s.StoreAttachments(ctx, q, msg.OrgID, sendID, msg.Attachments, sendAt); err != nil {
+			return err
+		}
+	}
+
+	var scheduled sql.NullString
+	if !sendAt.IsZero() {
+		scheduled = sql.NullString{String: sendAt.UTC().Format(time.DateTime), Valid: true}
+	}
+
+	err := q.EnqueueTransactionalSend(ctx, db.EnqueueTransactionalSendParams{
+		SendID:    sendID,
+		OrgID:     msg.OrgID,
+		ToEmail:   msg.To,
+		FromName:  sql.NullString{String: msg.FromName, Valid: msg.FromName != ""},
+		FromEmail: sql.NullString{String: msg.FromEmail, Valid: msg.FromEmail != ""},
+		ReplyTo:   sql.NullString{String: msg.ReplyTo, Valid: msg.ReplyTo != ""},
+		Subject:   msg.Subject,
+		HtmlBody:  msg.HTMLBody,
+		TextBody:  sql.NullString{String: msg.TextBody, Valid: msg.TextBody != ""},
+		SendAt:    scheduled,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to queue send %s: %w", sendID, err)
+	}
+	return nil
+}
+
+// WakeTransactional wakes the dispatcher to claim newly queued mail; a
+// pending wake-up already covers it
+func (s *Service) WakeTransactional() {
+	select {
+	case s.queued <- struct{}{}:
+	default:
+	}
+}
+
+// TransactionalQueued returns a channel that receives when transactional
+// mail is queued
+func (s *Service) TransactionalQueued() <-chan struct{} {
+	return s.queued
+}
+
+// queuedMessage rebuilds the message of a queued send
+func (s *Service) queuedMessage(ctx context.Context, item db.TransactionalQueue) (Message, error) {
+	msg := Message{
+		FromName:  item.FromName.String,
+		FromEmail: item.FromEmail.String,
+		To:        item.ToEmail,
+		ReplyTo:   item.ReplyTo.String,
+		Subject:   item.Subject,
+		HTMLBody:  item.HtmlBody,
+		TextBody:  item.TextBody.String,
+		OrgID:     item.OrgID,
+	}
+
+	attachments, err := s.db.ListTransactionalAttachmentContent(ctx, item.SendID)
+	if err != nil {
+		return msg, fmt.Errorf("failed to load attachments: %w", err)
+	}
+	for _, a := range attachments {
+		msg.Attachments = append(msg.Attachments, Attachment{
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Data:        a.Content,
+			ContentID:   a.ContentID.String,
+		})
+	}
+	return msg, nil
+}
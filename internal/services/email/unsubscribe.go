@@ -0,0 +1,32 @@
+package email
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// UnsubscribeURL returns the unsubscribe link for a tracking token. It accepts
+// GET from the link in the footer and RFC 8058 one-click POSTs.
+func UnsubscribeURL(baseURL, trackingToken string) string {
+	return fmt.Sprintf("%s/api/e/u/%s", baseURL, trackingToken)
+}
+
+// listUnsubscribeHeaders returns the List-Unsubscribe (RFC 2369) and
+// List-Unsubscribe-Post (RFC 8058) headers for marketing mail.
+// The mailto target goes to mailbox with the token in the subject; the SMTP
+// ingress matches the token and unsubscribes the recipient.
+func listUnsubscribeHeaders(baseURL, trackingToken, mailbox string) []Header {
+	if baseURL == "" || trackingToken == "" {
+		return nil
+	}
+
+	value := "<" + UnsubscribeURL(baseURL, trackingToken) + ">"
+	if mailbox != "" {
+		value += ", <mailto:" + mailbox + "?subject=" + url.PathEscape("unsubscribe "+trackingToken) + ">"
+	}
+
+	return []Header{
+		{Name: "List-Unsubscribe", Value: value},
+		{Name: "List-Unsubscribe-Post", Value: "List-Unsubscribe=One-Click"},
+	}
+}
@@ -0,0 +1,28 @@
+package email
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestListUnsubscribeHeaders(t *testing.T) {
+	got := listUnsubscribeHeaders("https://mail.example.com", "tok123", "news@example.com")
+	want := []Header{
+		{Name: "List-Unsubscribe", Value: "<https://mail.example.com/api/e/u/tok123>, <mailto:news@example.com?subject=unsubscribe%20tok123>"},
+		{Name: "List-Unsubscribe-Post", Value: "List-Unsubscribe=One-Click"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("listUnsubscribeHeaders() = %q, want %q", got, want)
+	}
+
+	// Without a mailbox only the https target is offered
+	got = listUnsubscribeHeaders("https://mail.example.com", "tok123", "")
+	if len(got) != 2 || got[0].Value != "<https://mail.example.com/api/e/u/tok123>" {
+		t.Errorf("listUnsubscribeHeaders() without mailbox = %q", got)
+	}
+
+	// Transactional mail has no token and gets no headers
+	if got := listUnsubscribeHeaders("https://mail.example.com", "", "news@example.com"); got != nil {
+		t.Errorf("listUnsubscribeHeaders() without token = %q, want none", got)
+	}
+}
@@ -0,0 +1,130 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/services/emailval"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// validationResolver resolves MX records for policy checks. Nil uses the
+// system resolver.
+var validationResolver emailval.Resolver
+
+// GetOrgValidationPolicy retrieves the signup validation policy for an organization
+// Returns nil if the org has no policy configured
+func GetOrgValidationPolicy(ctx context.Context, store *db.Store, orgID string) (*emailval.Policy, error) {
+	org, err := store.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	if !org.Settings.Valid || org.Settings.String == "" {
+		return nil, nil
+	}
+
+	var settings OrgSettings
+	if err := json.Unmarshal([]byte(org.Settings.String), &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse org settings: %w", err)
+	}
+
+	return settings.Validation, nil
+}
+
+// SaveOrgValidationPolicy saves the signup validation policy for an organization
+func SaveOrgValidationPolicy(ctx context.Context, store *db.Store, orgID string, policy *emailval.Policy) error {
+	org, err := store.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	// Parse existing settings or create new
+	var settings OrgSettings
+	if org.Settings.Valid && org.Settings.String != "" {
+		if err := json.Unmarshal([]byte(org.Settings.String), &settings); err != nil {
+			settings = OrgSettings{}
+		}
+	}
+
+	settings.Validation = policy
+
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to serialize settings: %w", err)
+	}
+
+	err = store.UpdateOrgSettings(ctx, db.UpdateOrgSettingsParams{
+		ID:       orgID,
+		Settings: sql.NullString{String: string(settingsJSON), Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save org settings: %w", err)
+	}
+
+	return nil
+}
+
+// ResolveValidationPolicy returns the policy that applies to a signup.
+// A list policy (email_lists.validation_policy) overrides the organization policy.
+func ResolveValidationPolicy(ctx context.Context, store *db.Store, orgID string, listPolicy sql.NullString) (*emailval.Policy, error) {
+	if listPolicy.Valid {
+		policy, err := emailval.ParsePolicy(listPolicy.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse list validation policy: %w", err)
+		}
+		if policy != nil {
+			return policy, nil
+		}
+	}
+	return GetOrgValidationPolicy(ctx, store, orgID)
+}
+
+// ValidateSignup checks an address against the policy for the org and list.
+// Returns a nil Result when no policy is enabled.
+func ValidateSignup(ctx context.Context, store *db.Store, orgID string, listPolicy sql.NullString, address string) (*emailval.Result, error) {
+	policy, err := ResolveValidationPolicy(ctx, store, orgID, listPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return CheckAddress(ctx, policy, address)
+}
+
+// CheckAddress checks an address against a policy. Returns a nil Result when
+// no policy is enabled or when the checks could not complete, such as on a
+// DNS timeout. A failed lookup fails open: it neither rejects the address nor
+// replaces a verdict recorded earlier.
+func CheckAddress(ctx context.Context, policy *emailval.Policy, address string) (*emailval.Result, error) {
+	if policy == nil || !policy.Enabled {
+		return nil, nil
+	}
+
+	opts := policy.Options()
+	opts.Resolver = validationResolver
+	res, err := emailval.Validate(ctx, address, opts)
+	if err != nil {
+		return nil, err
+	}
+	if res.LookupFailed() {
+		logx.Infof("Validation of %s could not complete, accepting it unverified: %s", address, res.Reason())
+		return nil, nil
+	}
+	return res, nil
+}
+
+// RecordValidation stores a validation result on the contact
+func RecordValidation(ctx context.Context, store *db.Store, contactID string, res *emailval.Result) error {
+	if res == nil {
+		return nil
+	}
+	reason := res.Reason()
+	return store.UpdateContactValidation(ctx, db.UpdateContactValidationParams{
+		ValidationStatus: sql.NullString{String: res.Status(), Valid: true},
+		ValidationReason: sql.NullString{String: reason, Valid: reason != ""},
+		ID:               contactID,
+	})
+}
@@ -0,0 +1,61 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"testing"
+
+	"github.com/outlet-sh/outlet/internal/db/dbtest"
+	"github.com/outlet-sh/outlet/internal/services/emailval"
+)
+
+// mxResolver answers every MX lookup with records or an error
+type mxResolver struct {
+	records []*net.MX
+	err     error
+}
+
+func (r mxResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	return r.records, r.err
+}
+
+func TestValidateSignup_LookupFailureFailsOpen(t *testing.T) {
+	ctx := context.Background()
+	store := dbtest.New(t)
+	dbtest.Exec(t, store, `INSERT INTO organizations (id, name, slug, api_key, settings) VALUES ('org', 'Org', 'org', 'key', '{"validation":{"enabled":true}}')`)
+	dbtest.Exec(t, store, `INSERT INTO contacts (id, org_id, name, email, status, validation_status) VALUES ('ann', 'org', 'Ann', 'ann@example.com', 'active', 'valid')`)
+
+	defer func(r emailval.Resolver) { validationResolver = r }(validationResolver)
+	validationResolver = mxResolver{err: &net.DNSError{Err: "i/o timeout", Name: "example.com", IsTimeout: true}}
+
+	res, err := ValidateSignup(ctx, store, "org", sql.NullString{}, "ann@example.com")
+	if err != nil || res != nil {
+		t.Fatalf("ValidateSignup() with a failing resolver = %+v, %v; want no result", res, err)
+	}
+
+	// Signup paths record nothing without a result, keeping the stored verdict
+	if err := RecordValidation(ctx, store, "ann", res); err != nil {
+		t.Fatal(err)
+	}
+	contact, err := store.GetContact(ctx, "ann")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contact.ValidationStatus.String != "valid" {
+		t.Errorf("validation_status = %q after a failed lookup, want the stored verdict", contact.ValidationStatus.String)
+	}
+
+	// A domain that has no MX records is still rejected
+	validationResolver = mxResolver{err: &net.DNSError{Err: "no such host", Name: "example.com", IsNotFound: true}}
+	res, err = ValidateSignup(ctx, store, "org", sql.NullString{}, "ann@example.com")
+	if err != nil || res == nil || res.Valid() {
+		t.Errorf("ValidateSignup() without MX = %+v, %v; want an invalid result", res, err)
+	}
+
+	validationResolver = mxResolver{records: []*net.MX{{Host: "mx.example.com", Pref: 10}}}
+	res, err = ValidateSignup(ctx, store, "org", sql.NullString{}, "ann@example.com")
+	if err != nil || res == nil || !res.Valid() {
+		t.Errorf("ValidateSignup() with MX = %+v, %v; want a valid result", res, err)
+	}
+}
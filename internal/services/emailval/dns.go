@@ -5,12 +5,20 @@ import (
 	"net"
 )
 
+// Resolver looks up MX records. *net.Resolver implements it.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
 // checkMX looks up MX records for a domain.
 // Returns true if at least one MX record exists.
 func checkMX(ctx context.Context, domain string) (bool, error) {
 	// Create a resolver that respects context cancellation.
-	resolver := &net.Resolver{}
+	return resolveMX(ctx, &net.Resolver{}, domain)
+}
 
+// resolveMX is checkMX with the given resolver
+func resolveMX(ctx context.Context, resolver Resolver, domain string) (bool, error) {
 	mxRecords, err := resolver.LookupMX(ctx, domain)
 	if err != nil {
 		// Check if it's a "no such host" error vs network error.
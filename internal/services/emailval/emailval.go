@@ -39,6 +39,17 @@ type Options struct {
 
 	// SMTPDialer is an optional custom SMTP dialer (for testing or proxying).
 	SMTPDialer SMTPDialer
+
+	// MXCache is an optional shared cache for MX lookups (for bulk validation).
+	MXCache *MXCache
+
+	// Resolver is an optional resolver for MX lookups made without MXCache.
+	// Defaults to the system resolver.
+	Resolver Resolver
+
+	// CheckCatchAll probes a random mailbox on the domain after a successful
+	// SMTP check to detect servers that accept every address. Requires CheckSMTP.
+	CheckCatchAll bool
 }
 
 // Result is a structured report of validation.
@@ -54,6 +65,10 @@ type Result struct {
 
 	SMTPChecked     bool
 	SMTPDeliverable *bool // nil = unknown, true/false if SMTP check done
+	CatchAll        bool  // domain accepts mail for any mailbox
+
+	// Unknown is set when a network check could not complete (DNS or SMTP error).
+	Unknown bool
 
 	// FailedAt is the first failing level, if any.
 	FailedAt *Level
@@ -110,11 +125,23 @@ func Validate(ctx context.Context, email string, opts *Options) (*Result, error)
 	res.SyntaxOK = true
 
 	// 2) Domain MX check
-	hasMX, err := checkMX(ctx, domain)
+	var hasMX bool
+	if opts.MXCache != nil {
+		hasMX, err = opts.MXCache.HasMX(ctx, domain)
+	} else if opts.Resolver != nil {
+		hasMX, err = resolveMX(ctx, opts.Resolver, domain)
+	} else {
+		hasMX, err = checkMX(ctx, domain)
+	}
 	if err != nil || !hasMX {
 		lvl := LevelDomain
 		res.FailedAt = &lvl
-		res.addMessage("no MX records for domain %q", domain)
+		if err != nil {
+			res.Unknown = true
+			res.addMessage("MX lookup failed for domain %q: %v", domain, err)
+		} else {
+			res.addMessage("no MX records for domain %q", domain)
+		}
 		return res, nil
 	}
 	res.DomainOK = true
@@ -151,6 +178,7 @@ func Validate(ctx context.Context, email string, opts *Options) (*Result, error)
 		if err != nil {
 			// Treat as unknown, do not hard-fail on transient SMTP errors.
 			res.SMTPDeliverable = nil
+			res.Unknown = true
 			res.addMessage("SMTP check error: %v", err)
 		} else {
 			res.SMTPDeliverable = &deliverable
@@ -162,6 +190,14 @@ func Validate(ctx context.Context, email string, opts *Options) (*Result, error)
 				res.addMessage("SMTP server reports mailbox undeliverable")
 			}
 		}
+
+		// 6) Optional catch-all detection
+		if opts.CheckCatchAll && deliverable && err == nil {
+			if catchAll, err := checkCatchAll(ctx, domain, opts); err == nil && catchAll {
+				res.CatchAll = true
+				res.addMessage("domain accepts mail for any address (catch-all)")
+			}
+		}
 	}
 
 	return res, nil
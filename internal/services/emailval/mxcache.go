@@ -0,0 +1,76 @@
+package emailval
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MXCache memoizes MX lookups per domain.
+// It is safe for concurrent use, so one cache can be shared by every worker
+// validating a large list. Concurrent lookups of the same domain share a
+// single DNS query. Lookup errors are not cached.
+type MXCache struct {
+	ttl    time.Duration
+	lookup func(ctx context.Context, domain string) (bool, error)
+
+	mu      sync.Mutex
+	entries map[string]*mxEntry
+}
+
+type mxEntry struct {
+	done    chan struct{}
+	hasMX   bool
+	err     error
+	expires time.Time
+}
+
+// NewMXCache creates a cache whose entries expire after ttl.
+// A zero ttl keeps entries for the lifetime of the cache.
+func NewMXCache(ttl time.Duration) *MXCache {
+	return &MXCache{
+		ttl:     ttl,
+		lookup:  checkMX,
+		entries: make(map[string]*mxEntry),
+	}
+}
+
+// HasMX reports whether the domain has at least one MX record.
+func (c *MXCache) HasMX(ctx context.Context, domain string) (bool, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[domain]
+	if ok {
+		select {
+		case <-entry.done:
+			if entry.err != nil || (c.ttl > 0 && time.Now().After(entry.expires)) {
+				ok = false
+			}
+		default:
+		}
+	}
+	if !ok {
+		entry = &mxEntry{done: make(chan struct{})}
+		c.entries[domain] = entry
+		c.mu.Unlock()
+
+		entry.hasMX, entry.err = c.lookup(ctx, domain)
+		entry.expires = time.Now().Add(c.ttl)
+		close(entry.done)
+		return entry.hasMX, entry.err
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-entry.done:
+		return entry.hasMX, entry.err
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// Len returns the number of cached domains.
+func (c *MXCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
@@ -0,0 +1,83 @@
+package emailval
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMXCache_CachesPerDomain(t *testing.T) {
+	var calls atomic.Int32
+	cache := NewMXCache(time.Hour)
+	cache.lookup = func(ctx context.Context, domain string) (bool, error) {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return domain == "example.com", nil
+	}
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, err := cache.HasMX(ctx, "example.com"); !ok || err != nil {
+				t.Errorf("HasMX(example.com) = %v, %v", ok, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if ok, _ := cache.HasMX(ctx, "nomx.test"); ok {
+		t.Error("HasMX(nomx.test) = true, want false")
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("lookups = %d, want 2", got)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", cache.Len())
+	}
+}
+
+func TestMXCache_RetriesErrors(t *testing.T) {
+	var calls atomic.Int32
+	cache := NewMXCache(0)
+	cache.lookup = func(ctx context.Context, domain string) (bool, error) {
+		if calls.Add(1) == 1 {
+			return false, errors.New("timeout")
+		}
+		return true, nil
+	}
+
+	ctx := context.Background()
+	if _, err := cache.HasMX(ctx, "example.com"); err == nil {
+		t.Fatal("expected error from first lookup")
+	}
+	if ok, err := cache.HasMX(ctx, "example.com"); !ok || err != nil {
+		t.Errorf("HasMX() after error = %v, %v; want true, nil", ok, err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("lookups = %d, want 2", got)
+	}
+}
+
+func TestValidate_UsesMXCache(t *testing.T) {
+	cache := NewMXCache(time.Hour)
+	cache.lookup = func(ctx context.Context, domain string) (bool, error) {
+		return false, errors.New("dns unavailable")
+	}
+
+	res, err := Validate(context.Background(), "user@example.com", &Options{MXCache: cache})
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if !res.Unknown {
+		t.Error("Unknown = false, want true for DNS error")
+	}
+	if res.Verdict() != VerdictUnknown {
+		t.Errorf("Verdict() = %q, want %q", res.Verdict(), VerdictUnknown)
+	}
+}
@@ -0,0 +1,84 @@
+package emailval
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Validation statuses recorded on contacts.
+const (
+	StatusValid   = "valid"
+	StatusInvalid = "invalid"
+)
+
+// Policy is a stored validation policy for an organization or list.
+// The zero value disables validation entirely.
+type Policy struct {
+	// Enabled turns validation on. Syntax and MX checks always apply when enabled.
+	Enabled bool `json:"enabled"`
+
+	// BlockDisposable rejects addresses on disposable/temporary domains.
+	BlockDisposable bool `json:"block_disposable,omitempty"`
+
+	// RejectRole rejects role-based addresses like info@ or support@.
+	RejectRole bool `json:"reject_role,omitempty"`
+
+	// CheckSMTP probes the mailbox over SMTP. See README before enabling.
+	CheckSMTP bool `json:"check_smtp,omitempty"`
+
+	// TimeoutSeconds bounds network work. Defaults to 3 seconds.
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+}
+
+// ParsePolicy decodes a JSON policy. An empty string yields a nil policy.
+func ParsePolicy(raw string) (*Policy, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	var p Policy
+	if err := json.Unmarshal([]byte(raw), &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Options converts the policy to validation options.
+func (p *Policy) Options() *Options {
+	return &Options{
+		Timeout:         time.Duration(p.TimeoutSeconds) * time.Second,
+		CheckSMTP:       p.CheckSMTP,
+		CheckDisposable: p.BlockDisposable,
+		AllowRole:       !p.RejectRole,
+	}
+}
+
+// Check validates email against the policy.
+// It returns a nil Result when the policy is nil or disabled.
+func (p *Policy) Check(ctx context.Context, email string) (*Result, error) {
+	if p == nil || !p.Enabled {
+		return nil, nil
+	}
+	return Validate(ctx, email, p.Options())
+}
+
+// Status returns StatusValid or StatusInvalid.
+func (r *Result) Status() string {
+	if r.Valid() {
+		return StatusValid
+	}
+	return StatusInvalid
+}
+
+// LookupFailed reports whether the result was rejected only because its
+// network checks could not complete, such as a DNS timeout. Such an address
+// is neither valid nor invalid; its verdict is VerdictUnknown.
+func (r *Result) LookupFailed() bool {
+	return r != nil && !r.Valid() && r.Verdict() == VerdictUnknown
+}
+
+// Reason joins the result messages into a single line.
+func (r *Result) Reason() string {
+	return strings.Join(r.Messages, "; ")
+}
@@ -0,0 +1,145 @@
+package emailval
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestParsePolicy(t *testing.T) {
+	p, err := ParsePolicy("")
+	if err != nil || p != nil {
+		t.Fatalf("ParsePolicy(\"\") = %v, %v; want nil, nil", p, err)
+	}
+
+	p, err = ParsePolicy(`{"enabled":true,"block_disposable":true,"reject_role":true,"timeout_seconds":5}`)
+	if err != nil {
+		t.Fatalf("ParsePolicy() error = %v", err)
+	}
+	if !p.Enabled || !p.BlockDisposable || !p.RejectRole || p.CheckSMTP {
+		t.Errorf("ParsePolicy() = %+v", p)
+	}
+
+	if _, err := ParsePolicy("{not json"); err == nil {
+		t.Error("ParsePolicy() expected error for invalid JSON")
+	}
+}
+
+func TestPolicy_Options(t *testing.T) {
+	p := &Policy{Enabled: true, BlockDisposable: true, RejectRole: true, TimeoutSeconds: 5}
+	opts := p.Options()
+
+	if !opts.CheckDisposable {
+		t.Error("CheckDisposable = false, want true")
+	}
+	if opts.AllowRole {
+		t.Error("AllowRole = true, want false")
+	}
+	if opts.CheckSMTP {
+		t.Error("CheckSMTP = true, want false")
+	}
+	if opts.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %v, want 5s", opts.Timeout)
+	}
+
+	if !(&Policy{}).Options().AllowRole {
+		t.Error("zero policy should allow role addresses")
+	}
+}
+
+func TestPolicy_CheckDisabled(t *testing.T) {
+	ctx := context.Background()
+
+	var nilPolicy *Policy
+	if res, err := nilPolicy.Check(ctx, "bad"); res != nil || err != nil {
+		t.Errorf("nil policy Check() = %v, %v; want nil, nil", res, err)
+	}
+	if res, err := (&Policy{}).Check(ctx, "bad"); res != nil || err != nil {
+		t.Errorf("disabled policy Check() = %v, %v; want nil, nil", res, err)
+	}
+}
+
+func TestPolicy_CheckSyntaxFailure(t *testing.T) {
+	res, err := (&Policy{Enabled: true}).Check(context.Background(), "not-an-email")
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if res == nil {
+		t.Fatal("Check() returned nil result for enabled policy")
+	}
+	if res.Status() != StatusInvalid {
+		t.Errorf("Status() = %q, want %q", res.Status(), StatusInvalid)
+	}
+	if res.Reason() == "" {
+		t.Error("Reason() is empty for failed result")
+	}
+}
+
+func TestResult_Reason(t *testing.T) {
+	r := &Result{Messages: []string{"disposable or temporary domain", "role-based address: info"}}
+	if got, want := r.Reason(), "disposable or temporary domain; role-based address: info"; got != want {
+		t.Errorf("Reason() = %q, want %q", got, want)
+	}
+	if r.Status() != StatusValid {
+		t.Errorf("Status() = %q, want %q", r.Status(), StatusValid)
+	}
+}
+
+// resolverFunc adapts a function to Resolver
+type resolverFunc func(ctx context.Context, name string) ([]*net.MX, error)
+
+func (f resolverFunc) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	return f(ctx, name)
+}
+
+func TestValidate_ResolverFailure(t *testing.T) {
+	failing := resolverFunc(func(ctx context.Context, name string) ([]*net.MX, error) {
+		return nil, &net.DNSError{Err: "i/o timeout", Name: name, IsTimeout: true}
+	})
+	res, err := Validate(context.Background(), "ann@example.com", &Options{Resolver: failing})
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if !res.LookupFailed() || res.Verdict() != VerdictUnknown {
+		t.Errorf("Validate() with a failing resolver = %+v, want a failed lookup", res)
+	}
+
+	// A domain without MX records is invalid, not unknown
+	noMX := resolverFunc(func(ctx context.Context, name string) ([]*net.MX, error) {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	})
+	res, _ = Validate(context.Background(), "ann@example.com", &Options{Resolver: noMX})
+	if res.LookupFailed() || res.Verdict() != VerdictInvalid {
+		t.Errorf("Validate() without MX = %+v, want invalid", res)
+	}
+
+	found := resolverFunc(func(ctx context.Context, name string) ([]*net.MX, error) {
+		return []*net.MX{{Host: "mx." + name, Pref: 10}}, nil
+	})
+	res, _ = Validate(context.Background(), "ann@example.com", &Options{Resolver: found})
+	if !res.Valid() || res.LookupFailed() {
+		t.Errorf("Validate() with MX = %+v, want valid", res)
+	}
+}
+
+func TestResult_LookupFailed(t *testing.T) {
+	domain, role := LevelDomain, LevelRole
+	tests := []struct {
+		name string
+		res  *Result
+		want bool
+	}{
+		{"no policy", nil, false},
+		{"valid", &Result{}, false},
+		{"dns error", &Result{FailedAt: &domain, Unknown: true}, true},
+		{"no mx", &Result{FailedAt: &domain}, false},
+		{"role with smtp error", &Result{FailedAt: &role, Unknown: true}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.res.LookupFailed(); got != tt.want {
+			t.Errorf("%s: LookupFailed() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package emailval
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net"
@@ -168,6 +170,18 @@ func checkSMTPMailbox(ctx context.Context, domain, fullEmail string, opts *Optio
 	return false, errors.New("all MX probes failed")
 }
 
+// checkCatchAll probes a random, almost certainly non-existent mailbox on the
+// domain. If the server accepts it, the domain is catch-all and a positive
+// SMTP result for the real address proves nothing.
+func checkCatchAll(ctx context.Context, domain string, opts *Options) (bool, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return false, err
+	}
+	probe := "outlet-probe-" + hex.EncodeToString(buf) + "@" + domain
+	return checkSMTPMailbox(ctx, domain, probe, opts)
+}
+
 // isGreylistError checks if an error looks like a greylisting response.
 func isGreylistError(err error) bool {
 	if err == nil {
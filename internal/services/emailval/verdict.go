@@ -0,0 +1,47 @@
+package emailval
+
+// Verdicts summarize a Result into a single list-cleaning category.
+const (
+	VerdictValid      = "valid"
+	VerdictInvalid    = "invalid"
+	VerdictDisposable = "disposable"
+	VerdictRole       = "role"
+	VerdictCatchAll   = "catch-all"
+	VerdictUnknown    = "unknown"
+)
+
+// Verdicts lists every verdict in display order.
+var Verdicts = []string{
+	VerdictValid,
+	VerdictInvalid,
+	VerdictDisposable,
+	VerdictRole,
+	VerdictCatchAll,
+	VerdictUnknown,
+}
+
+// Verdict classifies the result. Addresses whose checks could not complete
+// are unknown rather than invalid, so they can be retried later.
+func (r *Result) Verdict() string {
+	if r.FailedAt == nil {
+		switch {
+		case r.CatchAll:
+			return VerdictCatchAll
+		case r.SMTPChecked && r.SMTPDeliverable == nil:
+			return VerdictUnknown
+		}
+		return VerdictValid
+	}
+
+	switch *r.FailedAt {
+	case LevelDisposable:
+		return VerdictDisposable
+	case LevelRole:
+		return VerdictRole
+	case LevelDomain:
+		if r.Unknown {
+			return VerdictUnknown
+		}
+	}
+	return VerdictInvalid
+}
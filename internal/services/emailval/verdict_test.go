@@ -0,0 +1,32 @@
+package emailval
+
+import "testing"
+
+func TestResult_Verdict(t *testing.T) {
+	deliverable := true
+
+	tests := []struct {
+		name   string
+		result Result
+		want   string
+	}{
+		{"valid", Result{}, VerdictValid},
+		{"valid with smtp", Result{SMTPChecked: true, SMTPDeliverable: &deliverable}, VerdictValid},
+		{"smtp unknown", Result{SMTPChecked: true, Unknown: true}, VerdictUnknown},
+		{"catch-all", Result{SMTPChecked: true, SMTPDeliverable: &deliverable, CatchAll: true}, VerdictCatchAll},
+		{"syntax", Result{FailedAt: ptrLevel(LevelSyntax)}, VerdictInvalid},
+		{"no mx", Result{FailedAt: ptrLevel(LevelDomain)}, VerdictInvalid},
+		{"dns error", Result{FailedAt: ptrLevel(LevelDomain), Unknown: true}, VerdictUnknown},
+		{"disposable", Result{FailedAt: ptrLevel(LevelDisposable)}, VerdictDisposable},
+		{"role", Result{FailedAt: ptrLevel(LevelRole)}, VerdictRole},
+		{"smtp rejected", Result{FailedAt: ptrLevel(LevelSMTP), SMTPChecked: true}, VerdictInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.Verdict(); got != tt.want {
+				t.Errorf("Verdict() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Package geo locates IP addresses with a MaxMind GeoLite2 or GeoIP2 City
+// database.
+package geo
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// Locator finds the time zone of IP addresses. A nil Locator finds none.
+type Locator struct {
+	db *maxminddb.Reader
+}
+
+// Open loads a MaxMind City database (.mmdb)
+func Open(path string) (*Locator, error) {
+	db, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open geo database %s: %w", path, err)
+	}
+	return &Locator{db: db}, nil
+}
+
+// Timezone returns the IANA time zone of an IP address, or "" when it is not
+// known
+func (l *Locator) Timezone(ip string) string {
+	if l == nil {
+		return ""
+	}
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return ""
+	}
+
+	var record struct {
+		Location struct {
+			TimeZone string `maxminddb:"time_zone"`
+		} `maxminddb:"location"`
+	}
+	if err := l.db.Lookup(addr, &record); err != nil {
+		return ""
+	}
+	return record.Location.TimeZone
+}
+
+// Close releases the database
+func (l *Locator) Close() error {
+	if l == nil {
+		return nil
+	}
+	return l.db.Close()
+}
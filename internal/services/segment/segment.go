@@ -0,0 +1,692 @@
+package segment
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+)
+
+var (
+	ErrInvalidFilter = errors.New("invalid segment filter")
+	ErrNoLists       = errors.New("segment requires at least one list")
+	ErrNotFound      = errors.New("segment not found")
+	ErrInUse         = errors.New("segment is used by campaigns that are still sending")
+)
+
+// Supported condition fields
+const (
+	FieldTag          = "tag"
+	FieldCustomField  = "custom_field"
+	FieldSubscribedAt = "subscribed_at"
+	FieldOpened       = "opened"
+	FieldClicked      = "clicked"
+	FieldSource       = "source"
+	FieldGDPRConsent  = "gdpr_consent"
+)
+
+// Filter is the JSON segment DSL stored in email_campaigns.segment_filter.
+//
+// Example:
+//
+//	{
+//	  "match": "all",
+//	  "conditions": [
+//	    {"field": "tag", "op": "has", "value": "customer"},
+//	    {"field": "opened", "op": "within_days", "days": 30},
+//	    {"field": "custom_field", "key": "plan", "op": "eq", "value": "pro"}
+//	  ],
+//	  "groups": [
+//	    {"match": "any", "conditions": [...]}
+//	  ]
+//	}
+type Filter struct {
+	Match      string      `json:"match,omitempty"` // all (default) or any
+	Conditions []Condition `json:"conditions,omitempty"`
+	Groups     []Filter    `json:"groups,omitempty"`
+}
+
+// Condition is a single predicate in a segment filter
+type Condition struct {
+	Field  string   `json:"field"`
+	Op     string   `json:"op"`
+	Key    string   `json:"key,omitempty"`    // custom_field only
+	Value  string   `json:"value,omitempty"`  // single operand
+	Values []string `json:"values,omitempty"` // in / not_in / between
+	Days   int      `json:"days,omitempty"`   // within_days / not_within_days
+}
+
+// Parse decodes a segment filter. An empty string yields a nil filter (no filtering).
+func Parse(raw string) (*Filter, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || raw == "null" || raw == "{}" {
+		return nil, nil
+	}
+
+	var f Filter
+	if err := json.Unmarshal([]byte(raw), &f); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+
+	// Validate by compiling once
+	if _, _, err := f.Compile(""); err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+// IsEmpty reports whether the filter has no conditions
+func (f *Filter) IsEmpty() bool {
+	if f == nil {
+		return true
+	}
+	if len(f.Conditions) > 0 {
+		return false
+	}
+	for i := range f.Groups {
+		if !f.Groups[i].IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// Compile converts the filter into a parameterized SQL expression for an org.
+// The expression references the contacts table as "c" and list_subscribers as "ls".
+func (f *Filter) Compile(orgID string) (string, []any, error) {
+	if f.IsEmpty() {
+		return "1=1", nil, nil
+	}
+
+	var joiner string
+	switch strings.ToLower(f.Match) {
+	case "", "all":
+		joiner = " AND "
+	case "any":
+		joiner = " OR "
+	default:
+		return "", nil, fmt.Errorf("%w: unknown match %q", ErrInvalidFilter, f.Match)
+	}
+
+	var parts []string
+	var args []any
+
+	for _, c := range f.Conditions {
+		clause, clauseArgs, err := c.compile(orgID)
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, clause)
+		args = append(args, clauseArgs...)
+	}
+
+	for i := range f.Groups {
+		if f.Groups[i].IsEmpty() {
+			continue
+		}
+		clause, clauseArgs, err := f.Groups[i].Compile(orgID)
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, clause)
+		args = append(args, clauseArgs...)
+	}
+
+	return "(" + strings.Join(parts, joiner) + ")", args, nil
+}
+
+func (c Condition) compile(orgID string) (string, []any, error) {
+	switch c.Field {
+	case FieldTag:
+		return c.compileTag()
+	case FieldCustomField:
+		return c.compileCustomField(orgID)
+	case FieldSubscribedAt:
+		return c.compileSubscribedAt()
+	case FieldOpened:
+		return c.compileEngagement("opened_at")
+	case FieldClicked:
+		return c.compileEngagement("clicked_at")
+	case FieldSource:
+		return c.compileSource()
+	case FieldGDPRConsent:
+		return c.compileGDPRConsent()
+	default:
+		return "", nil, fmt.Errorf("%w: unknown field %q", ErrInvalidFilter, c.Field)
+	}
+}
+
+func (c Condition) compileTag() (string, []any, error) {
+	const hasTag = "EXISTS (SELECT 1 FROM contact_tags ct WHERE ct.contact_id = c.id AND ct.tag = ?)"
+
+	switch c.Op {
+	case "has":
+		if c.Value == "" {
+			return "", nil, c.missing("value")
+		}
+		return hasTag, []any{c.Value}, nil
+	case "not_has":
+		if c.Value == "" {
+			return "", nil, c.missing("value")
+		}
+		return "NOT " + hasTag, []any{c.Value}, nil
+	case "has_any", "has_none":
+		if len(c.Values) == 0 {
+			return "", nil, c.missing("values")
+		}
+		clause := "EXISTS (SELECT 1 FROM contact_tags ct WHERE ct.contact_id = c.id AND ct.tag IN (" + placeholders(len(c.Values)) + "))"
+		if c.Op == "has_none" {
+			clause = "NOT " + clause
+		}
+		return clause, stringArgs(c.Values), nil
+	default:
+		return "", nil, c.unknownOp()
+	}
+}
+
+func (c Condition) compileCustomField(orgID string) (string, []any, error) {
+	if c.Key == "" {
+		return "", nil, c.missing("key")
+	}
+
+	// Custom field values are stored per list subscription. Only fields of the
+	// org's own lists are read.
+	const valueOf = "(SELECT cfv.value FROM custom_field_values cfv JOIN custom_fields cf ON cf.id = cfv.field_id JOIN email_lists cfl ON cfl.id = cf.list_id WHERE cfv.subscriber_id = ls.id AND cf.field_key = ? AND cfl.org_id = ?)"
+	field := []any{c.Key, orgID}
+
+	switch c.Op {
+	case "eq":
+		return valueOf + " = ?", append(field, c.Value), nil
+	case "neq":
+		return "COALESCE(" + valueOf + ", '') <> ?", append(field, c.Value), nil
+	case "contains":
+		return valueOf + " LIKE ?", append(field, "%"+c.Value+"%"), nil
+	case "gt", "gte", "lt", "lte":
+		n, err := strconv.ParseFloat(c.Value, 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: %s requires a numeric value", ErrInvalidFilter, c.Op)
+		}
+		return "CAST(" + valueOf + " AS REAL) " + comparison(c.Op) + " ?", append(field, n), nil
+	case "in", "not_in":
+		if len(c.Values) == 0 {
+			return "", nil, c.missing("values")
+		}
+		clause := valueOf + " IN (" + placeholders(len(c.Values)) + ")"
+		if c.Op == "not_in" {
+			clause = "COALESCE(" + valueOf + ", '') NOT IN (" + placeholders(len(c.Values)) + ")"
+		}
+		return clause, append(field, stringArgs(c.Values)...), nil
+	case "exists":
+		return "COALESCE(" + valueOf + ", '') <> ''", field, nil
+	case "not_exists":
+		return "COALESCE(" + valueOf + ", '') = ''", field, nil
+	default:
+		return "", nil, c.unknownOp()
+	}
+}
+
+func (c Condition) compileSubscribedAt() (string, []any, error) {
+	switch c.Op {
+	case "after", "before":
+		ts, err := normalizeTime(c.Value)
+		if err != nil {
+			return "", nil, err
+		}
+		if c.Op == "after" {
+			return "datetime(ls.subscribed_at) >= datetime(?)", []any{ts}, nil
+		}
+		return "datetime(ls.subscribed_at) < datetime(?)", []any{ts}, nil
+	case "between":
+		if len(c.Values) != 2 {
+			return "", nil, fmt.Errorf("%w: between requires two values", ErrInvalidFilter)
+		}
+		from, err := normalizeTime(c.Values[0])
+		if err != nil {
+			return "", nil, err
+		}
+		to, err := normalizeTime(c.Values[1])
+		if err != nil {
+			return "", nil, err
+		}
+		return "(datetime(ls.subscribed_at) >= datetime(?) AND datetime(ls.subscribed_at) < datetime(?))", []any{from, to}, nil
+	case "within_days":
+		if c.Days <= 0 {
+			return "", nil, c.missing("days")
+		}
+		return "datetime(ls.subscribed_at) >= datetime('now', ?)", []any{daysModifier(c.Days)}, nil
+	case "older_than_days":
+		if c.Days <= 0 {
+			return "", nil, c.missing("days")
+		}
+		return "datetime(ls.subscribed_at) < datetime('now', ?)", []any{daysModifier(c.Days)}, nil
+	default:
+		return "", nil, c.unknownOp()
+	}
+}
+
+// compileEngagement matches opens or clicks on campaign and sequence emails
+func (c Condition) compileEngagement(column string) (string, []any, error) {
+	if c.Days <= 0 {
+		return "", nil, c.missing("days")
+	}
+
+	clause := "(EXISTS (SELECT 1 FROM campaign_sends cs WHERE cs.contact_id = c.id AND datetime(cs." + column + ") >= datetime('now', ?))" +
+		" OR EXISTS (SELECT 1 FROM email_queue eq WHERE eq.contact_id = c.id AND datetime(eq." + column + ") >= datetime('now', ?)))"
+	modifier := daysModifier(c.Days)
+
+	switch c.Op {
+	case "within_days":
+		return clause, []any{modifier, modifier}, nil
+	case "not_within_days":
+		return "NOT " + clause, []any{modifier, modifier}, nil
+	default:
+		return "", nil, c.unknownOp()
+	}
+}
+
+func (c Condition) compileSource() (string, []any, error) {
+	switch c.Op {
+	case "eq":
+		return "c.source = ?", []any{c.Value}, nil
+	case "neq":
+		return "COALESCE(c.source, '') <> ?", []any{c.Value}, nil
+	case "in", "not_in":
+		if len(c.Values) == 0 {
+			return "", nil, c.missing("values")
+		}
+		if c.Op == "not_in" {
+			return "COALESCE(c.source, '') NOT IN (" + placeholders(len(c.Values)) + ")", stringArgs(c.Values), nil
+		}
+		return "c.source IN (" + placeholders(len(c.Values)) + ")", stringArgs(c.Values), nil
+	default:
+		return "", nil, c.unknownOp()
+	}
+}
+
+func (c Condition) compileGDPRConsent() (string, []any, error) {
+	if c.Op != "eq" {
+		return "", nil, c.unknownOp()
+	}
+	consent, err := strconv.ParseBool(c.Value)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: gdpr_consent requires true or false", ErrInvalidFilter)
+	}
+	if consent {
+		return "COALESCE(c.gdpr_consent, 0) = 1", nil, nil
+	}
+	return "COALESCE(c.gdpr_consent, 0) = 0", nil, nil
+}
+
+func (c Condition) missing(param string) error {
+	return fmt.Errorf("%w: %s %s requires %s", ErrInvalidFilter, c.Field, c.Op, param)
+}
+
+func (c Condition) unknownOp() error {
+	return fmt.Errorf("%w: unknown op %q for field %s", ErrInvalidFilter, c.Op, c.Field)
+}
+
+// Audience describes who a campaign targets
+type Audience struct {
+	OrgID          string
+	ListIDs        []int64
+	ExcludeListIDs []int64
+	Filter         *Filter
+
+	// AllLists targets every list in the org when ListIDs is empty
+	AllLists bool
+
+	// ExcludeSequenceID skips contacts that have ever been enrolled in this sequence
+	ExcludeSequenceID string
+}
+
+// Member is a single resolved audience member
+type Member struct {
+	ContactID string
+	Email     string
+	Name      string
+	ListID    int64
+}
+
+// Resolve returns the deduplicated contacts matching the audience
+func Resolve(ctx context.Context, conn *sql.DB, a Audience) ([]Member, error) {
+	where, args, err := a.where()
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT c.id, c.email, c.name, MIN(ls.list_id)
+FROM list_subscribers ls
+JOIN contacts c ON c.id = ls.contact_id
+JOIN email_lists el ON el.id = ls.list_id
+WHERE ` + where + `
+GROUP BY c.id, c.email, c.name`
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []Member
+	for rows.Next() {
+		var m Member
+		if err := rows.Scan(&m.ContactID, &m.Email, &m.Name, &m.ListID); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+// Count returns the number of distinct contacts matching the audience
+func Count(ctx context.Context, conn *sql.DB, a Audience) (int64, error) {
+	where, args, err := a.where()
+	if err != nil {
+		return 0, err
+	}
+
+	query := `SELECT COUNT(DISTINCT c.id)
+FROM list_subscribers ls
+JOIN contacts c ON c.id = ls.contact_id
+JOIN email_lists el ON el.id = ls.list_id
+WHERE ` + where
+
+	var count int64
+	if err := conn.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Unsubscribe marks every matching contact unsubscribed from the audience's lists
+func Unsubscribe(ctx context.Context, conn *sql.DB, a Audience) (int64, error) {
+	where, args, err := a.where()
+	if err != nil {
+		return 0, err
+	}
+
+	query := `UPDATE list_subscribers
+SET status = 'unsubscribed', unsubscribed_at = datetime('now')
+WHERE id IN (
+	SELECT ls.id
+	FROM list_subscribers ls
+	JOIN contacts c ON c.id = ls.contact_id
+	JOIN email_lists el ON el.id = ls.list_id
+	WHERE ` + where + `
+)`
+
+	result, err := conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// Delete removes every matching contact from the org
+func Delete(ctx context.Context, conn *sql.DB, a Audience) (int64, error) {
+	where, args, err := a.where()
+	if err != nil {
+		return 0, err
+	}
+
+	query := `DELETE FROM contacts
+WHERE org_id = ? AND id IN (
+	SELECT c.id
+	FROM list_subscribers ls
+	JOIN contacts c ON c.id = ls.contact_id
+	JOIN email_lists el ON el.id = ls.list_id
+	WHERE ` + where + `
+)`
+
+	result, err := conn.ExecContext(ctx, query, append([]any{a.OrgID}, args...)...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (a Audience) where() (string, []any, error) {
+	if len(a.ListIDs) == 0 && !a.AllLists {
+		return "", nil, ErrNoLists
+	}
+
+	clauses := []string{
+		"el.org_id = ?",
+		"ls.status = 'active'",
+		"c.unsubscribed_at IS NULL",
+		"c.blocked_at IS NULL",
+	}
+	args := []any{a.OrgID}
+
+	if len(a.ListIDs) > 0 {
+		clauses = append(clauses, "ls.list_id IN ("+placeholders(len(a.ListIDs))+")")
+		args = append(args, int64Args(a.ListIDs)...)
+	}
+
+	if len(a.ExcludeListIDs) > 0 {
+		clauses = append(clauses, "c.id NOT IN (SELECT x.contact_id FROM list_subscribers x WHERE x.list_id IN ("+placeholders(len(a.ExcludeListIDs))+") AND x.status = 'active')")
+		args = append(args, int64Args(a.ExcludeListIDs)...)
+	}
+
+	if a.ExcludeSequenceID != "" {
+		clauses = append(clauses, "c.id NOT IN (SELECT css.contact_id FROM contact_sequence_state css WHERE css.sequence_id = ?)")
+		args = append(args, a.ExcludeSequenceID)
+	}
+
+	if !a.Filter.IsEmpty() {
+		clause, filterArgs, err := a.Filter.Compile(a.OrgID)
+		if err != nil {
+			return "", nil, err
+		}
+		clauses = append(clauses, clause)
+		args = append(args, filterArgs...)
+	}
+
+	return strings.Join(clauses, " AND "), args, nil
+}
+
+// SegmentStore loads saved segments
+type SegmentStore interface {
+	GetSegment(ctx context.Context, arg db.GetSegmentParams) (db.Segment, error)
+	UpdateSegmentCount(ctx context.Context, arg db.UpdateSegmentCountParams) error
+}
+
+// Targeting is a campaign's audience definition: lists, exclusions, an inline
+// filter and an optional saved segment
+type Targeting struct {
+	OrgID          string
+	ListIDs        []int64
+	ExcludeListIDs []int64
+	Filter         string
+	SegmentID      string
+}
+
+// Build resolves targeting into an audience. When a saved segment is referenced its
+// filter is ANDed with the inline filter, and its lists are used if the targeting has none.
+func (t Targeting) Build(ctx context.Context, store SegmentStore) (Audience, error) {
+	filter, err := Parse(t.Filter)
+	if err != nil {
+		return Audience{}, err
+	}
+
+	audience := Audience{
+		OrgID:          t.OrgID,
+		ListIDs:        t.ListIDs,
+		ExcludeListIDs: t.ExcludeListIDs,
+		Filter:         filter,
+	}
+
+	if t.SegmentID != "" {
+		saved, err := store.GetSegment(ctx, db.GetSegmentParams{ID: t.SegmentID, OrgID: t.OrgID})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return Audience{}, ErrNotFound
+			}
+			return Audience{}, err
+		}
+		savedAudience, err := FromSaved(saved)
+		if err != nil {
+			return Audience{}, err
+		}
+
+		if len(audience.ListIDs) == 0 {
+			audience.ListIDs = savedAudience.ListIDs
+			audience.AllLists = savedAudience.AllLists
+		}
+		audience.Filter = Combine(savedAudience.Filter, filter)
+	}
+
+	if len(audience.ListIDs) == 0 && !audience.AllLists {
+		return Audience{}, ErrNoLists
+	}
+
+	return audience, nil
+}
+
+// RefreshCount recomputes and caches the member count of a saved segment
+func RefreshCount(ctx context.Context, conn *sql.DB, store SegmentStore, s db.Segment) (int64, error) {
+	audience, err := FromSaved(s)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := Count(ctx, conn, audience)
+	if err != nil {
+		return 0, err
+	}
+
+	err = store.UpdateSegmentCount(ctx, db.UpdateSegmentCountParams{
+		ContactCount: sql.NullInt64{Int64: count, Valid: true},
+		ID:           s.ID,
+	})
+	return count, err
+}
+
+// DeleteSaved deletes a saved segment. It refuses while a scheduled, sending
+// or paused campaign targets the segment, since the campaign would otherwise
+// go to its whole lists. Other campaigns have the segment cleared.
+func DeleteSaved(ctx context.Context, store *db.Store, orgID, segmentID string) error {
+	ref := sql.NullString{String: segmentID, Valid: true}
+	return store.ExecTx(ctx, func(q *db.Queries) error {
+		active, err := q.CountActiveCampaignsUsingSegment(ctx, db.CountActiveCampaignsUsingSegmentParams{
+			SegmentID: ref,
+			OrgID:     orgID,
+		})
+		if err != nil {
+			return err
+		}
+		if active > 0 {
+			return fmt.Errorf("%w: %d campaign(s) must finish or be cancelled first", ErrInUse, active)
+		}
+
+		if err := q.ClearCampaignSegment(ctx, db.ClearCampaignSegmentParams{SegmentID: ref, OrgID: orgID}); err != nil {
+			return err
+		}
+		return q.DeleteSegment(ctx, db.DeleteSegmentParams{ID: segmentID, OrgID: orgID})
+	})
+}
+
+// Combine ANDs several filters together, ignoring empty ones
+func Combine(filters ...*Filter) *Filter {
+	combined := &Filter{Match: "all"}
+	for _, f := range filters {
+		if !f.IsEmpty() {
+			combined.Groups = append(combined.Groups, *f)
+		}
+	}
+	if combined.IsEmpty() {
+		return nil
+	}
+	return combined
+}
+
+// FromSaved builds the audience for a saved segment. A segment without lists targets
+// every list in its org.
+func FromSaved(s db.Segment) (Audience, error) {
+	filter, err := Parse(s.Filter)
+	if err != nil {
+		return Audience{}, err
+	}
+
+	var refs []string
+	if s.ListIds.Valid && s.ListIds.String != "" {
+		if err := json.Unmarshal([]byte(s.ListIds.String), &refs); err != nil {
+			return Audience{}, fmt.Errorf("%w: invalid list_ids: %v", ErrInvalidFilter, err)
+		}
+	}
+	listIDs := ParseListIDStrings(refs)
+
+	return Audience{
+		OrgID:    s.OrgID,
+		ListIDs:  listIDs,
+		Filter:   filter,
+		AllLists: len(listIDs) == 0,
+	}, nil
+}
+
+// ParseListIDStrings converts string list IDs to integers, skipping invalid entries
+func ParseListIDStrings(values []string) []int64 {
+	var ids []int64
+	for _, v := range values {
+		id, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err == nil && id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+func stringArgs(values []string) []any {
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
+
+func int64Args(values []int64) []any {
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
+
+func comparison(op string) string {
+	switch op {
+	case "gt":
+		return ">"
+	case "gte":
+		return ">="
+	case "lt":
+		return "<"
+	default:
+		return "<="
+	}
+}
+
+func daysModifier(days int) string {
+	return fmt.Sprintf("-%d days", days)
+}
+
+// normalizeTime accepts RFC3339 or YYYY-MM-DD and returns a SQLite datetime string
+func normalizeTime(v string) (string, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t.UTC().Format("2006-01-02 15:04:05"), nil
+	}
+	if t, err := time.Parse("2006-01-02", v); err == nil {
+		return t.Format("2006-01-02 15:04:05"), nil
+	}
+	return "", fmt.Errorf("%w: invalid date %q", ErrInvalidFilter, v)
+}
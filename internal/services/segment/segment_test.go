@@ -0,0 +1,336 @@
+package segment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/db/dbtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Empty(t *testing.T) {
+	for _, raw := range []string{"", "  ", "null", "{}"} {
+		f, err := Parse(raw)
+		require.NoError(t, err)
+		assert.Nil(t, f)
+		assert.True(t, f.IsEmpty())
+	}
+}
+
+func TestParse_InvalidJSON(t *testing.T) {
+	_, err := Parse(`{"conditions": [`)
+	assert.True(t, errors.Is(err, ErrInvalidFilter))
+}
+
+func TestParse_UnknownField(t *testing.T) {
+	_, err := Parse(`{"conditions": [{"field": "favourite_colour", "op": "eq", "value": "red"}]}`)
+	assert.True(t, errors.Is(err, ErrInvalidFilter))
+}
+
+func TestParse_UnknownOp(t *testing.T) {
+	_, err := Parse(`{"conditions": [{"field": "tag", "op": "like", "value": "vip"}]}`)
+	assert.True(t, errors.Is(err, ErrInvalidFilter))
+}
+
+func TestParse_UnknownMatch(t *testing.T) {
+	_, err := Parse(`{"match": "some", "conditions": [{"field": "tag", "op": "has", "value": "vip"}]}`)
+	assert.True(t, errors.Is(err, ErrInvalidFilter))
+}
+
+func TestCompile_Tag(t *testing.T) {
+	f := &Filter{Conditions: []Condition{{Field: FieldTag, Op: "has", Value: "vip"}}}
+	clause, args, err := f.Compile("org-1")
+	require.NoError(t, err)
+	assert.Contains(t, clause, "contact_tags")
+	assert.NotContains(t, clause, "NOT")
+	assert.Equal(t, []any{"vip"}, args)
+
+	f = &Filter{Conditions: []Condition{{Field: FieldTag, Op: "has_none", Values: []string{"a", "b"}}}}
+	clause, args, err = f.Compile("org-1")
+	require.NoError(t, err)
+	assert.Contains(t, clause, "NOT EXISTS")
+	assert.Contains(t, clause, "IN (?,?)")
+	assert.Equal(t, []any{"a", "b"}, args)
+}
+
+func TestCompile_TagRequiresValue(t *testing.T) {
+	f := &Filter{Conditions: []Condition{{Field: FieldTag, Op: "has"}}}
+	_, _, err := f.Compile("org-1")
+	assert.True(t, errors.Is(err, ErrInvalidFilter))
+}
+
+func TestCompile_CustomField(t *testing.T) {
+	f := &Filter{Conditions: []Condition{{Field: FieldCustomField, Key: "plan", Op: "eq", Value: "pro"}}}
+	clause, args, err := f.Compile("org-1")
+	require.NoError(t, err)
+	assert.Contains(t, clause, "custom_field_values")
+	assert.Contains(t, clause, "cfl.org_id = ?")
+	assert.Equal(t, []any{"plan", "org-1", "pro"}, args)
+
+	f = &Filter{Conditions: []Condition{{Field: FieldCustomField, Key: "age", Op: "gte", Value: "21"}}}
+	clause, args, err = f.Compile("org-1")
+	require.NoError(t, err)
+	assert.Contains(t, clause, "AS REAL) >= ?")
+	assert.Equal(t, []any{"age", "org-1", 21.0}, args)
+}
+
+func TestCompile_CustomFieldRequiresKey(t *testing.T) {
+	f := &Filter{Conditions: []Condition{{Field: FieldCustomField, Op: "eq", Value: "pro"}}}
+	_, _, err := f.Compile("org-1")
+	assert.True(t, errors.Is(err, ErrInvalidFilter))
+}
+
+func TestCompile_CustomFieldNumericValue(t *testing.T) {
+	f := &Filter{Conditions: []Condition{{Field: FieldCustomField, Key: "age", Op: "gt", Value: "old"}}}
+	_, _, err := f.Compile("org-1")
+	assert.True(t, errors.Is(err, ErrInvalidFilter))
+}
+
+func TestCompile_SubscribedAt(t *testing.T) {
+	f := &Filter{Conditions: []Condition{{Field: FieldSubscribedAt, Op: "after", Value: "2024-01-15"}}}
+	_, args, err := f.Compile("org-1")
+	require.NoError(t, err)
+	assert.Equal(t, []any{"2024-01-15 00:00:00"}, args)
+
+	f = &Filter{Conditions: []Condition{{Field: FieldSubscribedAt, Op: "between", Values: []string{"2024-01-01T00:00:00Z", "2024-02-01"}}}}
+	_, args, err = f.Compile("org-1")
+	require.NoError(t, err)
+	assert.Equal(t, []any{"2024-01-01 00:00:00", "2024-02-01 00:00:00"}, args)
+
+	f = &Filter{Conditions: []Condition{{Field: FieldSubscribedAt, Op: "within_days", Days: 7}}}
+	_, args, err = f.Compile("org-1")
+	require.NoError(t, err)
+	assert.Equal(t, []any{"-7 days"}, args)
+}
+
+func TestCompile_SubscribedAtInvalidDate(t *testing.T) {
+	f := &Filter{Conditions: []Condition{{Field: FieldSubscribedAt, Op: "after", Value: "last tuesday"}}}
+	_, _, err := f.Compile("org-1")
+	assert.True(t, errors.Is(err, ErrInvalidFilter))
+}
+
+func TestCompile_Engagement(t *testing.T) {
+	f := &Filter{Conditions: []Condition{{Field: FieldOpened, Op: "within_days", Days: 30}}}
+	clause, args, err := f.Compile("org-1")
+	require.NoError(t, err)
+	assert.Contains(t, clause, "cs.opened_at")
+	assert.Contains(t, clause, "eq.opened_at")
+	assert.Equal(t, []any{"-30 days", "-30 days"}, args)
+
+	f = &Filter{Conditions: []Condition{{Field: FieldClicked, Op: "not_within_days", Days: 90}}}
+	clause, _, err = f.Compile("org-1")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(clause, "(NOT "))
+	assert.Contains(t, clause, "clicked_at")
+}
+
+func TestCompile_EngagementRequiresDays(t *testing.T) {
+	f := &Filter{Conditions: []Condition{{Field: FieldOpened, Op: "within_days"}}}
+	_, _, err := f.Compile("org-1")
+	assert.True(t, errors.Is(err, ErrInvalidFilter))
+}
+
+func TestCompile_GDPRConsent(t *testing.T) {
+	f := &Filter{Conditions: []Condition{{Field: FieldGDPRConsent, Op: "eq", Value: "true"}}}
+	clause, args, err := f.Compile("org-1")
+	require.NoError(t, err)
+	assert.Contains(t, clause, "gdpr_consent, 0) = 1")
+	assert.Empty(t, args)
+
+	f = &Filter{Conditions: []Condition{{Field: FieldGDPRConsent, Op: "eq", Value: "maybe"}}}
+	_, _, err = f.Compile("org-1")
+	assert.True(t, errors.Is(err, ErrInvalidFilter))
+}
+
+func TestCompile_MatchAndGroups(t *testing.T) {
+	f, err := Parse(`{
+		"match": "all",
+		"conditions": [{"field": "source", "op": "eq", "value": "api"}],
+		"groups": [{
+			"match": "any",
+			"conditions": [
+				{"field": "tag", "op": "has", "value": "vip"},
+				{"field": "tag", "op": "has", "value": "customer"}
+			]
+		}]
+	}`)
+	require.NoError(t, err)
+
+	clause, args, err := f.Compile("org-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(clause, " OR "))
+	assert.Contains(t, clause, "c.source = ? AND (")
+	assert.Equal(t, []any{"api", "vip", "customer"}, args)
+}
+
+func TestAudienceWhere(t *testing.T) {
+	where, args, err := Audience{
+		OrgID:          "org-1",
+		ListIDs:        []int64{1, 2},
+		ExcludeListIDs: []int64{3},
+		Filter:         &Filter{Conditions: []Condition{{Field: FieldTag, Op: "has", Value: "vip"}}},
+	}.where()
+	require.NoError(t, err)
+	assert.Contains(t, where, "el.org_id = ?")
+	assert.Contains(t, where, "ls.list_id IN (?,?)")
+	assert.Contains(t, where, "c.id NOT IN")
+	assert.Equal(t, []any{"org-1", int64(1), int64(2), int64(3), "vip"}, args)
+}
+
+func TestAudienceWhere_NoLists(t *testing.T) {
+	_, _, err := Audience{OrgID: "org-1"}.where()
+	assert.ErrorIs(t, err, ErrNoLists)
+}
+
+func TestAudienceWhere_AllLists(t *testing.T) {
+	where, args, err := Audience{OrgID: "org-1", AllLists: true, ExcludeSequenceID: "seq-1"}.where()
+	require.NoError(t, err)
+	assert.NotContains(t, where, "ls.list_id IN")
+	assert.Contains(t, where, "contact_sequence_state")
+	assert.Equal(t, []any{"org-1", "seq-1"}, args)
+}
+
+func TestCombine(t *testing.T) {
+	assert.Nil(t, Combine(nil, &Filter{}))
+
+	a := &Filter{Conditions: []Condition{{Field: FieldTag, Op: "has", Value: "vip"}}}
+	b := &Filter{Conditions: []Condition{{Field: FieldSource, Op: "eq", Value: "api"}}}
+	combined := Combine(a, nil, b)
+	require.NotNil(t, combined)
+	assert.Len(t, combined.Groups, 2)
+
+	_, args, err := combined.Compile("org-1")
+	require.NoError(t, err)
+	assert.Equal(t, []any{"vip", "api"}, args)
+}
+
+func TestFromSaved(t *testing.T) {
+	a, err := FromSaved(db.Segment{
+		OrgID:   "org-1",
+		ListIds: sql.NullString{String: `["1","2"]`, Valid: true},
+		Filter:  `{"conditions": [{"field": "tag", "op": "has", "value": "vip"}]}`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, a.ListIDs)
+	assert.False(t, a.AllLists)
+	assert.False(t, a.Filter.IsEmpty())
+
+	a, err = FromSaved(db.Segment{OrgID: "org-1", ListIds: sql.NullString{String: "[]", Valid: true}, Filter: "{}"})
+	require.NoError(t, err)
+	assert.True(t, a.AllLists)
+	assert.Nil(t, a.Filter)
+}
+
+type fakeSegmentStore struct {
+	segments map[string]db.Segment
+}
+
+func (f fakeSegmentStore) GetSegment(ctx context.Context, arg db.GetSegmentParams) (db.Segment, error) {
+	s, ok := f.segments[arg.ID]
+	if !ok || s.OrgID != arg.OrgID {
+		return db.Segment{}, sql.ErrNoRows
+	}
+	return s, nil
+}
+
+func (f fakeSegmentStore) UpdateSegmentCount(ctx context.Context, arg db.UpdateSegmentCountParams) error {
+	return nil
+}
+
+func TestTargetingBuild_SavedSegment(t *testing.T) {
+	store := fakeSegmentStore{segments: map[string]db.Segment{
+		"seg-1": {
+			ID:      "seg-1",
+			OrgID:   "org-1",
+			ListIds: sql.NullString{String: `["5"]`, Valid: true},
+			Filter:  `{"conditions": [{"field": "tag", "op": "has", "value": "vip"}]}`,
+		},
+	}}
+
+	// Saved segment supplies lists when the campaign has none
+	a, err := Targeting{OrgID: "org-1", SegmentID: "seg-1"}.Build(context.Background(), store)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{5}, a.ListIDs)
+
+	// Campaign lists win, filters are ANDed
+	a, err = Targeting{
+		OrgID:     "org-1",
+		ListIDs:   []int64{1},
+		Filter:    `{"conditions": [{"field": "source", "op": "eq", "value": "api"}]}`,
+		SegmentID: "seg-1",
+	}.Build(context.Background(), store)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, a.ListIDs)
+	assert.Len(t, a.Filter.Groups, 2)
+
+	// Segments are scoped to their org
+	_, err = Targeting{OrgID: "org-2", SegmentID: "seg-1"}.Build(context.Background(), store)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestTargetingBuild_NoLists(t *testing.T) {
+	_, err := Targeting{OrgID: "org-1"}.Build(context.Background(), fakeSegmentStore{})
+	assert.ErrorIs(t, err, ErrNoLists)
+}
+
+func TestParseListIDStrings(t *testing.T) {
+	assert.Equal(t, []int64{1, 20}, ParseListIDStrings([]string{"1", " 20 ", "abc", "0", "-4"}))
+	assert.Nil(t, ParseListIDStrings(nil))
+}
+
+func TestDeleteSaved(t *testing.T) {
+	ctx := context.Background()
+	store := dbtest.New(t)
+	dbtest.Exec(t, store, `INSERT INTO organizations (id, name, slug, api_key) VALUES ('org', 'Org', 'org', 'key')`)
+	dbtest.Exec(t, store, `INSERT INTO segments (id, org_id, name, list_ids, filter) VALUES ('vip', 'org', 'VIP', '[1]', '{}')`)
+	dbtest.Exec(t, store, `INSERT INTO email_campaigns (id, org_id, name, subject, html_body, status, segment_id) VALUES ('draft', 'org', 'D', 's', 'b', 'draft', 'vip')`)
+	dbtest.Exec(t, store, `INSERT INTO email_campaigns (id, org_id, name, subject, html_body, status, segment_id) VALUES ('live', 'org', 'L', 's', 'b', 'scheduled', 'vip')`)
+
+	err := DeleteSaved(ctx, store, "org", "vip")
+	require.ErrorIs(t, err, ErrInUse)
+	_, err = store.GetSegment(ctx, db.GetSegmentParams{ID: "vip", OrgID: "org"})
+	require.NoError(t, err, "segment deleted while a scheduled campaign uses it")
+
+	dbtest.Exec(t, store, `UPDATE email_campaigns SET status = 'cancelled' WHERE id = 'live'`)
+	require.NoError(t, DeleteSaved(ctx, store, "org", "vip"))
+
+	_, err = store.GetSegment(ctx, db.GetSegmentParams{ID: "vip", OrgID: "org"})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	draft, err := store.GetCampaignByID(ctx, "draft")
+	require.NoError(t, err)
+	assert.False(t, draft.SegmentID.Valid, "draft campaign still references the deleted segment")
+}
+
+func TestCount_CustomFieldScopedToOrg(t *testing.T) {
+	ctx := context.Background()
+	store := dbtest.New(t)
+	for _, org := range []string{"a", "b"} {
+		dbtest.Exec(t, store, `INSERT INTO organizations (id, name, slug, api_key) VALUES (?, ?, ?, ?)`, org, org, org, "key-"+org)
+	}
+	dbtest.Exec(t, store, `INSERT INTO email_lists (id, public_id, org_id, name, slug) VALUES (1, 'l1', 'a', 'A', 'a'), (2, 'l2', 'b', 'B', 'b')`)
+	dbtest.Exec(t, store, `INSERT INTO contacts (id, org_id, name, email, status) VALUES ('ann', 'a', 'Ann', 'ann@example.com', 'active')`)
+	dbtest.Exec(t, store, `INSERT INTO list_subscribers (id, list_id, contact_id, status) VALUES ('sub', 1, 'ann', 'active')`)
+	dbtest.Exec(t, store, `INSERT INTO custom_fields (id, list_id, name, field_key) VALUES ('other', 2, 'Plan', 'plan')`)
+	dbtest.Exec(t, store, `INSERT INTO custom_field_values (id, subscriber_id, field_id, value) VALUES ('v1', 'sub', 'other', 'pro')`)
+
+	audience := Audience{
+		OrgID:   "a",
+		ListIDs: []int64{1},
+		Filter:  &Filter{Conditions: []Condition{{Field: FieldCustomField, Key: "plan", Op: "eq", Value: "pro"}}},
+	}
+	n, err := Count(ctx, store.GetDB(), audience)
+	require.NoError(t, err)
+	assert.Zero(t, n, "matched a value stored under another org's field")
+
+	dbtest.Exec(t, store, `INSERT INTO custom_fields (id, list_id, name, field_key) VALUES ('own', 1, 'Plan', 'plan')`)
+	dbtest.Exec(t, store, `INSERT INTO custom_field_values (id, subscriber_id, field_id, value) VALUES ('v2', 'sub', 'own', 'pro')`)
+	n, err = Count(ctx, store.GetDB(), audience)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+}
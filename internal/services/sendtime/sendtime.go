@@ -0,0 +1,161 @@
+// Package sendtime decides when each recipient of a campaign is sent to.
+package sendtime
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+)
+
+// Campaign delivery modes
+const (
+	ModeImmediate = "immediate"    // Everyone as soon as the campaign starts
+	ModeLocalTime = "local_time"   // LocalHour in each contact's time zone
+	ModeOptimal   = "optimal_time" // The hour each contact has opened most
+)
+
+// LocalHour is when local-time campaigns arrive in each contact's time zone
+const LocalHour = 9
+
+// historyWindow is how far back opens count toward a contact's best hour
+const historyWindow = 90 * 24 * time.Hour
+
+// ValidMode reports whether mode is a campaign delivery mode
+func ValidMode(mode string) bool {
+	switch mode {
+	case ModeImmediate, ModeLocalTime, ModeOptimal:
+		return true
+	}
+	return false
+}
+
+// LoadTimezone loads an IANA time zone such as "America/New_York"
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return loc, nil
+}
+
+// Store is the data a plan is loaded from
+type Store interface {
+	ListContactTimezones(ctx context.Context, orgID sql.NullString) ([]db.ListContactTimezonesRow, error)
+	ListContactOpenHours(ctx context.Context, arg db.ListContactOpenHoursParams) ([]db.ListContactOpenHoursRow, error)
+}
+
+// Plan times the sends of a campaign. Every contact is due within 24 hours
+// of the start, spread across their hour so the sending rate limits are not
+// met with one burst.
+type Plan struct {
+	mode    string
+	start   time.Time
+	zones   map[string]*time.Location // Contact time zones
+	hours   map[string]int            // Contact best UTC hours
+	orgHour int                       // Org best UTC hour, -1 without history
+	spread  func(n int64) int64
+}
+
+// Load builds the plan for an org's campaign starting at start
+func Load(ctx context.Context, store Store, orgID, mode string, start time.Time) (*Plan, error) {
+	p := &Plan{mode: mode, start: start, orgHour: -1, spread: rand.Int64N}
+
+	switch mode {
+	case ModeLocalTime:
+		rows, err := store.ListContactTimezones(ctx, sql.NullString{String: orgID, Valid: true})
+		if err != nil {
+			return nil, err
+		}
+		p.zones = make(map[string]*time.Location, len(rows))
+		for _, row := range rows {
+			// Time zones are checked when set, but the tz database can change
+			if loc, err := LoadTimezone(row.Timezone.String); err == nil {
+				p.zones[row.ID] = loc
+			}
+		}
+	case ModeOptimal:
+		rows, err := store.ListContactOpenHours(ctx, db.ListContactOpenHoursParams{
+			OrgID: orgID,
+			Since: sql.NullString{String: start.Add(-historyWindow).UTC().Format(time.DateTime), Valid: true},
+		})
+		if err != nil {
+			return nil, err
+		}
+		p.hours, p.orgHour = bestHours(rows)
+	}
+	return p, nil
+}
+
+// At returns when a contact is due, or the zero time to send right away.
+// Contacts without a known time zone or open history fall back to the org's
+// best hour in optimal mode, and are sent right away otherwise.
+func (p *Plan) At(contactID string) time.Time {
+	if p == nil {
+		return time.Time{}
+	}
+	switch p.mode {
+	case ModeLocalTime:
+		if loc, ok := p.zones[contactID]; ok {
+			return next(p.start.In(loc), LocalHour, p.spread)
+		}
+	case ModeOptimal:
+		hour, ok := p.hours[contactID]
+		if !ok {
+			hour = p.orgHour
+		}
+		if hour >= 0 {
+			return next(p.start.UTC(), hour, p.spread)
+		}
+	}
+	return time.Time{}
+}
+
+// next returns a random moment in the first occurrence of hour, in t's
+// location, that has not fully passed at t
+func next(t time.Time, hour int, spread func(n int64) int64) time.Time {
+	at := time.Date(t.Year(), t.Month(), t.Day(), hour, 0, 0, 0, t.Location())
+	if !at.Add(time.Hour).After(t) {
+		at = time.Date(t.Year(), t.Month(), t.Day()+1, hour, 0, 0, 0, t.Location())
+	}
+
+	from := at
+	if t.After(from) {
+		from = t
+	}
+	return from.Add(time.Duration(spread(int64(at.Add(time.Hour).Sub(from)))))
+}
+
+// bestHours returns the hour each contact opened most, and the hour the org's
+// contacts opened most overall (-1 without opens). Ties go to the earlier hour.
+func bestHours(rows []db.ListContactOpenHoursRow) (map[string]int, int) {
+	hours := make(map[string]int)
+	best := make(map[string]int64)
+	var org [24]int64
+	for _, row := range rows {
+		if !row.ContactID.Valid || row.Hour < 0 || row.Hour > 23 {
+			continue
+		}
+		id, hour := row.ContactID.String, int(row.Hour)
+		org[hour] += row.Opens
+
+		current, seen := hours[id]
+		if !seen || row.Opens > best[id] || (row.Opens == best[id] && hour < current) {
+			hours[id], best[id] = hour, row.Opens
+		}
+	}
+
+	orgHour := -1
+	for hour, opens := range org {
+		if opens > 0 && (orgHour < 0 || opens > org[orgHour]) {
+			orgHour = hour
+		}
+	}
+	return hours, orgHour
+}
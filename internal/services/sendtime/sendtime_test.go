@@ -0,0 +1,149 @@
+package sendtime
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+)
+
+func noSpread(int64) int64 { return 0 }
+
+func TestNext(t *testing.T) {
+	ny, err := LoadTimezone("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{"before the hour", time.Date(2026, 3, 2, 7, 30, 0, 0, ny), time.Date(2026, 3, 2, 9, 0, 0, 0, ny)},
+		{"during the hour", time.Date(2026, 3, 2, 9, 20, 0, 0, ny), time.Date(2026, 3, 2, 9, 20, 0, 0, ny)},
+		{"after the hour", time.Date(2026, 3, 2, 10, 0, 0, 0, ny), time.Date(2026, 3, 3, 9, 0, 0, 0, ny)},
+		{"across daylight saving", time.Date(2026, 3, 7, 12, 0, 0, 0, ny), time.Date(2026, 3, 8, 9, 0, 0, 0, ny)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := next(tt.now, 9, noSpread); !got.Equal(tt.want) {
+				t.Errorf("next(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNext_SpreadStaysInHour(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 45, 0, 0, time.UTC)
+	var window int64
+	got := next(now, 9, func(n int64) int64 {
+		window = n
+		return n - 1
+	})
+	if time.Duration(window) != 15*time.Minute {
+		t.Errorf("spread window = %v, want the 15m left in the hour", time.Duration(window))
+	}
+	if !got.Before(time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("next spread past the hour: %v", got)
+	}
+}
+
+func TestBestHours(t *testing.T) {
+	contact := func(id string) sql.NullString { return sql.NullString{String: id, Valid: true} }
+	hours, orgHour := bestHours([]db.ListContactOpenHoursRow{
+		{ContactID: contact("a"), Hour: 14, Opens: 2},
+		{ContactID: contact("a"), Hour: 8, Opens: 5},
+		{ContactID: contact("b"), Hour: 20, Opens: 3},
+		{ContactID: contact("b"), Hour: 6, Opens: 3},
+		{ContactID: contact("c"), Hour: 14, Opens: 4},
+	})
+
+	if hours["a"] != 8 {
+		t.Errorf("a best hour = %d, want 8", hours["a"])
+	}
+	if hours["b"] != 6 {
+		t.Errorf("b best hour = %d, want the earlier tied hour 6", hours["b"])
+	}
+	if orgHour != 14 {
+		t.Errorf("org best hour = %d, want 14", orgHour)
+	}
+
+	if _, orgHour := bestHours(nil); orgHour != -1 {
+		t.Errorf("org best hour without opens = %d, want -1", orgHour)
+	}
+}
+
+type fakeStore struct {
+	zones []db.ListContactTimezonesRow
+	opens []db.ListContactOpenHoursRow
+}
+
+func (f fakeStore) ListContactTimezones(context.Context, sql.NullString) ([]db.ListContactTimezonesRow, error) {
+	return f.zones, nil
+}
+
+func (f fakeStore) ListContactOpenHours(context.Context, db.ListContactOpenHoursParams) ([]db.ListContactOpenHoursRow, error) {
+	return f.opens, nil
+}
+
+func TestPlan(t *testing.T) {
+	start := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	store := fakeStore{
+		zones: []db.ListContactTimezonesRow{
+			{ID: "tokyo", Timezone: sql.NullString{String: "Asia/Tokyo", Valid: true}},
+			{ID: "la", Timezone: sql.NullString{String: "America/Los_Angeles", Valid: true}},
+		},
+		opens: []db.ListContactOpenHoursRow{
+			{ContactID: sql.NullString{String: "la", Valid: true}, Hour: 18, Opens: 3},
+		},
+	}
+
+	local, err := Load(context.Background(), store, "org", ModeLocalTime, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	local.spread = noSpread
+	if got, want := local.At("tokyo"), time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("tokyo due %v, want %v", got, want)
+	}
+	if got, want := local.At("la"), time.Date(2026, 3, 2, 17, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("la due %v, want %v", got, want)
+	}
+	if got := local.At("unknown"); !got.IsZero() {
+		t.Errorf("contact without a time zone due %v, want right away", got)
+	}
+
+	optimal, err := Load(context.Background(), store, "org", ModeOptimal, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	optimal.spread = noSpread
+	if got, want := optimal.At("la"), time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("la optimal due %v, want %v", got, want)
+	}
+	if got, want := optimal.At("unknown"), time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("contact without history due %v, want the org best hour %v", got, want)
+	}
+
+	immediate, err := Load(context.Background(), store, "org", ModeImmediate, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := immediate.At("la"); !got.IsZero() {
+		t.Errorf("immediate send due %v, want right away", got)
+	}
+}
+
+func TestLoadTimezone(t *testing.T) {
+	if _, err := LoadTimezone("Europe/Berlin"); err != nil {
+		t.Errorf("Europe/Berlin: %v", err)
+	}
+	for _, name := range []string{"", "Local", "Mars/Olympus"} {
+		if _, err := LoadTimezone(name); err == nil {
+			t.Errorf("LoadTimezone(%q) succeeded", name)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package tracking
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestLinkSignerClickURL(t *testing.T) {
+	signer := NewLinkSigner([]byte("test-key"))
+	clickURL := signer.ClickURL("https://outlet.test", "tok123", "https://example.com/a?b=1&c=2", "Read more")
+
+	u, err := url.Parse(clickURL)
+	if err != nil {
+		t.Fatalf("ClickURL() returned an invalid URL: %v", err)
+	}
+	if !strings.HasPrefix(clickURL, "https://outlet.test/api/e/c/tok123?") {
+		t.Errorf("ClickURL() = %s, want the click endpoint for the token", clickURL)
+	}
+
+	q := u.Query()
+	if q.Get("url") != "https://example.com/a?b=1&c=2" || q.Get("n") != "Read more" {
+		t.Errorf("ClickURL() query = %v", q)
+	}
+	if err := signer.Verify("tok123", q.Get("url"), q.Get("n"), q.Get("s")); err != nil {
+		t.Errorf("Verify() of a signed link = %v", err)
+	}
+}
+
+func TestLinkSignerRejectsTampering(t *testing.T) {
+	signer := NewLinkSigner([]byte("test-key"))
+	u, _ := url.Parse(signer.ClickURL("https://outlet.test", "tok123", "https://example.com/", "Home"))
+	sig := u.Query().Get("s")
+
+	tests := []struct {
+		name                    string
+		token, target, linkName string
+		signature               string
+	}{
+		{"other target", "tok123", "https://evil.example/", "Home", sig},
+		{"other token", "tok456", "https://example.com/", "Home", sig},
+		{"other name", "tok123", "https://example.com/", "Away", sig},
+		{"unsigned", "tok123", "https://example.com/", "Home", ""},
+		{"garbage signature", "tok123", "https://example.com/", "Home", "!!!"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := signer.Verify(tt.token, tt.target, tt.linkName, tt.signature); !errors.Is(err, ErrInvalidLink) {
+				t.Errorf("Verify() = %v, want ErrInvalidLink", err)
+			}
+		})
+	}
+
+	other := NewLinkSigner([]byte("other-key"))
+	if err := other.Verify("tok123", "https://example.com/", "Home", sig); !errors.Is(err, ErrInvalidLink) {
+		t.Errorf("Verify() with another key = %v, want ErrInvalidLink", err)
+	}
+
+	var none *LinkSigner
+	if err := none.Verify("tok123", "https://example.com/", "Home", sig); !errors.Is(err, ErrInvalidLink) {
+		t.Errorf("Verify() without a signer = %v, want ErrInvalidLink", err)
+	}
+}
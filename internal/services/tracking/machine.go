@@ -0,0 +1,105 @@
+package tracking
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// minHumanDelay is how soon after sending a person could plausibly open an
+// email or click a link in it; anything faster is a scanner
+const minHumanDelay = 2 * time.Second
+
+// machineAgents are user-agent fragments of image proxies, link scanners and
+// other automated clients
+var machineAgents = []string{
+	"googleimageproxy",
+	"yahoomailproxy",
+	"ggpht.com",
+	"barracuda",
+	"mimecast",
+	"proofpoint",
+	"symantec",
+	"forcepoint",
+	"trendmicro",
+	"bitdefender",
+	"safelinks",
+	"bot",
+	"crawler",
+	"spider",
+	"preview",
+	"python-requests",
+	"python-urllib",
+	"go-http-client",
+	"curl/",
+	"wget/",
+	"java/",
+	"okhttp",
+	"headlesschrome",
+}
+
+// machineNetworks are address ranges of mail privacy proxies
+var machineNetworks = parseNetworks(
+	"17.0.0.0/8",     // Apple Mail Privacy Protection
+	"66.102.0.0/20",  // Gmail image proxy
+	"66.249.80.0/20", // Gmail image proxy
+	"74.125.0.0/16",  // Gmail image proxy
+)
+
+// IsMachine reports whether a tracking hit was made by an automated client
+// rather than a person. sentAt is when the email was sent; zero skips the
+// timing check. Opens and clicks reported through the API carry no request
+// method and are taken as they are.
+func IsMachine(client Client, sentAt, now time.Time) bool {
+	if client.Method == "" {
+		return false
+	}
+	if client.Method == http.MethodHead {
+		return true
+	}
+	if !sentAt.IsZero() && now.Sub(sentAt) < minHumanDelay {
+		return true
+	}
+	return isMachineAgent(client.UserAgent) || isMachineIP(client.IP)
+}
+
+func isMachineAgent(userAgent string) bool {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	// Apple's privacy proxy sends a bare Mozilla/5.0; people's mail clients
+	// always say more
+	if ua == "" || ua == "mozilla/5.0" {
+		return true
+	}
+	for _, agent := range machineAgents {
+		if strings.Contains(ua, agent) {
+			return true
+		}
+	}
+	return false
+}
+
+func isMachineIP(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, network := range machineNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
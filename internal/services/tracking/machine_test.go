@@ -0,0 +1,38 @@
+package tracking
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIsMachine(t *testing.T) {
+	const browser = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko)"
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	sent := now.Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		client Client
+		sentAt time.Time
+		want   bool
+	}{
+		{"person", Client{UserAgent: browser, IP: "203.0.113.7", Method: http.MethodGet}, sent, false},
+		{"api report", Client{}, now, false},
+		{"head request", Client{UserAgent: browser, IP: "203.0.113.7", Method: http.MethodHead}, sent, true},
+		{"too soon after send", Client{UserAgent: browser, IP: "203.0.113.7", Method: http.MethodGet}, now.Add(-time.Second), true},
+		{"unknown send time", Client{UserAgent: browser, IP: "203.0.113.7", Method: http.MethodGet}, time.Time{}, false},
+		{"gmail proxy", Client{UserAgent: "Mozilla/5.0 (Windows NT 5.1; rv:11.0) Gecko Firefox/11.0 (via ggpht.com GoogleImageProxy)", Method: http.MethodGet}, sent, true},
+		{"apple privacy proxy", Client{UserAgent: "Mozilla/5.0", IP: "17.58.100.1", Method: http.MethodGet}, sent, true},
+		{"apple address", Client{UserAgent: browser, IP: "17.58.100.1", Method: http.MethodGet}, sent, true},
+		{"link scanner", Client{UserAgent: "Barracuda Sentinel (EE)", IP: "203.0.113.7", Method: http.MethodGet}, sent, true},
+		{"empty user agent", Client{IP: "203.0.113.7", Method: http.MethodGet}, sent, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsMachine(tt.client, tt.sentAt, now); got != tt.want {
+				t.Errorf("IsMachine() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
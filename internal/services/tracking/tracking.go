@@ -4,8 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/events"
+	"github.com/outlet-sh/outlet/internal/services/geo"
+
+	"github.com/google/uuid"
 )
 
 var (
@@ -15,7 +20,10 @@ var (
 
 // Service handles email tracking operations
 type Service struct {
-	db *db.Queries
+	db     *db.Queries
+	events *events.Subject
+	links  *LinkSigner
+	geo    *geo.Locator
 }
 
 // New creates a new tracking service
@@ -23,41 +31,271 @@ func New(db *db.Queries) *Service {
 	return &Service{db: db}
 }
 
-// RecordOpen records an email open event by tracking token
-func (s *Service) RecordOpen(ctx context.Context, token string) error {
-	if token == "" {
-		return ErrInvalidToken
+// SetEvents enables emitting opened, clicked and unsubscribed events
+func (s *Service) SetEvents(eventBus *events.Subject) {
+	s.events = eventBus
+}
+
+// SetLinkSigner sets the signer click links are verified with. Without one
+// every click link is rejected.
+func (s *Service) SetLinkSigner(links *LinkSigner) {
+	s.links = links
+}
+
+// SetLocator enables inferring contact time zones from click IPs
+func (s *Service) SetLocator(locator *geo.Locator) {
+	s.geo = locator
+}
+
+// Send types a tracking token can belong to
+const (
+	SendSequence      = "sequence"
+	SendCampaign      = "campaign"
+	SendTransactional = "transactional"
+)
+
+// Client identifies who opened an email or clicked a link
+type Client struct {
+	UserAgent string
+	IP        string
+	Method    string // HTTP method of the tracking request
+}
+
+// Click is a click on a tracked link
+type Click struct {
+	URL      string
+	LinkName string
+	Client
+}
+
+// RecordOpen records an open of a sequence, campaign or transactional email
+func (s *Service) RecordOpen(ctx context.Context, token string, client Client) error {
+	send, err := s.resolve(ctx, token)
+	if err != nil {
+		return err
 	}
 
-	emailRecord, err := s.db.GetEmailByTrackingToken(ctx, sql.NullString{String: token, Valid: true})
+	switch send.SendType {
+	case SendSequence:
+		err = s.db.RecordEmailOpen(ctx, send.ID)
+	case SendCampaign:
+		err = s.recordCampaignOpen(ctx, send)
+	case SendTransactional:
+		err = s.db.RecordTransactionalOpen(ctx, send.ID)
+	}
 	if err != nil {
-		return ErrNotFound
+		return err
+	}
+
+	machine := IsMachine(client, sentAt(send), time.Now())
+	if err := s.recordEvent(ctx, send, "opened", Click{Client: client}, machine); err != nil {
+		return err
+	}
+	s.emitEmailEvent(send, events.TopicEmailOpened, "opened", "", machine)
+	return nil
+}
+
+// VerifyClick checks that a click link's target was signed for the token
+func (s *Service) VerifyClick(token, target, name, signature string) error {
+	return s.links.Verify(token, target, name, signature)
+}
+
+// RecordClick records a click on a link in a sequence, campaign or
+// transactional email
+func (s *Service) RecordClick(ctx context.Context, token string, click Click) error {
+	send, err := s.resolve(ctx, token)
+	if err != nil {
+		return err
 	}
 
-	return s.db.RecordEmailOpen(ctx, emailRecord.ID)
+	switch send.SendType {
+	case SendSequence:
+		err = s.recordEmailClick(ctx, send, click)
+	case SendCampaign:
+		err = s.recordCampaignClick(ctx, send, click)
+	case SendTransactional:
+		err = s.db.RecordTransactionalClick(ctx, send.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	machine := IsMachine(click.Client, sentAt(send), time.Now())
+	if err := s.recordEvent(ctx, send, "clicked", click, machine); err != nil {
+		return err
+	}
+	// Scanners click from their own networks, not the contact's
+	if !machine {
+		if err := s.inferTimezone(ctx, send, click.IP); err != nil {
+			return err
+		}
+	}
+	s.emitEmailEvent(send, events.TopicEmailClicked, "clicked", click.URL, machine)
+	return nil
 }
 
-// RecordClick records an email click event by tracking token
-func (s *Service) RecordClick(ctx context.Context, token string) error {
+// resolve finds the send behind a tracking token
+func (s *Service) resolve(ctx context.Context, token string) (db.ResolveTrackingTokenRow, error) {
 	if token == "" {
-		return ErrInvalidToken
+		return db.ResolveTrackingTokenRow{}, ErrInvalidToken
+	}
+
+	send, err := s.db.ResolveTrackingToken(ctx, sql.NullString{String: token, Valid: true})
+	if errors.Is(err, sql.ErrNoRows) {
+		return send, ErrNotFound
+	}
+	return send, err
+}
+
+func (s *Service) recordEmailClick(ctx context.Context, send db.ResolveTrackingTokenRow, click Click) error {
+	if err := s.db.RecordEmailClick(ctx, send.ID); err != nil {
+		return err
+	}
+	if click.URL == "" {
+		return nil
+	}
+
+	_, err := s.db.CreateEmailClick(ctx, db.CreateEmailClickParams{
+		ID:           uuid.New().String(),
+		EmailQueueID: sql.NullString{String: send.ID, Valid: true},
+		ContactID:    send.ContactID,
+		LinkUrl:      click.URL,
+		LinkName:     nullString(click.LinkName),
+		UserAgent:    nullString(click.UserAgent),
+		IpAddress:    nullString(click.IP),
+	})
+	return err
+}
+
+// recordCampaignOpen records an open of a campaign send. opened_count
+// counts recipients, not opens: only the request that sets opened_at
+// increments it, so concurrent opens of one send count once.
+func (s *Service) recordCampaignOpen(ctx context.Context, send db.ResolveTrackingTokenRow) error {
+	first, err := s.db.MarkCampaignSendOpened(ctx, send.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.db.RecordCampaignOpen(ctx, send.ID); err != nil {
+		return err
 	}
+	if first == 0 {
+		return nil
+	}
+	return s.db.IncrementCampaignOpened(ctx, send.CampaignID)
+}
 
-	emailRecord, err := s.db.GetEmailByTrackingToken(ctx, sql.NullString{String: token, Valid: true})
+func (s *Service) recordCampaignClick(ctx context.Context, send db.ResolveTrackingTokenRow, click Click) error {
+	// clicked_count counts recipients, not clicks
+	first, err := s.db.MarkCampaignSendClicked(ctx, send.ID)
 	if err != nil {
-		return ErrNotFound
+		return err
+	}
+	if err := s.db.RecordCampaignClick(ctx, send.ID); err != nil {
+		return err
+	}
+	if first > 0 {
+		if err := s.db.IncrementCampaignClicked(ctx, send.CampaignID); err != nil {
+			return err
+		}
+	}
+	if click.URL == "" {
+		return nil
+	}
+
+	_, err = s.db.CreateCampaignClick(ctx, db.CreateCampaignClickParams{
+		ID:             uuid.New().String(),
+		CampaignSendID: send.ID,
+		CampaignID:     send.CampaignID,
+		ContactID:      send.ContactID.String,
+		LinkUrl:        click.URL,
+		LinkName:       nullString(click.LinkName),
+		UserAgent:      nullString(click.UserAgent),
+		IpAddress:      nullString(click.IP),
+	})
+	return err
+}
+
+// recordEvent adds an open or click to the send's event timeline
+func (s *Service) recordEvent(ctx context.Context, send db.ResolveTrackingTokenRow, event string, click Click, machine bool) error {
+	var flag int64
+	if machine {
+		flag = 1
+	}
+	if send.OrgID == "" {
+		return nil
+	}
+	return s.db.CreateTrackingEvent(ctx, db.CreateTrackingEventParams{
+		ID:        uuid.New().String(),
+		OrgID:     send.OrgID,
+		SendType:  send.SendType,
+		SendID:    send.ID,
+		ContactID: send.ContactID,
+		Event:     event,
+		LinkUrl:   nullString(click.URL),
+		LinkName:  nullString(click.LinkName),
+		UserAgent: nullString(click.UserAgent),
+		IpAddress: nullString(click.IP),
+		Machine:   flag,
+	})
+}
+
+// inferTimezone sets the time zone of a contact who has none from where
+// they clicked
+func (s *Service) inferTimezone(ctx context.Context, send db.ResolveTrackingTokenRow, ip string) error {
+	if !send.ContactID.Valid {
+		return nil
+	}
+	timezone := s.geo.Timezone(ip)
+	if timezone == "" {
+		return nil
+	}
+	return s.db.InferContactTimezone(ctx, db.InferContactTimezoneParams{
+		Timezone: sql.NullString{String: timezone, Valid: true},
+		ID:       send.ContactID.String,
+	})
+}
+
+// sentAt returns when the send went out, or zero if it is not known
+func sentAt(send db.ResolveTrackingTokenRow) time.Time {
+	if !send.SentAt.Valid {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.DateTime, send.SentAt.String)
+	if err != nil {
+		t, _ = time.Parse(time.RFC3339, send.SentAt.String)
 	}
+	return t
+}
 
-	return s.db.RecordEmailClick(ctx, emailRecord.ID)
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
 }
 
-// Unsubscribe unsubscribes a contact by tracking token and cancels pending emails
+// Unsubscribe unsubscribes the contact behind a sequence or campaign tracking
+// token and cancels pending emails
 func (s *Service) Unsubscribe(ctx context.Context, token string) error {
+	return s.unsubscribe(ctx, token, "unsubscribe_link")
+}
+
+// OneClickUnsubscribe handles an RFC 8058 List-Unsubscribe-Post request from a
+// mailbox provider. It unsubscribes the same way as the link, but is recorded
+// with its own event source.
+func (s *Service) OneClickUnsubscribe(ctx context.Context, token string) error {
+	return s.unsubscribe(ctx, token, "list_unsubscribe")
+}
+
+// MailtoUnsubscribe handles an unsubscribe request sent by email to the
+// List-Unsubscribe mailto target, with the token in the subject
+func (s *Service) MailtoUnsubscribe(ctx context.Context, token string) error {
+	return s.unsubscribe(ctx, token, "list_unsubscribe_mailto")
+}
+
+func (s *Service) unsubscribe(ctx context.Context, token, source string) error {
 	if token == "" {
 		return ErrInvalidToken
 	}
 
-	contact, err := s.db.GetContactByTrackingToken(ctx, sql.NullString{String: token, Valid: true})
+	contact, err := s.contactForToken(ctx, token)
 	if err != nil {
 		return ErrNotFound
 	}
@@ -66,5 +304,51 @@ func (s *Service) Unsubscribe(ctx context.Context, token string) error {
 		return err
 	}
 
-	return s.db.CancelEmailsForContact(ctx, sql.NullString{String: contact.ID, Valid: true})
+	if err := s.db.CancelEmailsForContact(ctx, sql.NullString{String: contact.ID, Valid: true}); err != nil {
+		return err
+	}
+
+	if s.events != nil && contact.OrgID.Valid {
+		_ = events.Emit(s.events, events.TopicContactUnsubscribed, events.ContactEvent{
+			OrgID:     contact.OrgID.String,
+			ContactID: contact.ID,
+			Email:     contact.Email,
+			Source:    source,
+			Timestamp: time.Now(),
+		})
+	}
+	return nil
+}
+
+// contactForToken resolves the contact a sequence, campaign or transactional
+// email was sent to
+func (s *Service) contactForToken(ctx context.Context, token string) (db.Contact, error) {
+	send, err := s.resolve(ctx, token)
+	if err != nil {
+		return db.Contact{}, err
+	}
+	if !send.ContactID.Valid {
+		return db.Contact{}, ErrNotFound
+	}
+	return s.db.GetContact(ctx, send.ContactID.String)
+}
+
+// emitEmailEvent publishes an engagement event for a send
+func (s *Service) emitEmailEvent(send db.ResolveTrackingTokenRow, topic, status, url string, machine bool) {
+	if s.events == nil || send.OrgID == "" {
+		return
+	}
+
+	_ = events.Emit(s.events, topic, events.EmailEvent{
+		OrgID:      send.OrgID,
+		EmailID:    send.ID,
+		ContactID:  send.ContactID.String,
+		SequenceID: send.SequenceID,
+		CampaignID: send.CampaignID,
+		Subject:    send.Subject,
+		Status:     status,
+		ClickedURL: url,
+		Machine:    machine,
+		Timestamp:  time.Now(),
+	})
 }
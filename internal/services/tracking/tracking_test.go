@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/db/dbtest"
 )
 
 // mockQueries implements the database methods needed by tracking service
@@ -70,7 +71,7 @@ func TestNew(t *testing.T) {
 
 func TestRecordOpen_EmptyToken(t *testing.T) {
 	svc := New(nil)
-	err := svc.RecordOpen(context.Background(), "")
+	err := svc.RecordOpen(context.Background(), "", Client{})
 	if err != ErrInvalidToken {
 		t.Errorf("Expected ErrInvalidToken, got %v", err)
 	}
@@ -87,7 +88,7 @@ func TestRecordOpen_TokenNotFound(t *testing.T) {
 	// Test with actual service using nil db (will fail on db call)
 	// For proper testing, we need interface-based mocking
 	// This test verifies the empty token check
-	err := svc.RecordOpen(context.Background(), "")
+	err := svc.RecordOpen(context.Background(), "", Client{})
 	if err != ErrInvalidToken {
 		t.Errorf("Expected ErrInvalidToken for empty token, got %v", err)
 	}
@@ -101,7 +102,7 @@ func TestRecordOpen_TokenNotFound(t *testing.T) {
 
 func TestRecordClick_EmptyToken(t *testing.T) {
 	svc := New(nil)
-	err := svc.RecordClick(context.Background(), "")
+	err := svc.RecordClick(context.Background(), "", Click{})
 	if err != ErrInvalidToken {
 		t.Errorf("Expected ErrInvalidToken, got %v", err)
 	}
@@ -302,7 +303,7 @@ func TestTokenValidation(t *testing.T) {
 			// We can only test empty token without a real DB
 			// Non-empty tokens will fail on DB call, not validation
 			if tt.token == "" {
-				err := svc.RecordOpen(context.Background(), tt.token)
+				err := svc.RecordOpen(context.Background(), tt.token, Client{})
 				if err != tt.wantErr {
 					t.Errorf("RecordOpen(%q) error = %v, want %v", tt.token, err, tt.wantErr)
 				}
@@ -310,3 +311,42 @@ func TestTokenValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestCampaignOpensAndClicks_CountRecipientsOnce(t *testing.T) {
+	store := dbtest.New(t)
+	dbtest.Exec(t, store, `INSERT INTO organizations (id, name, slug, api_key) VALUES ('org', 'Org', 'org', 'key')`)
+	dbtest.Exec(t, store, `INSERT INTO contacts (id, org_id, name, email, status) VALUES ('ann', 'org', 'Ann', 'ann@example.com', 'active')`)
+	dbtest.Exec(t, store, `INSERT INTO email_campaigns (id, org_id, name, subject, html_body) VALUES ('camp', 'org', 'Launch', 'Hi', 'b')`)
+	dbtest.Exec(t, store, `INSERT INTO campaign_sends (id, campaign_id, contact_id, status, tracking_token, sent_at) VALUES ('send', 'camp', 'ann', 'sent', 'tok', datetime('now'))`)
+
+	ctx := context.Background()
+	s := New(store.Queries)
+	send, err := s.resolve(ctx, "tok")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Two concurrent requests both resolve the token before either records
+	for i := 0; i < 2; i++ {
+		if err := s.recordCampaignOpen(ctx, send); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.recordCampaignClick(ctx, send, Click{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var opened, clicked, opens, clicks int
+	if err := store.GetDB().QueryRow(`SELECT opened_count, clicked_count FROM email_campaigns WHERE id = 'camp'`).Scan(&opened, &clicked); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.GetDB().QueryRow(`SELECT open_count, click_count FROM campaign_sends WHERE id = 'send'`).Scan(&opens, &clicks); err != nil {
+		t.Fatal(err)
+	}
+	if opened != 1 || clicked != 1 {
+		t.Errorf("opened_count, clicked_count = %d, %d, want 1, 1", opened, clicked)
+	}
+	if opens != 2 || clicks != 2 {
+		t.Errorf("open_count, click_count = %d, %d, want 2, 2", opens, clicks)
+	}
+}
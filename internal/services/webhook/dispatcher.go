@@ -64,6 +64,7 @@ func (d *Dispatcher) Start(ctx context.Context) error {
 	eventTopics := []string{
 		// Contact events
 		events.TopicContactCreated,
+		events.TopicContactSubscribed,
 		events.TopicContactUnsubscribed,
 
 		// Email events
@@ -1,8 +1,10 @@
 package smtp
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 
 	"github.com/outlet-sh/outlet/internal/db"
@@ -84,11 +86,10 @@ func (s *Session) authPlain(username, password string) error {
 	return nil
 }
 
-// Mail is called for MAIL FROM command
+// Mail is called for MAIL FROM command. Unauthenticated senders are let
+// through so mailbox providers can deliver List-Unsubscribe mailto requests;
+// Data rejects anything else they send.
 func (s *Session) Mail(from string, opts *smtp.MailOptions) error {
-	if !s.authed {
-		return errors.New("authentication required")
-	}
 	s.from = from
 	logx.Debugf("SMTP: MAIL FROM: %s (org=%s)", from, s.org.Slug)
 	return nil
@@ -96,9 +97,6 @@ func (s *Session) Mail(from string, opts *smtp.MailOptions) error {
 
 // Rcpt is called for RCPT TO command
 func (s *Session) Rcpt(to string, opts *smtp.RcptOptions) error {
-	if !s.authed {
-		return errors.New("authentication required")
-	}
 	s.recipients = append(s.recipients, to)
 	logx.Debugf("SMTP: RCPT TO: %s (org=%s)", to, s.org.Slug)
 	return nil
@@ -106,16 +104,30 @@ func (s *Session) Rcpt(to string, opts *smtp.RcptOptions) error {
 
 // Data is called when email data is received
 func (s *Session) Data(r io.Reader) error {
-	if !s.authed {
-		return errors.New("authentication required")
-	}
 	if len(s.recipients) == 0 {
 		return errors.New("no recipients specified")
 	}
 
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to read email data: %w", err)
+	}
+
+	// List-Unsubscribe mailto requests are accepted with or without auth
+	if handled, err := handleUnsubscribe(context.Background(), s.svcCtx, data); handled {
+		if err != nil {
+			logx.Errorf("SMTP: Failed to process unsubscribe request from %s: %v", s.from, err)
+		}
+		return err
+	}
+
+	if !s.authed {
+		return errors.New("authentication required")
+	}
+
 	// Process the email
 	processor := NewEmailProcessor(s.svcCtx, s.org, s.from, s.recipients)
-	messageID, err := processor.Process(r)
+	messageID, err := processor.Process(bytes.NewReader(data))
 	if err != nil {
 		logx.Errorf("SMTP: Failed to process email from %s to %v: %v", s.from, s.recipients, err)
 		return err
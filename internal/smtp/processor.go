@@ -5,16 +5,22 @@ import (
 	"context"
 	"crypto/rand"
 	"database/sql"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"html"
 	"io"
 	"mime"
 	"mime/multipart"
+	"mime/quotedprintable"
 	"net/mail"
+	"net/textproto"
 	"strings"
+	"time"
 
 	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/services/email"
 	"github.com/outlet-sh/outlet/internal/svc"
 
 	"github.com/google/uuid"
@@ -39,7 +45,7 @@ func NewEmailProcessor(svcCtx *svc.ServiceContext, org db.Organization, from str
 	}
 }
 
-// Process parses and sends an email
+// Process parses an email and queues it for each recipient
 func (p *EmailProcessor) Process(r io.Reader) (string, error) {
 	// Read all data
 	data, err := io.ReadAll(r)
@@ -67,19 +73,23 @@ func (p *EmailProcessor) Process(r io.Reader) (string, error) {
 	// Parse Outlet custom headers
 	headers := ParseOutletHeaders(msg)
 
-	// Extract body (HTML and plain text)
-	htmlBody, plainText, err := p.extractBody(msg)
+	// Extract body (HTML and plain text) and attachments
+	htmlBody, plainText, attachments, err := p.extractBody(msg)
 	if err != nil {
 		return "", fmt.Errorf("failed to extract body: %w", err)
 	}
+	attachments, err = email.NormalizeAttachments(attachments)
+	if err != nil {
+		return "", fmt.Errorf("invalid attachments: %w", err)
+	}
 
 	// Generate tracking token
 	trackingToken := p.generateTrackingToken()
 
-	// Send to each recipient
+	// Queue for each recipient
 	for _, recipient := range p.recipients {
-		if err := p.sendToRecipient(recipient, subject, htmlBody, plainText, headers, trackingToken); err != nil {
-			logx.Errorf("SMTP: Failed to send to %s: %v", recipient, err)
+		if err := p.queueForRecipient(recipient, subject, htmlBody, plainText, attachments, headers, trackingToken); err != nil {
+			logx.Errorf("SMTP: Failed to queue for %s: %v", recipient, err)
 			// Continue with other recipients
 		}
 	}
@@ -87,9 +97,38 @@ func (p *EmailProcessor) Process(r io.Reader) (string, error) {
 	return trackingToken, nil
 }
 
-// extractBody extracts HTML and plain text body from the email
-func (p *EmailProcessor) extractBody(msg *mail.Message) (htmlBody, plainText string, err error) {
-	contentType := msg.Header.Get("Content-Type")
+// maxMIMEDepth limits how deeply multipart bodies are walked
+const maxMIMEDepth = 10
+
+// messageBody collects the content of a received message
+type messageBody struct {
+	html        string
+	text        string
+	attachments []email.Attachment
+}
+
+// extractBody extracts the HTML and plain text bodies from the email along
+// with its attachments and inline parts
+func (p *EmailProcessor) extractBody(msg *mail.Message) (htmlBody, plainText string, attachments []email.Attachment, err error) {
+	var body messageBody
+	if err := body.walk(textproto.MIMEHeader(msg.Header), msg.Body, 0); err != nil {
+		return "", "", nil, err
+	}
+
+	// If we only have plain text, wrap it in basic HTML
+	if body.html == "" && body.text != "" {
+		body.html = "<pre>" + html.EscapeString(body.text) + "</pre>"
+	}
+
+	return body.html, body.text, body.attachments, nil
+}
+
+// walk reads one MIME entity, descending into multipart bodies. The first
+// text/html and text/plain parts are the message body; every other part,
+// and any part marked as an attachment or with a Content-ID, is kept as an
+// attachment.
+func (b *messageBody) walk(header textproto.MIMEHeader, r io.Reader, depth int) error {
+	contentType := header.Get("Content-Type")
 	if contentType == "" {
 		contentType = "text/plain"
 	}
@@ -97,59 +136,79 @@ func (p *EmailProcessor) extractBody(msg *mail.Message) (htmlBody, plainText str
 	mediaType, params, err := mime.ParseMediaType(contentType)
 	if err != nil {
 		// Treat as plain text if we can't parse
-		body, _ := io.ReadAll(msg.Body)
-		return "", string(body), nil
+		mediaType = "text/plain"
 	}
 
 	if strings.HasPrefix(mediaType, "multipart/") {
-		// Parse multipart message
-		boundary := params["boundary"]
-		if boundary == "" {
-			body, _ := io.ReadAll(msg.Body)
-			return "", string(body), nil
+		if params["boundary"] == "" || depth >= maxMIMEDepth {
+			return nil
 		}
-
-		mr := multipart.NewReader(msg.Body, boundary)
+		mr := multipart.NewReader(r, params["boundary"])
 		for {
 			part, err := mr.NextPart()
 			if err == io.EOF {
-				break
+				return nil
 			}
 			if err != nil {
-				return htmlBody, plainText, nil
+				// Keep what was parsed before the malformed part
+				return nil
+			}
+			if err := b.walk(part.Header, part, depth+1); err != nil {
+				return err
 			}
+		}
+	}
 
-			partContentType := part.Header.Get("Content-Type")
-			partMediaType, _, _ := mime.ParseMediaType(partContentType)
+	// multipart.Reader already decodes quoted-printable parts
+	var data io.Reader = r
+	switch strings.ToLower(header.Get("Content-Transfer-Encoding")) {
+	case "base64":
+		data = base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		data = quotedprintable.NewReader(r)
+	}
+	content, err := io.ReadAll(data)
+	if err != nil {
+		return fmt.Errorf("failed to read %s part: %w", mediaType, err)
+	}
 
-			partBody, _ := io.ReadAll(part)
+	disposition, dispParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	filename := dispParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	if decoded, err := new(mime.WordDecoder).DecodeHeader(filename); err == nil {
+		filename = decoded
+	}
+	contentID := strings.Trim(header.Get("Content-ID"), "<> ")
 
-			switch partMediaType {
-			case "text/html":
-				htmlBody = string(partBody)
-			case "text/plain":
-				plainText = string(partBody)
+	if disposition != "attachment" && filename == "" && contentID == "" {
+		switch mediaType {
+		case "text/html":
+			if b.html == "" {
+				b.html = string(content)
 			}
+			return nil
+		case "text/plain":
+			if b.text == "" {
+				b.text = string(content)
+			}
+			return nil
 		}
-	} else if mediaType == "text/html" {
-		body, _ := io.ReadAll(msg.Body)
-		htmlBody = string(body)
-	} else {
-		// Default to plain text
-		body, _ := io.ReadAll(msg.Body)
-		plainText = string(body)
 	}
 
-	// If we only have plain text, wrap it in basic HTML
-	if htmlBody == "" && plainText != "" {
-		htmlBody = "<pre>" + plainText + "</pre>"
-	}
-
-	return htmlBody, plainText, nil
+	b.attachments = append(b.attachments, email.Attachment{
+		Filename:    filename,
+		ContentType: mediaType,
+		Data:        content,
+		ContentID:   contentID,
+	})
+	return nil
 }
 
-// sendToRecipient sends the email to a single recipient
-func (p *EmailProcessor) sendToRecipient(recipient, subject, htmlBody, plainText string, headers *OutletHeaders, trackingToken string) error {
+// queueForRecipient records a transactional send for a single recipient and
+// queues it for the dispatcher
+func (p *EmailProcessor) queueForRecipient(recipient, subject, htmlBody, plainText string, attachments []email.Attachment, headers *OutletHeaders, trackingToken string) error {
 	ctx := context.Background()
 
 	// Prepare context data (meta + tags)
@@ -199,27 +258,29 @@ func (p *EmailProcessor) sendToRecipient(recipient, subject, htmlBody, plainText
 		fromName = orgSettings.FromName.String
 	}
 
-	// Send the email
-	sendErr := p.svcCtx.EmailService.SendEmailFrom(ctx, fromEmail, fromName, recipient, subject, htmlBody)
+	// Queue the email
+	queueErr := p.svcCtx.EmailService.QueueTransactional(ctx, send.ID, email.Message{
+		FromName:    fromName,
+		FromEmail:   fromEmail,
+		To:          recipient,
+		Subject:     subject,
+		HTMLBody:    htmlBody,
+		TextBody:    plainText,
+		Attachments: attachments,
+		OrgID:       p.org.ID,
+	}, time.Time{})
 
-	if sendErr != nil {
+	if queueErr != nil {
 		// Update status to failed
 		_ = p.svcCtx.DB.UpdateTransactionalSendStatus(ctx, db.UpdateTransactionalSendStatusParams{
 			ID:           send.ID,
 			Status:       sql.NullString{String: "failed", Valid: true},
-			ErrorMessage: sql.NullString{String: sendErr.Error(), Valid: true},
+			ErrorMessage: sql.NullString{String: queueErr.Error(), Valid: true},
 		})
-		return fmt.Errorf("failed to send: %w", sendErr)
+		return fmt.Errorf("failed to queue: %w", queueErr)
 	}
 
-	// Update status to sent
-	_ = p.svcCtx.DB.UpdateTransactionalSendStatus(ctx, db.UpdateTransactionalSendStatusParams{
-		ID:           send.ID,
-		Status:       sql.NullString{String: "sent", Valid: true},
-		ErrorMessage: sql.NullString{},
-	})
-
-	logx.Infof("SMTP: Email sent to=%s subject=%q org=%s msgId=%s", recipient, subject, p.org.Slug, trackingToken)
+	logx.Infof("SMTP: Email queued to=%s subject=%q org=%s msgId=%s", recipient, subject, p.org.Slug, trackingToken)
 	return nil
 }
 
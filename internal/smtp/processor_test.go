@@ -0,0 +1,92 @@
+package smtp
+
+import (
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseMessage(t *testing.T, raw string) *mail.Message {
+	t.Helper()
+	msg, err := mail.ReadMessage(strings.NewReader(strings.ReplaceAll(raw, "\n", "\r\n")))
+	require.NoError(t, err)
+	return msg
+}
+
+func TestExtractBody_NestedMultipart(t *testing.T) {
+	msg := parseMessage(t, `From: app@example.com
+To: user@example.com
+Subject: Invoice
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="outer"
+
+--outer
+Content-Type: multipart/related; boundary="rel"
+
+--rel
+Content-Type: multipart/alternative; boundary="alt"
+
+--alt
+Content-Type: text/plain; charset=UTF-8
+Content-Transfer-Encoding: quoted-printable
+
+Your invoice =E2=82=AC10
+--alt
+Content-Type: text/html; charset=UTF-8
+
+<p>Your invoice <img src="cid:logo@example.com"></p>
+--alt--
+
+--rel
+Content-Type: image/png
+Content-Transfer-Encoding: base64
+Content-ID: <logo@example.com>
+
+iVBORw0KGgo=
+--rel--
+
+--outer
+Content-Type: application/pdf; name="invoice.pdf"
+Content-Disposition: attachment; filename="invoice.pdf"
+Content-Transfer-Encoding: base64
+
+JVBERi0xLjQK
+--outer--
+`)
+
+	p := &EmailProcessor{}
+	htmlBody, plainText, attachments, err := p.extractBody(msg)
+	require.NoError(t, err)
+
+	assert.Equal(t, `<p>Your invoice <img src="cid:logo@example.com"></p>`, htmlBody)
+	assert.Equal(t, "Your invoice €10", plainText)
+	require.Len(t, attachments, 2)
+
+	assert.Equal(t, "image/png", attachments[0].ContentType)
+	assert.Equal(t, "logo@example.com", attachments[0].ContentID)
+	assert.Equal(t, "\x89PNG\r\n\x1a\n", string(attachments[0].Data))
+
+	assert.Equal(t, "invoice.pdf", attachments[1].Filename)
+	assert.Equal(t, "application/pdf", attachments[1].ContentType)
+	assert.Equal(t, "%PDF-1.4\n", string(attachments[1].Data))
+}
+
+func TestExtractBody_PlainTextOnly(t *testing.T) {
+	msg := parseMessage(t, `From: app@example.com
+To: user@example.com
+Subject: Hi
+Content-Type: text/plain; charset=UTF-8
+
+a < b`)
+
+	p := &EmailProcessor{}
+	htmlBody, plainText, attachments, err := p.extractBody(msg)
+	require.NoError(t, err)
+
+	assert.Equal(t, "a < b", plainText)
+	assert.Equal(t, "<pre>a &lt; b</pre>", htmlBody)
+	assert.Empty(t, attachments)
+}
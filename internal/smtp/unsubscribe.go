@@ -0,0 +1,61 @@
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime"
+	"net/mail"
+	"strings"
+
+	"github.com/outlet-sh/outlet/internal/services/tracking"
+	"github.com/outlet-sh/outlet/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// unsubscribeToken returns the tracking token from the subject of a
+// List-Unsubscribe mailto request, "unsubscribe <token>"
+func unsubscribeToken(subject string) (string, bool) {
+	fields := strings.Fields(subject)
+	if len(fields) != 2 || !strings.EqualFold(fields[0], "unsubscribe") {
+		return "", false
+	}
+	return fields[1], true
+}
+
+// handleUnsubscribe unsubscribes the contact behind a List-Unsubscribe mailto
+// request. It reports false when the message is not an unsubscribe request or
+// its token does not belong to a send, so it can be handled as regular mail.
+func handleUnsubscribe(ctx context.Context, svcCtx *svc.ServiceContext, data []byte) (bool, error) {
+	if svcCtx.Tracking == nil {
+		return false, nil
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return false, nil
+	}
+
+	subject := msg.Header.Get("Subject")
+	dec := new(mime.WordDecoder)
+	if decoded, err := dec.DecodeHeader(subject); err == nil {
+		subject = decoded
+	}
+
+	token, ok := unsubscribeToken(subject)
+	if !ok {
+		return false, nil
+	}
+
+	err = svcCtx.Tracking.MailtoUnsubscribe(ctx, token)
+	if errors.Is(err, tracking.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return true, err
+	}
+
+	logx.Infof("SMTP: Unsubscribed contact by email request from %s", msg.Header.Get("From"))
+	return true, nil
+}
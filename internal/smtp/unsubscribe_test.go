@@ -0,0 +1,61 @@
+package smtp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/outlet-sh/outlet/internal/db/dbtest"
+	"github.com/outlet-sh/outlet/internal/services/tracking"
+	"github.com/outlet-sh/outlet/internal/svc"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnsubscribeToken(t *testing.T) {
+	tests := []struct {
+		subject string
+		token   string
+		ok      bool
+	}{
+		{"unsubscribe tok123", "tok123", true},
+		{"  Unsubscribe   tok123 ", "tok123", true},
+		{"unsubscribe", "", false},
+		{"please unsubscribe me", "", false},
+		{"Invoice tok123", "", false},
+	}
+
+	for _, tt := range tests {
+		token, ok := unsubscribeToken(tt.subject)
+		assert.Equal(t, tt.ok, ok, tt.subject)
+		assert.Equal(t, tt.token, token, tt.subject)
+	}
+}
+
+func TestSession_DataUnsubscribes(t *testing.T) {
+	store := dbtest.New(t)
+	dbtest.Exec(t, store, `INSERT INTO organizations (id, name, slug, api_key) VALUES ('org', 'Org', 'org', 'key')`)
+	dbtest.Exec(t, store, `INSERT INTO contacts (id, org_id, name, email, status) VALUES ('ann', 'org', 'Ann', 'ann@example.com', 'active')`)
+	dbtest.Exec(t, store, `INSERT INTO email_campaigns (id, org_id, name, subject, html_body) VALUES ('camp', 'org', 'Launch', 'Hi', 'b')`)
+	dbtest.Exec(t, store, `INSERT INTO campaign_sends (id, campaign_id, contact_id, status, tracking_token, sent_at) VALUES ('send', 'camp', 'ann', 'sent', 'tok123', datetime('now'))`)
+
+	svcCtx := &svc.ServiceContext{DB: store, Tracking: tracking.New(store.Queries)}
+	message := func(subject string) *strings.Reader {
+		return strings.NewReader("From: ann@example.com\r\nTo: news@example.com\r\nSubject: " + subject + "\r\n\r\nunsubscribe\r\n")
+	}
+
+	// Mailbox providers deliver the request without authenticating
+	s := &Session{svcCtx: svcCtx}
+	require.NoError(t, s.Mail("ann@example.com", nil))
+	require.NoError(t, s.Rcpt("news@example.com", nil))
+
+	// Other mail and unknown tokens still need auth
+	assert.EqualError(t, s.Data(message("Hello")), "authentication required")
+	assert.EqualError(t, s.Data(message("unsubscribe nope")), "authentication required")
+
+	require.NoError(t, s.Data(message("unsubscribe tok123")))
+
+	var unsubscribed bool
+	require.NoError(t, store.GetDB().QueryRow(`SELECT unsubscribed_at IS NOT NULL FROM contacts WHERE id = 'ann'`).Scan(&unsubscribed))
+	assert.True(t, unsubscribed)
+}
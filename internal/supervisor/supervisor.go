@@ -0,0 +1,259 @@
+// Package supervisor owns the background workers started by serve.
+// It restarts workers that crash, drains them on shutdown and reports
+// per-worker health for the admin system endpoint.
+package supervisor
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// Worker statuses
+const (
+	StatusRunning = "running"
+	StatusCrashed = "crashed"
+	StatusStopped = "stopped"
+)
+
+// Worker is a started background worker
+type Worker interface {
+	Stop()
+}
+
+// CrashReporter is implemented by workers that can report an unexpected exit.
+// Crashed returns a non-nil error once the worker's loop has died.
+type CrashReporter interface {
+	Crashed() error
+}
+
+// StartFunc starts a worker and returns it
+type StartFunc func() Worker
+
+// StatsFunc reads counters from a running worker
+type StatsFunc func(w Worker) map[string]int64
+
+// WorkerStatus is a snapshot of one supervised worker
+type WorkerStatus struct {
+	Name      string
+	Status    string
+	StartedAt time.Time
+	Restarts  int
+	LastError string
+	Counters  map[string]int64
+}
+
+type entry struct {
+	name      string
+	start     StartFunc
+	stats     StatsFunc
+	worker    Worker
+	status    string
+	startedAt time.Time
+	restarts  int
+	lastError string
+}
+
+// Supervisor starts, monitors and stops background workers
+type Supervisor struct {
+	interval time.Duration
+
+	mu      sync.Mutex
+	entries []*entry
+
+	stop    chan struct{}
+	wg      sync.WaitGroup
+	stopped bool
+}
+
+// New creates a supervisor that checks worker health every interval
+func New(interval time.Duration) *Supervisor {
+	return &Supervisor{
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Add starts a worker and places it under supervision.
+// stats may be nil for workers without counters.
+func (s *Supervisor) Add(name string, start StartFunc, stats StatsFunc) {
+	e := &entry{name: name, start: start, stats: stats}
+
+	s.mu.Lock()
+	s.entries = append(s.entries, e)
+	s.mu.Unlock()
+
+	s.startEntry(e)
+}
+
+// Start begins monitoring workers
+func (s *Supervisor) Start() {
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Stop stops monitoring and drains workers in reverse start order
+func (s *Supervisor) Stop() {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	s.stopped = true
+	s.mu.Unlock()
+
+	close(s.stop)
+	s.wg.Wait()
+
+	s.mu.Lock()
+	entries := append([]*entry(nil), s.entries...)
+	s.mu.Unlock()
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		s.stopEntry(e)
+		logx.Infof("Worker %s stopped", e.name)
+	}
+}
+
+// Stats returns a snapshot of every supervised worker in start order
+func (s *Supervisor) Stats() []WorkerStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]WorkerStatus, 0, len(s.entries))
+	for _, e := range s.entries {
+		st := WorkerStatus{
+			Name:      e.name,
+			Status:    e.status,
+			StartedAt: e.startedAt,
+			Restarts:  e.restarts,
+			LastError: e.lastError,
+		}
+		if e.status == StatusRunning && e.stats != nil && e.worker != nil {
+			st.Counters = e.stats(e.worker)
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses
+}
+
+func (s *Supervisor) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.check()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// check restarts workers that crashed or failed to start. Crashed entries are
+// collected under the lock and stopped and restarted outside it, so a slow
+// Stop does not block Stats or Add.
+func (s *Supervisor) check() {
+	var crashed []*entry
+
+	s.mu.Lock()
+	for _, e := range s.entries {
+		if e.status == StatusRunning {
+			reporter, ok := e.worker.(CrashReporter)
+			if !ok {
+				continue
+			}
+			err := reporter.Crashed()
+			if err == nil {
+				continue
+			}
+			logx.Errorf("Worker %s crashed: %v", e.name, err)
+			e.lastError = err.Error()
+			e.status = StatusCrashed
+		}
+		if e.status == StatusCrashed {
+			crashed = append(crashed, e)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, e := range crashed {
+		s.stopEntry(e)
+
+		s.mu.Lock()
+		e.restarts++
+		logx.Infof("Restarting worker %s (restart #%d)", e.name, e.restarts)
+		s.mu.Unlock()
+
+		s.startEntry(e)
+	}
+}
+
+// startEntry starts the worker, recording a crash if start panics.
+// Must be called without s.mu held.
+func (s *Supervisor) startEntry(e *entry) {
+	worker, err := startWorker(e)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		e.worker = nil
+		e.status = StatusCrashed
+		e.lastError = err.Error()
+		return
+	}
+	e.worker = worker
+	e.status = StatusRunning
+	e.startedAt = time.Now()
+}
+
+// startWorker calls the entry's start func, turning a panic into an error
+func startWorker(e *entry) (worker Worker, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logx.Errorf("Worker %s panicked on start: %v\n%s", e.name, r, debug.Stack())
+			err = fmt.Errorf("panic on start: %v", r)
+		}
+	}()
+
+	return e.start(), nil
+}
+
+// stopEntry stops the worker, tolerating panics from a half-dead worker.
+// Must be called without s.mu held.
+func (s *Supervisor) stopEntry(e *entry) {
+	s.mu.Lock()
+	worker := e.worker
+	s.mu.Unlock()
+
+	if worker != nil {
+		stopWorker(e.name, worker)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e.worker = nil
+	if e.status != StatusCrashed {
+		e.status = StatusStopped
+	}
+}
+
+// stopWorker calls Stop, recovering from a panic in a half-dead worker
+func stopWorker(name string, w Worker) {
+	defer func() {
+		if r := recover(); r != nil {
+			logx.Errorf("Worker %s panicked on stop: %v", name, r)
+		}
+	}()
+
+	w.Stop()
+}
@@ -0,0 +1,167 @@
+package supervisor
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeWorker struct {
+	mu      sync.Mutex
+	stopped bool
+	err     error
+}
+
+func (w *fakeWorker) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopped = true
+}
+
+func (w *fakeWorker) Crashed() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+func (w *fakeWorker) crash(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.err = err
+}
+
+func TestSupervisor_RestartsCrashedWorker(t *testing.T) {
+	s := New(time.Hour)
+
+	var started []*fakeWorker
+	s.Add("fake", func() Worker {
+		w := &fakeWorker{}
+		started = append(started, w)
+		return w
+	}, func(w Worker) map[string]int64 {
+		return map[string]int64{"instances": int64(len(started))}
+	})
+
+	started[0].crash(errors.New("panic: boom"))
+	s.check()
+
+	if len(started) != 2 {
+		t.Fatalf("started %d workers, want 2", len(started))
+	}
+	if !started[0].stopped {
+		t.Error("crashed worker was not stopped before restart")
+	}
+
+	stats := s.Stats()
+	if len(stats) != 1 {
+		t.Fatalf("Stats() returned %d entries, want 1", len(stats))
+	}
+	st := stats[0]
+	if st.Status != StatusRunning || st.Restarts != 1 || st.LastError != "panic: boom" {
+		t.Errorf("Stats() = %+v", st)
+	}
+	if st.Counters["instances"] != 2 {
+		t.Errorf("Counters = %v", st.Counters)
+	}
+}
+
+func TestSupervisor_RetriesPanicOnStart(t *testing.T) {
+	s := New(time.Hour)
+
+	attempts := 0
+	s.Add("flaky", func() Worker {
+		attempts++
+		if attempts == 1 {
+			panic("not ready")
+		}
+		return &fakeWorker{}
+	}, nil)
+
+	if st := s.Stats()[0]; st.Status != StatusCrashed {
+		t.Fatalf("Status = %q, want %q", st.Status, StatusCrashed)
+	}
+
+	s.check()
+
+	if st := s.Stats()[0]; st.Status != StatusRunning || st.Restarts != 1 {
+		t.Errorf("after check Stats() = %+v", st)
+	}
+}
+
+func TestSupervisor_CheckDoesNotHoldLockDuringStop(t *testing.T) {
+	s := New(time.Hour)
+
+	release := make(chan struct{})
+	stopping := make(chan struct{})
+	crashed := &fakeWorker{err: errors.New("panic: boom")}
+	first := true
+	s.Add("slow", func() Worker {
+		if first {
+			first = false
+			return struct {
+				stopFunc
+				CrashReporter
+			}{stopFunc(func() { close(stopping); <-release }), crashed}
+		}
+		return &fakeWorker{}
+	}, nil)
+
+	done := make(chan struct{})
+	go func() {
+		s.check()
+		close(done)
+	}()
+	<-stopping
+
+	// Stats must not wait for the crashed worker's Stop to return
+	statsDone := make(chan []WorkerStatus)
+	go func() { statsDone <- s.Stats() }()
+	select {
+	case stats := <-statsDone:
+		if stats[0].Status != StatusCrashed {
+			t.Errorf("status while stopping = %q, want %q", stats[0].Status, StatusCrashed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Stats() blocked while a crashed worker was stopping")
+	}
+
+	close(release)
+	<-done
+	if st := s.Stats()[0]; st.Status != StatusRunning || st.Restarts != 1 {
+		t.Errorf("after check Stats() = %+v", st)
+	}
+}
+
+func TestSupervisor_StopDrainsInReverseOrder(t *testing.T) {
+	s := New(time.Hour)
+	s.Start()
+
+	var order []string
+	for _, name := range []string{"first", "second", "third"} {
+		name := name
+		s.Add(name, func() Worker { return stopFunc(func() { order = append(order, name) }) }, nil)
+	}
+
+	s.Stop()
+	s.Stop() // second call is a no-op
+
+	want := []string{"third", "second", "first"}
+	if len(order) != len(want) {
+		t.Fatalf("stop order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("stop order = %v, want %v", order, want)
+		}
+	}
+	for _, st := range s.Stats() {
+		if st.Status != StatusStopped {
+			t.Errorf("%s status = %q, want %q", st.Name, st.Status, StatusStopped)
+		}
+	}
+}
+
+type stopFunc func()
+
+func (f stopFunc) Stop() { f() }
@@ -12,10 +12,13 @@ import (
 	"github.com/outlet-sh/outlet/internal/db/migrations"
 	"github.com/outlet-sh/outlet/internal/events"
 	"github.com/outlet-sh/outlet/internal/middleware"
+	"github.com/outlet-sh/outlet/internal/services/automation"
 	"github.com/outlet-sh/outlet/internal/services/crypto"
 	"github.com/outlet-sh/outlet/internal/services/email"
+	"github.com/outlet-sh/outlet/internal/services/geo"
 	"github.com/outlet-sh/outlet/internal/services/tracking"
 	"github.com/outlet-sh/outlet/internal/services/webhook"
+	"github.com/outlet-sh/outlet/internal/supervisor"
 	"github.com/outlet-sh/outlet/internal/websocket"
 
 	"github.com/zeromicro/go-zero/rest"
@@ -34,7 +37,9 @@ type ServiceContext struct {
 	Tracking          *tracking.Service
 	Events            *events.Subject
 	WebhookDispatcher *webhook.Dispatcher
+	Automation        *automation.Engine
 	WebSocketHub      *websocket.Hub
+	Workers           *supervisor.Supervisor // Set by serve once background workers start
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
@@ -50,22 +55,18 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		log.Fatalf("Failed to create database directory: %v", err)
 	}
 
-	// Open SQLite database with WAL mode for better concurrency
-	conn, err := sql.Open("sqlite", dbPath)
+	// Open SQLite database with WAL mode for better concurrency. Foreign keys
+	// and the busy timeout are per-connection settings, so they go in the DSN
+	// to apply to every connection in the pool.
+	conn, err := sql.Open("sqlite", dbPath+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
-	// Enable WAL mode and other SQLite optimizations
+	// Enable WAL mode (persisted in the database file)
 	if _, err := conn.Exec("PRAGMA journal_mode=WAL"); err != nil {
 		log.Printf("Warning: Failed to enable WAL mode: %v", err)
 	}
-	if _, err := conn.Exec("PRAGMA foreign_keys=ON"); err != nil {
-		log.Printf("Warning: Failed to enable foreign keys: %v", err)
-	}
-	if _, err := conn.Exec("PRAGMA busy_timeout=5000"); err != nil {
-		log.Printf("Warning: Failed to set busy timeout: %v", err)
-	}
 
 	// Test database connection
 	if err := conn.Ping(); err != nil {
@@ -103,10 +104,32 @@ func NewServiceContext(c config.Config) *ServiceContext {
 	if c.App.BaseURL != "" {
 		emailService.SetBaseURL(c.App.BaseURL)
 	}
+	emailService.SetAttachmentRetention(c.Email.AttachmentRetentionDays)
+	emailService.SetOutbox(c.Email.OutboxDir, c.Email.CaptureOutbox)
+	emailService.SetThrottle(email.NewOrgThrottle(store, c.Email.RateLimit, c.Email.RateBurst))
 
 	// Initialize Tracking service
 	trackingService := tracking.New(store.Queries)
 
+	// Click links are signed so the redirect only goes to links we sent
+	linkSigner, err := tracking.LoadLinkSigner(context.Background(), store.Queries)
+	if err != nil {
+		log.Printf("Warning: %v - click tracking disabled", err)
+	} else {
+		emailService.SetLinkSigner(linkSigner)
+		trackingService.SetLinkSigner(linkSigner)
+	}
+
+	// Contact time zones are inferred from clicks when a geo database is set
+	if c.Geo.Database != "" {
+		locator, err := geo.Open(c.Geo.Database)
+		if err != nil {
+			log.Printf("Warning: %v - time zone inference disabled", err)
+		} else {
+			trackingService.SetLocator(locator)
+		}
+	}
+
 	// Initialize rate limit middleware for auth endpoints
 	authRateLimiter := middleware.NewRateLimitMiddleware(middleware.DefaultAuthRateLimitConfig())
 
@@ -119,6 +142,7 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		events.WithReplay(100),
 	)
 	log.Printf("Event bus initialized")
+	trackingService.SetEvents(eventSubject)
 
 	// Initialize and start Webhook Dispatcher for outbound webhook delivery
 	webhookDispatcher := webhook.NewDispatcher(store.Queries, eventSubject)
@@ -128,6 +152,14 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		log.Printf("Webhook dispatcher started")
 	}
 
+	// Initialize and start Automation engine for org rules
+	automationEngine := automation.NewEngine(store, emailService, c.App.BaseURL, eventSubject)
+	if err := automationEngine.Start(context.Background()); err != nil {
+		log.Printf("Warning: Failed to start automation engine: %v", err)
+	} else {
+		log.Printf("Automation engine started")
+	}
+
 	// Initialize WebSocket Hub for real-time updates
 	wsHub := websocket.NewHub()
 	go wsHub.Run()
@@ -145,6 +177,7 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		Tracking:          trackingService,
 		Events:            eventSubject,
 		WebhookDispatcher: webhookDispatcher,
+		Automation:        automationEngine,
 		WebSocketHub:      wsHub,
 	}
 }
@@ -57,6 +57,40 @@ type AnalyticsResponse struct {
 	Message string `json:"message,optional"`
 }
 
+type ApplyListVerificationRequest struct {
+	Id       string   `path:"id"`
+	JobId    string   `path:"jobId"`
+	Action   string   `json:"action"`            // suppress or tag
+	Verdicts []string `json:"verdicts,optional"` // Defaults to invalid
+	Tag      string   `json:"tag,optional"`      // Tag for the tag action (default: email-<verdict>)
+}
+
+type ApplyListVerificationResponse struct {
+	Action   string `json:"action"`
+	Affected int    `json:"affected"`
+}
+
+type AutomationLogInfo struct {
+	Id              string `json:"id"`
+	EventId         string `json:"event_id"`
+	EventType       string `json:"event_type"`
+	EventPayload    string `json:"event_payload,optional"` // JSON string
+	RuleId          string `json:"rule_id,optional"`
+	RuleName        string `json:"rule_name"`
+	ActionsExecuted string `json:"actions_executed,optional"` // JSON string
+	Success         bool   `json:"success"`
+	ErrorMessage    string `json:"error_message,optional"`
+	ExecutionTimeMs int    `json:"execution_time_ms"`
+	CreatedAt       string `json:"created_at"`
+}
+
+type AutomationLogStats struct {
+	Total              int     `json:"total"`
+	Successful         int     `json:"successful"`
+	Failed             int     `json:"failed"`
+	AvgExecutionTimeMs float64 `json:"avg_execution_time_ms"`
+}
+
 type BackupInfo struct {
 	Id           string `json:"id"`
 	Filename     string `json:"filename"`
@@ -99,6 +133,19 @@ type BackupSettingsResponse struct {
 	NextBackupAt    string `json:"next_backup_at,omitempty"`
 }
 
+type BatchEmailRecipient struct {
+	To        string            `json:"to"`                 // Email address
+	Variables map[string]string `json:"variables,optional"` // Template variables for this recipient
+	Meta      map[string]string `json:"meta,optional"`      // Merged over the batch meta
+}
+
+type BatchEmailResult struct {
+	To        string `json:"to"`
+	MessageId string `json:"message_id,optional"`
+	Status    string `json:"status"` // queued, scheduled, failed
+	Message   string `json:"message,optional"`
+}
+
 type BlockedDomainInfo struct {
 	Id            string `json:"id"`
 	OrgId         string `json:"org_id"`
@@ -131,6 +178,23 @@ type BulkSuppressEmailsResponse struct {
 	Errors  []string `json:"errors,optional"`
 }
 
+type CampaignAbTestInfo struct {
+	TestPercent     int                   `json:"test_percent"`
+	WinnerMetric    string                `json:"winner_metric"`
+	WaitHours       int                   `json:"wait_hours"`
+	Status          string                `json:"status"` // pending, testing, waiting, finished
+	WinnerVariantId string                `json:"winner_variant_id,optional"`
+	TestCompletedAt string                `json:"test_completed_at,optional"`
+	Variants        []CampaignVariantStat `json:"variants"`
+}
+
+type CampaignAbTestInput struct {
+	TestPercent  int                    `json:"test_percent"`                             // Share of the audience in the test group
+	WinnerMetric string                 `json:"winner_metric,optional,default=open_rate"` // open_rate, click_rate, manual
+	WaitHours    int                    `json:"wait_hours,optional,default=4"`            // Wait after the test sends before picking the winner
+	Variants     []CampaignVariantInput `json:"variants"`
+}
+
 type CampaignActivityItem struct {
 	CampaignId      string `json:"campaign_id"`
 	CampaignName    string `json:"campaign_name"`
@@ -157,12 +221,15 @@ type CampaignInfo struct {
 	PlainText         string   `json:"plain_text,optional"`
 	ListIds           []string `json:"list_ids"`
 	ExcludeListIds    []string `json:"exclude_list_ids,optional"`
-	Status            string   `json:"status"` // draft, scheduled, sending, sent, paused, cancelled
+	SegmentFilter     string   `json:"segment_filter,optional"` // JSON segment DSL
+	SegmentId         string   `json:"segment_id,optional"`     // Saved segment
+	Status            string   `json:"status"`                  // draft, scheduled, sending, sent, paused, cancelled
 	ScheduledAt       string   `json:"scheduled_at,optional"`
 	StartedAt         string   `json:"started_at,optional"`
 	CompletedAt       string   `json:"completed_at,optional"`
 	TrackOpens        bool     `json:"track_opens"`
 	TrackClicks       bool     `json:"track_clicks"`
+	DeliveryMode      string   `json:"delivery_mode"` // immediate, local_time, optimal_time
 	RecipientsCount   int      `json:"recipients_count"`
 	SentCount         int      `json:"sent_count"`
 	DeliveredCount    int      `json:"delivered_count"`
@@ -182,14 +249,48 @@ type CampaignLinkStat struct {
 }
 
 type CampaignStatsResponse struct {
-	Campaign CampaignInfo       `json:"campaign"`
-	Links    []CampaignLinkStat `json:"links"`
+	Campaign     CampaignInfo        `json:"campaign"`
+	Links        []CampaignLinkStat  `json:"links"`
+	TotalOpened  int                 `json:"total_opened"`
+	HumanOpened  int                 `json:"human_opened"`
+	TotalClicked int                 `json:"total_clicked"`
+	HumanClicked int                 `json:"human_clicked"`
+	AbTest       *CampaignAbTestInfo `json:"ab_test,optional"`
+}
+
+type CampaignVariantInput struct {
+	Name      string `json:"name"`
+	Subject   string `json:"subject,optional"`
+	FromName  string `json:"from_name,optional"`
+	HtmlBody  string `json:"html_body,optional"`
+	PlainText string `json:"plain_text,optional"`
+}
+
+type CampaignVariantStat struct {
+	Id           string  `json:"id"`
+	Name         string  `json:"name"`
+	Recipients   int     `json:"recipients"`
+	Sent         int     `json:"sent"`
+	Opened       int     `json:"opened"`
+	Clicked      int     `json:"clicked"`
+	HumanOpened  int     `json:"human_opened"`
+	HumanClicked int     `json:"human_clicked"`
+	OpenRate     float64 `json:"open_rate"` // Human opens per send, as the winner is picked
+	ClickRate    float64 `json:"click_rate"`
 }
 
 type CancelEmailRequest struct {
 	Id string `path:"id"`
 }
 
+type CancelEmailSendRequest struct {
+	MessageId string `path:"messageId"`
+}
+
+type CancelExportJobRequest struct {
+	Id string `path:"id"`
+}
+
 type CancelImportJobRequest struct {
 	Id string `path:"id"`
 }
@@ -223,6 +324,7 @@ type ContactRequest struct {
 	UtmMedium   string            `json:"utm_medium,optional"`
 	UtmCampaign string            `json:"utm_campaign,optional"`
 	Meta        map[string]string `json:"meta,optional"`
+	Timezone    string            `json:"timezone,optional"` // IANA time zone, e.g. America/New_York
 }
 
 type ContactResponse struct {
@@ -257,19 +359,23 @@ type CreateBlockedDomainRequest struct {
 }
 
 type CreateCampaignRequest struct {
-	DesignId       *string  `json:"design_id,optional"`
-	Name           string   `json:"name"`
-	Subject        string   `json:"subject"`
-	PreviewText    string   `json:"preview_text,optional"`
-	FromName       string   `json:"from_name,optional"`
-	FromEmail      string   `json:"from_email,optional"`
-	ReplyTo        string   `json:"reply_to,optional"`
-	HtmlBody       string   `json:"html_body"`
-	PlainText      string   `json:"plain_text,optional"`
-	ListIds        []string `json:"list_ids"`
-	ExcludeListIds []string `json:"exclude_list_ids,optional"`
-	TrackOpens     bool     `json:"track_opens,optional,default=true"`
-	TrackClicks    bool     `json:"track_clicks,optional,default=true"`
+	DesignId       *string              `json:"design_id,optional"`
+	Name           string               `json:"name"`
+	Subject        string               `json:"subject"`
+	PreviewText    string               `json:"preview_text,optional"`
+	FromName       string               `json:"from_name,optional"`
+	FromEmail      string               `json:"from_email,optional"`
+	ReplyTo        string               `json:"reply_to,optional"`
+	HtmlBody       string               `json:"html_body"`
+	PlainText      string               `json:"plain_text,optional"`
+	ListIds        []string             `json:"list_ids"`
+	ExcludeListIds []string             `json:"exclude_list_ids,optional"`
+	SegmentFilter  string               `json:"segment_filter,optional"` // JSON segment DSL
+	SegmentId      string               `json:"segment_id,optional"`     // Saved segment
+	TrackOpens     bool                 `json:"track_opens,optional,default=true"`
+	TrackClicks    bool                 `json:"track_clicks,optional,default=true"`
+	DeliveryMode   string               `json:"delivery_mode,optional,default=immediate"` // immediate, local_time (9am contact time), optimal_time (contact's best open hour)
+	AbTest         *CampaignAbTestInput `json:"ab_test,optional"`
 }
 
 type CreateCustomFieldRequest struct {
@@ -302,11 +408,24 @@ type CreateEmailDesignRequest struct {
 
 type CreateEntryRuleRequest struct {
 	SequenceId  string `json:"sequence_id"`
-	TriggerType string `json:"trigger_type"` // list_join, sequence_complete, tag_added, manual
-	SourceId    string `json:"source_id,optional"`
+	TriggerType string `json:"trigger_type"`       // list_join, sequence_complete, tag_added, link_clicked, segment_enter, manual
+	SourceId    string `json:"source_id,optional"` // List, sequence or segment ID, tag name, or URL prefix for link_clicked
 	Priority    int    `json:"priority,optional,default=0"`
 }
 
+type CreateExportJobRequest struct {
+	Type        string `json:"type"`                                // list, segment, suppression_list, blocked_domains, campaign_sends
+	SourceId    string `json:"source_id,optional"`                  // Required for list, segment and campaign_sends
+	Format      string `json:"format,optional,default=csv"`         // csv, ndjson
+	StorageType string `json:"storage_type,optional,default=local"` // local, or s3 using the backup S3 settings
+}
+
+type CreateImportJobRequest struct {
+	Type    string `form:"type"` // subscribers, suppression, blocked_domains
+	ListId  string `form:"list_id,optional"`
+	Options string `form:"options,optional"` // JSON-encoded ImportJobOptions
+}
+
 type CreateInitialAdminRequest struct {
 	Email           string `json:"email"`
 	Password        string `json:"password"`
@@ -352,6 +471,24 @@ type CreateOrgRequest struct {
 	ReplyTo     string `json:"reply_to,optional"`
 }
 
+type CreateRuleRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,optional"`
+	Category    string `json:"category,optional,default=automation"`
+	RuleJson    string `json:"rule_json"`
+	EntityType  string `json:"entity_type,optional"`
+	EntityId    string `json:"entity_id,optional"`
+	Enabled     bool   `json:"enabled,optional,default=true"`
+	Salience    int    `json:"salience,optional"`
+}
+
+type CreateSegmentRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,optional"`
+	ListIds     []string `json:"list_ids,optional"`
+	Filter      string   `json:"filter,optional"` // JSON segment DSL
+}
+
 type CreateSequenceRequest struct {
 	ListId       string `json:"list_id,optional"` // Optional - use entry rules instead
 	Slug         string `json:"slug"`
@@ -412,6 +549,15 @@ type CustomFieldInfo struct {
 	UpdatedAt    string   `json:"updated_at"`
 }
 
+type DKIMKeyInfo struct {
+	Selector    string `json:"selector"`
+	Algorithm   string `json:"algorithm"` // rsa-sha256, ed25519-sha256
+	Version     int64  `json:"version"`
+	Status      string `json:"status"` // pending (publish the DNS record), active
+	ActivatedAt string `json:"activated_at,optional"`
+	CreatedAt   string `json:"created_at"`
+}
+
 type DNSRecord struct {
 	Type     string `json:"type"`     // CNAME, TXT, MX
 	Name     string `json:"name"`     // Record name/host
@@ -464,6 +610,10 @@ type DeleteEntryRuleRequest struct {
 	Id string `path:"id"`
 }
 
+type DeleteExportJobRequest struct {
+	Id string `path:"id"`
+}
+
 type DeleteListRequest struct {
 	Id string `path:"id"`
 }
@@ -472,6 +622,14 @@ type DeleteOrgRequest struct {
 	Id string `path:"id"`
 }
 
+type DeleteRuleRequest struct {
+	Id string `path:"id"`
+}
+
+type DeleteSegmentRequest struct {
+	Id string `path:"id"`
+}
+
 type DeleteSuppressedEmailRequest struct {
 	Id string `path:"id"`
 }
@@ -500,22 +658,43 @@ type DetectSESQuotaRequest struct {
 }
 
 type DomainIdentityInfo struct {
-	Id                 string      `json:"id"`
-	OrgId              string      `json:"org_id"`
-	Domain             string      `json:"domain"`
-	VerificationStatus string      `json:"verification_status"` // pending, success, failed, temporary_failure, not_started
-	DKIMStatus         string      `json:"dkim_status"`
-	MailFromDomain     string      `json:"mail_from_domain,optional"` // The custom MAIL FROM subdomain (e.g., "mail.example.com")
-	MailFromStatus     string      `json:"mail_from_status"`
-	DNSRecords         []DNSRecord `json:"dns_records"`
-	LastCheckedAt      string      `json:"last_checked_at,optional"`
-	CreatedAt          string      `json:"created_at"`
+	Id                 string        `json:"id"`
+	OrgId              string        `json:"org_id"`
+	Domain             string        `json:"domain"`
+	VerificationStatus string        `json:"verification_status"` // pending, success, failed, temporary_failure, not_started
+	DKIMStatus         string        `json:"dkim_status"`
+	MailFromDomain     string        `json:"mail_from_domain,optional"` // The custom MAIL FROM subdomain (e.g., "mail.example.com")
+	MailFromStatus     string        `json:"mail_from_status"`
+	DNSRecords         []DNSRecord   `json:"dns_records"`
+	DKIMKeys           []DKIMKeyInfo `json:"dkim_keys"` // Keys Outlet signs SMTP relay mail with
+	LastCheckedAt      string        `json:"last_checked_at,optional"`
+	CreatedAt          string        `json:"created_at"`
 }
 
 type DownloadBackupRequest struct {
 	Id string `path:"id"`
 }
 
+type DownloadExportJobRequest struct {
+	Id string `path:"id"`
+}
+
+type EmailAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type,optional"` // Guessed from the filename if omitted
+	Content     string `json:"content"`               // Base64-encoded file content
+	ContentId   string `json:"content_id,optional"`   // Inline image, referenced in the body as cid:<content_id>
+}
+
+type EmailAttachmentInfo struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`                // Bytes
+	ContentId   string `json:"content_id,optional"` // Set for inline images
+	Stored      bool   `json:"stored"`              // Content is retained
+	ExpiresAt   string `json:"expires_at,optional"` // When retained content is purged
+}
+
 type EmailClickRequest struct {
 	Token string `path:"token"`
 	Url   string `form:"url"`
@@ -586,29 +765,33 @@ type EmailQueueListResponse struct {
 }
 
 type EmailStatsPoint struct {
-	Date      string  `json:"date"`
-	Sent      int     `json:"sent"`
-	Delivered int     `json:"delivered"`
-	Opened    int     `json:"opened"`
-	Clicked   int     `json:"clicked"`
-	Bounced   int     `json:"bounced"`
-	OpenRate  float64 `json:"open_rate"`
-	ClickRate float64 `json:"click_rate"`
+	Date         string  `json:"date"`
+	Sent         int     `json:"sent"`
+	Delivered    int     `json:"delivered"`
+	Opened       int     `json:"opened"`
+	Clicked      int     `json:"clicked"`
+	HumanOpened  int     `json:"human_opened"`
+	HumanClicked int     `json:"human_clicked"`
+	Bounced      int     `json:"bounced"`
+	OpenRate     float64 `json:"open_rate"`
+	ClickRate    float64 `json:"click_rate"`
 }
 
 type EmailStatusResponse struct {
-	MessageId   string `json:"message_id"`
-	To          string `json:"to"`
-	Subject     string `json:"subject"`
-	Status      string `json:"status"` // queued, sent, delivered, opened, clicked, bounced, complained
-	SentAt      string `json:"sent_at,optional"`
-	DeliveredAt string `json:"delivered_at,optional"`
-	OpenedAt    string `json:"opened_at,optional"`
-	ClickedAt   string `json:"clicked_at,optional"`
-	BouncedAt   string `json:"bounced_at,optional"`
-	BounceType  string `json:"bounce_type,optional"`
-	Opens       int    `json:"opens"`
-	Clicks      int    `json:"clicks"`
+	MessageId   string                `json:"message_id"`
+	To          string                `json:"to"`
+	Subject     string                `json:"subject"`
+	Status      string                `json:"status"`                // queued, scheduled, sent, delivered, opened, clicked, bounced, complained
+	ScheduledAt string                `json:"scheduled_at,optional"` // Set while a scheduled send waits
+	SentAt      string                `json:"sent_at,optional"`
+	DeliveredAt string                `json:"delivered_at,optional"`
+	OpenedAt    string                `json:"opened_at,optional"`
+	ClickedAt   string                `json:"clicked_at,optional"`
+	BouncedAt   string                `json:"bounced_at,optional"`
+	BounceType  string                `json:"bounce_type,optional"`
+	Opens       int                   `json:"opens"`
+	Clicks      int                   `json:"clicks"`
+	Attachments []EmailAttachmentInfo `json:"attachments"`
 }
 
 type EmbedCodeResponse struct {
@@ -640,9 +823,9 @@ type EnrollSequenceResponse struct {
 type EntryRuleInfo struct {
 	Id          string `json:"id"`
 	SequenceId  string `json:"sequence_id"`
-	TriggerType string `json:"trigger_type"` // list_join, sequence_complete, tag_added, manual
-	SourceId    string `json:"source_id,optional"`
-	SourceName  string `json:"source_name,optional"` // list name or sequence name
+	TriggerType string `json:"trigger_type"`         // list_join, sequence_complete, tag_added, link_clicked, segment_enter, manual
+	SourceId    string `json:"source_id,optional"`   // List, sequence or segment ID, tag name, or URL prefix for link_clicked
+	SourceName  string `json:"source_name,optional"` // list, sequence or segment name
 	Priority    int    `json:"priority"`
 	IsActive    bool   `json:"is_active"`
 	CreatedAt   string `json:"created_at"`
@@ -669,6 +852,25 @@ type EventRequest struct {
 type ExportBlockedDomainsRequest struct {
 }
 
+type ExportJobInfo struct {
+	Id            string `json:"id"`
+	OrgId         string `json:"org_id"`
+	Type          string `json:"type"`               // list, segment, suppression_list, blocked_domains, campaign_sends
+	SourceId      string `json:"source_id,optional"` // List, segment or campaign ID
+	Format        string `json:"format"`             // csv, ndjson
+	StorageType   string `json:"storage_type"`       // local, s3
+	Status        string `json:"status"`             // pending, running, completed, failed, cancelled
+	Filename      string `json:"filename"`
+	FileSize      int64  `json:"file_size"`
+	TotalRows     int    `json:"total_rows"`
+	ProcessedRows int    `json:"processed_rows"`
+	ErrorMessage  string `json:"error_message,optional"`
+	DownloadUrl   string `json:"download_url,optional"` // Set once completed
+	StartedAt     string `json:"started_at,optional"`
+	CompletedAt   string `json:"completed_at,optional"`
+	CreatedAt     string `json:"created_at"`
+}
+
 type ExportSubscribersRequest struct {
 	ListId string `path:"list_id"`
 	Status string `form:"status,optional"` // active, unsubscribed, all
@@ -791,6 +993,8 @@ type GetEmailStatsResponse struct {
 	TotalDelivered int               `json:"total_delivered"`
 	TotalOpened    int               `json:"total_opened"`
 	TotalClicked   int               `json:"total_clicked"`
+	HumanOpened    int               `json:"human_opened"`
+	HumanClicked   int               `json:"human_clicked"`
 	TotalBounced   int               `json:"total_bounced"`
 	AvgOpenRate    float64           `json:"avg_open_rate"`
 	AvgClickRate   float64           `json:"avg_click_rate"`
@@ -804,6 +1008,10 @@ type GetEmbedCodeRequest struct {
 	Id string `path:"id"`
 }
 
+type GetExportJobRequest struct {
+	Id string `path:"id"`
+}
+
 type GetImportJobRequest struct {
 	Id string `path:"id"`
 }
@@ -812,6 +1020,15 @@ type GetListRequest struct {
 	Id string `path:"id"`
 }
 
+type GetListValidationPolicyRequest struct {
+	Id string `path:"id"`
+}
+
+type GetListVerificationRequest struct {
+	Id    string `path:"id"`
+	JobId string `path:"jobId"`
+}
+
 type GetOrgBySlugRequest struct {
 	Slug string `path:"slug"`
 }
@@ -824,6 +1041,10 @@ type GetOrgRequest struct {
 	Id string `path:"id"`
 }
 
+type GetOrgValidationPolicyRequest struct {
+	OrgId string `path:"org_id"`
+}
+
 type GetPlatformSettingsByCategoryRequest struct {
 	Category string `path:"category"` // Category: email, google
 }
@@ -832,6 +1053,14 @@ type GetPlatformSettingsResponse struct {
 	Settings []PlatformSettingInfo `json:"settings"`
 }
 
+type GetRuleRequest struct {
+	Id string `path:"id"`
+}
+
+type GetSegmentRequest struct {
+	Id string `path:"id"`
+}
+
 type GetSequenceEnrollmentRequest struct {
 	Email        string `form:"email"`
 	SequenceSlug string `form:"sequence_slug,optional"` // If not provided, returns all
@@ -885,27 +1114,67 @@ type HousekeepingResponse struct {
 	Message       string `json:"message"`
 }
 
+type HousekeepingSegmentRequest struct {
+	SegmentId string `json:"segment_id"`
+	Action    string `json:"action,optional,default=unsubscribe"` // unsubscribe or delete
+	DryRun    bool   `json:"dry_run,optional,default=true"`
+}
+
 type HousekeepingUnconfirmedRequest struct {
 	OlderThanDays int  `json:"older_than_days,optional,default=30"` // Delete unconfirmed older than N days
 	DryRun        bool `json:"dry_run,optional,default=true"`
 }
 
 type ImportJobInfo struct {
-	Id            string `json:"id"`
-	OrgId         string `json:"org_id"`
-	ListId        string `json:"list_id,optional"`
-	Type          string `json:"type"`   // subscribers, suppression, blocked_domains
-	Status        string `json:"status"` // pending, processing, completed, failed, cancelled
-	Filename      string `json:"filename"`
-	TotalRows     int    `json:"total_rows"`
-	ProcessedRows int    `json:"processed_rows"`
-	SuccessCount  int    `json:"success_count"`
-	ErrorCount    int    `json:"error_count"`
-	SkipCount     int    `json:"skip_count"`
-	Errors        string `json:"errors,optional"`
-	StartedAt     string `json:"started_at,optional"`
-	CompletedAt   string `json:"completed_at,optional"`
-	CreatedAt     string `json:"created_at"`
+	Id            string           `json:"id"`
+	OrgId         string           `json:"org_id"`
+	ListId        string           `json:"list_id,optional"`
+	Type          string           `json:"type"`   // subscribers, suppression, blocked_domains
+	Status        string           `json:"status"` // pending, processing, completed, failed, cancelled
+	Filename      string           `json:"filename"`
+	TotalRows     int              `json:"total_rows"`
+	ProcessedRows int              `json:"processed_rows"`
+	SuccessCount  int              `json:"success_count"`
+	ErrorCount    int              `json:"error_count"`
+	SkipCount     int              `json:"skip_count"`
+	Errors        string           `json:"errors,optional"`
+	Options       ImportJobOptions `json:"options,optional"`
+	StartedAt     string           `json:"started_at,optional"`
+	CompletedAt   string           `json:"completed_at,optional"`
+	CreatedAt     string           `json:"created_at"`
+}
+
+type ImportJobOptions struct {
+	Mapping         map[string]string `json:"mapping,optional"`            // CSV column -> field: email, name, tags, reason, domain, custom:<field_key>, or "" to ignore
+	Tags            []string          `json:"tags,optional"`               // Added to every imported contact
+	UpdateExisting  bool              `json:"update_existing,optional"`    // Update name, tags and custom fields of existing contacts instead of skipping them
+	SendDoubleOptIn bool              `json:"send_double_opt_in,optional"` // Subscribe as pending and send a confirmation email
+}
+
+type InstantiateRuleTemplateRequest struct {
+	Id         string            `path:"id"`
+	Name       string            `json:"name,optional"` // Defaults to the template name
+	Params     map[string]string `json:"params,optional"`
+	EntityType string            `json:"entity_type,optional"`
+	EntityId   string            `json:"entity_id,optional"`
+	Enabled    bool              `json:"enabled,optional,default=true"`
+	Salience   int               `json:"salience,optional"`
+}
+
+type ListAutomationLogRequest struct {
+	EventType string `form:"event_type,optional"`
+	RuleId    string `form:"rule_id,optional"`
+	Success   string `form:"success,optional"` // true or false
+	Page      int    `form:"page,optional,default=1"`
+	Limit     int    `form:"limit,optional,default=50"`
+}
+
+type ListAutomationLogResponse struct {
+	Entries []AutomationLogInfo `json:"entries"`
+	Total   int                 `json:"total"`
+	Page    int                 `json:"page"`
+	Limit   int                 `json:"limit"`
+	Stats   AutomationLogStats  `json:"stats"` // Last 24 hours
 }
 
 type ListBackupsRequest struct {
@@ -987,6 +1256,18 @@ type ListEmailEventsResponse struct {
 	Events []EmailEventInfo `json:"events"`
 }
 
+type ListExportJobsRequest struct {
+	Page  int `form:"page,optional,default=1"`
+	Limit int `form:"limit,optional,default=20"`
+}
+
+type ListExportJobsResponse struct {
+	Jobs  []ExportJobInfo `json:"jobs"`
+	Total int             `json:"total"`
+	Page  int             `json:"page"`
+	Limit int             `json:"limit"`
+}
+
 type ListImportJobsRequest struct {
 	Page  int `form:"page,optional,default=1"`
 	Limit int `form:"limit,optional,default=20"`
@@ -1027,6 +1308,18 @@ type ListInfo struct {
 	UnsubscribeScope       string `json:"unsubscribe_scope,optional"`
 }
 
+type ListListVerificationsRequest struct {
+	Id    string `path:"id"`
+	Page  int    `form:"page,optional,default=1"`
+	Limit int    `form:"limit,optional,default=20"`
+}
+
+type ListListVerificationsResponse struct {
+	Jobs  []ListVerificationJobInfo `json:"jobs"`
+	Page  int                       `json:"page"`
+	Limit int                       `json:"limit"`
+}
+
 type ListListsResponse struct {
 	Lists []ListInfo `json:"lists"`
 }
@@ -1036,6 +1329,32 @@ type ListMCPAPIKeysResponse struct {
 	Total int             `json:"total"`
 }
 
+type ListRuleTemplatesRequest struct {
+	Category string `form:"category,optional"`
+}
+
+type ListRuleTemplatesResponse struct {
+	Templates []RuleTemplateInfo `json:"templates"`
+}
+
+type ListRulesRequest struct {
+	Category string `form:"category,optional"`
+	Enabled  string `form:"enabled,optional"` // true or false
+	Page     int    `form:"page,optional,default=1"`
+	Limit    int    `form:"limit,optional,default=20"`
+}
+
+type ListRulesResponse struct {
+	Rules []RuleInfo `json:"rules"`
+	Total int        `json:"total"`
+	Page  int        `json:"page"`
+	Limit int        `json:"limit"`
+}
+
+type ListSegmentsResponse struct {
+	Segments []SegmentInfo `json:"segments"`
+}
+
 type ListSequenceEnrollmentsResponse struct {
 	Enrollments []SequenceEnrollmentInfo `json:"enrollments"`
 }
@@ -1092,6 +1411,46 @@ type ListTransactionalEmailsResponse struct {
 	Total     int                      `json:"total"`
 }
 
+type ListVerificationJobInfo struct {
+	Id           string         `json:"id"`
+	ListId       string         `json:"list_id"`
+	Status       string         `json:"status"` // pending, running, completed, failed, cancelled
+	CheckSMTP    bool           `json:"check_smtp"`
+	Concurrency  int            `json:"concurrency"`
+	Total        int            `json:"total"`
+	Processed    int            `json:"processed"`
+	Verdicts     map[string]int `json:"verdicts"` // valid, invalid, disposable, role, catch-all, unknown
+	ErrorMessage string         `json:"error_message,omitempty"`
+	CreatedBy    string         `json:"created_by,omitempty"`
+	StartedAt    string         `json:"started_at,omitempty"`
+	CompletedAt  string         `json:"completed_at,omitempty"`
+	CreatedAt    string         `json:"created_at"`
+}
+
+type ListVerificationResultInfo struct {
+	SubscriberId string `json:"subscriber_id"`
+	ContactId    string `json:"contact_id"`
+	Email        string `json:"email"`
+	Verdict      string `json:"verdict"`
+	Reason       string `json:"reason,omitempty"`
+	CheckedAt    string `json:"checked_at"`
+}
+
+type ListVerificationResultsRequest struct {
+	Id      string `path:"id"`
+	JobId   string `path:"jobId"`
+	Verdict string `form:"verdict,optional"`
+	Page    int    `form:"page,optional,default=1"`
+	Limit   int    `form:"limit,optional,default=50"`
+}
+
+type ListVerificationResultsResponse struct {
+	Results []ListVerificationResultInfo `json:"results"`
+	Total   int                          `json:"total"`
+	Page    int                          `json:"page"`
+	Limit   int                          `json:"limit"`
+}
+
 type ListWebhookLogsRequest struct {
 	Id    string `path:"id"`
 	Limit int    `form:"limit,optional"`
@@ -1196,6 +1555,11 @@ type PauseSequenceRequest struct {
 	SequenceSlug string `json:"sequence_slug"`
 }
 
+type PickCampaignWinnerRequest struct {
+	Id        string `path:"id"`
+	VariantId string `json:"variant_id"`
+}
+
 type PlatformSettingInfo struct {
 	Key         string `json:"key"`
 	Value       string `json:"value"` // Decrypted value for display (masked for sensitive)
@@ -1204,6 +1568,19 @@ type PlatformSettingInfo struct {
 	IsSensitive bool   `json:"is_sensitive"`
 }
 
+type PreviewImportRequest struct {
+	Type   string `form:"type"` // subscribers, suppression, blocked_domains
+	ListId string `form:"list_id,optional"`
+}
+
+type PreviewImportResponse struct {
+	Type    string            `json:"type"`
+	Header  []string          `json:"header"`
+	Rows    [][]string        `json:"rows"`
+	Mapping map[string]string `json:"mapping"` // Suggested column mapping
+	Fields  []string          `json:"fields"`  // Fields the columns can be mapped to
+}
+
 type RefreshDomainIdentityRequest struct {
 	OrgId string `path:"org_id"`
 	Id    string `path:"id"`
@@ -1284,6 +1661,48 @@ type RevokeMCPAPIKeyResponse struct {
 	Message string `json:"message"`
 }
 
+type RotateDomainDKIMRequest struct {
+	OrgId string `path:"org_id"`
+	Id    string `path:"id"`
+}
+
+type RuleInfo struct {
+	Id               string   `json:"id"`
+	OrgId            string   `json:"org_id"`
+	Name             string   `json:"name"`
+	Description      string   `json:"description,optional"`
+	Category         string   `json:"category"`
+	RuleJson         string   `json:"rule_json"`            // JSON rule document
+	EntityType       string   `json:"entity_type,optional"` // email_list, sequence, campaign
+	EntityId         string   `json:"entity_id,optional"`
+	Enabled          bool     `json:"enabled"`
+	Salience         int      `json:"salience"` // Higher runs first
+	CompiledHash     string   `json:"compiled_hash,optional"`
+	ValidationErrors []string `json:"validation_errors"`
+	LastValidatedAt  string   `json:"last_validated_at,optional"`
+	CreatedAt        string   `json:"created_at"`
+	UpdatedAt        string   `json:"updated_at"`
+}
+
+type RuleTemplateInfo struct {
+	Id            string                  `json:"id"`
+	Name          string                  `json:"name"`
+	Description   string                  `json:"description,optional"`
+	Category      string                  `json:"category"`
+	RuleJson      string                  `json:"rule_json"`
+	Params        []RuleTemplateParamInfo `json:"params"`
+	IsRecommended bool                    `json:"is_recommended"`
+	IsDefault     bool                    `json:"is_default"`
+}
+
+type RuleTemplateParamInfo struct {
+	Name     string `json:"name"`
+	Label    string `json:"label,optional"`
+	Type     string `json:"type"` // string or number
+	Default  string `json:"default,optional"`
+	Required bool   `json:"required"`
+}
+
 type SDKContactInfo struct {
 	Id            string            `json:"id"`
 	Email         string            `json:"email"`
@@ -1296,6 +1715,7 @@ type SDKContactInfo struct {
 	Lists         []string          `json:"lists"` // List slugs subscribed to
 	CustomFields  map[string]string `json:"custom_fields,omitempty"`
 	Source        string            `json:"source,omitempty"`
+	Timezone      string            `json:"timezone,omitempty"`
 	EmailsSent    int               `json:"emails_sent"`
 	EmailsOpened  int               `json:"emails_opened"`
 	EmailsClicked int               `json:"emails_clicked"`
@@ -1320,28 +1740,74 @@ type ScheduleCampaignRequest struct {
 	ScheduledAt string `json:"scheduled_at"` // ISO8601 timestamp
 }
 
+type SegmentInfo struct {
+	Id               string   `json:"id"`
+	OrgId            string   `json:"org_id"`
+	Name             string   `json:"name"`
+	Description      string   `json:"description,optional"`
+	ListIds          []string `json:"list_ids"` // Empty means all lists
+	Filter           string   `json:"filter"`   // JSON segment DSL
+	ContactCount     int      `json:"contact_count"`
+	CountRefreshedAt string   `json:"count_refreshed_at,optional"`
+	CreatedAt        string   `json:"created_at"`
+	UpdatedAt        string   `json:"updated_at"`
+}
+
+type SegmentPreviewRequest struct {
+	ListIds        []string `json:"list_ids,optional"`
+	ExcludeListIds []string `json:"exclude_list_ids,optional"`
+	SegmentFilter  string   `json:"segment_filter,optional"` // JSON segment DSL
+	SegmentId      string   `json:"segment_id,optional"`     // Saved segment
+}
+
+type SegmentPreviewResponse struct {
+	Count int `json:"count"`
+}
+
+type SendBatchEmailRequest struct {
+	TemplateSlug   string                `json:"template_slug,optional"`     // Use design template
+	Subject        string                `json:"subject,optional"`           // Required if no template
+	Body           string                `json:"body,optional"`              // HTML body, required if no template
+	TextBody       string                `json:"text_body,optional"`         // Plain text fallback
+	Tags           []string              `json:"tags,optional"`              // Applied to every recipient
+	Meta           map[string]string     `json:"meta,optional"`              // Applied to every recipient
+	SendAt         string                `json:"send_at,optional"`           // RFC 3339, up to 30 days ahead; empty sends now
+	Recipients     []BatchEmailRecipient `json:"recipients"`                 // Max 500
+	IdempotencyKey string                `header:"Idempotency-Key,optional"` // Retries with the same key return the original sends
+}
+
+type SendBatchEmailResponse struct {
+	Success bool               `json:"success"` // Every recipient was queued
+	Queued  int                `json:"queued"`
+	Failed  int                `json:"failed"`
+	Results []BatchEmailResult `json:"results"` // In recipient order
+}
+
 type SendCampaignNowRequest struct {
 	Id string `path:"id"`
 }
 
 type SendEmailRequest struct {
-	To           string            `json:"to"`                     // Email address
-	TemplateSlug string            `json:"template_slug,optional"` // Use design template
-	Subject      string            `json:"subject,optional"`       // Required if no template
-	Body         string            `json:"body,optional"`          // HTML body, required if no template
-	TextBody     string            `json:"text_body,optional"`     // Plain text fallback
-	FromName     string            `json:"from_name,optional"`     // Override org default
-	FromEmail    string            `json:"from_email,optional"`    // Override org default
-	ReplyTo      string            `json:"reply_to,optional"`
-	Variables    map[string]string `json:"variables,optional"` // Template variables
-	Tags         []string          `json:"tags,optional"`      // For tracking/filtering
-	Meta         map[string]string `json:"meta,optional"`      // Custom metadata
+	To             string            `json:"to"`                     // Email address
+	TemplateSlug   string            `json:"template_slug,optional"` // Use design template
+	Subject        string            `json:"subject,optional"`       // Required if no template
+	Body           string            `json:"body,optional"`          // HTML body, required if no template
+	TextBody       string            `json:"text_body,optional"`     // Plain text fallback
+	FromName       string            `json:"from_name,optional"`     // Override org default
+	FromEmail      string            `json:"from_email,optional"`    // Override org default
+	ReplyTo        string            `json:"reply_to,optional"`
+	Variables      map[string]string `json:"variables,optional"`         // Template variables
+	Tags           []string          `json:"tags,optional"`              // For tracking/filtering
+	Meta           map[string]string `json:"meta,optional"`              // Custom metadata
+	Attachments    []EmailAttachment `json:"attachments,optional"`       // Max 10, 7MB total
+	SendAt         string            `json:"send_at,optional"`           // RFC 3339, up to 30 days ahead; empty sends now
+	IdempotencyKey string            `header:"Idempotency-Key,optional"` // Retries with the same key return the original send
 }
 
 type SendEmailResponse struct {
 	Success   bool   `json:"success"`
 	MessageId string `json:"message_id"` // For tracking
-	Status    string `json:"status"`     // queued, sent, failed
+	Status    string `json:"status"`     // queued, scheduled, sent, failed
 	Message   string `json:"message,optional"`
 }
 
@@ -1419,6 +1885,12 @@ type SetupStatusResponse struct {
 	MissingSettings    []string `json:"missing_settings"`    // List of missing required settings
 }
 
+type StartListVerificationRequest struct {
+	Id          string `path:"id"`
+	CheckSMTP   bool   `json:"check_smtp,optional"`  // Probe mailboxes and detect catch-all domains
+	Concurrency int    `json:"concurrency,optional"` // Parallel checks, 1-20 (default 5)
+}
+
 type StatsOverviewResponse struct {
 	TotalContacts   int     `json:"total_contacts"`
 	NewContacts     int     `json:"new_contacts"`    // In period
@@ -1427,6 +1899,8 @@ type StatsOverviewResponse struct {
 	EmailsDelivered int     `json:"emails_delivered"`
 	EmailsOpened    int     `json:"emails_opened"`
 	EmailsClicked   int     `json:"emails_clicked"`
+	HumanOpened     int     `json:"human_opened"`  // Opens not made by proxies, scanners or bots
+	HumanClicked    int     `json:"human_clicked"` // Clicks not made by proxies, scanners or bots
 	EmailsBounced   int     `json:"emails_bounced"`
 	OpenRate        float64 `json:"open_rate"`   // Percentage
 	ClickRate       float64 `json:"click_rate"`  // Percentage
@@ -1485,6 +1959,10 @@ type SubscriberInfo struct {
 	VerifiedAt     string `json:"verified_at,optional"`
 	UnsubscribedAt string `json:"unsubscribed_at,optional"`
 	BlockedAt      string `json:"blocked_at,optional"`
+	// Outcome of the last signup validation ('valid' or 'invalid')
+	ValidationStatus string `json:"validation_status,optional"`
+	ValidationReason string `json:"validation_reason,optional"`
+	ValidatedAt      string `json:"validated_at,optional"`
 }
 
 type SuppressedEmailInfo struct {
@@ -1579,19 +2057,23 @@ type UnenrollSequenceRequest struct {
 }
 
 type UpdateCampaignRequest struct {
-	Id             string   `path:"id"`
-	Name           string   `json:"name,optional"`
-	Subject        string   `json:"subject,optional"`
-	PreviewText    string   `json:"preview_text,optional"`
-	FromName       string   `json:"from_name,optional"`
-	FromEmail      string   `json:"from_email,optional"`
-	ReplyTo        string   `json:"reply_to,optional"`
-	HtmlBody       string   `json:"html_body,optional"`
-	PlainText      string   `json:"plain_text,optional"`
-	ListIds        []string `json:"list_ids,optional"`
-	ExcludeListIds []string `json:"exclude_list_ids,optional"`
-	TrackOpens     bool     `json:"track_opens,optional"`
-	TrackClicks    bool     `json:"track_clicks,optional"`
+	Id             string               `path:"id"`
+	Name           string               `json:"name,optional"`
+	Subject        string               `json:"subject,optional"`
+	PreviewText    string               `json:"preview_text,optional"`
+	FromName       string               `json:"from_name,optional"`
+	FromEmail      string               `json:"from_email,optional"`
+	ReplyTo        string               `json:"reply_to,optional"`
+	HtmlBody       string               `json:"html_body,optional"`
+	PlainText      string               `json:"plain_text,optional"`
+	ListIds        []string             `json:"list_ids,optional"`
+	ExcludeListIds []string             `json:"exclude_list_ids,optional"`
+	SegmentFilter  string               `json:"segment_filter,optional"` // JSON segment DSL
+	SegmentId      string               `json:"segment_id,optional"`     // Saved segment
+	TrackOpens     bool                 `json:"track_opens,optional"`
+	TrackClicks    bool                 `json:"track_clicks,optional"`
+	DeliveryMode   string               `json:"delivery_mode,optional"` // immediate, local_time, optimal_time
+	AbTest         *CampaignAbTestInput `json:"ab_test,optional"`       // No variants removes the test
 }
 
 type UpdateCheckResponse struct {
@@ -1609,6 +2091,7 @@ type UpdateContactRequest struct {
 	Phone        string            `json:"phone,optional"`
 	Company      string            `json:"company,optional"`
 	CustomFields map[string]string `json:"custom_fields,optional"`
+	Timezone     string            `json:"timezone,optional"` // IANA time zone, e.g. America/New_York
 }
 
 type UpdateCustomFieldRequest struct {
@@ -1682,6 +2165,16 @@ type UpdateListRequest struct {
 	UnsubscribeScope       *string `json:"unsubscribe_scope,optional"`
 }
 
+type UpdateListValidationPolicyRequest struct {
+	Id              string `path:"id"`
+	Inherit         bool   `json:"inherit,optional"` // Clear the list policy and use the org policy
+	Enabled         bool   `json:"enabled,optional"`
+	BlockDisposable bool   `json:"block_disposable,optional"`
+	RejectRole      bool   `json:"reject_role,optional"`
+	CheckSMTP       bool   `json:"check_smtp,optional"`
+	TimeoutSeconds  int    `json:"timeout_seconds,optional"`
+}
+
 type UpdateOrgEmailConfigRequest struct {
 	OrgId         string  `path:"org_id"`
 	SESRateLimit  float64 `json:"ses_rate_limit,optional"`
@@ -1712,6 +2205,34 @@ type UpdateOrgRequest struct {
 	AppUrl      string `json:"app_url,optional"`
 }
 
+type UpdateOrgValidationPolicyRequest struct {
+	OrgId           string `path:"org_id"`
+	Enabled         bool   `json:"enabled"`
+	BlockDisposable bool   `json:"block_disposable,optional"`
+	RejectRole      bool   `json:"reject_role,optional"`
+	CheckSMTP       bool   `json:"check_smtp,optional"`
+	TimeoutSeconds  int    `json:"timeout_seconds,optional"`
+}
+
+type UpdateRuleRequest struct {
+	Id          string `path:"id"`
+	Name        string `json:"name,optional"`
+	Description string `json:"description,optional"`
+	RuleJson    string `json:"rule_json,optional"`
+	EntityType  string `json:"entity_type,optional"`
+	EntityId    string `json:"entity_id,optional"`
+	Enabled     *bool  `json:"enabled,optional"`
+	Salience    *int   `json:"salience,optional"`
+}
+
+type UpdateSegmentRequest struct {
+	Id          string   `path:"id"`
+	Name        string   `json:"name,optional"`
+	Description string   `json:"description,optional"`
+	ListIds     []string `json:"list_ids,optional"`
+	Filter      string   `json:"filter,optional"` // JSON segment DSL
+}
+
 type UpdateSequenceRequest struct {
 	Id                     string  `path:"id"`
 	Name                   string  `json:"name,optional"`
@@ -1798,6 +2319,21 @@ type ValidateInvitationResponse struct {
 	Message   string `json:"message,omitempty"`
 }
 
+type ValidateRuleResponse struct {
+	Valid  bool     `json:"valid"`
+	Errors []string `json:"errors"`
+	Rule   RuleInfo `json:"rule"`
+}
+
+type ValidationPolicyInfo struct {
+	Enabled         bool `json:"enabled"` // Syntax and MX checks apply when enabled
+	BlockDisposable bool `json:"block_disposable"`
+	RejectRole      bool `json:"reject_role"` // Reject info@, support@, etc.
+	CheckSMTP       bool `json:"check_smtp"`  // Probe the mailbox over SMTP
+	TimeoutSeconds  int  `json:"timeout_seconds"`
+	Inherited       bool `json:"inherited"` // List has no policy of its own and uses the org policy
+}
+
 type VerifyEmailRequest struct {
 	Token string `form:"token"`
 }
@@ -1838,3 +2374,16 @@ type WebhookLogInfo struct {
 	Duration    int    `json:"duration_ms"`
 	DeliveredAt string `json:"delivered_at"`
 }
+
+type WorkerStatusInfo struct {
+	Name      string           `json:"name"`
+	Status    string           `json:"status"` // running, crashed, stopped
+	StartedAt string           `json:"started_at,omitempty"`
+	Restarts  int              `json:"restarts"`
+	LastError string           `json:"last_error,omitempty"`
+	Counters  map[string]int64 `json:"counters,omitempty"`
+}
+
+type WorkerStatusResponse struct {
+	Workers []WorkerStatusInfo `json:"workers"`
+}
@@ -0,0 +1,142 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/events"
+	"github.com/outlet-sh/outlet/internal/services/email"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// ProviderWebhookRequest defines the path parameters for provider webhooks
+type ProviderWebhookRequest struct {
+	OrgID string `path:"orgId"`
+}
+
+// ProviderHandler returns an HTTP handler for delivery events from the
+// organization's configured email provider (Postmark, Mailgun, SendGrid,
+// Resend). The provider authenticates and parses the request.
+// The orgID is extracted from the URL path: /webhooks/email/:orgId
+func ProviderHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ProviderWebhookRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		if req.OrgID == "" {
+			http.Error(w, "Missing org ID", http.StatusBadRequest)
+			return
+		}
+
+		ctx := context.Background()
+
+		provider, err := svcCtx.EmailService.Provider(ctx, req.OrgID)
+		if err != nil {
+			fmt.Printf("[Provider Webhook] No provider for org %s: %v\n", req.OrgID, err)
+			http.Error(w, "Email provider not configured", http.StatusNotFound)
+			return
+		}
+
+		evts, err := provider.ParseWebhook(r)
+		if errors.Is(err, email.ErrNotSupported) {
+			http.Error(w, "Provider does not support webhooks", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			fmt.Printf("[Provider Webhook] Rejected %s webhook for org %s: %v\n", provider.Name(), req.OrgID, err)
+			http.Error(w, "Invalid webhook", http.StatusUnauthorized)
+			return
+		}
+
+		for _, evt := range evts {
+			processProviderEvent(ctx, svcCtx, req.OrgID, provider.Name(), evt)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success": true, "message": "Notification processed"}`))
+	}
+}
+
+// processProviderEvent records a provider event against the organization
+// that owns the webhook. Each organization controls its own webhook secret,
+// so suppressions and contact blocks stay within that organization rather
+// than going to the platform-wide bounce and complaint lists.
+func processProviderEvent(ctx context.Context, svcCtx *svc.ServiceContext, orgID, providerName string, evt email.WebhookEvent) {
+	switch evt.Type {
+	case email.EventBounced:
+		fmt.Printf("[Provider Webhook] Recording %s bounce for %s (org: %s, provider: %s)\n", evt.BounceType, evt.Email, orgID, providerName)
+
+		if svcCtx.Events != nil {
+			_ = events.Emit(svcCtx.Events, events.TopicEmailBounced, events.EmailEvent{
+				OrgID:      orgID,
+				EmailID:    evt.MessageID,
+				Status:     "bounced",
+				BounceType: evt.BounceType,
+				Timestamp:  time.Now(),
+			})
+		}
+
+		// Soft bounces may recover; only hard bounces suppress the address
+		if evt.BounceType == "hard" {
+			suppressProviderAddress(ctx, svcCtx, orgID, providerName, evt, "hard bounce")
+		}
+
+	case email.EventComplained:
+		fmt.Printf("[Provider Webhook] Recording complaint for %s (org: %s, provider: %s)\n", evt.Email, orgID, providerName)
+
+		if svcCtx.Events != nil {
+			_ = events.Emit(svcCtx.Events, events.TopicEmailComplained, events.EmailEvent{
+				OrgID:     orgID,
+				EmailID:   evt.MessageID,
+				Status:    "complained",
+				Timestamp: time.Now(),
+			})
+		}
+
+		suppressProviderAddress(ctx, svcCtx, orgID, providerName, evt, "complaint")
+
+	case email.EventDelivered:
+		if svcCtx.Events != nil {
+			_ = events.Emit(svcCtx.Events, events.TopicEmailDelivered, events.EmailEvent{
+				OrgID:     orgID,
+				EmailID:   evt.MessageID,
+				Status:    "delivered",
+				Timestamp: time.Now(),
+			})
+		}
+	}
+}
+
+// suppressProviderAddress adds a bounced or complaining address to the
+// organization's suppression list and blocks its contact there
+func suppressProviderAddress(ctx context.Context, svcCtx *svc.ServiceContext, orgID, providerName string, evt email.WebhookEvent, reason string) {
+	if evt.Reason != "" {
+		reason += ": " + evt.Reason
+	}
+	_, err := svcCtx.DB.AddToSuppressionList(ctx, db.AddToSuppressionListParams{
+		OrgID:  orgID,
+		Email:  evt.Email,
+		Reason: sql.NullString{String: reason, Valid: true},
+		Source: sql.NullString{String: providerName, Valid: true},
+	})
+	if err != nil {
+		fmt.Printf("[Provider Webhook] Failed to suppress %s: %v\n", evt.Email, err)
+		return
+	}
+
+	if err := svcCtx.DB.BlockOrgContactByEmail(ctx, db.BlockOrgContactByEmailParams{
+		OrgID: orgID,
+		Email: evt.Email,
+	}); err != nil {
+		fmt.Printf("[Provider Webhook] Failed to block contact %s: %v\n", evt.Email, err)
+	}
+}
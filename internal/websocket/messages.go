@@ -12,6 +12,8 @@ const (
 	TypeSubscribe                = "subscribe"
 	TypeUnsubscribe              = "unsubscribe"
 	TypeBackupUpdate             = "backup_update"
+	TypeListVerificationUpdate   = "list_verification_update"
+	TypeExportUpdate             = "export_update"
 )
 
 // Message is the base WebSocket message structure
@@ -74,3 +76,38 @@ func NewBackupUpdate(id, status, filename string, fileSize int64, errorMsg strin
 		Error:    errorMsg,
 	})
 }
+
+// ListVerificationUpdate is sent as a list verification job makes progress
+type ListVerificationUpdate struct {
+	ID        string           `json:"id"`
+	OrgID     string           `json:"org_id"`
+	ListID    int64            `json:"list_id"`
+	Status    string           `json:"status"`
+	Total     int64            `json:"total"`
+	Processed int64            `json:"processed"`
+	Verdicts  map[string]int64 `json:"verdicts"`
+	Error     string           `json:"error,omitempty"`
+}
+
+// NewListVerificationUpdate creates a list verification progress message
+func NewListVerificationUpdate(update ListVerificationUpdate) *Message {
+	return NewMessage(TypeListVerificationUpdate, update)
+}
+
+// ExportUpdate is sent as an export job makes progress
+type ExportUpdate struct {
+	ID        string `json:"id"`
+	OrgID     string `json:"org_id"`
+	Type      string `json:"type"`
+	Status    string `json:"status"`
+	Total     int64  `json:"total"`
+	Processed int64  `json:"processed"`
+	Filename  string `json:"filename"`
+	FileSize  int64  `json:"file_size,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// NewExportUpdate creates an export progress message
+func NewExportUpdate(update ExportUpdate) *Message {
+	return NewMessage(TypeExportUpdate, update)
+}
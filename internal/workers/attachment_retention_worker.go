@@ -0,0 +1,80 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/svc"
+)
+
+// AttachmentRetentionWorker drops stored attachment content of transactional
+// sends once its retention window has passed. Attachment metadata is kept.
+type AttachmentRetentionWorker struct {
+	svcCtx   *svc.ServiceContext
+	interval time.Duration
+	stop     chan struct{}
+	wg       sync.WaitGroup
+	crashGuard
+}
+
+// NewAttachmentRetentionWorker creates a new attachment retention worker
+func NewAttachmentRetentionWorker(svcCtx *svc.ServiceContext, interval time.Duration) *AttachmentRetentionWorker {
+	return &AttachmentRetentionWorker{
+		svcCtx:   svcCtx,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start starts the attachment retention worker
+func (w *AttachmentRetentionWorker) Start() {
+	w.wg.Add(1)
+	go w.run()
+}
+
+// Stop stops the attachment retention worker
+func (w *AttachmentRetentionWorker) Stop() {
+	close(w.stop)
+	w.wg.Wait()
+}
+
+func (w *AttachmentRetentionWorker) run() {
+	defer w.wg.Done()
+	defer w.recover("Attachment retention worker")
+
+	// Run immediately on start
+	w.purgeExpired()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.purgeExpired()
+		case <-w.stop:
+			log.Println("Attachment retention worker stopping...")
+			return
+		}
+	}
+}
+
+func (w *AttachmentRetentionWorker) purgeExpired() {
+	purged, err := w.svcCtx.DB.PurgeExpiredTransactionalAttachments(context.Background())
+	if err != nil {
+		log.Printf("Failed to purge expired attachments: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("Purged content of %d expired attachments", purged)
+	}
+}
+
+// StartAttachmentRetentionWorker starts the attachment retention worker with a 1-hour interval
+func StartAttachmentRetentionWorker(svcCtx *svc.ServiceContext) *AttachmentRetentionWorker {
+	worker := NewAttachmentRetentionWorker(svcCtx, time.Hour)
+	worker.Start()
+	return worker
+}
@@ -0,0 +1,190 @@
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/logic/admin/backup"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+	"github.com/outlet-sh/outlet/internal/websocket"
+
+	"github.com/robfig/cron/v3"
+)
+
+// BackupWorker runs scheduled backups on backup.schedule_cron and prunes
+// backups older than backup.retention_days. Settings are re-read on every
+// tick, so changes from the admin UI apply without a restart.
+type BackupWorker struct {
+	svcCtx   *svc.ServiceContext
+	interval time.Duration
+	stop     chan struct{}
+	wg       sync.WaitGroup
+	crashGuard
+
+	// lastCheck is the time the schedule was last evaluated
+	lastCheck time.Time
+}
+
+// NewBackupWorker creates a new backup worker
+func NewBackupWorker(svcCtx *svc.ServiceContext, interval time.Duration) *BackupWorker {
+	return &BackupWorker{
+		svcCtx:    svcCtx,
+		interval:  interval,
+		stop:      make(chan struct{}),
+		lastCheck: time.Now(),
+	}
+}
+
+// Start starts the backup worker
+func (w *BackupWorker) Start() {
+	w.wg.Add(1)
+	go w.run()
+}
+
+// Stop stops the backup worker
+func (w *BackupWorker) Stop() {
+	close(w.stop)
+	w.wg.Wait()
+}
+
+func (w *BackupWorker) run() {
+	defer w.wg.Done()
+	defer w.recover("Backup worker")
+
+	// Backups run in-process, so anything still in progress was interrupted
+	w.failInterruptedBackups()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			w.tick(now)
+		case <-w.stop:
+			log.Println("Backup worker stopping...")
+			return
+		}
+	}
+}
+
+func (w *BackupWorker) tick(now time.Time) {
+	ctx := context.Background()
+
+	settings, err := backup.NewGetBackupSettingsLogic(ctx, w.svcCtx).GetBackupSettings()
+	if err != nil {
+		log.Printf("Failed to load backup settings: %v", err)
+		return
+	}
+
+	since := w.lastCheck
+	w.lastCheck = now
+
+	if !settings.ScheduleEnabled || settings.ScheduleCron == "" {
+		return
+	}
+
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	schedule, err := parser.Parse(settings.ScheduleCron)
+	if err != nil {
+		log.Printf("Invalid backup schedule %q: %v", settings.ScheduleCron, err)
+		return
+	}
+
+	// Due when a scheduled time fell between the previous tick and now
+	if schedule.Next(since).After(now) {
+		return
+	}
+
+	w.runScheduledBackup(ctx, settings)
+	w.pruneExpiredBackups(ctx, settings.RetentionDays)
+}
+
+func (w *BackupWorker) runScheduledBackup(ctx context.Context, settings *types.BackupSettingsResponse) {
+	inProgress, err := w.svcCtx.DB.GetBackupsInProgress(ctx)
+	if err != nil {
+		log.Printf("Failed to check backups in progress: %v", err)
+		return
+	}
+	if len(inProgress) > 0 {
+		log.Printf("Skipping scheduled backup: backup %s is still in progress", inProgress[0].ID)
+		return
+	}
+
+	req := &types.CreateBackupRequest{Format: "db"}
+	if settings.S3Enabled && settings.S3Bucket != "" && settings.HasS3Creds {
+		req.UploadToS3 = true
+		req.S3Bucket = settings.S3Bucket
+	}
+
+	// CreateScheduledBackup records the run in backup_history, writes the
+	// archive in the background and broadcasts progress over the websocket hub
+	resp, err := backup.NewCreateBackupLogic(ctx, w.svcCtx).CreateScheduledBackup(req)
+	if err != nil {
+		log.Printf("Scheduled backup failed to start: %v", err)
+		return
+	}
+
+	log.Printf("Scheduled backup started: %s (storage=%s)", resp.Backup.Filename, resp.Backup.StorageType)
+}
+
+// pruneExpiredBackups deletes completed backups older than the retention period
+// from local disk, S3 and backup_history
+func (w *BackupWorker) pruneExpiredBackups(ctx context.Context, retentionDays int) {
+	if retentionDays <= 0 {
+		return
+	}
+
+	expired, err := w.svcCtx.DB.GetExpiredBackups(ctx, sql.NullString{String: fmt.Sprintf("-%d", retentionDays), Valid: true})
+	if err != nil {
+		log.Printf("Failed to list expired backups: %v", err)
+		return
+	}
+
+	deleter := backup.NewDeleteBackupLogic(ctx, w.svcCtx)
+	for _, b := range expired {
+		if _, err := deleter.DeleteBackup(&types.DeleteBackupRequest{Id: b.ID}); err != nil {
+			log.Printf("Failed to delete expired backup %s: %v", b.Filename, err)
+			continue
+		}
+		log.Printf("Deleted expired backup %s (created %s)", b.Filename, b.CreatedAt.String)
+	}
+}
+
+func (w *BackupWorker) failInterruptedBackups() {
+	ctx := context.Background()
+
+	inProgress, err := w.svcCtx.DB.GetBackupsInProgress(ctx)
+	if err != nil {
+		log.Printf("Failed to check backups in progress: %v", err)
+		return
+	}
+
+	for _, b := range inProgress {
+		const errMsg = "interrupted by server restart"
+		if _, err := w.svcCtx.DB.UpdateBackupStatus(ctx, db.UpdateBackupStatusParams{
+			ID:           b.ID,
+			Status:       "failed",
+			ErrorMessage: sql.NullString{String: errMsg, Valid: true},
+		}); err != nil {
+			log.Printf("Failed to mark backup %s as failed: %v", b.ID, err)
+			continue
+		}
+		if w.svcCtx.WebSocketHub != nil {
+			w.svcCtx.WebSocketHub.Broadcast(websocket.NewBackupUpdate(b.ID, "failed", b.Filename, 0, errMsg))
+		}
+	}
+}
+
+// StartBackupWorker starts the backup worker, checking the schedule every minute
+func StartBackupWorker(svcCtx *svc.ServiceContext) *BackupWorker {
+	worker := NewBackupWorker(svcCtx, time.Minute)
+	worker.Start()
+	return worker
+}
@@ -0,0 +1,219 @@
+package workers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/db/dbtest"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
+)
+
+// newBackupWorkerFixture returns a worker on a fresh database with the given
+// backup settings. Backups are written to a temp working directory.
+func newBackupWorkerFixture(t *testing.T, settings map[string]string) (*BackupWorker, *db.Store) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	store := dbtest.New(t)
+	for key, value := range settings {
+		dbtest.Exec(t, store, `INSERT INTO platform_settings (key, value_text, category) VALUES (?, ?, 'backup')`, key, value)
+	}
+
+	var seq int
+	var name, path string
+	if err := store.GetDB().QueryRow(`PRAGMA database_list`).Scan(&seq, &name, &path); err != nil {
+		t.Fatal(err)
+	}
+
+	svcCtx := &svc.ServiceContext{DB: store}
+	svcCtx.Config.Database.Path = path
+	return NewBackupWorker(svcCtx, time.Minute), store
+}
+
+// waitForBackups waits until no backup is in progress so the background
+// archive goroutine is done before the temp dirs are removed
+func waitForBackups(t *testing.T, store *db.Store) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		inProgress, err := store.GetBackupsInProgress(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(inProgress) == 0 {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatal("backup still in progress")
+}
+
+func backupStatuses(t *testing.T, store *db.Store) map[string]string {
+	t.Helper()
+	rows, err := store.GetDB().Query(`SELECT id, backup_type || ':' || status FROM backup_history`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	statuses := map[string]string{}
+	for rows.Next() {
+		var id, status string
+		if err := rows.Scan(&id, &status); err != nil {
+			t.Fatal(err)
+		}
+		statuses[id] = status
+	}
+	return statuses
+}
+
+func TestBackupWorker_Schedule(t *testing.T) {
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		settings map[string]string
+		since    time.Time
+		now      time.Time
+		want     int
+	}{
+		{"due", map[string]string{"backup.schedule_cron": "0 3 * * *"}, day.Add(2*time.Hour + 59*time.Minute), day.Add(3*time.Hour + 30*time.Second), 1},
+		{"not due", map[string]string{"backup.schedule_cron": "0 3 * * *"}, day.Add(3*time.Hour + 30*time.Second), day.Add(3*time.Hour + 90*time.Second), 0},
+		{"default schedule", nil, day.Add(2*time.Hour + 59*time.Minute), day.Add(3*time.Hour + 30*time.Second), 1},
+		{"disabled", map[string]string{"backup.schedule_enabled": "false"}, day.Add(2*time.Hour + 59*time.Minute), day.Add(3*time.Hour + 30*time.Second), 0},
+		{"invalid cron", map[string]string{"backup.schedule_cron": "every day"}, day, day.Add(24 * time.Hour), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, store := newBackupWorkerFixture(t, tt.settings)
+			w.lastCheck = tt.since
+
+			w.tick(tt.now)
+			waitForBackups(t, store)
+
+			statuses := backupStatuses(t, store)
+			if len(statuses) != tt.want {
+				t.Fatalf("backups = %v, want %d", statuses, tt.want)
+			}
+			for id, status := range statuses {
+				if status != "scheduled:completed" {
+					t.Errorf("backup %s = %s, want scheduled:completed", id, status)
+				}
+			}
+			if !w.lastCheck.Equal(tt.now) {
+				t.Errorf("lastCheck = %v, want %v", w.lastCheck, tt.now)
+			}
+		})
+	}
+}
+
+func TestBackupWorker_SkipsWhileBackupInProgress(t *testing.T) {
+	w, store := newBackupWorkerFixture(t, nil)
+	dbtest.Exec(t, store, `INSERT INTO backup_history (id, filename, backup_type, storage_type, status) VALUES ('running', 'running.zip', 'manual', 'local', 'in_progress')`)
+
+	w.runScheduledBackup(context.Background(), &types.BackupSettingsResponse{})
+
+	if statuses := backupStatuses(t, store); len(statuses) != 1 {
+		t.Errorf("backups = %v, want only the running backup", statuses)
+	}
+}
+
+func TestBackupWorker_FailedBackupIsRecorded(t *testing.T) {
+	w, store := newBackupWorkerFixture(t, map[string]string{"backup.schedule_cron": "0 3 * * *"})
+	// The SQL dump cannot open a directory, so the archive step fails
+	w.svcCtx.Config.Database.Path = t.TempDir()
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	w.lastCheck = day.Add(2 * time.Hour)
+
+	w.tick(day.Add(3*time.Hour + 30*time.Second))
+	waitForBackups(t, store)
+
+	statuses := backupStatuses(t, store)
+	if len(statuses) != 1 {
+		t.Fatalf("backups = %v, want 1", statuses)
+	}
+	for id, status := range statuses {
+		if status != "scheduled:failed" {
+			t.Errorf("backup %s = %s, want scheduled:failed", id, status)
+		}
+	}
+
+	// A failed run does not block the next scheduled one
+	w.svcCtx.Config.Database.Path = filepath.Join(t.TempDir(), "outlet.db")
+	w.tick(day.Add(27*time.Hour + 30*time.Second))
+	waitForBackups(t, store)
+	if statuses := backupStatuses(t, store); len(statuses) != 2 {
+		t.Errorf("backups = %v, want a second run after the failure", statuses)
+	}
+}
+
+func TestBackupWorker_PrunesExpiredBackups(t *testing.T) {
+	w, store := newBackupWorkerFixture(t, nil)
+
+	dir := t.TempDir()
+	files := map[string]string{}
+	for _, id := range []string{"old", "recent", "old-failed"} {
+		files[id] = filepath.Join(dir, id+".zip")
+		if err := os.WriteFile(files[id], []byte("backup"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dbtest.Exec(t, store, `INSERT INTO backup_history (id, filename, file_path, backup_type, storage_type, status, created_at) VALUES
+		('old', 'old.zip', ?, 'scheduled', 'local', 'completed', datetime('now', '-40 days')),
+		('recent', 'recent.zip', ?, 'scheduled', 'local', 'completed', datetime('now', '-10 days')),
+		('old-failed', 'old-failed.zip', ?, 'scheduled', 'local', 'failed', datetime('now', '-40 days'))`,
+		files["old"], files["recent"], files["old-failed"])
+
+	w.pruneExpiredBackups(context.Background(), 30)
+
+	statuses := backupStatuses(t, store)
+	if _, ok := statuses["old"]; ok {
+		t.Error("expired backup record was not deleted")
+	}
+	if _, err := os.Stat(files["old"]); !os.IsNotExist(err) {
+		t.Error("expired backup file was not deleted")
+	}
+	for _, id := range []string{"recent", "old-failed"} {
+		if _, ok := statuses[id]; !ok {
+			t.Errorf("backup %s was pruned", id)
+		}
+		if _, err := os.Stat(files[id]); err != nil {
+			t.Errorf("backup file %s was removed: %v", id, err)
+		}
+	}
+
+	// Retention of zero keeps everything
+	w.pruneExpiredBackups(context.Background(), 0)
+	if got := len(backupStatuses(t, store)); got != 2 {
+		t.Errorf("retention 0 pruned backups, %d left", got)
+	}
+}
+
+func TestBackupWorker_FailsInterruptedBackups(t *testing.T) {
+	w, store := newBackupWorkerFixture(t, nil)
+	dbtest.Exec(t, store, `INSERT INTO backup_history (id, filename, backup_type, storage_type, status) VALUES
+		('running', 'running.zip', 'manual', 'local', 'in_progress'),
+		('done', 'done.zip', 'manual', 'local', 'completed')`)
+
+	w.failInterruptedBackups()
+
+	running, err := store.GetBackup(context.Background(), "running")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if running.Status != "failed" || running.ErrorMessage.String != "interrupted by server restart" {
+		t.Errorf("interrupted backup = %s (%q), want failed", running.Status, running.ErrorMessage.String)
+	}
+	done, err := store.GetBackup(context.Background(), "done")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if done.Status != "completed" {
+		t.Errorf("completed backup = %s, want completed", done.Status)
+	}
+}
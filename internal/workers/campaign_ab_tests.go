@@ -0,0 +1,174 @@
+package workers
+
+import (
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/services/segment"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// abTest returns a campaign's A/B test and its variants, or nil when the
+// campaign is not a test
+func (s *CampaignScheduler) abTest(campaignID string) (*db.CampaignAbTest, []db.CampaignVariant, error) {
+	test, err := s.store.GetCampaignABTest(s.ctx, campaignID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variants, err := s.store.ListCampaignVariants(s.ctx, campaignID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(variants) < 2 {
+		return nil, nil, nil
+	}
+	return &test, variants, nil
+}
+
+// testGroup picks a random share of the audience to test the variants on
+func testGroup(subscribers []segment.Member, percent int64) []segment.Member {
+	if percent >= 100 || len(subscribers) == 0 {
+		return subscribers
+	}
+
+	size := (len(subscribers)*int(percent) + 99) / 100
+	group := make([]segment.Member, len(subscribers))
+	copy(group, subscribers)
+	rand.Shuffle(len(group), func(i, j int) {
+		group[i], group[j] = group[j], group[i]
+	})
+	return group[:size]
+}
+
+// holdForWinner reports whether a campaign whose sends are all done is
+// waiting on its A/B test's winner, starting the wait if it just began
+func (s *CampaignScheduler) holdForWinner(campaignID string) bool {
+	test, err := s.store.GetCampaignABTest(s.ctx, campaignID)
+	if err != nil {
+		return false
+	}
+
+	switch test.Status {
+	case "testing":
+		if err := s.store.SetCampaignABTestStatus(s.ctx, db.SetCampaignABTestStatusParams{
+			Status:     "waiting",
+			CampaignID: campaignID,
+		}); err != nil {
+			logx.Errorf("Failed to start A/B test wait for campaign %s: %v", campaignID, err)
+		}
+		logx.Infof("Campaign %s A/B test variants sent, waiting for a winner", campaignID)
+		return true
+	case "waiting":
+		return true
+	}
+	return false
+}
+
+// finishABTests picks the winners of tests whose wait is over and sends the
+// rest of their audience the winning variant
+func (s *CampaignScheduler) finishABTests() {
+	due, err := s.store.ListDueCampaignABTests(s.ctx, time.Now().UTC().Format(time.DateTime))
+	if err != nil {
+		logx.Errorf("Failed to get due A/B tests: %v", err)
+		return
+	}
+
+	for _, test := range due {
+		winnerID := test.WinnerVariantID.String
+		if !test.WinnerVariantID.Valid {
+			stats, err := s.store.GetCampaignVariantStats(s.ctx, test.CampaignID)
+			if err != nil {
+				logx.Errorf("Failed to get variant stats for campaign %s: %v", test.CampaignID, err)
+				continue
+			}
+			winnerID = pickWinner(stats, test.WinnerMetric)
+			if winnerID == "" {
+				continue
+			}
+			if _, err := s.store.SetCampaignABTestWinner(s.ctx, db.SetCampaignABTestWinnerParams{
+				WinnerVariantID: sql.NullString{String: winnerID, Valid: true},
+				CampaignID:      test.CampaignID,
+			}); err != nil {
+				logx.Errorf("Failed to set A/B test winner for campaign %s: %v", test.CampaignID, err)
+				continue
+			}
+		}
+
+		if err := s.sendWinner(test.CampaignID, winnerID); err != nil {
+			logx.Errorf("Failed to send A/B test winner for campaign %s: %v", test.CampaignID, err)
+		}
+	}
+}
+
+// sendWinner sends the winning variant to everyone in the audience the test
+// did not reach
+func (s *CampaignScheduler) sendWinner(campaignID, winnerID string) error {
+	campaign, err := s.store.GetCampaignByID(s.ctx, campaignID)
+	if err != nil {
+		return err
+	}
+
+	// Finished before the sends exist, so they complete the campaign
+	// rather than hold it again
+	if err := s.store.SetCampaignABTestStatus(s.ctx, db.SetCampaignABTestStatusParams{
+		Status:     "finished",
+		CampaignID: campaignID,
+	}); err != nil {
+		return err
+	}
+	if campaign.Status.String == "cancelled" {
+		return nil
+	}
+
+	audience, err := s.buildAudience(campaign)
+	if err != nil {
+		return err
+	}
+	subscribers, err := segment.Resolve(s.ctx, s.store.GetDB(), audience)
+	if err != nil {
+		return err
+	}
+
+	created := s.createSends(campaignID, subscribers, []db.CampaignVariant{{ID: winnerID}}, s.sendPlan(campaign))
+	if err := s.store.SetCampaignRecipientsCount(s.ctx, db.SetCampaignRecipientsCountParams{
+		ID:              campaignID,
+		RecipientsCount: sql.NullInt64{Int64: campaign.RecipientsCount.Int64 + created, Valid: true},
+	}); err != nil {
+		logx.Errorf("Failed to update recipient count: %v", err)
+	}
+
+	logx.Infof("Campaign %s A/B test winner %s queued for %d more recipients", campaignID, winnerID, created)
+
+	// With nobody left to send to, the campaign is already done
+	s.checkCampaignComplete(campaignID)
+	return nil
+}
+
+// pickWinner returns the variant with the best human open or click rate.
+// Ties go to the earlier variant.
+func pickWinner(stats []db.GetCampaignVariantStatsRow, metric string) string {
+	var winner string
+	best := -1.0
+	for _, v := range stats {
+		var rate float64
+		if v.Sent > 0 {
+			engaged := v.HumanOpened
+			if metric == "click_rate" {
+				engaged = v.HumanClicked
+			}
+			rate = float64(engaged) / float64(v.Sent)
+		}
+		if rate > best {
+			winner, best = v.ID, rate
+		}
+	}
+	return winner
+}
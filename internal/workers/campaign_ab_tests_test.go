@@ -0,0 +1,71 @@
+package workers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/services/segment"
+)
+
+func TestTestGroup(t *testing.T) {
+	subscribers := make([]segment.Member, 10)
+	for i := range subscribers {
+		subscribers[i] = segment.Member{ContactID: fmt.Sprint(i)}
+	}
+
+	tests := []struct {
+		percent int64
+		want    int
+	}{
+		{100, 10},
+		{50, 5},
+		{25, 3},
+		{1, 1},
+	}
+	for _, tt := range tests {
+		group := testGroup(subscribers, tt.percent)
+		if len(group) != tt.want {
+			t.Errorf("testGroup(%d%%) picked %d subscribers, want %d", tt.percent, len(group), tt.want)
+		}
+
+		seen := make(map[string]bool)
+		for _, m := range group {
+			if seen[m.ContactID] {
+				t.Errorf("testGroup(%d%%) picked %s twice", tt.percent, m.ContactID)
+			}
+			seen[m.ContactID] = true
+		}
+	}
+
+	if group := testGroup(nil, 50); len(group) != 0 {
+		t.Errorf("testGroup of no subscribers picked %d", len(group))
+	}
+}
+
+func TestPickWinner(t *testing.T) {
+	stats := []db.GetCampaignVariantStatsRow{
+		{ID: "a", Sent: 100, HumanOpened: 20, HumanClicked: 10},
+		{ID: "b", Sent: 50, HumanOpened: 15, HumanClicked: 2},
+		{ID: "c", Sent: 0},
+	}
+
+	if got := pickWinner(stats, "open_rate"); got != "b" {
+		t.Errorf("open_rate winner = %s, want b", got)
+	}
+	if got := pickWinner(stats, "click_rate"); got != "a" {
+		t.Errorf("click_rate winner = %s, want a", got)
+	}
+
+	tied := []db.GetCampaignVariantStatsRow{
+		{ID: "a", Sent: 10, HumanOpened: 5},
+		{ID: "b", Sent: 20, HumanOpened: 10},
+	}
+	if got := pickWinner(tied, "open_rate"); got != "a" {
+		t.Errorf("tied winner = %s, want the first variant a", got)
+	}
+
+	if got := pickWinner(nil, "open_rate"); got != "" {
+		t.Errorf("winner of no variants = %q, want none", got)
+	}
+}
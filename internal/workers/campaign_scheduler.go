@@ -3,6 +3,7 @@ package workers
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -10,6 +11,8 @@ import (
 
 	"github.com/outlet-sh/outlet/internal/db"
 	"github.com/outlet-sh/outlet/internal/services/email"
+	"github.com/outlet-sh/outlet/internal/services/segment"
+	"github.com/outlet-sh/outlet/internal/services/sendtime"
 	"github.com/outlet-sh/outlet/internal/svc"
 
 	"github.com/google/uuid"
@@ -27,9 +30,6 @@ type CampaignSchedulerConfig struct {
 	// Worker pool settings
 	Workers int
 
-	// Rate limiting (emails per second)
-	RateLimit int
-
 	// Batch processing - larger = fewer DB round trips
 	BatchSize int
 
@@ -46,7 +46,6 @@ func DefaultCampaignSchedulerConfig() CampaignSchedulerConfig {
 		ScheduleInterval: 10 * time.Second,
 		SendPollInterval: 2 * time.Second,
 		Workers:          10,             // Increased from 5
-		RateLimit:        14,             // SES sandbox default
 		BatchSize:        1000,           // Increased from 100
 		ErrorThreshold:   100,            // Pause after 100 consecutive errors
 		PoolSize:         20,             // SMTP connection pool size
@@ -122,9 +121,6 @@ type CampaignScheduler struct {
 	store        *db.Store
 	emailService *email.Service
 
-	// Rate limiter (sliding window)
-	limiter *email.CampaignRateLimiter
-
 	// Campaign pipes
 	pipes   map[string]*CampaignPipe
 	pipesMu sync.RWMutex
@@ -141,6 +137,8 @@ type CampaignScheduler struct {
 	totalScheduled atomic.Int64
 	totalSent      atomic.Int64
 	totalFailed    atomic.Int64
+
+	crashGuard
 }
 
 // NewCampaignScheduler creates a new campaign scheduler
@@ -151,7 +149,6 @@ func NewCampaignScheduler(store *db.Store, emailService *email.Service, config C
 		config:       config,
 		store:        store,
 		emailService: emailService,
-		limiter:      email.NewCampaignRateLimiter(config.RateLimit),
 		pipes:        make(map[string]*CampaignPipe),
 		msgQueue:     make(chan db.GetPendingCampaignSendsRow, config.BatchSize),
 		ctx:          ctx,
@@ -161,8 +158,8 @@ func NewCampaignScheduler(store *db.Store, emailService *email.Service, config C
 
 // Start begins the campaign scheduler
 func (s *CampaignScheduler) Start() {
-	logx.Infof("Starting campaign scheduler: %d workers, %d emails/sec, batch=%d, pool=%d",
-		s.config.Workers, s.config.RateLimit, s.config.BatchSize, s.config.PoolSize)
+	logx.Infof("Starting campaign scheduler: %d workers, batch=%d, pool=%d",
+		s.config.Workers, s.config.BatchSize, s.config.PoolSize)
 
 	// Enable SMTP connection pooling (loads SMTP config from platform_settings)
 	if err := s.emailService.EnablePool(s.ctx, s.config.PoolSize); err != nil {
@@ -220,19 +217,52 @@ func (s *CampaignScheduler) Stop() {
 // scheduleChecker polls for scheduled campaigns due to send
 func (s *CampaignScheduler) scheduleChecker() {
 	defer s.wg.Done()
+	defer s.recover("Campaign scheduler")
 	ticker := time.NewTicker(s.config.ScheduleInterval)
 	defer ticker.Stop()
 
 	// Check immediately on startup
+	s.resumeQuotaPausedCampaigns()
 	s.checkScheduledCampaigns()
+	s.finishABTests()
 
 	for {
 		select {
 		case <-s.ctx.Done():
 			return
 		case <-ticker.C:
+			s.resumeQuotaPausedCampaigns()
 			s.checkScheduledCampaigns()
+			s.finishABTests()
+		}
+	}
+}
+
+// resumeQuotaPausedCampaigns resumes campaigns paused for the daily quota
+// once the quota has reset
+func (s *CampaignScheduler) resumeQuotaPausedCampaigns() {
+	due, err := s.store.ListDueCampaignQuotaPauses(s.ctx, time.Now().UTC().Format(time.DateTime))
+	if err != nil {
+		logx.Errorf("Failed to get quota-paused campaigns: %v", err)
+		return
+	}
+
+	for _, campaignID := range due {
+		// The marker guards the resume, so a campaign paused for another
+		// reason since stays paused
+		err := s.store.ExecTx(s.ctx, func(q *db.Queries) error {
+			if err := q.ResumeQuotaPausedCampaign(s.ctx, campaignID); err != nil {
+				return err
+			}
+			return q.DeleteCampaignQuotaPause(s.ctx, campaignID)
+		})
+		if err != nil {
+			logx.Errorf("Failed to resume campaign %s: %v", campaignID, err)
+			continue
 		}
+		// Start with a fresh pipe so the paused flag is cleared
+		s.removePipe(campaignID)
+		logx.Infof("Campaign %s resumed after daily quota reset", campaignID)
 	}
 }
 
@@ -267,53 +297,71 @@ func (s *CampaignScheduler) processCampaign(campaign db.EmailCampaign) error {
 		return err
 	}
 
-	// Parse list_ids (comma-separated)
-	listIDs := parseListIDs(campaign.ListIds.String)
-	if len(listIDs) == 0 {
-		logx.Errorf("Campaign %s has no target lists", campaign.ID)
+	// Build the audience - an invalid segment must never widen it, so fail the campaign
+	audience, err := s.buildAudience(campaign)
+	if err != nil {
+		logx.Errorf("Campaign %s has no valid audience: %v", campaign.ID, err)
 		return s.store.UpdateCampaignStatusByID(s.ctx, db.UpdateCampaignStatusByIDParams{
 			ID:     campaign.ID,
 			Status: sql.NullString{String: "failed", Valid: true},
 		})
 	}
 
-	// Collect all unique subscribers from target lists
-	subscriberMap := make(map[string]db.GetActiveSubscribersForListRow)
+	// Resolve unique subscribers from target lists, minus exclusions, narrowed by the segment
+	subscribers, err := segment.Resolve(s.ctx, s.store.GetDB(), audience)
+	if err != nil {
+		return err
+	}
 
-	for _, listID := range listIDs {
-		subscribers, err := s.store.GetActiveSubscribersForList(s.ctx, listID)
-		if err != nil {
-			logx.Errorf("Failed to get subscribers for list %d: %v", listID, err)
-			continue
+	// An A/B test sends its variants to a share of the audience first; the
+	// rest get the winner once it is picked
+	test, variants, err := s.abTest(campaign.ID)
+	if err != nil {
+		return err
+	}
+	if test != nil {
+		// Started before the sends exist, so the campaign is held for the
+		// winner however quickly the variants go out. A test of the whole
+		// audience leaves nobody to send the winner to.
+		status := "testing"
+		if test.TestPercent >= 100 {
+			status = "finished"
 		}
-
-		for _, sub := range subscribers {
-			// Dedupe by contact ID
-			if _, exists := subscriberMap[sub.ContactID]; !exists {
-				subscriberMap[sub.ContactID] = sub
-			}
+		if err := s.store.SetCampaignABTestStatus(s.ctx, db.SetCampaignABTestStatusParams{
+			Status:     status,
+			CampaignID: campaign.ID,
+		}); err != nil {
+			return err
 		}
+		subscribers = testGroup(subscribers, test.TestPercent)
 	}
 
-	// Parse exclude_list_ids and remove those contacts
-	excludeListIDs := parseListIDs(campaign.ExcludeListIds.String)
-	for _, excludeListID := range excludeListIDs {
-		excludedSubs, err := s.store.GetActiveSubscribersForList(s.ctx, excludeListID)
-		if err != nil {
-			logx.Errorf("Failed to get excluded subscribers for list %d: %v", excludeListID, err)
-			continue
-		}
-		for _, sub := range excludedSubs {
-			delete(subscriberMap, sub.ContactID)
-		}
+	recipientCount := s.createSends(campaign.ID, subscribers, variants, s.sendPlan(campaign))
+
+	// Update recipient count
+	err = s.store.SetCampaignRecipientsCount(s.ctx, db.SetCampaignRecipientsCountParams{
+		ID:              campaign.ID,
+		RecipientsCount: sql.NullInt64{Int64: recipientCount, Valid: true},
+	})
+	if err != nil {
+		logx.Errorf("Failed to update recipient count: %v", err)
 	}
 
-	// Create campaign_sends for each subscriber
-	var recipientCount int64
-	for _, sub := range subscriberMap {
+	s.totalScheduled.Add(1)
+	logx.Infof("Campaign %s queued with %d recipients", campaign.ID, recipientCount)
+
+	return nil
+}
+
+// createSends creates a pending send for each subscriber who has none yet,
+// dealing the variants out in turn and timing each by the plan. It returns
+// how many were created.
+func (s *CampaignScheduler) createSends(campaignID string, subscribers []segment.Member, variants []db.CampaignVariant, plan *sendtime.Plan) int64 {
+	var created int64
+	for i, sub := range subscribers {
 		// Check if send already exists (idempotency)
 		exists, err := s.store.CheckCampaignSendExists(s.ctx, db.CheckCampaignSendExistsParams{
-			CampaignID: campaign.ID,
+			CampaignID: campaignID,
 			ContactID:  sub.ContactID,
 		})
 		if err != nil {
@@ -324,40 +372,62 @@ func (s *CampaignScheduler) processCampaign(campaign db.EmailCampaign) error {
 			continue
 		}
 
+		var variantID sql.NullString
+		if len(variants) > 0 {
+			variantID = sql.NullString{String: variants[i%len(variants)].ID, Valid: true}
+		}
+
+		var sendAt sql.NullString
+		if at := plan.At(sub.ContactID); !at.IsZero() {
+			sendAt = sql.NullString{String: at.UTC().Format(time.DateTime), Valid: true}
+		}
+
 		// Create campaign send
 		trackingToken := uuid.NewString()
 		_, err = s.store.CreateCampaignSend(s.ctx, db.CreateCampaignSendParams{
 			ID:            uuid.NewString(),
-			CampaignID:    campaign.ID,
+			CampaignID:    campaignID,
 			ContactID:     sub.ContactID,
 			ListID:        sql.NullInt64{Int64: sub.ListID, Valid: true},
 			TrackingToken: sql.NullString{String: trackingToken, Valid: true},
+			VariantID:     variantID,
+			SendAt:        sendAt,
 		})
 		if err != nil {
 			logx.Errorf("Failed to create campaign send: %v", err)
 			continue
 		}
-		recipientCount++
+		created++
 	}
+	return created
+}
 
-	// Update recipient count
-	err = s.store.SetCampaignRecipientsCount(s.ctx, db.SetCampaignRecipientsCountParams{
-		ID:              campaign.ID,
-		RecipientsCount: sql.NullInt64{Int64: recipientCount, Valid: true},
-	})
+// sendPlan times a campaign's sends from now by its delivery mode. Without
+// a plan everyone is sent to right away.
+func (s *CampaignScheduler) sendPlan(campaign db.EmailCampaign) *sendtime.Plan {
+	plan, err := sendtime.Load(s.ctx, s.store, campaign.OrgID, campaign.DeliveryMode, time.Now())
 	if err != nil {
-		logx.Errorf("Failed to update recipient count: %v", err)
+		logx.Errorf("Failed to plan send times for campaign %s, sending right away: %v", campaign.ID, err)
+		return nil
 	}
+	return plan
+}
 
-	s.totalScheduled.Add(1)
-	logx.Infof("Campaign %s queued with %d recipients", campaign.ID, recipientCount)
-
-	return nil
+// buildAudience combines a campaign's lists, exclusions, saved segment and inline filter
+func (s *CampaignScheduler) buildAudience(campaign db.EmailCampaign) (segment.Audience, error) {
+	return segment.Targeting{
+		OrgID:          campaign.OrgID,
+		ListIDs:        parseListIDs(campaign.ListIds.String),
+		ExcludeListIDs: parseListIDs(campaign.ExcludeListIds.String),
+		Filter:         campaign.SegmentFilter.String,
+		SegmentID:      campaign.SegmentID.String,
+	}.Build(s.ctx, s.store)
 }
 
 // batchFetcher continuously fetches pending sends and queues them
 func (s *CampaignScheduler) batchFetcher() {
 	defer s.wg.Done()
+	defer s.recover("Campaign scheduler")
 	ticker := time.NewTicker(s.config.SendPollInterval)
 	defer ticker.Stop()
 
@@ -398,6 +468,7 @@ func (s *CampaignScheduler) fetchAndQueueSends() {
 // sendWorker processes messages from the queue
 func (s *CampaignScheduler) sendWorker(id int) {
 	defer s.wg.Done()
+	defer s.recover("Campaign scheduler")
 	logx.Infof("Campaign send worker %d started", id)
 
 	for send := range s.msgQueue {
@@ -416,16 +487,20 @@ func (s *CampaignScheduler) sendWorker(id int) {
 			continue
 		}
 
-		// Rate limit (sliding window)
-		if err := s.limiter.WaitGlobal(s.ctx); err != nil {
-			if s.ctx.Err() != nil {
-				return
-			}
+		// Send the email; without a free token for the org the send is
+		// deferred so the worker moves on to other organizations' mail
+		err := s.sendCampaignEmail(send)
+		var limited *email.RateLimitError
+		if errors.As(err, &limited) {
+			s.deferSend(send, limited.RetryAt)
 			continue
 		}
-
-		// Send the email
-		if err := s.sendCampaignEmail(send); err != nil {
+		if errors.Is(err, email.ErrDailyQuotaReached) {
+			// The send stays pending for when the campaign resumes
+			s.pauseForQuota(pipe, send)
+			continue
+		}
+		if err != nil {
 			s.markSendFailed(send.ID, err.Error())
 			s.totalFailed.Add(1)
 
@@ -450,6 +525,16 @@ func (s *CampaignScheduler) sendWorker(id int) {
 	}
 }
 
+// deferSend keeps a pending send from being fetched again until the time
+func (s *CampaignScheduler) deferSend(send db.GetPendingCampaignSendsRow, until time.Time) {
+	if err := s.store.DeferCampaignSend(s.ctx, db.DeferCampaignSendParams{
+		SendAt: sql.NullString{String: until.UTC().Format(time.DateTime), Valid: true},
+		ID:     send.ID,
+	}); err != nil {
+		logx.Errorf("Failed to defer campaign send %s: %v", send.ID, err)
+	}
+}
+
 // sendCampaignEmail sends a single campaign email
 func (s *CampaignScheduler) sendCampaignEmail(send db.GetPendingCampaignSendsRow) error {
 	// Build email content
@@ -461,6 +546,9 @@ func (s *CampaignScheduler) sendCampaignEmail(send db.GetPendingCampaignSendsRow
 
 	subject := strings.ReplaceAll(send.Subject, "{{.Name}}", send.Name)
 
+	// Text version, generated from the HTML when the campaign has none
+	textBody := campaignPlainText(send, s.emailService.GetBaseURL())
+
 	// Add tracking pixel if enabled
 	if send.TrackOpens.Valid && send.TrackOpens.Int64 == 1 && send.TrackingToken.Valid {
 		trackingPixel := `<img src="` + s.emailService.GetTrackingPixelURL(send.TrackingToken.String) + `" width="1" height="1" style="display:none" />`
@@ -486,7 +574,26 @@ func (s *CampaignScheduler) sendCampaignEmail(send db.GetPendingCampaignSendsRow
 		replyTo = send.ReplyTo.String
 	}
 
-	return s.emailService.SendCampaignEmail(send.Email, subject, htmlBody, fromName, fromEmail, replyTo)
+	return s.emailService.SendCampaignEmail(send.OrgID, send.Email, subject, htmlBody, textBody, fromName, fromEmail, replyTo, send.TrackingToken.String)
+}
+
+// campaignPlainText returns the campaign's text version for one recipient,
+// with the unsubscribe link the HTML version carries in its footer. Returns
+// an empty string when the campaign has no text version.
+func campaignPlainText(send db.GetPendingCampaignSendsRow, baseURL string) string {
+	if !send.PlainText.Valid || strings.TrimSpace(send.PlainText.String) == "" {
+		return ""
+	}
+
+	text := strings.ReplaceAll(send.PlainText.String, "{{.Name}}", send.Name)
+	text = strings.ReplaceAll(text, "{{.Email}}", send.Email)
+	if send.TrackingToken.Valid && send.TrackingToken.String != "" {
+		unsubscribeURL := email.UnsubscribeURL(baseURL, send.TrackingToken.String)
+		if !strings.Contains(text, unsubscribeURL) {
+			text += "\n\nUnsubscribe: " + unsubscribeURL
+		}
+	}
+	return text
 }
 
 // markSendSent marks a campaign send as sent
@@ -514,6 +621,12 @@ func (s *CampaignScheduler) checkCampaignComplete(campaignID string) {
 	}
 
 	if pending == 0 {
+		// The test variants are out; the rest of the audience waits for
+		// the winner
+		if s.holdForWinner(campaignID) {
+			return
+		}
+
 		err := s.store.UpdateCampaignStatusByID(s.ctx, db.UpdateCampaignStatusByIDParams{
 			ID:     campaignID,
 			Status: sql.NullString{String: "sent", Valid: true},
@@ -528,17 +641,55 @@ func (s *CampaignScheduler) checkCampaignComplete(campaignID string) {
 	}
 }
 
-// pauseCampaign pauses a campaign due to errors
+// pauseCampaign pauses a campaign due to errors. It clears any quota pause
+// so the quota reset does not resume it.
 func (s *CampaignScheduler) pauseCampaign(campaignID, reason string) {
-	err := s.store.UpdateCampaignStatusByID(s.ctx, db.UpdateCampaignStatusByIDParams{
-		ID:     campaignID,
-		Status: sql.NullString{String: "paused", Valid: true},
+	err := s.store.ExecTx(s.ctx, func(q *db.Queries) error {
+		if err := q.UpdateCampaignStatusByID(s.ctx, db.UpdateCampaignStatusByIDParams{
+			ID:     campaignID,
+			Status: sql.NullString{String: "paused", Valid: true},
+		}); err != nil {
+			return err
+		}
+		return q.DeleteCampaignQuotaPause(s.ctx, campaignID)
 	})
 	if err != nil {
 		logx.Errorf("Failed to pause campaign %s: %v", campaignID, err)
 	}
 }
 
+// pauseForQuota pauses a campaign whose organization reached its daily
+// quota and schedules it to resume when the quota resets. A campaign
+// already paused for another reason is left for a person to resume.
+func (s *CampaignScheduler) pauseForQuota(pipe *CampaignPipe, send db.GetPendingCampaignSendsRow) {
+	if !pipe.paused.CompareAndSwap(false, true) {
+		return
+	}
+
+	resumeAt := email.QuotaResetTime(time.Now())
+	var paused int64
+	err := s.store.ExecTx(s.ctx, func(q *db.Queries) (err error) {
+		paused, err = q.PauseSendingCampaign(s.ctx, send.CampaignID)
+		if err != nil || paused == 0 {
+			return err
+		}
+		return q.UpsertCampaignQuotaPause(s.ctx, db.UpsertCampaignQuotaPauseParams{
+			CampaignID: send.CampaignID,
+			OrgID:      send.OrgID,
+			ResumeAt:   resumeAt.Format(time.DateTime),
+		})
+	})
+	if err != nil {
+		logx.Errorf("Failed to pause campaign %s for its daily quota: %v", send.CampaignID, err)
+		return
+	}
+	if paused == 0 {
+		return
+	}
+	logx.Infof("Campaign %s paused: organization %s reached its daily quota, resuming at %s",
+		send.CampaignID, send.OrgID, resumeAt.Format(time.RFC3339))
+}
+
 // getOrCreatePipe gets or creates a pipe for a campaign
 func (s *CampaignScheduler) getOrCreatePipe(campaignID string) *CampaignPipe {
 	s.pipesMu.Lock()
@@ -570,6 +721,7 @@ func (s *CampaignScheduler) removePipe(campaignID string) {
 // pipeCleanup periodically cleans up stale pipes
 func (s *CampaignScheduler) pipeCleanup() {
 	defer s.wg.Done()
+	defer s.recover("Campaign scheduler")
 	ticker := time.NewTicker(5 * time.Minute)
 	defer ticker.Stop()
 
@@ -594,6 +746,7 @@ func (s *CampaignScheduler) pipeCleanup() {
 // metricsReporter periodically logs metrics
 func (s *CampaignScheduler) metricsReporter() {
 	defer s.wg.Done()
+	defer s.recover("Campaign scheduler")
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
 
@@ -606,11 +759,10 @@ func (s *CampaignScheduler) metricsReporter() {
 			sent := s.totalSent.Load()
 			failed := s.totalFailed.Load()
 			pooled, created := s.emailService.PoolStats()
-			current, max := s.limiter.GlobalStats()
 
 			if scheduled > 0 || sent > 0 || failed > 0 {
-				logx.Infof("Campaign stats - campaigns: %d, sent: %d, failed: %d, pool: %d/%d, rate: %d/%d",
-					scheduled, sent, failed, pooled, created, current, max)
+				logx.Infof("Campaign stats - campaigns: %d, sent: %d, failed: %d, pool: %d/%d",
+					scheduled, sent, failed, pooled, created)
 			}
 
 			// Log per-campaign stats
@@ -632,18 +784,15 @@ func (s *CampaignScheduler) Stats() (scheduled, sent, failed int64) {
 	return s.totalScheduled.Load(), s.totalSent.Load(), s.totalFailed.Load()
 }
 
-// SetRateLimit dynamically updates the rate limit
-func (s *CampaignScheduler) SetRateLimit(rate int) {
-	s.limiter.SetGlobalRate(rate)
-	logx.Infof("Campaign scheduler rate limit updated to %d/sec", rate)
-}
-
-// parseListIDs parses comma-separated list IDs
+// parseListIDs parses list IDs stored comma-separated or as a JSON array
 func parseListIDs(s string) []int64 {
 	if s == "" {
 		return nil
 	}
 
+	// Admin API stores list IDs as a JSON array (["1","2"])
+	s = strings.NewReplacer("[", "", "]", "", `"`, "").Replace(s)
+
 	parts := strings.Split(s, ",")
 	var ids []int64
 	for _, p := range parts {
@@ -676,9 +825,6 @@ func StartCampaignScheduler(svcCtx *svc.ServiceContext) *CampaignScheduler {
 	if svcCtx.Config.Email.WorkerCount > 0 {
 		config.Workers = svcCtx.Config.Email.WorkerCount
 	}
-	if svcCtx.Config.Email.RateLimit > 0 {
-		config.RateLimit = int(svcCtx.Config.Email.RateLimit)
-	}
 	if svcCtx.Config.Email.RateBurst > 0 {
 		config.PoolSize = svcCtx.Config.Email.RateBurst // Use burst as pool size hint
 	}
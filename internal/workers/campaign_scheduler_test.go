@@ -1,9 +1,14 @@
 package workers
 
 import (
+	"context"
+	"database/sql"
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/db/dbtest"
 )
 
 func TestDefaultCampaignSchedulerConfig(t *testing.T) {
@@ -18,9 +23,6 @@ func TestDefaultCampaignSchedulerConfig(t *testing.T) {
 	if config.Workers != 10 {
 		t.Errorf("Workers should be 10, got %d", config.Workers)
 	}
-	if config.RateLimit != 14 {
-		t.Errorf("RateLimit should be 14, got %d", config.RateLimit)
-	}
 	if config.BatchSize != 1000 {
 		t.Errorf("BatchSize should be 1000, got %d", config.BatchSize)
 	}
@@ -274,6 +276,20 @@ func TestParseListIDs_OnlyCommas(t *testing.T) {
 	}
 }
 
+func TestParseListIDs_JSONArray(t *testing.T) {
+	ids := parseListIDs(`["1","2", "3"]`)
+	if len(ids) != 3 {
+		t.Fatalf("Expected 3 IDs, got %d: %v", len(ids), ids)
+	}
+	if ids[0] != 1 || ids[1] != 2 || ids[2] != 3 {
+		t.Errorf("Expected [1, 2, 3], got %v", ids)
+	}
+
+	if ids := parseListIDs("[]"); len(ids) != 0 {
+		t.Errorf("Expected 0 IDs for empty array, got %v", ids)
+	}
+}
+
 func TestCampaignPipe_PausedFlags(t *testing.T) {
 	pipe := NewCampaignPipe("test")
 
@@ -295,3 +311,86 @@ func TestCampaignPipe_PausedFlags(t *testing.T) {
 		t.Error("stopped should be true")
 	}
 }
+
+func TestQuotaResetResumesOnlyQuotaPauses(t *testing.T) {
+	store := dbtest.New(t)
+	dbtest.Exec(t, store, `INSERT INTO organizations (id, name, slug, api_key) VALUES ('org', 'Org', 'org', 'key')`)
+	for _, id := range []string{"quota", "errors", "both"} {
+		dbtest.Exec(t, store, `INSERT INTO email_campaigns (id, org_id, name, subject, html_body, status) VALUES (?, 'org', ?, 's', 'b', 'sending')`, id, id)
+	}
+	s := NewCampaignScheduler(store, nil, DefaultCampaignSchedulerConfig())
+
+	// Paused for the quota only
+	s.pauseForQuota(NewCampaignPipe("quota"), db.GetPendingCampaignSendsRow{CampaignID: "quota", OrgID: "org"})
+	// Paused for errors before the quota was reached
+	s.pauseCampaign("errors", "Too many consecutive errors")
+	s.pauseForQuota(NewCampaignPipe("errors"), db.GetPendingCampaignSendsRow{CampaignID: "errors", OrgID: "org"})
+	// Paused for errors while paused for the quota
+	s.pauseForQuota(NewCampaignPipe("both"), db.GetPendingCampaignSendsRow{CampaignID: "both", OrgID: "org"})
+	s.pauseCampaign("both", "Too many consecutive errors")
+
+	dbtest.Exec(t, store, `UPDATE campaign_quota_pauses SET resume_at = '2000-01-01 00:00:00'`)
+	s.resumeQuotaPausedCampaigns()
+
+	want := map[string]string{"quota": "sending", "errors": "paused", "both": "paused"}
+	for id, status := range want {
+		campaign, err := store.GetCampaignByID(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if campaign.Status.String != status {
+			t.Errorf("campaign %s status = %q after the quota reset, want %q", id, campaign.Status.String, status)
+		}
+	}
+}
+
+func TestDeferSendHidesRateLimitedSend(t *testing.T) {
+	store := dbtest.New(t)
+	dbtest.Exec(t, store, `INSERT INTO organizations (id, name, slug, api_key) VALUES ('org', 'Org', 'org', 'key')`)
+	dbtest.Exec(t, store, `INSERT INTO contacts (id, org_id, name, email, status) VALUES ('ann', 'org', 'Ann', 'ann@example.com', 'active')`)
+	dbtest.Exec(t, store, `INSERT INTO email_campaigns (id, org_id, name, subject, html_body, status) VALUES ('camp', 'org', 'Launch', 's', 'b', 'sending')`)
+	dbtest.Exec(t, store, `INSERT INTO campaign_sends (id, campaign_id, contact_id, status) VALUES ('send', 'camp', 'ann', 'pending')`)
+	s := NewCampaignScheduler(store, nil, DefaultCampaignSchedulerConfig())
+
+	s.deferSend(db.GetPendingCampaignSendsRow{ID: "send"}, time.Now().Add(time.Hour))
+	sends, err := store.GetPendingCampaignSends(context.Background(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sends) != 0 {
+		t.Fatalf("deferred send fetched again before its retry time")
+	}
+
+	s.deferSend(db.GetPendingCampaignSendsRow{ID: "send"}, time.Now().Add(-time.Second))
+	sends, err = store.GetPendingCampaignSends(context.Background(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sends) != 1 {
+		t.Errorf("fetched %d sends after the retry time, want 1", len(sends))
+	}
+}
+
+func TestCampaignPlainText(t *testing.T) {
+	send := db.GetPendingCampaignSendsRow{
+		Name:          "Ann",
+		PlainText:     sql.NullString{String: "Hi {{.Name}}", Valid: true},
+		TrackingToken: sql.NullString{String: "tok", Valid: true},
+	}
+	want := "Hi Ann\n\nUnsubscribe: https://mail.example.com/api/e/u/tok"
+	if got := campaignPlainText(send, "https://mail.example.com"); got != want {
+		t.Errorf("campaignPlainText() = %q, want %q", got, want)
+	}
+
+	// A text version that already links to the unsubscribe page is unchanged
+	send.PlainText.String = "Leave: https://mail.example.com/api/e/u/tok"
+	if got := campaignPlainText(send, "https://mail.example.com"); got != send.PlainText.String {
+		t.Errorf("campaignPlainText() = %q, want the link once", got)
+	}
+
+	// Without a text version the sender generates one from the HTML
+	send.PlainText = sql.NullString{}
+	if got := campaignPlainText(send, "https://mail.example.com"); got != "" {
+		t.Errorf("campaignPlainText() without text = %q, want empty", got)
+	}
+}
@@ -0,0 +1,33 @@
+package workers
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// crashGuard records a panic in a worker goroutine so the supervisor can
+// restart the worker instead of the whole process going down
+type crashGuard struct {
+	mu  sync.Mutex
+	err error
+}
+
+// recover must be deferred at the top of a worker goroutine
+func (g *crashGuard) recover(name string) {
+	if r := recover(); r != nil {
+		logx.Errorf("%s panicked: %v\n%s", name, r, debug.Stack())
+		g.mu.Lock()
+		g.err = fmt.Errorf("panic: %v", r)
+		g.mu.Unlock()
+	}
+}
+
+// Crashed returns the panic that stopped the worker, if any
+func (g *crashGuard) Crashed() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.err
+}
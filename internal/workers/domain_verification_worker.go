@@ -3,6 +3,7 @@ package workers
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"log"
 	"sync"
 	"time"
@@ -18,6 +19,7 @@ type DomainVerificationWorker struct {
 	interval time.Duration
 	stop     chan struct{}
 	wg       sync.WaitGroup
+	crashGuard
 }
 
 // NewDomainVerificationWorker creates a new domain verification worker
@@ -43,6 +45,7 @@ func (w *DomainVerificationWorker) Stop() {
 
 func (w *DomainVerificationWorker) run() {
 	defer w.wg.Done()
+	defer w.recover("Domain verification worker")
 
 	// Run immediately on start
 	w.checkPendingDomains()
@@ -64,6 +67,8 @@ func (w *DomainVerificationWorker) run() {
 func (w *DomainVerificationWorker) checkPendingDomains() {
 	ctx := context.Background()
 
+	w.checkPendingDKIMKeys(ctx)
+
 	// Get all domain identities with pending status
 	identities, err := w.svcCtx.DB.ListPendingDomainIdentities(ctx)
 	if err != nil {
@@ -85,6 +90,10 @@ func (w *DomainVerificationWorker) checkPendingDomains() {
 func (w *DomainVerificationWorker) checkDomainStatus(ctx context.Context, identity db.DomainIdentity) {
 	// Get AWS credentials
 	region, accessKey, secretKey, err := w.getAWSCredentials(ctx, identity.OrgID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// SES not configured: the domain is verified by its DKIM keys
+		return
+	}
 	if err != nil {
 		log.Printf("Failed to get AWS credentials for org %s: %v", identity.OrgID, err)
 		return
@@ -135,6 +144,60 @@ func (w *DomainVerificationWorker) checkDomainStatus(ctx context.Context, identi
 	}
 }
 
+// checkPendingDKIMKeys activates locally managed DKIM keys once their DNS
+// records are published. Without SES this also verifies the domain.
+func (w *DomainVerificationWorker) checkPendingDKIMKeys(ctx context.Context) {
+	keys, err := w.svcCtx.DB.ListPendingDKIMKeys(ctx)
+	if err != nil {
+		log.Printf("Failed to list pending DKIM keys: %v", err)
+		return
+	}
+
+	checked := make(map[string]bool)
+	for _, key := range keys {
+		if checked[key.DomainIdentityID] {
+			continue
+		}
+		checked[key.DomainIdentityID] = true
+
+		activated, err := w.svcCtx.EmailService.ActivatePublishedDKIMKeys(ctx, key.DomainIdentityID)
+		if err != nil {
+			log.Printf("Failed to check DKIM keys for %s: %v", key.Domain, err)
+			continue
+		}
+		if !activated {
+			continue
+		}
+		log.Printf("DKIM keys version %d for %s are published and now active", key.Version, key.Domain)
+
+		if _, _, _, err := w.getAWSCredentials(ctx, key.OrgID); !errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+
+		updated, err := w.svcCtx.DB.UpdateDomainIdentityStatus(ctx, db.UpdateDomainIdentityStatusParams{
+			ID:                 key.DomainIdentityID,
+			VerificationStatus: sql.NullString{String: "success", Valid: true},
+			DkimStatus:         sql.NullString{String: "success", Valid: true},
+		})
+		if err != nil {
+			log.Printf("Failed to update domain identity status: %v", err)
+			continue
+		}
+
+		if w.svcCtx.WebSocketHub != nil {
+			w.svcCtx.WebSocketHub.BroadcastDomainIdentityUpdate(
+				updated.ID,
+				updated.OrgID,
+				updated.Domain,
+				updated.VerificationStatus.String,
+				updated.DkimStatus.String,
+				updated.MailFromStatus.String,
+				updated.LastCheckedAt.String,
+			)
+		}
+	}
+}
+
 func (w *DomainVerificationWorker) getAWSCredentials(ctx context.Context, orgID string) (region, accessKey, secretKey string, err error) {
 	// First try org-specific credentials
 	emailConfig, err := email.GetOrgEmailConfig(ctx, w.svcCtx.DB, orgID)
@@ -10,6 +10,7 @@ import (
 // EmailWorker wraps the dispatcher for the worker interface
 type EmailWorker struct {
 	dispatcher *email.Dispatcher
+	crashGuard
 }
 
 // StartEmailWorker starts the high-performance email dispatcher
@@ -40,13 +41,15 @@ func StartEmailWorker(svcCtx *svc.ServiceContext) *EmailWorker {
 		config.BatchSize = svcCtx.Config.Email.BatchSize
 	}
 
-	// Create and start dispatcher
-	dispatcher := email.NewDispatcher(sequenceService, svcCtx.DB, config)
-	dispatcher.Start()
-
-	return &EmailWorker{
-		dispatcher: dispatcher,
+	// Create and start dispatcher; a panic in any of its goroutines is
+	// recorded so the supervisor restarts the worker
+	w := &EmailWorker{
+		dispatcher: email.NewDispatcher(sequenceService, svcCtx.DB, config),
 	}
+	w.dispatcher.SetGuard(w.recover)
+	w.dispatcher.Start()
+
+	return w
 }
 
 // Stop gracefully shuts down the email worker
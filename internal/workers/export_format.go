@@ -0,0 +1,143 @@
+package workers
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Export job types
+const (
+	ExportTypeList            = "list"
+	ExportTypeSegment         = "segment"
+	ExportTypeSuppressionList = "suppression_list"
+	ExportTypeBlockedDomains  = "blocked_domains"
+	ExportTypeCampaignSends   = "campaign_sends"
+)
+
+// Export file formats
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+)
+
+// ExportFileExtension returns the file extension for an export format
+func ExportFileExtension(format string) string {
+	if format == ExportFormatNDJSON {
+		return ".ndjson"
+	}
+	return ".csv"
+}
+
+// exportWriter writes rows with a fixed column order. Values are nil, string,
+// int64 or []string.
+type exportWriter interface {
+	WriteHeader(columns []string) error
+	WriteRow(values []interface{}) error
+	Flush() error
+}
+
+// newExportWriter returns a writer for the given format
+func newExportWriter(format string, w io.Writer) (exportWriter, error) {
+	switch format {
+	case ExportFormatCSV:
+		return &csvExportWriter{w: csv.NewWriter(w)}, nil
+	case ExportFormatNDJSON:
+		return &ndjsonExportWriter{w: bufio.NewWriter(w)}, nil
+	}
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+// csvExportWriter writes a header row followed by one record per row.
+// Tags are joined with commas.
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvExportWriter) WriteHeader(columns []string) error {
+	return c.w.Write(columns)
+}
+
+func (c *csvExportWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		switch v := v.(type) {
+		case nil:
+		case string:
+			record[i] = v
+		case int64:
+			record[i] = strconv.FormatInt(v, 10)
+		case []string:
+			record[i] = strings.Join(v, ",")
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvExportWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// ndjsonExportWriter writes one JSON object per line, keeping the column order
+type ndjsonExportWriter struct {
+	w       *bufio.Writer
+	columns [][]byte
+}
+
+func (n *ndjsonExportWriter) WriteHeader(columns []string) error {
+	n.columns = make([][]byte, len(columns))
+	for i, col := range columns {
+		key, err := json.Marshal(col)
+		if err != nil {
+			return err
+		}
+		n.columns[i] = key
+	}
+	return nil
+}
+
+func (n *ndjsonExportWriter) WriteRow(values []interface{}) error {
+	n.w.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			n.w.WriteByte(',')
+		}
+		n.w.Write(n.columns[i])
+		n.w.WriteByte(':')
+		value, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		n.w.Write(value)
+	}
+	n.w.WriteByte('}')
+	return n.w.WriteByte('\n')
+}
+
+func (n *ndjsonExportWriter) Flush() error {
+	return n.w.Flush()
+}
+
+// exportString returns the value of a nullable column, or nil if NULL
+func exportString(s sql.NullString) interface{} {
+	if !s.Valid {
+		return nil
+	}
+	return s.String
+}
+
+// exportInt returns the value of a nullable column, or nil if NULL
+func exportInt(i sql.NullInt64) interface{} {
+	if !i.Valid {
+		return nil
+	}
+	return i.Int64
+}
@@ -0,0 +1,75 @@
+package workers
+
+import (
+	"bytes"
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/outlet-sh/outlet/internal/db"
+)
+
+func TestExportWriters(t *testing.T) {
+	columns := []string{"email", "name", "tags", "open_count"}
+	rows := [][]interface{}{
+		{"a@example.com", "Ann, Jr.", []string{"vip", "beta"}, int64(3)},
+		{"b@example.com", nil, []string{}, nil},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{ExportFormatCSV, "email,name,tags,open_count\n" +
+			"a@example.com,\"Ann, Jr.\",\"vip,beta\",3\n" +
+			"b@example.com,,,\n"},
+		{ExportFormatNDJSON, `{"email":"a@example.com","name":"Ann, Jr.","tags":["vip","beta"],"open_count":3}` + "\n" +
+			`{"email":"b@example.com","name":null,"tags":[],"open_count":null}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		w, err := newExportWriter(tt.format, &buf)
+		if err != nil {
+			t.Fatalf("newExportWriter(%s) error = %v", tt.format, err)
+		}
+		if err := w.WriteHeader(columns); err != nil {
+			t.Fatalf("%s WriteHeader() error = %v", tt.format, err)
+		}
+		for _, row := range rows {
+			if err := w.WriteRow(row); err != nil {
+				t.Fatalf("%s WriteRow() error = %v", tt.format, err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatalf("%s Flush() error = %v", tt.format, err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("%s output = %q, want %q", tt.format, buf.String(), tt.want)
+		}
+	}
+
+	if _, err := newExportWriter("xml", &bytes.Buffer{}); err == nil {
+		t.Error("newExportWriter(xml) expected error")
+	}
+}
+
+func TestListExportRow(t *testing.T) {
+	sub := db.GetListSubscribersForExportRow{
+		Email:        "a@example.com",
+		Name:         "Ann",
+		Status:       sql.NullString{String: "active", Valid: true},
+		SubscribedAt: sql.NullString{String: "2024-01-02 03:04:05", Valid: true},
+		Tags:         `["vip"]`,
+		CustomFields: `{"company":"Acme","plan":null}`,
+	}
+
+	got, err := listExportRow(sub, []string{"company", "plan", "city"})
+	if err != nil {
+		t.Fatalf("listExportRow() error = %v", err)
+	}
+	want := []interface{}{"a@example.com", "Ann", "active", "2024-01-02 03:04:05", nil, []string{"vip"}, "Acme", nil, nil}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("listExportRow() = %#v, want %#v", got, want)
+	}
+}
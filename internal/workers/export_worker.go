@@ -0,0 +1,582 @@
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/logic/admin/backup"
+	backupService "github.com/outlet-sh/outlet/internal/services/backup"
+	"github.com/outlet-sh/outlet/internal/services/segment"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/websocket"
+)
+
+const (
+	// ExportDir is where export files are written before upload or download
+	ExportDir = "./data/exports"
+
+	// ExportS3Prefix is appended to the backup S3 prefix for export objects
+	ExportS3Prefix = "exports/"
+
+	// exportBatchSize is the number of rows read per batch
+	exportBatchSize = 1000
+)
+
+// ExportWorker runs export jobs in the background. Exports are written to
+// ExportDir and, for S3 storage, uploaded with the backup S3 settings.
+// Jobs interrupted by a restart start again from scratch.
+type ExportWorker struct {
+	svcCtx   *svc.ServiceContext
+	interval time.Duration
+	stop     chan struct{}
+	wg       sync.WaitGroup
+	crashGuard
+}
+
+// NewExportWorker creates a new export worker
+func NewExportWorker(svcCtx *svc.ServiceContext, interval time.Duration) *ExportWorker {
+	return &ExportWorker{
+		svcCtx:   svcCtx,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start starts the export worker
+func (w *ExportWorker) Start() {
+	w.wg.Add(1)
+	go w.run()
+}
+
+// Stop stops the worker and waits for the running export to stop
+func (w *ExportWorker) Stop() {
+	close(w.stop)
+	w.wg.Wait()
+}
+
+func (w *ExportWorker) run() {
+	defer w.wg.Done()
+	defer w.recover("Export worker")
+
+	if err := w.svcCtx.DB.ResetRunningExportJobs(context.Background()); err != nil {
+		log.Printf("Failed to reset interrupted export jobs: %v", err)
+	}
+
+	w.pickUpJobs()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.pickUpJobs()
+		case <-w.stop:
+			log.Println("Export worker stopping...")
+			return
+		}
+	}
+}
+
+func (w *ExportWorker) pickUpJobs() {
+	jobs, err := w.svcCtx.DB.GetPendingExportJobs(context.Background())
+	if err != nil {
+		log.Printf("Failed to get pending export jobs: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		select {
+		case <-w.stop:
+			return
+		default:
+		}
+		w.processJob(job)
+	}
+}
+
+// exportSource reads the rows of one export in batches
+type exportSource struct {
+	columns []string
+	total   int64
+
+	// next returns the next batch of rows, or none when the export is done
+	next func(ctx context.Context) ([][]interface{}, error)
+}
+
+func (w *ExportWorker) processJob(job db.ExportJob) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-w.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	source, err := w.openSource(ctx, job)
+	if err != nil {
+		w.failJob(job, err)
+		return
+	}
+
+	job, err = w.svcCtx.DB.StartExportJob(ctx, db.StartExportJobParams{
+		TotalRows: source.total,
+		ID:        job.ID,
+	})
+	if err == sql.ErrNoRows {
+		// Cancelled before it started
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to start export job %s: %v", job.ID, err)
+		return
+	}
+
+	log.Printf("Export job %s started: type=%s format=%s total=%d", job.ID, job.Type, job.Format, source.total)
+	w.broadcast(job)
+
+	if err := os.MkdirAll(ExportDir, 0755); err != nil {
+		w.failJob(job, fmt.Errorf("failed to create export directory: %w", err))
+		return
+	}
+	filePath := filepath.Join(ExportDir, job.ID+"_"+job.Filename)
+
+	processed, cancelled, err := w.writeExport(ctx, job, source, filePath)
+	if err != nil || cancelled || ctx.Err() != nil {
+		os.Remove(filePath)
+		switch {
+		case ctx.Err() != nil:
+			// Shutting down: the job is reset to pending on next start
+			log.Printf("Export job %s interrupted at %d/%d", job.ID, processed, source.total)
+		case cancelled:
+			log.Printf("Export job %s cancelled", job.ID)
+		default:
+			w.failJob(job, err)
+		}
+		return
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		w.failJob(job, fmt.Errorf("failed to stat export file: %w", err))
+		return
+	}
+
+	params := db.CompleteExportJobParams{
+		StorageType:   job.StorageType,
+		FileSize:      info.Size(),
+		ProcessedRows: processed,
+		ID:            job.ID,
+	}
+
+	var s3Config *backupService.S3Config
+	if job.StorageType == "s3" {
+		s3Config = backup.GetS3Config(ctx, w.svcCtx)
+		if s3Config == nil {
+			os.Remove(filePath)
+			w.failJob(job, fmt.Errorf("S3 is not configured"))
+			return
+		}
+		s3Config.Prefix += ExportS3Prefix
+
+		s3Key, err := backupService.UploadToS3(ctx, *s3Config, filePath, job.ID+"_"+job.Filename)
+		os.Remove(filePath)
+		if err != nil {
+			w.failJob(job, err)
+			return
+		}
+		params.S3Bucket = sql.NullString{String: s3Config.Bucket, Valid: true}
+		params.S3Key = sql.NullString{String: s3Key, Valid: true}
+	} else {
+		params.FilePath = sql.NullString{String: filePath, Valid: true}
+	}
+
+	completed, err := w.svcCtx.DB.CompleteExportJob(context.Background(), params)
+	if err != nil {
+		// Cancelled while the file was being finished
+		if params.S3Key.Valid {
+			backupService.DeleteFromS3(context.Background(), *s3Config, params.S3Key.String)
+		} else {
+			os.Remove(filePath)
+		}
+		if err != sql.ErrNoRows {
+			log.Printf("Failed to complete export job %s: %v", job.ID, err)
+		}
+		return
+	}
+
+	log.Printf("Export job %s completed: %d rows, %d bytes (storage=%s)", job.ID, processed, info.Size(), job.StorageType)
+	w.broadcast(completed)
+}
+
+// writeExport streams the source to filePath, recording progress after every
+// batch. Returns the number of rows written and whether the job was cancelled.
+func (w *ExportWorker) writeExport(ctx context.Context, job db.ExportJob, source *exportSource, filePath string) (int64, bool, error) {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer file.Close()
+
+	out, err := newExportWriter(job.Format, file)
+	if err != nil {
+		return 0, false, err
+	}
+	if err := out.WriteHeader(source.columns); err != nil {
+		return 0, false, fmt.Errorf("failed to write export: %w", err)
+	}
+
+	var processed int64
+	for {
+		rows, err := source.next(ctx)
+		if err != nil {
+			return processed, false, fmt.Errorf("failed to read rows: %w", err)
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		for _, row := range rows {
+			if err := out.WriteRow(row); err != nil {
+				return processed, false, fmt.Errorf("failed to write export: %w", err)
+			}
+		}
+		processed += int64(len(rows))
+
+		updated, err := w.svcCtx.DB.UpdateExportJobProgress(context.Background(), db.UpdateExportJobProgressParams{
+			ProcessedRows: processed,
+			ID:            job.ID,
+		})
+		if err == nil {
+			if updated.Status == "cancelled" {
+				return processed, true, nil
+			}
+			w.broadcast(updated)
+		}
+
+		if ctx.Err() != nil {
+			return processed, false, ctx.Err()
+		}
+	}
+
+	if err := out.Flush(); err != nil {
+		return processed, false, fmt.Errorf("failed to write export: %w", err)
+	}
+	return processed, false, nil
+}
+
+// openSource prepares the rows for an export job
+func (w *ExportWorker) openSource(ctx context.Context, job db.ExportJob) (*exportSource, error) {
+	switch job.Type {
+	case ExportTypeList:
+		listID, err := strconv.ParseInt(job.SourceID.String, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid list id %q", job.SourceID.String)
+		}
+		return w.listSource(ctx, listID)
+	case ExportTypeSegment:
+		return w.segmentSource(ctx, job.OrgID, job.SourceID.String)
+	case ExportTypeSuppressionList:
+		return w.suppressionSource(ctx, job.OrgID)
+	case ExportTypeBlockedDomains:
+		return w.blockedDomainsSource(ctx, job.OrgID)
+	case ExportTypeCampaignSends:
+		return w.campaignSendsSource(ctx, job.SourceID.String)
+	}
+	return nil, fmt.Errorf("unknown export type %q", job.Type)
+}
+
+// listSource exports list subscribers with their tags and custom fields.
+// Each custom field gets its own column named after its key.
+func (w *ExportWorker) listSource(ctx context.Context, listID int64) (*exportSource, error) {
+	total, err := w.svcCtx.DB.CountListSubscribers(ctx, db.CountListSubscribersParams{ListID: listID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count subscribers: %w", err)
+	}
+
+	fields, err := w.svcCtx.DB.ListCustomFieldsByList(ctx, listID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load custom fields: %w", err)
+	}
+
+	columns := []string{"email", "name", "status", "subscribed_at", "unsubscribed_at", "tags"}
+	builtIn := make(map[string]bool, len(columns))
+	for _, col := range columns {
+		builtIn[col] = true
+	}
+	keys := make([]string, len(fields))
+	for i, f := range fields {
+		keys[i] = f.FieldKey
+		// Keep the column unambiguous, imports map "custom:<key>" back to the field
+		if builtIn[f.FieldKey] {
+			columns = append(columns, ImportCustomFieldPrefix+f.FieldKey)
+		} else {
+			columns = append(columns, f.FieldKey)
+		}
+	}
+
+	var after int64
+	return &exportSource{
+		columns: columns,
+		total:   total,
+		next: func(ctx context.Context) ([][]interface{}, error) {
+			subscribers, err := w.svcCtx.DB.GetListSubscribersForExport(ctx, db.GetListSubscribersForExportParams{
+				ListID:     listID,
+				AfterRowID: after,
+				LimitVal:   exportBatchSize,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			rows := make([][]interface{}, 0, len(subscribers))
+			for _, s := range subscribers {
+				after = s.RowID
+				row, err := listExportRow(s, keys)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, row)
+			}
+			return rows, nil
+		},
+	}, nil
+}
+
+// listExportRow flattens a subscriber, appending custom field values in key order
+func listExportRow(s db.GetListSubscribersForExportRow, customFieldKeys []string) ([]interface{}, error) {
+	tags := []string{}
+	if err := json.Unmarshal([]byte(s.Tags), &tags); err != nil {
+		return nil, fmt.Errorf("invalid tags for subscriber %s: %w", s.Email, err)
+	}
+
+	var custom map[string]interface{}
+	if err := json.Unmarshal([]byte(s.CustomFields), &custom); err != nil {
+		return nil, fmt.Errorf("invalid custom fields for subscriber %s: %w", s.Email, err)
+	}
+
+	row := []interface{}{
+		s.Email,
+		s.Name,
+		exportString(s.Status),
+		exportString(s.SubscribedAt),
+		exportString(s.UnsubscribedAt),
+		tags,
+	}
+	for _, key := range customFieldKeys {
+		if v, ok := custom[key].(string); ok {
+			row = append(row, v)
+		} else {
+			row = append(row, nil)
+		}
+	}
+	return row, nil
+}
+
+// segmentSource exports the contacts matching a saved segment
+func (w *ExportWorker) segmentSource(ctx context.Context, orgID, segmentID string) (*exportSource, error) {
+	seg, err := w.svcCtx.DB.GetSegment(ctx, db.GetSegmentParams{ID: segmentID, OrgID: orgID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load segment: %w", err)
+	}
+
+	audience, err := segment.FromSaved(seg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid segment: %w", err)
+	}
+
+	members, err := segment.Resolve(ctx, w.svcCtx.DB.GetDB(), audience)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve segment: %w", err)
+	}
+
+	return &exportSource{
+		columns: []string{"contact_id", "email", "name", "list_id"},
+		total:   int64(len(members)),
+		next: func(ctx context.Context) ([][]interface{}, error) {
+			n := len(members)
+			if n > exportBatchSize {
+				n = exportBatchSize
+			}
+			rows := make([][]interface{}, 0, n)
+			for _, m := range members[:n] {
+				rows = append(rows, []interface{}{m.ContactID, m.Email, m.Name, m.ListID})
+			}
+			members = members[n:]
+			return rows, nil
+		},
+	}, nil
+}
+
+// suppressionSource exports the org's suppression list
+func (w *ExportWorker) suppressionSource(ctx context.Context, orgID string) (*exportSource, error) {
+	total, err := w.svcCtx.DB.CountSuppressedEmails(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count suppressed emails: %w", err)
+	}
+
+	var after int64
+	return &exportSource{
+		columns: []string{"email", "reason", "source", "block_attempts", "created_at"},
+		total:   total,
+		next: func(ctx context.Context) ([][]interface{}, error) {
+			entries, err := w.svcCtx.DB.GetSuppressionListForExport(ctx, db.GetSuppressionListForExportParams{
+				OrgID:    orgID,
+				AfterID:  after,
+				LimitVal: exportBatchSize,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			rows := make([][]interface{}, 0, len(entries))
+			for _, e := range entries {
+				after = e.ID
+				rows = append(rows, []interface{}{
+					e.Email,
+					exportString(e.Reason),
+					exportString(e.Source),
+					exportInt(e.BlockAttempts),
+					exportString(e.CreatedAt),
+				})
+			}
+			return rows, nil
+		},
+	}, nil
+}
+
+// blockedDomainsSource exports the org's blocked domains
+func (w *ExportWorker) blockedDomainsSource(ctx context.Context, orgID string) (*exportSource, error) {
+	total, err := w.svcCtx.DB.CountBlockedDomains(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count blocked domains: %w", err)
+	}
+
+	var after int64
+	return &exportSource{
+		columns: []string{"domain", "reason", "block_attempts", "created_at"},
+		total:   total,
+		next: func(ctx context.Context) ([][]interface{}, error) {
+			domains, err := w.svcCtx.DB.GetBlockedDomainsForExport(ctx, db.GetBlockedDomainsForExportParams{
+				OrgID:    orgID,
+				AfterID:  after,
+				LimitVal: exportBatchSize,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			rows := make([][]interface{}, 0, len(domains))
+			for _, d := range domains {
+				after = d.ID
+				rows = append(rows, []interface{}{
+					d.Domain,
+					exportString(d.Reason),
+					exportInt(d.BlockAttempts),
+					exportString(d.CreatedAt),
+				})
+			}
+			return rows, nil
+		},
+	}, nil
+}
+
+// campaignSendsSource exports per-recipient delivery and engagement for a campaign
+func (w *ExportWorker) campaignSendsSource(ctx context.Context, campaignID string) (*exportSource, error) {
+	total, err := w.svcCtx.DB.CountCampaignSends(ctx, campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count campaign sends: %w", err)
+	}
+
+	var after int64
+	return &exportSource{
+		columns: []string{
+			"contact_id", "email", "name", "list_id", "status",
+			"sent_at", "delivered_at", "opened_at", "open_count",
+			"clicked_at", "click_count", "bounce_type", "error_message",
+		},
+		total: total,
+		next: func(ctx context.Context) ([][]interface{}, error) {
+			sends, err := w.svcCtx.DB.GetCampaignSendsForExport(ctx, db.GetCampaignSendsForExportParams{
+				CampaignID: campaignID,
+				AfterRowID: after,
+				LimitVal:   exportBatchSize,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			rows := make([][]interface{}, 0, len(sends))
+			for _, s := range sends {
+				after = s.RowID
+				rows = append(rows, []interface{}{
+					s.ContactID,
+					s.Email,
+					s.Name,
+					exportInt(s.ListID),
+					exportString(s.Status),
+					exportString(s.SentAt),
+					exportString(s.DeliveredAt),
+					exportString(s.OpenedAt),
+					exportInt(s.OpenCount),
+					exportString(s.ClickedAt),
+					exportInt(s.ClickCount),
+					exportString(s.BounceType),
+					exportString(s.ErrorMessage),
+				})
+			}
+			return rows, nil
+		},
+	}, nil
+}
+
+func (w *ExportWorker) failJob(job db.ExportJob, cause error) {
+	log.Printf("Export job %s failed: %v", job.ID, cause)
+
+	updated, err := w.svcCtx.DB.FailExportJob(context.Background(), db.FailExportJobParams{
+		ErrorMessage: sql.NullString{String: cause.Error(), Valid: true},
+		ID:           job.ID,
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("Failed to mark export job %s as failed: %v", job.ID, err)
+		}
+		return
+	}
+	w.broadcast(updated)
+}
+
+func (w *ExportWorker) broadcast(job db.ExportJob) {
+	if w.svcCtx.WebSocketHub == nil {
+		return
+	}
+	w.svcCtx.WebSocketHub.BroadcastToOrg(job.OrgID, websocket.NewExportUpdate(websocket.ExportUpdate{
+		ID:        job.ID,
+		OrgID:     job.OrgID,
+		Type:      job.Type,
+		Status:    job.Status,
+		Total:     job.TotalRows,
+		Processed: job.ProcessedRows,
+		Filename:  job.Filename,
+		FileSize:  job.FileSize,
+		Error:     job.ErrorMessage.String,
+	}))
+}
+
+// StartExportWorker starts the export worker with a 5-second interval
+func StartExportWorker(svcCtx *svc.ServiceContext) *ExportWorker {
+	worker := NewExportWorker(svcCtx, 5*time.Second)
+	worker.Start()
+	return worker
+}
@@ -0,0 +1,245 @@
+package workers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/outlet-sh/outlet/internal/types"
+)
+
+// Import job types
+const (
+	ImportTypeSubscribers    = "subscribers"
+	ImportTypeSuppression    = "suppression"
+	ImportTypeBlockedDomains = "blocked_domains"
+)
+
+// Fields a CSV column can be mapped to
+const (
+	ImportFieldEmail  = "email"
+	ImportFieldName   = "name"
+	ImportFieldTags   = "tags"
+	ImportFieldReason = "reason"
+	ImportFieldDomain = "domain"
+
+	// ImportCustomFieldPrefix maps a column to a list custom field, e.g. "custom:company"
+	ImportCustomFieldPrefix = "custom:"
+)
+
+// ImportFields returns the built-in fields for an import type, or nil if the type is unknown
+func ImportFields(importType string) []string {
+	switch importType {
+	case ImportTypeSubscribers:
+		return []string{ImportFieldEmail, ImportFieldName, ImportFieldTags}
+	case ImportTypeSuppression:
+		return []string{ImportFieldEmail, ImportFieldReason}
+	case ImportTypeBlockedDomains:
+		return []string{ImportFieldDomain}
+	}
+	return nil
+}
+
+// requiredImportField returns the field every row must have for an import type
+func requiredImportField(importType string) string {
+	if importType == ImportTypeBlockedDomains {
+		return ImportFieldDomain
+	}
+	return ImportFieldEmail
+}
+
+// columnAliases maps common header spellings to built-in fields
+var columnAliases = map[string]string{
+	"email":         ImportFieldEmail,
+	"e-mail":        ImportFieldEmail,
+	"email address": ImportFieldEmail,
+	"email_address": ImportFieldEmail,
+	"name":          ImportFieldName,
+	"full name":     ImportFieldName,
+	"full_name":     ImportFieldName,
+	"tags":          ImportFieldTags,
+	"tag":           ImportFieldTags,
+	"reason":        ImportFieldReason,
+	"domain":        ImportFieldDomain,
+}
+
+// normalizeColumn normalizes a CSV header for matching
+func normalizeColumn(col string) string {
+	return strings.ToLower(strings.TrimSpace(col))
+}
+
+// DetectImportMapping suggests a column mapping from the CSV header.
+// Columns matching a list custom field key map to that field; anything
+// unrecognised is left unmapped.
+func DetectImportMapping(importType string, header []string, customFieldKeys []string) map[string]string {
+	allowed := make(map[string]bool)
+	for _, f := range ImportFields(importType) {
+		allowed[f] = true
+	}
+
+	mapping := make(map[string]string)
+	used := make(map[string]bool)
+	for _, col := range header {
+		norm := normalizeColumn(col)
+		if field, ok := columnAliases[norm]; ok && allowed[field] && !used[field] {
+			mapping[col] = field
+			used[field] = true
+			continue
+		}
+		if importType != ImportTypeSubscribers {
+			continue
+		}
+		for _, key := range customFieldKeys {
+			if norm == strings.ToLower(key) {
+				mapping[col] = ImportCustomFieldPrefix + key
+				break
+			}
+		}
+	}
+	return mapping
+}
+
+// ValidateImportMapping checks a mapping against the CSV header and import type
+func ValidateImportMapping(importType string, header []string, mapping map[string]string, hasList bool) error {
+	allowed := make(map[string]bool)
+	for _, f := range ImportFields(importType) {
+		allowed[f] = true
+	}
+	if len(allowed) == 0 {
+		return fmt.Errorf("unknown import type %q", importType)
+	}
+
+	columns := make(map[string]bool)
+	for _, col := range header {
+		columns[normalizeColumn(col)] = true
+	}
+
+	seen := make(map[string]string)
+	for col, field := range mapping {
+		if field == "" {
+			continue
+		}
+		if !columns[normalizeColumn(col)] {
+			return fmt.Errorf("column %q is not in the CSV header", col)
+		}
+		if strings.HasPrefix(field, ImportCustomFieldPrefix) {
+			if importType != ImportTypeSubscribers {
+				return fmt.Errorf("custom fields are only supported for subscriber imports")
+			}
+			if !hasList {
+				return fmt.Errorf("custom fields require a list")
+			}
+			if strings.TrimPrefix(field, ImportCustomFieldPrefix) == "" {
+				return fmt.Errorf("column %q has an empty custom field key", col)
+			}
+		} else if !allowed[field] {
+			return fmt.Errorf("field %q is not valid for %s imports", field, importType)
+		}
+		// Tags may come from several columns, everything else maps once
+		if field != ImportFieldTags {
+			if other, ok := seen[field]; ok {
+				return fmt.Errorf("columns %q and %q both map to %s", other, col, field)
+			}
+			seen[field] = col
+		}
+	}
+
+	required := requiredImportField(importType)
+	if _, ok := seen[required]; !ok {
+		return fmt.Errorf("a column must be mapped to %s", required)
+	}
+	return nil
+}
+
+// ParseImportOptions decodes import_jobs.options
+func ParseImportOptions(options sql.NullString) (types.ImportJobOptions, error) {
+	var opts types.ImportJobOptions
+	if !options.Valid || options.String == "" {
+		return opts, nil
+	}
+	if err := json.Unmarshal([]byte(options.String), &opts); err != nil {
+		return opts, fmt.Errorf("invalid import options: %w", err)
+	}
+	return opts, nil
+}
+
+// importColumns resolves a mapping to column indices for one CSV file
+type importColumns struct {
+	fields map[string]int // built-in field -> column index
+	custom map[string]int // custom field key -> column index
+	tags   []int
+}
+
+// resolveImportColumns maps header positions to fields. Jobs without an
+// explicit mapping fall back to matching header names.
+func resolveImportColumns(importType string, header []string, mapping map[string]string) importColumns {
+	if len(mapping) == 0 {
+		mapping = DetectImportMapping(importType, header, nil)
+	}
+
+	byColumn := make(map[string]string, len(mapping))
+	for col, field := range mapping {
+		byColumn[normalizeColumn(col)] = field
+	}
+
+	cols := importColumns{
+		fields: make(map[string]int),
+		custom: make(map[string]int),
+	}
+	for i, col := range header {
+		field := byColumn[normalizeColumn(col)]
+		switch {
+		case field == "":
+		case field == ImportFieldTags:
+			cols.tags = append(cols.tags, i)
+		case strings.HasPrefix(field, ImportCustomFieldPrefix):
+			cols.custom[strings.TrimPrefix(field, ImportCustomFieldPrefix)] = i
+		default:
+			if _, ok := cols.fields[field]; !ok {
+				cols.fields[field] = i
+			}
+		}
+	}
+	return cols
+}
+
+// value returns the trimmed value of a built-in field, or "" if unmapped
+func (c importColumns) value(record []string, field string) string {
+	idx, ok := c.fields[field]
+	if !ok || idx >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[idx])
+}
+
+// customValues returns the non-empty custom field values keyed by field key
+func (c importColumns) customValues(record []string) map[string]string {
+	values := make(map[string]string)
+	for key, idx := range c.custom {
+		if idx < len(record) {
+			if v := strings.TrimSpace(record[idx]); v != "" {
+				values[key] = v
+			}
+		}
+	}
+	return values
+}
+
+// tagValues splits the tag columns on commas, semicolons and pipes
+func (c importColumns) tagValues(record []string) []string {
+	var tags []string
+	for _, idx := range c.tags {
+		if idx >= len(record) {
+			continue
+		}
+		for _, tag := range strings.FieldsFunc(record[idx], func(r rune) bool {
+			return r == ',' || r == ';' || r == '|'
+		}) {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
@@ -0,0 +1,94 @@
+package workers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectImportMapping(t *testing.T) {
+	header := []string{"E-Mail", "Full Name", "Company", "Notes"}
+
+	got := DetectImportMapping(ImportTypeSubscribers, header, []string{"company"})
+	want := map[string]string{
+		"E-Mail":    ImportFieldEmail,
+		"Full Name": ImportFieldName,
+		"Company":   "custom:company",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectImportMapping() = %v, want %v", got, want)
+	}
+
+	// Suppression imports have no name or custom fields
+	got = DetectImportMapping(ImportTypeSuppression, header, []string{"company"})
+	if !reflect.DeepEqual(got, map[string]string{"E-Mail": ImportFieldEmail}) {
+		t.Errorf("DetectImportMapping(suppression) = %v", got)
+	}
+}
+
+func TestValidateImportMapping(t *testing.T) {
+	header := []string{"Email", "Name", "Company", "Tags", "More Tags"}
+
+	tests := []struct {
+		name       string
+		importType string
+		mapping    map[string]string
+		hasList    bool
+		wantErr    bool
+	}{
+		{"valid", ImportTypeSubscribers, map[string]string{"Email": "email", "Name": "name", "Company": "custom:company"}, true, false},
+		{"multiple tag columns", ImportTypeSubscribers, map[string]string{"Email": "email", "Tags": "tags", "More Tags": "tags"}, false, false},
+		{"ignored column", ImportTypeSubscribers, map[string]string{"Email": "email", "Name": ""}, false, false},
+		{"missing email", ImportTypeSubscribers, map[string]string{"Name": "name"}, false, true},
+		{"unknown column", ImportTypeSubscribers, map[string]string{"Email": "email", "Phone": "name"}, false, true},
+		{"duplicate field", ImportTypeSubscribers, map[string]string{"Email": "email", "Name": "email"}, false, true},
+		{"custom field without list", ImportTypeSubscribers, map[string]string{"Email": "email", "Company": "custom:company"}, false, true},
+		{"field not valid for type", ImportTypeBlockedDomains, map[string]string{"Email": "email"}, false, true},
+		{"unknown type", "contacts", map[string]string{"Email": "email"}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateImportMapping(tt.importType, header, tt.mapping, tt.hasList)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateImportMapping() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveImportColumns(t *testing.T) {
+	header := []string{" Email ", "Name", "Company", "Tags", "Labels"}
+	cols := resolveImportColumns(ImportTypeSubscribers, header, map[string]string{
+		"email":   ImportFieldEmail,
+		"Company": "custom:company",
+		"Tags":    ImportFieldTags,
+		"Labels":  ImportFieldTags,
+	})
+
+	record := []string{"ada@example.com", "Ada", "Acme", "vip, beta", "early;  "}
+
+	if got := cols.value(record, ImportFieldEmail); got != "ada@example.com" {
+		t.Errorf("email = %q", got)
+	}
+	if got := cols.value(record, ImportFieldName); got != "" {
+		t.Errorf("unmapped name = %q, want empty", got)
+	}
+	if got := cols.customValues(record); !reflect.DeepEqual(got, map[string]string{"company": "Acme"}) {
+		t.Errorf("customValues() = %v", got)
+	}
+	if got := cols.tagValues(record); !reflect.DeepEqual(got, []string{"vip", "beta", "early"}) {
+		t.Errorf("tagValues() = %v", got)
+	}
+
+	// Short rows don't panic
+	if got := cols.value([]string{}, ImportFieldEmail); got != "" {
+		t.Errorf("short row email = %q", got)
+	}
+}
+
+func TestResolveImportColumns_DetectsWithoutMapping(t *testing.T) {
+	cols := resolveImportColumns(ImportTypeBlockedDomains, []string{"DOMAIN"}, nil)
+	if got := cols.value([]string{"Spam.example"}, ImportFieldDomain); got != "Spam.example" {
+		t.Errorf("domain = %q", got)
+	}
+}
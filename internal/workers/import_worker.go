@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -12,7 +15,10 @@ import (
 	"time"
 
 	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/services/email"
+	"github.com/outlet-sh/outlet/internal/services/emailval"
 	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/types"
 
 	"github.com/google/uuid"
 	"github.com/zeromicro/go-zero/core/logx"
@@ -52,12 +58,39 @@ type ImportWorker struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
+	crashGuard
+
+	// Sends double opt-in confirmations for imported subscribers
+	mailer  confirmationMailer
+	baseURL string
 
 	// Metrics
 	processed atomic.Int64
 	failed    atomic.Int64
 }
 
+// confirmationMailer sends double opt-in confirmation emails
+type confirmationMailer interface {
+	SendEmailFrom(ctx context.Context, orgID, fromEmail, fromName, to, subject, htmlBody string) error
+}
+
+// errRowSkipped marks a row that was read but left nothing to import
+var errRowSkipped = errors.New("row skipped")
+
+// importRun holds per-job state shared by the row importers
+type importRun struct {
+	job    db.ImportJob
+	opts   types.ImportJobOptions
+	cols   importColumns
+	policy *emailval.Policy
+
+	// list is the target list for subscriber imports, nil if the job has none
+	list *db.EmailList
+
+	// fieldIDs caches custom field IDs by key; "" marks an unknown key
+	fieldIDs map[string]string
+}
+
 // NewImportWorker creates a new import worker
 func NewImportWorker(store *db.Store, config ImportWorkerConfig) *ImportWorker {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -109,6 +142,7 @@ func (w *ImportWorker) Stop() {
 // worker processes pending import jobs
 func (w *ImportWorker) worker(id int) {
 	defer w.wg.Done()
+	defer w.recover("Import worker")
 	ticker := time.NewTicker(w.config.PollInterval)
 	defer ticker.Stop()
 
@@ -172,6 +206,7 @@ func (w *ImportWorker) processImportJob(job db.ImportJob) error {
 	// Parse CSV
 	reader := csv.NewReader(file)
 	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
 
 	// Read header
 	header, err := reader.Read()
@@ -179,10 +214,17 @@ func (w *ImportWorker) processImportJob(job db.ImportJob) error {
 		return w.failJob(job.ID, "Failed to read CSV header: "+err.Error())
 	}
 
-	// Map column indices
-	colMap := make(map[string]int)
-	for i, col := range header {
-		colMap[strings.ToLower(strings.TrimSpace(col))] = i
+	opts, err := ParseImportOptions(job.Options)
+	if err != nil {
+		return w.failJob(job.ID, err.Error())
+	}
+
+	// Map columns to fields using the job's mapping, or the header names
+	run := &importRun{
+		job:      job,
+		opts:     opts,
+		cols:     resolveImportColumns(job.Type, header, opts.Mapping),
+		fieldIDs: make(map[string]string),
 	}
 
 	// Count total rows first
@@ -202,6 +244,7 @@ func (w *ImportWorker) processImportJob(job db.ImportJob) error {
 	file.Seek(0, 0)
 	reader = csv.NewReader(file)
 	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
 	reader.Read() // Skip header
 
 	// Update total rows
@@ -213,6 +256,21 @@ func (w *ImportWorker) processImportJob(job db.ImportJob) error {
 		logx.Errorf("Failed to set total rows: %v", err)
 	}
 
+	// Resolve the target list and validation policy once per job
+	if job.Type == ImportTypeSubscribers {
+		if job.ListID.Valid {
+			list, err := w.store.GetEmailList(w.ctx, job.ListID.Int64)
+			if err != nil {
+				return w.failJob(job.ID, "Failed to load list: "+err.Error())
+			}
+			run.list = &list
+		}
+		run.policy, err = w.validationPolicy(job, run.list)
+		if err != nil {
+			logx.Errorf("Failed to load validation policy for import job %s: %v", job.ID, err)
+		}
+	}
+
 	// Process rows
 	var processed, success, errors, skipped int64
 	var errorMessages []string
@@ -239,18 +297,19 @@ func (w *ImportWorker) processImportJob(job db.ImportJob) error {
 		// Process based on import type
 		var importErr error
 		switch job.Type {
-		case "subscribers":
-			importErr = w.importSubscriber(job, record, colMap)
-		case "suppression":
-			importErr = w.importSuppression(job, record, colMap)
-		case "blocked_domains":
-			importErr = w.importBlockedDomain(job, record, colMap)
+		case ImportTypeSubscribers:
+			importErr = w.importSubscriber(run, record)
+		case ImportTypeSuppression:
+			importErr = w.importSuppression(run, record)
+		case ImportTypeBlockedDomains:
+			importErr = w.importBlockedDomain(run, record)
 		default:
-			importErr = nil
-			skipped++
+			importErr = errRowSkipped
 		}
 
-		if importErr != nil {
+		if importErr == errRowSkipped {
+			skipped++
+		} else if importErr != nil {
 			errors++
 			if len(errorMessages) < 100 { // Limit error messages
 				errorMessages = append(errorMessages, importErr.Error())
@@ -280,6 +339,10 @@ func (w *ImportWorker) processImportJob(job db.ImportJob) error {
 		SkipCount:     sql.NullInt64{Int64: skipped, Valid: true},
 	})
 
+	if len(errorMessages) > 0 {
+		w.saveErrors(job.ID, errorMessages)
+	}
+
 	// Mark as completed
 	err = w.store.UpdateImportJobStatus(w.ctx, db.UpdateImportJobStatusParams{
 		ID:     job.ID,
@@ -296,41 +359,51 @@ func (w *ImportWorker) processImportJob(job db.ImportJob) error {
 	return nil
 }
 
+// validationPolicy returns the policy for the job's target list, falling back to the org policy
+func (w *ImportWorker) validationPolicy(job db.ImportJob, list *db.EmailList) (*emailval.Policy, error) {
+	var listPolicy sql.NullString
+	if list != nil {
+		listPolicy = list.ValidationPolicy
+	}
+	return email.ResolveValidationPolicy(w.ctx, w.store, job.OrgID, listPolicy)
+}
+
 // importSubscriber imports a single subscriber row
-func (w *ImportWorker) importSubscriber(job db.ImportJob, record []string, colMap map[string]int) error {
+func (w *ImportWorker) importSubscriber(run *importRun, record []string) error {
 	// Get email (required)
-	emailIdx, ok := colMap["email"]
-	if !ok {
-		return nil // Skip if no email column
+	address := strings.ToLower(run.cols.value(record, ImportFieldEmail))
+	if address == "" {
+		return errRowSkipped
 	}
-	if emailIdx >= len(record) {
-		return nil
-	}
-	email := strings.TrimSpace(strings.ToLower(record[emailIdx]))
-	if email == "" {
-		return nil
+
+	// Validation errors and failed lookups fail open, as they do for
+	// signups: the row is imported without a recorded result
+	validation, err := email.CheckAddress(w.ctx, run.policy, address)
+	if err != nil {
+		logx.Errorf("Failed to validate %s for import job %s: %v", address, run.job.ID, err)
+		validation = nil
 	}
 
 	// Get name (optional)
-	var name string
-	if nameIdx, ok := colMap["name"]; ok && nameIdx < len(record) {
-		name = strings.TrimSpace(record[nameIdx])
-	}
+	name := run.cols.value(record, ImportFieldName)
 
 	// Check if contact exists
 	existingContact, err := w.store.GetContactByOrgAndEmail(w.ctx, db.GetContactByOrgAndEmailParams{
-		OrgID: sql.NullString{String: job.OrgID, Valid: true},
-		Email: email,
+		OrgID: sql.NullString{String: run.job.OrgID, Valid: true},
+		Email: address,
 	})
 	if err != nil && err != sql.ErrNoRows {
 		return err
 	}
 
+	// New contacts always take the row's fields; existing ones only when the
+	// job is set to update them
 	var contactID string
+	update := true
 	if existingContact.ID != "" {
 		contactID = existingContact.ID
-		// Update name if provided
-		if name != "" && name != existingContact.Name {
+		update = run.opts.UpdateExisting
+		if update && name != "" && name != existingContact.Name {
 			w.store.UpdateContactName(w.ctx, db.UpdateContactNameParams{
 				ID:   contactID,
 				Name: name,
@@ -340,67 +413,197 @@ func (w *ImportWorker) importSubscriber(job db.ImportJob, record []string, colMa
 		// Create new contact
 		contactID = uuid.NewString()
 		_, err = w.store.CreateContact(w.ctx, db.CreateContactParams{
-			ID:    contactID,
-			OrgID: sql.NullString{String: job.OrgID, Valid: true},
-			Email: email,
-			Name:  name,
+			ID:     contactID,
+			OrgID:  sql.NullString{String: run.job.OrgID, Valid: true},
+			Email:  address,
+			Name:   name,
+			Source: sql.NullString{String: "import", Valid: true},
 		})
 		if err != nil {
 			return err
 		}
 	}
 
-	// Add to list if list_id is specified
-	if job.ListID.Valid {
-		// Check if already subscribed
-		exists, err := w.store.CheckListSubscription(w.ctx, db.CheckListSubscriptionParams{
-			ListID:    job.ListID.Int64,
+	// Record the outcome on the contact; rejected rows are not subscribed
+	if validation != nil {
+		if err := email.RecordValidation(w.ctx, w.store, contactID, validation); err != nil {
+			logx.Errorf("Failed to record validation for contact %s: %v", contactID, err)
+		}
+		if !validation.Valid() {
+			return fmt.Errorf("%s rejected by validation policy: %s", address, validation.Reason())
+		}
+	}
+
+	changed := update
+
+	// Add to the job's list if set
+	if run.list != nil {
+		subscriberID, added, err := w.subscribe(run, contactID, address, name)
+		if err != nil {
+			return err
+		}
+		changed = changed || added
+		if update {
+			w.setCustomFields(run, subscriberID, record)
+		}
+	}
+
+	if update {
+		w.addTags(run, contactID, record)
+	}
+
+	if !changed {
+		return errRowSkipped
+	}
+	return nil
+}
+
+// subscribe adds the contact to the job's list unless it is already on it,
+// in any status. Returns the subscriber ID and whether a subscription was created.
+func (w *ImportWorker) subscribe(run *importRun, contactID, address, name string) (string, bool, error) {
+	existing, err := w.store.GetListSubscriber(w.ctx, db.GetListSubscriberParams{
+		ListID:    run.list.ID,
+		ContactID: contactID,
+	})
+	if err == nil {
+		return existing.ID, false, nil
+	}
+	if err != sql.ErrNoRows {
+		return "", false, err
+	}
+
+	if !run.opts.SendDoubleOptIn {
+		subscriber, err := w.store.SubscribeToList(w.ctx, db.SubscribeToListParams{
+			ID:        uuid.NewString(),
+			ListID:    run.list.ID,
 			ContactID: contactID,
 		})
 		if err != nil {
-			return err
+			return "", false, err
 		}
-		if exists == 0 {
-			_, err = w.store.CreateListSubscriber(w.ctx, db.CreateListSubscriberParams{
-				ListID:    job.ListID.Int64,
-				ContactID: contactID,
-				Status:    sql.NullString{String: "active", Valid: true},
+		return subscriber.ID, true, nil
+	}
+
+	// Double opt-in: subscribe as pending and ask the contact to confirm
+	verificationToken := uuid.NewString()
+	subscriber, err := w.store.SubscribeToListPending(w.ctx, db.SubscribeToListPendingParams{
+		ID:                uuid.NewString(),
+		ListID:            run.list.ID,
+		ContactID:         contactID,
+		VerificationToken: sql.NullString{String: verificationToken, Valid: true},
+	})
+	if err != nil {
+		return "", false, err
+	}
+	if err := w.sendConfirmation(run, address, name, verificationToken); err != nil {
+		logx.Errorf("Failed to send confirmation email to %s: %v", address, err)
+	}
+	return subscriber.ID, true, nil
+}
+
+// sendConfirmation sends the list's double opt-in email
+func (w *ImportWorker) sendConfirmation(run *importRun, toEmail, toName, token string) error {
+	if w.mailer == nil {
+		return fmt.Errorf("no email service configured")
+	}
+
+	baseURL := w.baseURL
+	if baseURL == "" {
+		baseURL = "http://localhost:9888"
+	}
+	confirmURL := baseURL + "/confirm/" + token
+
+	subject := "Please confirm your subscription"
+	if run.list.ConfirmationEmailSubject.Valid && run.list.ConfirmationEmailSubject.String != "" {
+		subject = run.list.ConfirmationEmailSubject.String
+	}
+
+	greeting := "Hi"
+	if toName != "" {
+		greeting += " " + toName
+	}
+	htmlBody := `
+		<p>` + greeting + `,</p>
+		<p>Please confirm your subscription to <strong>` + run.list.Name + `</strong> by clicking the link below:</p>
+		<p><a href="` + confirmURL + `">Confirm Subscription</a></p>
+		<p>If you didn't request this, you can safely ignore this email.</p>
+	`
+	if run.list.ConfirmationEmailBody.Valid && run.list.ConfirmationEmailBody.String != "" {
+		htmlBody = strings.ReplaceAll(run.list.ConfirmationEmailBody.String, "{{confirm_url}}", confirmURL)
+	}
+
+	// Empty from address lets the email service use the org or platform defaults
+	var fromEmail, fromName string
+	if org, err := w.store.GetOrganizationByID(w.ctx, run.job.OrgID); err == nil {
+		fromEmail = org.FromEmail.String
+		fromName = org.FromName.String
+	}
+
+	return w.mailer.SendEmailFrom(w.ctx, run.job.OrgID, fromEmail, fromName, toEmail, subject, htmlBody)
+}
+
+// setCustomFields stores the row's custom field values for a list subscriber
+func (w *ImportWorker) setCustomFields(run *importRun, subscriberID string, record []string) {
+	for key, value := range run.cols.customValues(record) {
+		fieldID, ok := run.fieldIDs[key]
+		if !ok {
+			field, err := w.store.GetCustomFieldByKey(w.ctx, db.GetCustomFieldByKeyParams{
+				ListID:   run.list.ID,
+				FieldKey: key,
 			})
 			if err != nil {
-				return err
+				logx.Errorf("Import job %s: custom field %q not found on list %d: %v", run.job.ID, key, run.list.ID, err)
 			}
+			fieldID = field.ID
+			run.fieldIDs[key] = fieldID
+		}
+		if fieldID == "" {
+			continue
+		}
+
+		_, err := w.store.UpsertCustomFieldValue(w.ctx, db.UpsertCustomFieldValueParams{
+			ID:           uuid.NewString(),
+			SubscriberID: subscriberID,
+			FieldID:      fieldID,
+			Value:        sql.NullString{String: value, Valid: true},
+		})
+		if err != nil {
+			logx.Errorf("Failed to set custom field %s for subscriber %s: %v", key, subscriberID, err)
 		}
 	}
+}
 
-	return nil
+// addTags applies the job's tags and the row's tag columns to a contact
+func (w *ImportWorker) addTags(run *importRun, contactID string, record []string) {
+	tags := append(append([]string(nil), run.opts.Tags...), run.cols.tagValues(record)...)
+	for _, tag := range tags {
+		_, err := w.store.AddContactTag(w.ctx, db.AddContactTagParams{
+			ContactID: sql.NullString{String: contactID, Valid: true},
+			Tag:       tag,
+		})
+		// ErrNoRows means the contact already has the tag
+		if err != nil && err != sql.ErrNoRows {
+			logx.Errorf("Failed to tag contact %s with %q: %v", contactID, tag, err)
+		}
+	}
 }
 
 // importSuppression imports a suppression list entry
-func (w *ImportWorker) importSuppression(job db.ImportJob, record []string, colMap map[string]int) error {
-	emailIdx, ok := colMap["email"]
-	if !ok {
-		return nil
-	}
-	if emailIdx >= len(record) {
-		return nil
-	}
-	email := strings.TrimSpace(strings.ToLower(record[emailIdx]))
+func (w *ImportWorker) importSuppression(run *importRun, record []string) error {
+	email := strings.ToLower(run.cols.value(record, ImportFieldEmail))
 	if email == "" {
-		return nil
+		return errRowSkipped
 	}
 
 	// Get reason (optional)
-	var reason string
-	if reasonIdx, ok := colMap["reason"]; ok && reasonIdx < len(record) {
-		reason = strings.TrimSpace(record[reasonIdx])
-	}
+	reason := run.cols.value(record, ImportFieldReason)
 	if reason == "" {
 		reason = "imported"
 	}
 
 	// Add to suppression list
 	_, err := w.store.AddToSuppressionList(w.ctx, db.AddToSuppressionListParams{
-		OrgID:  job.OrgID,
+		OrgID:  run.job.OrgID,
 		Email:  email,
 		Reason: sql.NullString{String: reason, Valid: true},
 		Source: sql.NullString{String: "import", Valid: true},
@@ -416,23 +619,16 @@ func (w *ImportWorker) importSuppression(job db.ImportJob, record []string, colM
 }
 
 // importBlockedDomain imports a blocked domain entry
-func (w *ImportWorker) importBlockedDomain(job db.ImportJob, record []string, colMap map[string]int) error {
-	domainIdx, ok := colMap["domain"]
-	if !ok {
-		return nil
-	}
-	if domainIdx >= len(record) {
-		return nil
-	}
-	domain := strings.TrimSpace(strings.ToLower(record[domainIdx]))
+func (w *ImportWorker) importBlockedDomain(run *importRun, record []string) error {
+	domain := strings.ToLower(run.cols.value(record, ImportFieldDomain))
 	if domain == "" {
-		return nil
+		return errRowSkipped
 	}
 
 	// Add to blocked domains
 	_, err := w.store.AddBlockedDomain(w.ctx, db.AddBlockedDomainParams{
 		ID:     time.Now().UnixNano(),
-		OrgID:  job.OrgID,
+		OrgID:  run.job.OrgID,
 		Domain: domain,
 	})
 	if err != nil {
@@ -445,15 +641,31 @@ func (w *ImportWorker) importBlockedDomain(job db.ImportJob, record []string, co
 	return nil
 }
 
-// failJob marks an import job as failed
+// failJob marks an import job as failed and records the reason
 func (w *ImportWorker) failJob(id, reason string) error {
 	w.failed.Add(1)
+	w.saveErrors(id, []string{reason})
 	return w.store.UpdateImportJobStatus(w.ctx, db.UpdateImportJobStatusParams{
 		ID:     id,
 		Status: sql.NullString{String: "failed", Valid: true},
 	})
 }
 
+// saveErrors stores row errors on the job as a JSON array
+func (w *ImportWorker) saveErrors(id string, messages []string) {
+	data, err := json.Marshal(messages)
+	if err != nil {
+		return
+	}
+	err = w.store.SetImportJobErrors(w.ctx, db.SetImportJobErrorsParams{
+		ID:     id,
+		Errors: sql.NullString{String: string(data), Valid: true},
+	})
+	if err != nil {
+		logx.Errorf("Failed to save errors for import job %s: %v", id, err)
+	}
+}
+
 // Stats returns worker statistics
 func (w *ImportWorker) Stats() (processed, failed int64) {
 	return w.processed.Load(), w.failed.Load()
@@ -464,6 +676,10 @@ func StartImportWorker(svcCtx *svc.ServiceContext) *ImportWorker {
 	config := DefaultImportWorkerConfig()
 
 	worker := NewImportWorker(svcCtx.DB, config)
+	if svcCtx.EmailService != nil {
+		worker.mailer = svcCtx.EmailService
+	}
+	worker.baseURL = svcCtx.Config.App.BaseURL
 	worker.Start()
 
 	return worker
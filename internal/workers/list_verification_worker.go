@@ -0,0 +1,356 @@
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/services/emailval"
+	"github.com/outlet-sh/outlet/internal/svc"
+	"github.com/outlet-sh/outlet/internal/websocket"
+)
+
+const (
+	// listVerificationBatchSize is the number of subscribers loaded per batch
+	listVerificationBatchSize = 200
+
+	// DefaultListVerificationConcurrency is used when a job does not set one
+	DefaultListVerificationConcurrency = 5
+
+	// MaxListVerificationConcurrency caps parallel checks per job
+	MaxListVerificationConcurrency = 20
+)
+
+// ListVerificationWorker runs list verification jobs in the background.
+// Jobs are picked up from list_verification_jobs, so pending and interrupted
+// jobs resume after a restart. Results are written per subscriber and
+// subscribers with a result are skipped when a job resumes, except unknown
+// results, which are checked again since they usually come from failed lookups.
+type ListVerificationWorker struct {
+	svcCtx   *svc.ServiceContext
+	mxCache  *emailval.MXCache
+	interval time.Duration
+	stop     chan struct{}
+	wg       sync.WaitGroup
+	crashGuard
+
+	mu     sync.Mutex
+	active map[string]bool
+}
+
+// NewListVerificationWorker creates a new list verification worker
+func NewListVerificationWorker(svcCtx *svc.ServiceContext, interval time.Duration) *ListVerificationWorker {
+	return &ListVerificationWorker{
+		svcCtx:   svcCtx,
+		mxCache:  emailval.NewMXCache(time.Hour),
+		interval: interval,
+		stop:     make(chan struct{}),
+		active:   make(map[string]bool),
+	}
+}
+
+// Start starts the list verification worker
+func (w *ListVerificationWorker) Start() {
+	w.wg.Add(1)
+	go w.run()
+}
+
+// Stop stops the worker and waits for running jobs to checkpoint
+func (w *ListVerificationWorker) Stop() {
+	close(w.stop)
+	w.wg.Wait()
+}
+
+func (w *ListVerificationWorker) run() {
+	defer w.wg.Done()
+	defer w.recover("List verification worker")
+
+	// Resume interrupted jobs immediately on start
+	w.pickUpJobs()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.pickUpJobs()
+		case <-w.stop:
+			log.Println("List verification worker stopping...")
+			return
+		}
+	}
+}
+
+func (w *ListVerificationWorker) pickUpJobs() {
+	jobs, err := w.svcCtx.DB.GetResumableListVerificationJobs(context.Background())
+	if err != nil {
+		log.Printf("Failed to get list verification jobs: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		w.mu.Lock()
+		if w.active[job.ID] {
+			w.mu.Unlock()
+			continue
+		}
+		w.active[job.ID] = true
+		w.mu.Unlock()
+
+		w.wg.Add(1)
+		go func(job db.ListVerificationJob) {
+			defer w.wg.Done()
+			defer w.recover("List verification worker")
+			defer func() {
+				w.mu.Lock()
+				delete(w.active, job.ID)
+				w.mu.Unlock()
+			}()
+			w.processJob(job)
+		}(job)
+	}
+}
+
+// verification is the outcome of checking one subscriber
+type verification struct {
+	subscriber db.GetUnverifiedListSubscribersRow
+	verdict    string
+	reason     string
+}
+
+func (w *ListVerificationWorker) processJob(job db.ListVerificationJob) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-w.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// Unknown results are usually DNS or SMTP lookups that failed, drop them so
+	// a resumed job checks those subscribers again
+	if err := w.svcCtx.DB.DeleteUnknownListVerificationResults(ctx, job.ID); err != nil {
+		log.Printf("Failed to reset unknown results for list verification job %s: %v", job.ID, err)
+	}
+
+	total, err := w.svcCtx.DB.CountListSubscribersForVerification(ctx, job.ListID)
+	if err != nil {
+		w.failJob(job, fmt.Errorf("failed to count subscribers: %w", err))
+		return
+	}
+
+	job, err = w.svcCtx.DB.StartListVerificationJob(ctx, db.StartListVerificationJobParams{
+		TotalCount: total,
+		ID:         job.ID,
+	})
+	if err == sql.ErrNoRows {
+		// Cancelled before it started
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to start list verification job %s: %v", job.ID, err)
+		return
+	}
+
+	log.Printf("List verification job %s started: list=%d total=%d processed=%d", job.ID, job.ListID, total, job.ProcessedCount)
+	w.broadcast(job)
+
+	concurrency := int(job.Concurrency)
+	if concurrency <= 0 {
+		concurrency = DefaultListVerificationConcurrency
+	}
+	if concurrency > MaxListVerificationConcurrency {
+		concurrency = MaxListVerificationConcurrency
+	}
+
+	opts := &emailval.Options{
+		Timeout:         5 * time.Second,
+		CheckSMTP:       job.CheckSmtp == 1,
+		CheckCatchAll:   job.CheckSmtp == 1,
+		CheckDisposable: true,
+		AllowRole:       false,
+		MXCache:         w.mxCache,
+	}
+
+	for {
+		subscribers, err := w.svcCtx.DB.GetUnverifiedListSubscribers(ctx, db.GetUnverifiedListSubscribersParams{
+			ListID:   job.ListID,
+			JobID:    job.ID,
+			LimitVal: listVerificationBatchSize,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			w.failJob(job, fmt.Errorf("failed to load subscribers: %w", err))
+			return
+		}
+
+		if len(subscribers) == 0 {
+			break
+		}
+
+		recorded := w.verifyBatch(ctx, job, subscribers, opts, concurrency)
+
+		if updated, err := w.svcCtx.DB.RefreshListVerificationJobCounts(context.Background(), job.ID); err == nil {
+			job = updated
+			w.broadcast(job)
+		}
+
+		// Shutting down: leave the job running so it resumes on next start
+		if ctx.Err() != nil {
+			log.Printf("List verification job %s paused at %d/%d", job.ID, job.ProcessedCount, job.TotalCount)
+			return
+		}
+
+		// Cancelled by an admin
+		if job.Status == "cancelled" {
+			log.Printf("List verification job %s cancelled", job.ID)
+			return
+		}
+
+		// Nothing could be recorded, bail out instead of retrying the same batch forever
+		if recorded == 0 {
+			w.failJob(job, fmt.Errorf("failed to record verification results"))
+			return
+		}
+	}
+
+	job, err = w.svcCtx.DB.UpdateListVerificationJobStatus(ctx, db.UpdateListVerificationJobStatusParams{
+		Status: "completed",
+		ID:     job.ID,
+	})
+	if err != nil {
+		log.Printf("Failed to complete list verification job %s: %v", job.ID, err)
+		return
+	}
+
+	log.Printf("List verification job %s completed: %d valid, %d invalid, %d disposable, %d role, %d catch-all, %d unknown",
+		job.ID, job.ValidCount, job.InvalidCount, job.DisposableCount, job.RoleCount, job.CatchAllCount, job.UnknownCount)
+	w.broadcast(job)
+}
+
+// verifyBatch checks subscribers with a bounded pool and records each verdict.
+// Returns the number of results written.
+func (w *ListVerificationWorker) verifyBatch(ctx context.Context, job db.ListVerificationJob, subscribers []db.GetUnverifiedListSubscribersRow, opts *emailval.Options, concurrency int) int {
+	work := make(chan db.GetUnverifiedListSubscribersRow)
+	results := make(chan verification)
+
+	var pool sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		pool.Add(1)
+		go func() {
+			defer pool.Done()
+			for sub := range work {
+				res, err := emailval.Validate(ctx, sub.Email, opts)
+				// Interrupted checks are not recorded so they run again on resume
+				if ctx.Err() != nil {
+					continue
+				}
+				verdict, reason := verificationVerdict(res, err)
+				results <- verification{subscriber: sub, verdict: verdict, reason: reason}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(work)
+		for _, sub := range subscribers {
+			select {
+			case work <- sub:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		pool.Wait()
+		close(results)
+	}()
+
+	// Results are written from a single goroutine to keep SQLite writes serialized
+	recorded := 0
+	for v := range results {
+		err := w.svcCtx.DB.CreateListVerificationResult(context.Background(), db.CreateListVerificationResultParams{
+			JobID:        job.ID,
+			SubscriberID: v.subscriber.ID,
+			ContactID:    v.subscriber.ContactID,
+			Email:        v.subscriber.Email,
+			Verdict:      v.verdict,
+			Reason:       sql.NullString{String: v.reason, Valid: v.reason != ""},
+		})
+		if err != nil {
+			log.Printf("Failed to record verification for subscriber %s: %v", v.subscriber.ID, err)
+			continue
+		}
+		recorded++
+	}
+
+	return recorded
+}
+
+// verificationVerdict maps a validation outcome to the verdict stored for a
+// subscriber. Errors and failed lookups are recorded as unknown, never invalid,
+// so they are checked again when the job resumes.
+func verificationVerdict(res *emailval.Result, err error) (string, string) {
+	if err != nil {
+		return emailval.VerdictUnknown, err.Error()
+	}
+	if res.LookupFailed() {
+		return emailval.VerdictUnknown, res.Reason()
+	}
+	return res.Verdict(), res.Reason()
+}
+
+func (w *ListVerificationWorker) failJob(job db.ListVerificationJob, cause error) {
+	log.Printf("List verification job %s failed: %v", job.ID, cause)
+
+	updated, err := w.svcCtx.DB.UpdateListVerificationJobStatus(context.Background(), db.UpdateListVerificationJobStatusParams{
+		Status:       "failed",
+		ErrorMessage: sql.NullString{String: cause.Error(), Valid: true},
+		ID:           job.ID,
+	})
+	if err != nil {
+		log.Printf("Failed to mark list verification job %s as failed: %v", job.ID, err)
+		return
+	}
+	w.broadcast(updated)
+}
+
+func (w *ListVerificationWorker) broadcast(job db.ListVerificationJob) {
+	if w.svcCtx.WebSocketHub == nil {
+		return
+	}
+	w.svcCtx.WebSocketHub.BroadcastToOrg(job.OrgID, websocket.NewListVerificationUpdate(websocket.ListVerificationUpdate{
+		ID:        job.ID,
+		OrgID:     job.OrgID,
+		ListID:    job.ListID,
+		Status:    job.Status,
+		Total:     job.TotalCount,
+		Processed: job.ProcessedCount,
+		Verdicts: map[string]int64{
+			emailval.VerdictValid:      job.ValidCount,
+			emailval.VerdictInvalid:    job.InvalidCount,
+			emailval.VerdictDisposable: job.DisposableCount,
+			emailval.VerdictRole:       job.RoleCount,
+			emailval.VerdictCatchAll:   job.CatchAllCount,
+			emailval.VerdictUnknown:    job.UnknownCount,
+		},
+		Error: job.ErrorMessage.String,
+	}))
+}
+
+// StartListVerificationWorker starts the list verification worker with a 10-second interval
+func StartListVerificationWorker(svcCtx *svc.ServiceContext) *ListVerificationWorker {
+	worker := NewListVerificationWorker(svcCtx, 10*time.Second)
+	worker.Start()
+	return worker
+}
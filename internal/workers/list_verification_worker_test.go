@@ -0,0 +1,82 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/outlet-sh/outlet/internal/db/dbtest"
+	"github.com/outlet-sh/outlet/internal/services/emailval"
+	"github.com/outlet-sh/outlet/internal/svc"
+)
+
+func TestVerificationVerdict(t *testing.T) {
+	domain := emailval.LevelDomain
+
+	tests := []struct {
+		name string
+		res  *emailval.Result
+		err  error
+		want string
+	}{
+		{"error", nil, errors.New("timeout"), emailval.VerdictUnknown},
+		{"lookup failed", &emailval.Result{FailedAt: &domain, Unknown: true}, nil, emailval.VerdictUnknown},
+		{"no mx", &emailval.Result{FailedAt: &domain}, nil, emailval.VerdictInvalid},
+		{"valid", &emailval.Result{}, nil, emailval.VerdictValid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := verificationVerdict(tt.res, tt.err); got != tt.want {
+				t.Errorf("verificationVerdict() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListVerificationWorker_ResumeRetriesUnknown(t *testing.T) {
+	store := dbtest.New(t)
+	dbtest.Exec(t, store, `INSERT INTO organizations (id, name, slug, api_key) VALUES ('org', 'Org', 'org', 'key')`)
+	dbtest.Exec(t, store, `INSERT INTO email_lists (id, public_id, org_id, name, slug) VALUES (1, 'list', 'org', 'List', 'list')`)
+	dbtest.Exec(t, store, `INSERT INTO contacts (id, org_id, name, email, status) VALUES ('c1', 'org', 'One', 'not-an-address', 'active'), ('c2', 'org', 'Two', 'two@example.com', 'active')`)
+	dbtest.Exec(t, store, `INSERT INTO list_subscribers (id, list_id, contact_id, status) VALUES ('s1', 1, 'c1', 'active'), ('s2', 1, 'c2', 'active')`)
+	dbtest.Exec(t, store, `INSERT INTO list_verification_jobs (id, org_id, list_id, status) VALUES ('job', 'org', 1, 'running')`)
+	// s1 was interrupted by a failed lookup, s2 already has a definite verdict
+	dbtest.Exec(t, store, `INSERT INTO list_verification_results (job_id, subscriber_id, contact_id, email, verdict) VALUES ('job', 's1', 'c1', 'not-an-address', 'unknown'), ('job', 's2', 'c2', 'two@example.com', 'valid')`)
+
+	w := NewListVerificationWorker(&svc.ServiceContext{DB: store}, 0)
+	job, err := store.GetListVerificationJob(context.Background(), "job")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.processJob(job)
+
+	verdicts := map[string]string{}
+	rows, err := store.GetDB().Query(`SELECT subscriber_id, verdict FROM list_verification_results WHERE job_id = 'job'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, verdict string
+		if err := rows.Scan(&id, &verdict); err != nil {
+			t.Fatal(err)
+		}
+		verdicts[id] = verdict
+	}
+
+	if verdicts["s1"] != emailval.VerdictInvalid {
+		t.Errorf("unknown result was not re-checked, verdict = %q", verdicts["s1"])
+	}
+	if verdicts["s2"] != emailval.VerdictValid {
+		t.Errorf("definite result was changed, verdict = %q", verdicts["s2"])
+	}
+
+	job, err = store.GetListVerificationJob(context.Background(), "job")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != "completed" || job.UnknownCount != 0 || job.ProcessedCount != 2 {
+		t.Errorf("job = %s processed=%d unknown=%d, want completed with 2 processed and no unknown", job.Status, job.ProcessedCount, job.UnknownCount)
+	}
+}
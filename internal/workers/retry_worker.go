@@ -3,6 +3,7 @@ package workers
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -53,6 +54,7 @@ type RetryWorker struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
+	crashGuard
 
 	// Metrics
 	retried   atomic.Int64
@@ -104,6 +106,7 @@ func (w *RetryWorker) Stop() {
 // worker processes failed emails for retry
 func (w *RetryWorker) worker() {
 	defer w.wg.Done()
+	defer w.recover("Retry worker")
 	ticker := time.NewTicker(w.config.PollInterval)
 	defer ticker.Stop()
 
@@ -169,12 +172,18 @@ func (w *RetryWorker) retrySend(send db.GetFailedCampaignSendsForRetryRow) {
 	// Attempt retry
 	logx.Infof("Retrying send %s (attempt %d/%d)", send.ID, retryCount+1, w.config.MaxRetries)
 
-	// Increment retry count first
+	// Try to send
+	err := w.sendEmail(send)
+
+	// Rate or quota limited: not an attempt, try again on a later round
+	var limited *email.RateLimitError
+	if errors.As(err, &limited) || errors.Is(err, email.ErrDailyQuotaReached) {
+		return
+	}
+
 	w.store.IncrementCampaignSendRetry(w.ctx, send.ID)
 	w.retried.Add(1)
 
-	// Try to send
-	err := w.sendEmail(send)
 	if err != nil {
 		logx.Errorf("Retry failed for send %s: %v", send.ID, err)
 		w.store.MarkCampaignSendFailed(w.ctx, db.MarkCampaignSendFailedParams{
@@ -205,12 +214,15 @@ func (w *RetryWorker) sendEmail(send db.GetFailedCampaignSendsForRetryRow) error
 	}
 
 	return w.emailService.SendCampaignEmail(
+		send.OrgID,
 		send.Email,
 		send.Subject,
 		send.HtmlBody,
+		send.PlainText.String,
 		fromName,
 		fromEmail,
 		replyTo,
+		send.TrackingToken.String,
 	)
 }
 
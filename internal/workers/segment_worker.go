@@ -0,0 +1,217 @@
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/services/email"
+	"github.com/outlet-sh/outlet/internal/services/segment"
+	"github.com/outlet-sh/outlet/internal/svc"
+)
+
+// SegmentWorker periodically refreshes cached segment counts and enrolls
+// contacts that entered a segment into sequences with a segment_enter entry rule
+type SegmentWorker struct {
+	svcCtx    *svc.ServiceContext
+	sequences *email.SequenceService
+	interval  time.Duration
+	stop      chan struct{}
+	wg        sync.WaitGroup
+	crashGuard
+}
+
+// NewSegmentWorker creates a new segment worker
+func NewSegmentWorker(svcCtx *svc.ServiceContext, interval time.Duration) *SegmentWorker {
+	return &SegmentWorker{
+		svcCtx: svcCtx,
+		sequences: email.NewSequenceServiceWithBaseURL(
+			svcCtx.DB,
+			svcCtx.EmailService,
+			svcCtx.Config.App.BaseURL,
+		),
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start starts the segment worker
+func (w *SegmentWorker) Start() {
+	w.wg.Add(1)
+	go w.run()
+}
+
+// Stop stops the segment worker
+func (w *SegmentWorker) Stop() {
+	close(w.stop)
+	w.wg.Wait()
+}
+
+func (w *SegmentWorker) run() {
+	defer w.wg.Done()
+	defer w.recover("Segment worker")
+
+	// Run immediately on start
+	w.processSegments()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.processSegments()
+		case <-w.stop:
+			log.Println("Segment worker stopping...")
+			return
+		}
+	}
+}
+
+func (w *SegmentWorker) processSegments() {
+	ctx := context.Background()
+
+	segments, err := w.svcCtx.DB.ListAllSegments(ctx)
+	if err != nil {
+		log.Printf("Failed to list segments: %v", err)
+		return
+	}
+
+	for _, s := range segments {
+		select {
+		case <-w.stop:
+			return
+		default:
+		}
+
+		if _, err := segment.RefreshCount(ctx, w.svcCtx.DB.GetDB(), w.svcCtx.DB, s); err != nil {
+			log.Printf("Failed to refresh count for segment %s: %v", s.ID, err)
+			continue
+		}
+
+		w.enrollEntrants(ctx, s)
+	}
+}
+
+// enrollEntrants starts segment_enter sequences for contacts that entered
+// the segment since the previous pass. The first pass after a rule appears
+// only records the current members, so existing members are not enrolled.
+func (w *SegmentWorker) enrollEntrants(ctx context.Context, s db.Segment) {
+	rules, err := w.svcCtx.DB.GetActiveEntryRuleForTrigger(ctx, db.GetActiveEntryRuleForTriggerParams{
+		TriggerType: "segment_enter",
+		SourceID:    s.ID,
+	})
+	if err != nil {
+		log.Printf("Failed to get entry rules for segment %s: %v", s.ID, err)
+		return
+	}
+
+	if len(rules) == 0 {
+		w.forgetMembers(ctx, s)
+		return
+	}
+
+	audience, err := segment.FromSaved(s)
+	if err != nil {
+		log.Printf("Invalid segment %s: %v", s.ID, err)
+		return
+	}
+
+	entrants, baseline, err := w.updateMembers(ctx, s, audience)
+	if err != nil {
+		log.Printf("Failed to update members of segment %s: %v", s.ID, err)
+		return
+	}
+	if baseline || len(entrants) == 0 {
+		return
+	}
+
+	for _, rule := range rules {
+		log.Printf("Enrolling %d contacts from segment %s into sequence %s", len(entrants), s.Name, rule.SequenceName)
+
+		for _, contactID := range entrants {
+			if err := w.sequences.StartSequenceByID(ctx, contactID, rule.SequenceID); err != nil {
+				log.Printf("Failed to enroll contact %s into sequence %s: %v", contactID, rule.SequenceID, err)
+			}
+		}
+	}
+}
+
+// updateMembers replaces the stored membership of a segment with its current
+// members and returns the contacts that were not members before. baseline
+// is true when there was no stored membership to compare against.
+func (w *SegmentWorker) updateMembers(ctx context.Context, s db.Segment, audience segment.Audience) (entrants []string, baseline bool, err error) {
+	members, err := segment.Resolve(ctx, w.svcCtx.DB.GetDB(), audience)
+	if err != nil {
+		return nil, false, err
+	}
+
+	_, err = w.svcCtx.DB.GetSegmentMemberSnapshot(ctx, s.ID)
+	baseline = errors.Is(err, sql.ErrNoRows)
+	if err != nil && !baseline {
+		return nil, false, err
+	}
+
+	previous := map[string]bool{}
+	if !baseline {
+		ids, err := w.svcCtx.DB.ListSegmentMemberIDs(ctx, s.ID)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, id := range ids {
+			previous[id] = true
+		}
+	}
+
+	err = w.svcCtx.DB.ExecTx(ctx, func(q *db.Queries) error {
+		for _, m := range members {
+			if previous[m.ContactID] {
+				delete(previous, m.ContactID)
+				continue
+			}
+			if err := q.AddSegmentMember(ctx, db.AddSegmentMemberParams{SegmentID: s.ID, ContactID: m.ContactID}); err != nil {
+				return err
+			}
+			entrants = append(entrants, m.ContactID)
+		}
+		// Whatever is left has left the segment
+		for id := range previous {
+			if err := q.RemoveSegmentMember(ctx, db.RemoveSegmentMemberParams{SegmentID: s.ID, ContactID: id}); err != nil {
+				return err
+			}
+		}
+		return q.SaveSegmentMemberSnapshot(ctx, s.ID)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return entrants, baseline, nil
+}
+
+// forgetMembers drops the stored membership of a segment that no longer has
+// segment_enter rules, so a rule added later starts from a fresh baseline
+func (w *SegmentWorker) forgetMembers(ctx context.Context, s db.Segment) {
+	if _, err := w.svcCtx.DB.GetSegmentMemberSnapshot(ctx, s.ID); err != nil {
+		return
+	}
+	err := w.svcCtx.DB.ExecTx(ctx, func(q *db.Queries) error {
+		if err := q.DeleteSegmentMembers(ctx, s.ID); err != nil {
+			return err
+		}
+		return q.DeleteSegmentMemberSnapshot(ctx, s.ID)
+	})
+	if err != nil {
+		log.Printf("Failed to clear members of segment %s: %v", s.ID, err)
+	}
+}
+
+// StartSegmentWorker starts the segment worker with a 5-minute interval
+func StartSegmentWorker(svcCtx *svc.ServiceContext) *SegmentWorker {
+	worker := NewSegmentWorker(svcCtx, 5*time.Minute)
+	worker.Start()
+	return worker
+}
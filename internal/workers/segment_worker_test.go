@@ -0,0 +1,77 @@
+package workers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/outlet-sh/outlet/internal/db"
+	"github.com/outlet-sh/outlet/internal/db/dbtest"
+	"github.com/outlet-sh/outlet/internal/services/email"
+	"github.com/outlet-sh/outlet/internal/svc"
+)
+
+func addSegmentContact(t *testing.T, store *db.Store, id string) {
+	t.Helper()
+	dbtest.Exec(t, store, `INSERT INTO contacts (id, org_id, name, email, status) VALUES (?, 'org', ?, ?, 'active')`, id, id, id+"@example.com")
+	dbtest.Exec(t, store, `INSERT INTO list_subscribers (id, list_id, contact_id, status) VALUES (?, 1, ?, 'active')`, "sub-"+id, id)
+}
+
+func enrolledContacts(t *testing.T, store *db.Store) map[string]bool {
+	t.Helper()
+	rows, err := store.GetDB().Query(`SELECT contact_id FROM contact_sequence_state WHERE sequence_id = 'seq'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	enrolled := map[string]bool{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+		enrolled[id] = true
+	}
+	return enrolled
+}
+
+func TestSegmentWorker_EnrollsOnlyNewEntrants(t *testing.T) {
+	ctx := context.Background()
+	store := dbtest.New(t)
+	dbtest.Exec(t, store, `INSERT INTO organizations (id, name, slug, api_key) VALUES ('org', 'Org', 'org', 'key')`)
+	dbtest.Exec(t, store, `INSERT INTO email_lists (id, public_id, org_id, name, slug) VALUES (1, 'list', 'org', 'List', 'list')`)
+	dbtest.Exec(t, store, `INSERT INTO segments (id, org_id, name, list_ids, filter) VALUES ('seg', 'org', 'All', '["1"]', '{}')`)
+	dbtest.Exec(t, store, `INSERT INTO email_sequences (id, org_id, slug, name, trigger_event) VALUES ('seq', 'org', 'welcome', 'Welcome', 'segment_enter')`)
+	dbtest.Exec(t, store, `INSERT INTO sequence_entry_rules (id, sequence_id, trigger_type, source_id) VALUES ('rule', 'seq', 'segment_enter', 'seg')`)
+	addSegmentContact(t, store, "existing")
+
+	w := &SegmentWorker{
+		svcCtx:    &svc.ServiceContext{DB: store},
+		sequences: email.NewSequenceServiceWithBaseURL(store, nil, ""),
+	}
+	s, err := store.GetSegment(ctx, db.GetSegmentParams{ID: "seg", OrgID: "org"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first pass records who is already in the segment
+	w.enrollEntrants(ctx, s)
+	if enrolled := enrolledContacts(t, store); len(enrolled) != 0 {
+		t.Fatalf("first pass enrolled existing members %v", enrolled)
+	}
+
+	addSegmentContact(t, store, "newcomer")
+	w.enrollEntrants(ctx, s)
+	enrolled := enrolledContacts(t, store)
+	if len(enrolled) != 1 || !enrolled["newcomer"] {
+		t.Fatalf("second pass enrolled %v, want only the newcomer", enrolled)
+	}
+
+	// Leaving and re-entering counts as entering again
+	dbtest.Exec(t, store, `UPDATE list_subscribers SET status = 'unsubscribed' WHERE contact_id = 'existing'`)
+	w.enrollEntrants(ctx, s)
+	dbtest.Exec(t, store, `UPDATE list_subscribers SET status = 'active' WHERE contact_id = 'existing'`)
+	w.enrollEntrants(ctx, s)
+	if enrolled := enrolledContacts(t, store); !enrolled["existing"] {
+		t.Fatalf("re-entering contact was not enrolled: %v", enrolled)
+	}
+}